@@ -236,39 +236,40 @@ func (f *File) adjustSingleRowFormulas(sheet, sheetN string, r *xlsxRow, num, of
 	return nil
 }
 
-// adjustCellRef provides a function to adjust cell reference.
+// adjustCellRef provides a function to adjust cell reference. The reference
+// can be a space-separated list of cell references or ranges, the range which
+// straddles the inserted position will be grown, and the range which is fully
+// below or right of the position will be moved entirely. The returned bool
+// value indicates whether all of them have been deleted.
 func (f *File) adjustCellRef(ref string, dir adjustDirection, num, offset int) (string, bool, error) {
-	if !strings.Contains(ref, ":") {
-		ref += ":" + ref
-	}
-	var delete bool
-	coordinates, err := rangeRefToCoordinates(ref)
-	if err != nil {
-		return ref, delete, err
-	}
-	if dir == columns {
-		if offset < 0 && coordinates[0] == coordinates[2] {
-			delete = true
+	var refs []string
+	for _, cellRef := range strings.Fields(ref) {
+		single := !strings.Contains(cellRef, ":")
+		if single {
+			cellRef += ":" + cellRef
 		}
-		if coordinates[0] >= num {
-			coordinates[0] += offset
+		coordinates, err := rangeRefToCoordinates(cellRef)
+		if err != nil {
+			return ref, false, err
 		}
-		if coordinates[2] >= num {
-			coordinates[2] += offset
+		_ = sortCoordinates(coordinates)
+		p1, p2 := 1, 3
+		if dir == columns {
+			p1, p2 = 0, 2
 		}
-	} else {
-		if offset < 0 && coordinates[1] == coordinates[3] {
-			delete = true
+		if offset < 0 && coordinates[p1] == num && coordinates[p2] == num {
+			continue
 		}
-		if coordinates[1] >= num {
-			coordinates[1] += offset
+		coordinates[p1], coordinates[p2] = f.adjustMergeCellsHelper(coordinates[p1], coordinates[p2], num, offset)
+		if cellRef, err = f.coordinatesToRangeRef(coordinates); err != nil {
+			return ref, false, err
 		}
-		if coordinates[3] >= num {
-			coordinates[3] += offset
+		if single {
+			cellRef = strings.Split(cellRef, ":")[0]
 		}
+		refs = append(refs, cellRef)
 	}
-	ref, err = f.coordinatesToRangeRef(coordinates)
-	return ref, delete, err
+	return strings.Join(refs, " "), len(refs) == 0, nil
 }
 
 // adjustFormula provides a function to adjust formula reference and shared
@@ -533,11 +534,12 @@ func (f *File) adjustTable(ws *xlsxWorksheet, sheet string, dir adjustDirection,
 		}
 		t.Ref, _ = f.coordinatesToRangeRef([]int{x1, y1, x2, y2})
 		if t.AutoFilter != nil {
-			t.AutoFilter.Ref = t.Ref
+			// The auto filter range excludes the totals row of the table
+			t.AutoFilter.Ref, _ = f.coordinatesToRangeRef([]int{x1, y1, x2, y2 - t.TotalsRowCount})
 		}
 		_ = f.setTableColumns(sheet, true, x1, y1, x2, &t)
 		// Currently doesn't support query table
-		t.TableType, t.TotalsRowCount, t.ConnectionID = "", 0, 0
+		t.TableType, t.ConnectionID = "", 0
 		table, _ := xml.Marshal(t)
 		f.saveFileList(tableXML, table)
 	}
@@ -603,7 +605,7 @@ func (f *File) adjustMergeCells(ws *xlsxWorksheet, sheet string, dir adjustDirec
 	if ws.MergeCells == nil {
 		return nil
 	}
-
+	ws.MergeCells.merged = false
 	for i := 0; i < len(ws.MergeCells.Cells); i++ {
 		mergedCells := ws.MergeCells.Cells[i]
 		mergedCellsRef := mergedCells.Ref
@@ -821,8 +823,11 @@ func (f *File) adjustDataValidations(ws *xlsxWorksheet, sheet string, dir adjust
 			}
 			return err
 		}
+		if err = f.adjustExtDataValidations(worksheet, sheet, sheetN, dir, num, offset); err != nil {
+			return err
+		}
 		if worksheet.DataValidations == nil {
-			return nil
+			continue
 		}
 		for i := 0; i < len(worksheet.DataValidations.DataValidation); i++ {
 			dv := worksheet.DataValidations.DataValidation[i]
@@ -864,6 +869,39 @@ func (f *File) adjustDataValidations(ws *xlsxWorksheet, sheet string, dir adjust
 	return nil
 }
 
+// adjustExtDataValidations updates the range and formulas of data validations
+// stored in the worksheet extension list when inserting or deleting rows or
+// columns.
+func (f *File) adjustExtDataValidations(ws *xlsxWorksheet, sheet, sheetN string, dir adjustDirection, num, offset int) error {
+	dvs, err := f.getX14DataValidations(ws)
+	if err != nil || len(dvs) == 0 {
+		return err
+	}
+	var applyDVs []*decodeX14DataValidation
+	for _, dv := range dvs {
+		if sheet == sheetN {
+			ref, del, err := f.adjustCellRef(dv.Sqref, dir, num, offset)
+			if err != nil {
+				return err
+			}
+			if del {
+				continue
+			}
+			dv.Sqref = ref
+		}
+		for _, formula := range []*decodeX14DVFormula{dv.Formula1, dv.Formula2} {
+			if formula == nil {
+				continue
+			}
+			if formula.F, err = f.adjustFormulaRef(sheet, sheetN, formula.F, false, dir, num, offset); err != nil {
+				return err
+			}
+		}
+		applyDVs = append(applyDVs, dv)
+	}
+	return f.setX14DataValidations(sheetN, ws, applyDVs)
+}
+
 // adjustDrawings updates the starting anchor of the two cell anchor pictures
 // and charts object when inserting or deleting rows or columns.
 func (from *xlsxFrom) adjustDrawings(dir adjustDirection, num, offset int, editAs string) (bool, error) {
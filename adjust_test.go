@@ -982,7 +982,7 @@ func TestAdjustConditionalFormats(t *testing.T) {
 	opts, err := f.GetConditionalFormats("Sheet1")
 	assert.NoError(t, err)
 	assert.Len(t, format, 1)
-	assert.Equal(t, format, opts["C1:D1"])
+	assert.Equal(t, []ConditionalFormatOptions{{Type: "cell", Criteria: "greater than", Format: formatID, Value: "0", Priority: 2}}, opts["C1:D1"])
 
 	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
 	assert.True(t, ok)
@@ -1037,7 +1037,7 @@ func TestAdjustDataValidations(t *testing.T) {
 	assert.NoError(t, f.RemoveCol("Sheet1", "B"))
 	dvs, err = f.GetDataValidations("Sheet1")
 	assert.NoError(t, err)
-	assert.Equal(t, "\"A<,B>,C\",D\t,E',F\"", dvs[2].Formula1)
+	assert.Equal(t, "\"A<,B>,C\",D\t,E',F\"", dvs[1].Formula1)
 
 	dv = NewDataValidation(true)
 	dv.Sqref = "C5:D6"
@@ -1063,6 +1063,68 @@ func TestAdjustDataValidations(t *testing.T) {
 	assert.EqualError(t, f.adjustDataValidations(nil, "Sheet1", columns, 0, 0, 1), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestAdjustMergeCellsConditionalFormatsAndDataValidations(t *testing.T) {
+	f := NewFile()
+	dv := NewDataValidation(true)
+	dv.Sqref = "A2:A5 E8"
+	assert.NoError(t, dv.SetDropList([]string{"1", "2", "3"}))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	formatID, err := f.NewConditionalStyle(&Style{Font: &Font{Color: "09600B"}})
+	assert.NoError(t, err)
+	format := []ConditionalFormatOptions{{Type: "cell", Criteria: "greater than", Format: formatID, Value: "0"}}
+	expected := []ConditionalFormatOptions{{Type: "cell", Criteria: "greater than", Format: formatID, Value: "0", Priority: 1}}
+	for _, ref := range []string{"A2:A5", "B10:B12"} {
+		assert.NoError(t, f.SetConditionalFormat("Sheet1", ref, format))
+	}
+	assert.NoError(t, f.MergeCell("Sheet1", "C2", "D4"))
+	assert.NoError(t, f.MergeCell("Sheet1", "C8", "D9"))
+	// Test insert rows in the middle of the ranges
+	assert.NoError(t, f.InsertRows("Sheet1", 3, 2))
+	dvs, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A2:A7 E10", dvs[0].Sqref)
+	opts, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, opts["A2:A7"])
+	assert.Equal(t, []ConditionalFormatOptions{{Type: "cell", Criteria: "greater than", Format: formatID, Value: "0", Priority: 2}}, opts["B12:B14"])
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "C2:D6", mergeCells[0].GetStartAxis()+":"+mergeCells[0].GetEndAxis())
+	assert.Equal(t, "C10:D11", mergeCells[1].GetStartAxis()+":"+mergeCells[1].GetEndAxis())
+	// Test remove the first row of the ranges
+	assert.NoError(t, f.RemoveRow("Sheet1", 2))
+	dvs, err = f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A2:A6 E9", dvs[0].Sqref)
+	opts, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, opts["A2:A6"])
+	assert.Equal(t, []ConditionalFormatOptions{{Type: "cell", Criteria: "greater than", Format: formatID, Value: "0", Priority: 2}}, opts["B11:B13"])
+	mergeCells, err = f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "C2:D5", mergeCells[0].GetStartAxis()+":"+mergeCells[0].GetEndAxis())
+	assert.Equal(t, "C9:D10", mergeCells[1].GetStartAxis()+":"+mergeCells[1].GetEndAxis())
+	// Test remove the row of the single cell reference in the list
+	assert.NoError(t, f.RemoveRow("Sheet1", 9))
+	dvs, err = f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A2:A6", dvs[0].Sqref)
+	// Test adjust data validations of other worksheet which refer to the
+	// worksheet without data validations
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	_, err = f.NewSheet("Sheet3")
+	assert.NoError(t, err)
+	dv = NewDataValidation(true)
+	dv.Sqref = "A1"
+	dv.SetSqrefDropList("Sheet2!$A$3:$A$5")
+	assert.NoError(t, f.AddDataValidation("Sheet3", dv))
+	assert.NoError(t, f.InsertRows("Sheet2", 1, 1))
+	dvs, err = f.GetDataValidations("Sheet3")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sheet2!$A$4:$A$6", dvs[0].Formula1)
+}
+
 func TestAdjustDrawings(t *testing.T) {
 	f := NewFile()
 	// Test add pictures to sheet with positioning
@@ -16,6 +16,7 @@ import (
 	"container/list"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"math/big"
 	"math/cmplx"
@@ -116,6 +117,19 @@ var (
 		">":  1,
 		">=": 1,
 	}
+	// referenceFuncs defined the formula functions which only use the
+	// references of the arguments instead of the values of the cells
+	referenceFuncs = map[string]bool{
+		"COLUMN":      true,
+		"COLUMNS":     true,
+		"FORMULATEXT": true,
+		"ISFORMULA":   true,
+		"ISREF":       true,
+		"ROW":         true,
+		"ROWS":        true,
+		"SHEET":       true,
+		"SHEETS":      true,
+	}
 	month2num = map[string]int{
 		"january":   1,
 		"february":  2,
@@ -218,8 +232,40 @@ type calcContext struct {
 	mu                sync.Mutex
 	entry             string
 	maxCalcIterations uint
-	iterations        map[string]uint
+	maxCalcChange     float64
+	circular          bool
+	ignoreErrors      bool
+	referenceOnly     bool
+	current           string
+	evaluating        map[string]bool
+	iterations        map[string]formulaArg
 	iterationsCache   map[string]formulaArg
+	arrayFormulas     map[string][]calcArrayFormula
+	arrayEvaluating   map[string]bool
+	arrayResults      map[string]formulaArg
+	tables            []calcTable
+	tablesLoaded      bool
+}
+
+// calcTable defines the worksheet name, name, coordinates and column names of
+// the table in the formula execution context for resolving the structured
+// references.
+type calcTable struct {
+	sheet       string
+	name        string
+	coordinates []int
+	headerRow   bool
+	totalsRow   bool
+	columns     []string
+}
+
+// calcArrayFormula defines the master cell and the range of the array formula
+// or the spill range of the dynamic array formula in the formula execution
+// context.
+type calcArrayFormula struct {
+	master      string
+	coordinates []int
+	dynamic     bool
 }
 
 // cellRef defines the structure of a cell reference.
@@ -346,9 +392,32 @@ type formulaFuncs struct {
 }
 
 // CalcCellValue provides a function to get calculated cell value. This feature
-// is currently in working processing. Iterative calculation, implicit
-// intersection, explicit intersection, array formula, table formula and some
-// other formulas are not supported currently.
+// is currently in working processing. Implicit intersection, explicit
+// intersection, table formula and some other formulas are not supported
+// currently. The cells within the range of an array formula or the spill
+// range of a dynamic array formula will get the corresponding element of the
+// array formula result, and the operators will be evaluated element-wise on
+// the arrays. For example, calculate the legacy array formula {=A1:A3*B1:B3}
+// in the range C1:C3, and get the result of the cell C2:
+//
+//	formulaType, ref := excelize.STCellFormulaTypeArray, "C1:C3"
+//	err := f.SetCellFormula("Sheet1", "C1", "A1:A3*B1:B3",
+//	    excelize.FormulaOpts{Type: &formulaType, Ref: &ref})
+//	result, err := f.CalcCellValue("Sheet1", "C2")
+//
+// Note that this function doesn't change the worksheet, the spill range of a
+// dynamic array formula (the Ref of the formula options) will not be updated
+// by the size of the result. Use the CalcSheet function to calculate the
+// worksheet and update the spill ranges of the dynamic array formulas.
+//
+// By default, the error values in the arguments and the ranges are propagated
+// to the result of the formula. Set the IgnoreErrors field of the options for
+// this function to make the functions AVERAGE, AVERAGEA, MAX, MAXA, MIN, MINA
+// and SUM skip the error values, like the AGGREGATE function does. For
+// example, sum the values in Sheet1!A1:A3 while ignoring the cells that
+// contain #N/A:
+//
+//	result, err := f.CalcCellValue("Sheet1", "B1", excelize.Options{IgnoreErrors: true})
 //
 // Supported formula functions:
 //
@@ -502,6 +571,7 @@ type formulaFuncs struct {
 //	FACTDOUBLE
 //	FALSE
 //	FDIST
+//	FILTER
 //	FIND
 //	FINDB
 //	FINV
@@ -657,6 +727,7 @@ type formulaFuncs struct {
 //	ODDFYIELD
 //	ODDLPRICE
 //	ODDLYIELD
+//	OFFSET
 //	OR
 //	PDURATION
 //	PEARSON
@@ -711,6 +782,7 @@ type formulaFuncs struct {
 //	SEC
 //	SECH
 //	SECOND
+//	SEQUENCE
 //	SERIESSUM
 //	SHEET
 //	SHEETS
@@ -722,6 +794,7 @@ type formulaFuncs struct {
 //	SLN
 //	SLOPE
 //	SMALL
+//	SORT
 //	SQRT
 //	SQRTPI
 //	STANDARDIZE
@@ -775,6 +848,7 @@ type formulaFuncs struct {
 //	TYPE
 //	UNICHAR
 //	UNICODE
+//	UNIQUE
 //	UPPER
 //	VALUE
 //	VALUETOTEXT
@@ -809,13 +883,10 @@ func (f *File) CalcCellValue(sheet, cell string, opts ...Options) (result string
 		styleIdx     int
 		token        formulaArg
 	)
-	if token, err = f.calcCellValue(&calcContext{
-		entry:             fmt.Sprintf("%s!%s", sheet, cell),
-		maxCalcIterations: getOptions(opts...).MaxCalcIterations,
-		iterations:        make(map[string]uint),
-		iterationsCache:   make(map[string]formulaArg),
-	}, sheet, cell); err != nil {
-		result = token.String
+	if token, err = f.calcCellValueIterative(f.newCalcContext(sheet, cell, opts...), sheet, cell); err != nil {
+		if result = token.String; err == ErrCircularReference {
+			result = formulaErrorREF
+		}
 		return
 	}
 	if !rawCellValue {
@@ -834,22 +905,462 @@ func (f *File) CalcCellValue(sheet, cell string, opts ...Options) (result string
 	return
 }
 
+// CalcSheet provides a function to calculate all formulas on the worksheet by
+// given worksheet name, and write the calculated results as the cached values
+// of the formula cells. The intermediate results of the referenced formula
+// cells will be calculated once and reused during the calculation, and the
+// cells without formula will be kept as is. All formulas on the worksheet will
+// be calculated, and returns the first error of evaluation with the cell
+// reference. The results of the array formulas will be written to all cells
+// within the range of the array formulas, and the spill range of the dynamic
+// array formulas will be updated by the size of the results. The options are
+// the same as the CalcCellValue. For example, calculate all formulas on
+// Sheet1 and save the workbook:
+//
+//	if err := f.CalcSheet("Sheet1"); err != nil {
+//	    fmt.Println(err)
+//	}
+//	if err := f.SaveAs("Book1.xlsx"); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) CalcSheet(sheet string, opts ...Options) error {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	var cells []string
+	ws.mu.Lock()
+	for _, row := range ws.SheetData.Row {
+		for _, c := range row.C {
+			if c.F != nil {
+				cells = append(cells, c.R)
+			}
+		}
+	}
+	ws.mu.Unlock()
+	var (
+		firstErr      error
+		ctx           = f.newCalcContext(sheet, "", opts...)
+		results       = make([]formulaArg, len(cells))
+		arrayFormulas = make(map[int]*calcArrayFormula)
+		arrayResults  = make(map[int]formulaArg)
+	)
+	for i, cell := range cells {
+		if ctx.maxCalcIterations > 0 {
+			ctx = f.newCalcContext(sheet, cell, opts...)
+		}
+		ctx.entry = fmt.Sprintf("%s!%s", sheet, cell)
+		if arg, ok := ctx.iterations[ctx.entry]; ok && ctx.maxCalcIterations == 0 {
+			if results[i] = arg; arg.Type == ArgError && firstErr == nil {
+				firstErr = newCalcCellError(sheet, cell, errors.New(arg.Error))
+			}
+			if arrayFormula, result, ok := f.getCalcArrayFormulaResult(ctx, sheet, cell); ok {
+				arrayFormulas[i], arrayResults[i] = arrayFormula, result
+			}
+			continue
+		}
+		if results[i], err = f.calcCellValueIterative(ctx, sheet, cell); err == nil {
+			ctx.iterations[ctx.entry] = results[i]
+			if arrayFormula, result, ok := f.getCalcArrayFormulaResult(ctx, sheet, cell); ok {
+				arrayFormulas[i], arrayResults[i] = arrayFormula, result
+			}
+			continue
+		}
+		if firstErr == nil {
+			firstErr = newCalcCellError(sheet, cell, err)
+		}
+		if results[i].Type != ArgError {
+			results[i] = newErrorFormulaArg(formulaErrorVALUE, err.Error())
+			if err == ErrCircularReference {
+				results[i].String = formulaErrorREF
+			}
+			if inStrSlice([]string{
+				formulaErrorDIV, formulaErrorNAME, formulaErrorNA, formulaErrorNUM,
+				formulaErrorREF, formulaErrorNULL, formulaErrorSPILL, formulaErrorCALC,
+			}, err.Error(), true) != -1 {
+				results[i].String = err.Error()
+			}
+		}
+		if err != ErrCircularReference {
+			ctx.iterations[ctx.entry] = results[i]
+		}
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	for i, cell := range cells {
+		if arrayFormula, ok := arrayFormulas[i]; ok {
+			if result := f.setArrayFormulaCachedValues(ws, arrayFormula, arrayResults[i]); result.Type == ArgError && firstErr == nil {
+				firstErr = newCalcCellError(sheet, cell, errors.New(result.String))
+			}
+			continue
+		}
+		col, row, _ := CellNameToCoordinates(cell)
+		ws.prepareSheetXML(col, row)
+		ws.SheetData.Row[row-1].C[col-1].setCachedValue(results[i])
+	}
+	return firstErr
+}
+
+// getCalcArrayFormulaResult returns the array formula and the whole result of
+// it by given context, worksheet name and cell reference, if the cell is the
+// master cell of an array formula which has been calculated in the context.
+func (f *File) getCalcArrayFormulaResult(ctx *calcContext, sheet, cell string) (*calcArrayFormula, formulaArg, bool) {
+	arrayFormula := f.getCalcArrayFormula(ctx, sheet, cell)
+	if arrayFormula == nil || arrayFormula.master != cell {
+		return nil, newEmptyFormulaArg(), false
+	}
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	result, ok := ctx.arrayResults[fmt.Sprintf("%s!%s", sheet, cell)]
+	return arrayFormula, result, ok
+}
+
+// setArrayFormulaCachedValues set the calculated result of the array formula
+// as the cached values of the cells within the range of the array formula. For
+// the dynamic array formula, the spill range will be updated by the size of
+// the result, and the master cell will get the #SPILL! error if any cell in
+// the spill range is not empty. This function returns the cached value of the
+// master cell.
+func (f *File) setArrayFormulaCachedValues(ws *xlsxWorksheet, arrayFormula *calcArrayFormula, result formulaArg) formulaArg {
+	coordinates := arrayFormula.coordinates
+	col, row := coordinates[0], coordinates[1]
+	if arrayFormula.dynamic {
+		spill := []int{col, row, col, row}
+		if result.Type == ArgMatrix && len(result.Matrix) > 0 && len(result.Matrix[0]) > 0 {
+			spill[2], spill[3] = col+len(result.Matrix[0])-1, row+len(result.Matrix)-1
+		}
+		if spill[2] > MaxColumns || spill[3] > TotalRows || ws.isSpillRangeBlocked(spill, coordinates) {
+			result, spill = newErrorFormulaArg(formulaErrorSPILL, formulaErrorSPILL), []int{col, row, col, row}
+		}
+		for r := coordinates[1]; r <= coordinates[3]; r++ {
+			for c := coordinates[0]; c <= coordinates[2]; c++ {
+				if !cellInRange([]int{c, r}, spill) {
+					ws.prepareSheetXML(c, r)
+					ws.SheetData.Row[r-1].C[c-1].setCachedValue(newEmptyFormulaArg())
+				}
+			}
+		}
+		ref, _ := CoordinatesToCellName(col, row)
+		if spill[2] != col || spill[3] != row {
+			ref, _ = f.coordinatesToRangeRef(spill)
+		}
+		ws.prepareSheetXML(col, row)
+		ws.SheetData.Row[row-1].C[col-1].F.Ref = ref
+		coordinates = spill
+	}
+	for r := coordinates[1]; r <= coordinates[3]; r++ {
+		for c := coordinates[0]; c <= coordinates[2]; c++ {
+			ws.prepareSheetXML(c, r)
+			ws.SheetData.Row[r-1].C[c-1].setCachedValue(arrayFormulaElement(result, r-row, c-col, arrayFormula.dynamic))
+		}
+	}
+	return arrayFormulaElement(result, 0, 0, arrayFormula.dynamic)
+}
+
+// isSpillRangeBlocked returns if any cell in the spill range of the dynamic
+// array formula is not empty by given spill range and the previous spill range
+// coordinates, the cells within the previous spill range will be overwritten.
+func (ws *xlsxWorksheet) isSpillRangeBlocked(spill, prev []int) bool {
+	for r := spill[1]; r <= spill[3] && r <= len(ws.SheetData.Row); r++ {
+		for c := spill[0]; c <= spill[2] && c <= len(ws.SheetData.Row[r-1].C); c++ {
+			if cellInRange([]int{c, r}, prev) {
+				continue
+			}
+			if cell := ws.SheetData.Row[r-1].C[c-1]; cell.F != nil || cell.V != "" || cell.IS != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// newCalcContext create the formula execution context by given worksheet name,
+// cell reference and options.
+func (f *File) newCalcContext(sheet, cell string, opts ...Options) *calcContext {
+	options := getOptions(opts...)
+	if options.MaxCalcIterations == 0 && f.options != nil {
+		options.MaxCalcIterations, options.MaxCalcChange = f.options.MaxCalcIterations, f.options.MaxCalcChange
+	}
+	if options.MaxCalcChange <= 0 {
+		options.MaxCalcChange = 0.001
+	}
+	return &calcContext{
+		entry:             fmt.Sprintf("%s!%s", sheet, cell),
+		maxCalcIterations: options.MaxCalcIterations,
+		maxCalcChange:     options.MaxCalcChange,
+		ignoreErrors:      options.IgnoreErrors,
+		evaluating:        make(map[string]bool),
+		iterations:        make(map[string]formulaArg),
+		iterationsCache:   make(map[string]formulaArg),
+		arrayFormulas:     make(map[string][]calcArrayFormula),
+		arrayEvaluating:   make(map[string]bool),
+		arrayResults:      make(map[string]formulaArg),
+	}
+}
+
+// aggregateErrorArg returns the first error value in the arguments of the
+// aggregate functions, including the error values in the ranges and arrays.
+// The empty formula argument will be returned if there are no error values,
+// or the aggregate functions should skip the error values.
+func (fn *formulaFuncs) aggregateErrorArg(argsList *list.List) formulaArg {
+	if fn.ctx != nil && fn.ctx.ignoreErrors {
+		return newEmptyFormulaArg()
+	}
+	for arg := argsList.Front(); arg != nil; arg = arg.Next() {
+		token := arg.Value.(formulaArg)
+		if token.Type == ArgError {
+			return token
+		}
+		if token.Type == ArgList || token.Type == ArgMatrix {
+			for _, value := range token.ToList() {
+				if value.Type == ArgError {
+					return value
+				}
+			}
+		}
+	}
+	return newEmptyFormulaArg()
+}
+
+// setCachedValue set the calculated result of the formula as the cached value
+// of the cell.
+func (c *xlsxC) setCachedValue(arg formulaArg) {
+	if arg.Type == ArgMatrix && len(arg.Matrix) > 0 && len(arg.Matrix[0]) > 0 {
+		arg = arg.Matrix[0][0]
+	}
+	c.IS = nil
+	switch arg.Type {
+	case ArgNumber:
+		if c.T, c.V = "", strconv.FormatFloat(arg.Number, 'f', -1, 64); arg.Boolean {
+			c.T = "b"
+		}
+	case ArgString:
+		c.T, c.V = "str", arg.String
+	case ArgError:
+		c.T, c.V = "e", arg.String
+	default:
+		c.T, c.V = "", ""
+	}
+}
+
+// calcCellValueIterative calculate cell value by given context, worksheet name
+// and cell reference. When the formula refers to its own cell directly or
+// indirectly and iterative calculation enabled, the formula will be
+// recalculated with the values of the previous iteration, until the maximum
+// iterations reached or all values change by less than the maximum change.
+func (f *File) calcCellValueIterative(ctx *calcContext, sheet, cell string) (result formulaArg, err error) {
+	entry := ctx.entry
+	ctx.evaluating[entry], ctx.current = true, entry
+	defer delete(ctx.evaluating, entry)
+	result, err = f.calcCellValue(ctx, sheet, cell)
+	for i := uint(1); err == nil && ctx.circular && i < ctx.maxCalcIterations; i++ {
+		ctx.iterations[ctx.entry] = result
+		ctx.iterationsCache, ctx.iterations = ctx.iterations, make(map[string]formulaArg)
+		ctx.arrayResults = make(map[string]formulaArg)
+		if result, err = f.calcCellValue(ctx, sheet, cell); err != nil {
+			return
+		}
+		ctx.iterations[ctx.entry] = result
+		if calcMaxChange(ctx.iterationsCache, ctx.iterations) < ctx.maxCalcChange {
+			return
+		}
+	}
+	return
+}
+
+// calcMaxChange returns the maximum amount of change between the cell values
+// of two iterations.
+func calcMaxChange(prev, curr map[string]formulaArg) (delta float64) {
+	for ref, arg := range curr {
+		prevArg, ok := prev[ref]
+		if !ok {
+			return math.Inf(1)
+		}
+		prevNum, currNum := prevArg.ToNumber(), arg.ToNumber()
+		if prevNum.Type != ArgNumber || currNum.Type != ArgNumber {
+			if prevArg.Value() != arg.Value() {
+				return math.Inf(1)
+			}
+			continue
+		}
+		delta = math.Max(delta, math.Abs(currNum.Number-prevNum.Number))
+	}
+	return
+}
+
 // calcCellValue calculate cell value by given context, worksheet name and cell
-// reference.
+// reference. If the cell is within the range of an array formula or the spill
+// range of a dynamic array formula, the corresponding element of the array
+// formula result will be returned.
 func (f *File) calcCellValue(ctx *calcContext, sheet, cell string) (result formulaArg, err error) {
 	var formula string
 	if formula, err = f.GetCellFormula(sheet, cell); err != nil {
 		return
 	}
+	if arrayFormula := f.getCalcArrayFormula(ctx, sheet, cell); arrayFormula != nil &&
+		(formula == "" || arrayFormula.master == cell) {
+		return f.calcArrayFormulaCell(ctx, sheet, cell, arrayFormula)
+	}
+	if formula, err = f.convertStructuredRefs(ctx, sheet, cell, formula); err != nil {
+		return newErrorFormulaArg(err.Error(), err.Error()), err
+	}
 	ps := efp.ExcelParser()
 	tokens := ps.Parse(formula)
 	if tokens == nil {
 		return f.cellResolver(ctx, sheet, cell)
 	}
-	result, err = f.evalInfixExp(ctx, sheet, cell, tokens)
+	if result, err = f.evalInfixExp(ctx, sheet, cell, tokens); err == nil &&
+		result.Type == ArgMatrix && len(result.Matrix) > 0 && len(result.Matrix[0]) > 0 {
+		result = result.Matrix[0][0]
+	}
 	return
 }
 
+// getCalcArrayFormula returns the array formula which the cell participates in
+// by given context, worksheet name and cell reference. The array formulas of
+// the worksheet will be cached in the context.
+func (f *File) getCalcArrayFormula(ctx *calcContext, sheet, cell string) *calcArrayFormula {
+	if ctx == nil {
+		return nil
+	}
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return nil
+	}
+	ctx.mu.Lock()
+	arrayFormulas, ok := ctx.arrayFormulas[sheet]
+	ctx.mu.Unlock()
+	if !ok {
+		arrayFormulas = f.getCalcArrayFormulas(sheet)
+		ctx.mu.Lock()
+		ctx.arrayFormulas[sheet] = arrayFormulas
+		ctx.mu.Unlock()
+	}
+	for i := range arrayFormulas {
+		if cellInRange([]int{col, row}, arrayFormulas[i].coordinates) {
+			return &arrayFormulas[i]
+		}
+	}
+	return nil
+}
+
+// getCalcArrayFormulas returns all array formulas and dynamic array formulas
+// of the worksheet by given worksheet name.
+func (f *File) getCalcArrayFormulas(sheet string) []calcArrayFormula {
+	var arrayFormulas []calcArrayFormula
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return arrayFormulas
+	}
+	var cms []*uint
+	ws.mu.Lock()
+	for _, r := range ws.SheetData.Row {
+		for _, c := range r.C {
+			if c.F == nil || c.F.T != STCellFormulaTypeArray {
+				continue
+			}
+			ref := c.F.Ref
+			if ref == "" {
+				ref = c.R
+			}
+			if !strings.Contains(ref, ":") {
+				ref += ":" + ref
+			}
+			coordinates, err := rangeRefToCoordinates(ref)
+			if err != nil {
+				continue
+			}
+			_ = sortCoordinates(coordinates)
+			arrayFormulas = append(arrayFormulas, calcArrayFormula{master: c.R, coordinates: coordinates})
+			cms = append(cms, c.Cm)
+		}
+	}
+	ws.mu.Unlock()
+	for i, cm := range cms {
+		if cm != nil {
+			arrayFormulas[i].dynamic, _ = f.isDynamicArrayCellMetadata(*cm)
+		}
+	}
+	return arrayFormulas
+}
+
+// calcArrayFormulaCell calculate the cell value by given context, worksheet
+// name, cell reference and the array formula which the cell participates in.
+// The result of the array formula will be calculated once and cached in the
+// context.
+func (f *File) calcArrayFormulaCell(ctx *calcContext, sheet, cell string, arrayFormula *calcArrayFormula) (formulaArg, error) {
+	result, err := f.calcArrayFormula(ctx, sheet, arrayFormula.master)
+	if err != nil {
+		return result, err
+	}
+	col, row, _ := CellNameToCoordinates(cell)
+	return arrayFormulaElement(result, row-arrayFormula.coordinates[1], col-arrayFormula.coordinates[0], arrayFormula.dynamic), err
+}
+
+// calcArrayFormula calculate the result of the array formula by given
+// context, worksheet name and the master cell reference of the array formula.
+func (f *File) calcArrayFormula(ctx *calcContext, sheet, master string) (formulaArg, error) {
+	ref := fmt.Sprintf("%s!%s", sheet, master)
+	ctx.mu.Lock()
+	if result, ok := ctx.arrayResults[ref]; ok {
+		ctx.mu.Unlock()
+		return result, nil
+	}
+	if ctx.arrayEvaluating[ref] {
+		ctx.mu.Unlock()
+		return newErrorFormulaArg(formulaErrorREF, ErrCircularReference.Error()), ErrCircularReference
+	}
+	ctx.arrayEvaluating[ref] = true
+	ctx.mu.Unlock()
+	defer func() {
+		ctx.mu.Lock()
+		delete(ctx.arrayEvaluating, ref)
+		ctx.mu.Unlock()
+	}()
+	formula, err := f.GetCellFormula(sheet, master)
+	if err != nil {
+		return newEmptyFormulaArg(), err
+	}
+	if formula, err = f.convertStructuredRefs(ctx, sheet, master, formula); err != nil {
+		return newErrorFormulaArg(err.Error(), err.Error()), err
+	}
+	ps := efp.ExcelParser()
+	tokens := ps.Parse(formula)
+	if tokens == nil {
+		return newEmptyFormulaArg(), err
+	}
+	result, err := f.evalInfixExp(ctx, sheet, master, tokens)
+	if err == nil {
+		ctx.mu.Lock()
+		ctx.arrayResults[ref] = result
+		ctx.mu.Unlock()
+	}
+	return result, err
+}
+
+// arrayFormulaElement returns the element of the array formula result by given
+// 0-based row and column offset from the master cell. For the legacy array
+// formula, the single value or the single row or column result will be
+// expanded to fill the range of the array formula, and the cells beyond the
+// result will get the #N/A error. For the dynamic array formula, the cells
+// beyond the result will be empty.
+func arrayFormulaElement(result formulaArg, row, col int, dynamic bool) formulaArg {
+	if result.Type != ArgMatrix {
+		if row == 0 && col == 0 || !dynamic {
+			return result
+		}
+		return newEmptyFormulaArg()
+	}
+	if dynamic && (row >= len(result.Matrix) || len(result.Matrix) == 0 || col >= len(result.Matrix[0])) {
+		return newEmptyFormulaArg()
+	}
+	return calcMatrixElement(result, row, col)
+}
+
 // getPriority calculate arithmetic operator priority.
 func getPriority(token efp.Token) (pri int) {
 	pri = tokenPriority[token.TValue]
@@ -905,6 +1416,318 @@ func newEmptyFormulaArg() formulaArg {
 	return formulaArg{Type: ArgEmpty}
 }
 
+// getCalcTables provides a function to get the tables in the workbook for
+// resolving the structured references, the tables will be cached in the
+// formula execution context.
+func (f *File) getCalcTables(ctx *calcContext) []calcTable {
+	if ctx != nil {
+		ctx.mu.Lock()
+		defer ctx.mu.Unlock()
+		if ctx.tablesLoaded {
+			return ctx.tables
+		}
+	}
+	var tables []calcTable
+	for _, sheet := range f.GetSheetList() {
+		sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+		rels, _ := f.relsReader("xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels")
+		if rels == nil {
+			continue
+		}
+		for _, rel := range rels.Relationships {
+			if rel.Type != SourceRelationshipTable {
+				continue
+			}
+			content, ok := f.Pkg.Load(strings.TrimPrefix(strings.ReplaceAll(rel.Target, "..", "xl"), "/"))
+			if !ok {
+				continue
+			}
+			var t xlsxTable
+			if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+				Decode(&t); err != nil && err != io.EOF {
+				continue
+			}
+			coordinates, err := rangeRefToCoordinates(t.Ref)
+			if err != nil {
+				continue
+			}
+			_ = sortCoordinates(coordinates)
+			table := calcTable{
+				sheet: sheet, name: t.Name, coordinates: coordinates,
+				headerRow: t.HeaderRowCount == nil || *t.HeaderRowCount != 0, totalsRow: t.TotalsRowCount > 0,
+			}
+			if t.TableColumns != nil {
+				for _, column := range t.TableColumns.TableColumn {
+					table.columns = append(table.columns, column.Name)
+				}
+			}
+			tables = append(tables, table)
+		}
+	}
+	if ctx != nil {
+		ctx.tables, ctx.tablesLoaded = tables, true
+	}
+	return tables
+}
+
+// isStructuredRefNameChar checks if the given character could be a part of
+// the table name in the structured reference.
+func isStructuredRefNameChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' || r == '\\' || r == '$'
+}
+
+// mayHaveStructuredRefs returns if the formula may contain the structured
+// references, which contains brackets or the names that are not functions,
+// worksheet names, logical values or cell references outside the string
+// literals, so that the tables in the workbook needn't be loaded for the
+// formula without structured references.
+func mayHaveStructuredRefs(formula string) bool {
+	if strings.Contains(formula, "[") {
+		return true
+	}
+	runes := []rune(formula)
+	for i := 0; i < len(runes); i++ {
+		if r := runes[i]; r == '"' || r == '\'' {
+			for i++; i < len(runes) && runes[i] != r; i++ {
+			}
+			continue
+		}
+		if !unicode.IsLetter(runes[i]) && runes[i] != '_' && runes[i] != '\\' {
+			continue
+		}
+		j := i
+		for j < len(runes) && isStructuredRefNameChar(runes[j]) {
+			j++
+		}
+		name := string(runes[i:j])
+		if (j == len(runes) || !strings.ContainsRune("(!:", runes[j])) && (i == 0 || runes[i-1] != ':') &&
+			!strings.EqualFold(name, "TRUE") && !strings.EqualFold(name, "FALSE") {
+			if _, _, err := CellNameToCoordinates(strings.ReplaceAll(name, "$", "")); err != nil {
+				return true
+			}
+		}
+		i = j - 1
+	}
+	return false
+}
+
+// matchStructuredRefBracket returns the index of the bracket which closes the
+// bracket at the given start index in the formula, the escaped characters in
+// the structured reference will be skipped. The -1 will be returned if the
+// bracket not closed.
+func matchStructuredRefBracket(runes []rune, start int) int {
+	var depth int
+	for i := start; i < len(runes); i++ {
+		switch runes[i] {
+		case '\'':
+			i++
+		case '[':
+			depth++
+		case ']':
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// convertStructuredRefs provides a function to convert the structured
+// references in the formula to the cell references by given formula execution
+// context, worksheet name and cell reference of the formula. The structured
+// reference without table name will be resolved against the table which
+// contains the formula cell, and the structured reference with unknown table
+// name will be kept. The error will be returned if the specifier of the
+// structured reference could not be resolved.
+func (f *File) convertStructuredRefs(ctx *calcContext, sheet, cell, formula string) (string, error) {
+	if !mayHaveStructuredRefs(formula) {
+		return formula, nil
+	}
+	tables := f.getCalcTables(ctx)
+	if len(tables) == 0 {
+		return formula, nil
+	}
+	col, row, _ := CellNameToCoordinates(cell)
+	getTable := func(name string) *calcTable {
+		for i := range tables {
+			t := &tables[i]
+			if (name == "" && t.sheet == sheet && col >= t.coordinates[0] && col <= t.coordinates[2] &&
+				row >= t.coordinates[1] && row <= t.coordinates[3]) || (name != "" && strings.EqualFold(t.name, name)) {
+				return t
+			}
+		}
+		return nil
+	}
+	var (
+		buf   strings.Builder
+		runes = []rune(formula)
+	)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == '"' || r == '\'' {
+			j := i + 1
+			for ; j < len(runes); j++ {
+				if runes[j] == r {
+					if j+1 < len(runes) && runes[j+1] == r {
+						j++
+						continue
+					}
+					break
+				}
+			}
+			if j >= len(runes) {
+				j = len(runes) - 1
+			}
+			buf.WriteString(string(runes[i : j+1]))
+			i = j
+			continue
+		}
+		if (i > 0 && isStructuredRefNameChar(runes[i-1])) || (r != '[' && !unicode.IsLetter(r) && r != '_' && r != '\\') {
+			buf.WriteRune(r)
+			continue
+		}
+		j := i
+		for j < len(runes) && isStructuredRefNameChar(runes[j]) {
+			j++
+		}
+		name := string(runes[i:j])
+		if j < len(runes) && runes[j] == '[' {
+			end := matchStructuredRefBracket(runes, j)
+			if t := getTable(name); end != -1 && t != nil &&
+				(end+1 >= len(runes) || (!isStructuredRefNameChar(runes[end+1]) && runes[end+1] != '!')) {
+				ref, err := t.resolve(string(runes[j+1:end]), row)
+				if err != nil {
+					return formula, err
+				}
+				buf.WriteString(ref)
+				i = end
+				continue
+			}
+		} else if t := getTable(name); name != "" && t != nil && (j >= len(runes) || (runes[j] != '(' && runes[j] != '!')) {
+			ref, err := t.resolve("", row)
+			if err != nil {
+				return formula, err
+			}
+			buf.WriteString(ref)
+			i = j - 1
+			continue
+		}
+		if name == "" {
+			buf.WriteRune(r)
+			continue
+		}
+		buf.WriteString(name)
+		i = j - 1
+	}
+	return buf.String(), nil
+}
+
+// resolve provides a function to convert the structured reference specifier
+// of the table to the cell reference by given specifier in the brackets and
+// the row number of the formula cell. The error will be returned if the
+// specifier could not be resolved.
+func (t *calcTable) resolve(spec string, row int) (string, error) {
+	var (
+		items                  []string
+		dataFrom, dataTo       = t.coordinates[1], t.coordinates[3]
+		specials               = map[string]bool{}
+		colFrom, colTo         = -1, -1
+		unescape               = strings.NewReplacer("''", "'", "'[", "[", "']", "]", "'#", "#")
+		fromRow, toRow, hasRow = 0, 0, false
+	)
+	if t.headerRow {
+		dataFrom++
+	}
+	if t.totalsRow {
+		dataTo--
+	}
+	if strings.HasPrefix(spec, "@") {
+		specials["#this row"] = true
+		if spec = strings.TrimSpace(spec[1:]); spec != "" {
+			items = append(items, strings.TrimSuffix(strings.TrimPrefix(spec, "["), "]"))
+		}
+	} else if runes := []rune(strings.TrimSpace(spec)); len(runes) > 0 && runes[0] == '[' {
+		for i := 0; i < len(runes); i++ {
+			if runes[i] != '[' {
+				continue
+			}
+			end := matchStructuredRefBracket(runes, i)
+			if end == -1 {
+				return "", errors.New(formulaErrorREF)
+			}
+			items, i = append(items, string(runes[i+1:end])), end
+		}
+	} else if spec != "" {
+		items = append(items, spec)
+	}
+	for _, item := range items {
+		if strings.HasPrefix(item, "#") {
+			specials[strings.ToLower(strings.TrimSpace(item))] = true
+			continue
+		}
+		idx := inStrSlice(t.columns, unescape.Replace(item), false)
+		if idx == -1 {
+			return "", errors.New(formulaErrorREF)
+		}
+		if colFrom == -1 || idx < colFrom {
+			colFrom = idx
+		}
+		if idx > colTo {
+			colTo = idx
+		}
+	}
+	if colFrom == -1 {
+		colFrom, colTo = 0, t.coordinates[2]-t.coordinates[0]
+	}
+	selectRows := func(from, to int) {
+		if !hasRow || from < fromRow {
+			fromRow = from
+		}
+		if !hasRow || to > toRow {
+			toRow = to
+		}
+		hasRow = true
+	}
+	for special := range specials {
+		switch special {
+		case "#all":
+			selectRows(t.coordinates[1], t.coordinates[3])
+		case "#data":
+			selectRows(dataFrom, dataTo)
+		case "#headers":
+			if !t.headerRow {
+				return "", errors.New(formulaErrorREF)
+			}
+			selectRows(t.coordinates[1], t.coordinates[1])
+		case "#totals":
+			if !t.totalsRow {
+				return "", errors.New(formulaErrorREF)
+			}
+			selectRows(t.coordinates[3], t.coordinates[3])
+		case "#this row":
+			if row < dataFrom || row > dataTo {
+				return "", errors.New(formulaErrorVALUE)
+			}
+			selectRows(row, row)
+		default:
+			return "", errors.New(formulaErrorREF)
+		}
+	}
+	if !hasRow {
+		selectRows(dataFrom, dataTo)
+	}
+	if fromRow > toRow {
+		return "", errors.New(formulaErrorREF)
+	}
+	from, _ := CoordinatesToCellName(t.coordinates[0]+colFrom, fromRow, true)
+	to, _ := CoordinatesToCellName(t.coordinates[0]+colTo, toRow, true)
+	ref := "'" + strings.ReplaceAll(t.sheet, "'", "''") + "'!" + from
+	if from != to {
+		ref += ":" + to
+	}
+	return ref, nil
+}
+
 // evalInfixExp evaluate syntax analysis by given infix expression after
 // lexical analysis. Evaluate an infix expression containing formulas by
 // stacks:
@@ -926,7 +1749,7 @@ func (f *File) evalInfixExp(ctx *calcContext, sheet, cell string, tokens []efp.T
 
 		// out of function stack
 		if opfStack.Len() == 0 {
-			if err = f.parseToken(ctx, sheet, token, opdStack, optStack); err != nil {
+			if err = f.parseToken(ctx, sheet, cell, token, opdStack, optStack); err != nil {
 				return newEmptyFormulaArg(), err
 			}
 		}
@@ -957,12 +1780,8 @@ func (f *File) evalInfixExp(ctx *calcContext, sheet, cell string, tokens []efp.T
 			// current token is args or range, skip next token, order required: parse reference first
 			if token.TSubType == efp.TokenSubTypeRange {
 				if opftStack.Peek().(efp.Token) != opfStack.Peek().(efp.Token) {
-					refTo := f.getDefinedNameRefTo(token.TValue, sheet)
-					if refTo != "" {
-						token.TValue = refTo
-					}
 					// parse reference: must reference at here
-					result, err := f.parseReference(ctx, sheet, token.TValue)
+					result, err := f.parseDefinedNameOrReference(ctx, sheet, cell, token.TValue)
 					if err != nil {
 						return result, err
 					}
@@ -971,11 +1790,7 @@ func (f *File) evalInfixExp(ctx *calcContext, sheet, cell string, tokens []efp.T
 				}
 				if nextToken.TType == efp.TokenTypeArgument || nextToken.TType == efp.TokenTypeFunction {
 					// parse reference: reference or range at here
-					refTo := f.getDefinedNameRefTo(token.TValue, sheet)
-					if refTo != "" {
-						token.TValue = refTo
-					}
-					result, err := f.parseReference(ctx, sheet, token.TValue)
+					result, err := f.parseFuncReference(ctx, sheet, cell, token.TValue, opfStack)
 					if err != nil {
 						return result, err
 					}
@@ -998,7 +1813,7 @@ func (f *File) evalInfixExp(ctx *calcContext, sheet, cell string, tokens []efp.T
 			}
 
 			// check current token is opft
-			if err = f.parseToken(ctx, sheet, token, opfdStack, opftStack); err != nil {
+			if err = f.parseToken(ctx, sheet, cell, token, opfdStack, opftStack); err != nil {
 				return newEmptyFormulaArg(), err
 			}
 
@@ -1056,7 +1871,7 @@ func (f *File) evalInfixExpFunc(ctx *calcContext, sheet, cell string, token, nex
 	prepareEvalInfixExp(opfStack, opftStack, opfdStack, argsStack)
 	// call formula function to evaluate
 	arg := callFuncByName(&formulaFuncs{f: f, sheet: sheet, cell: cell, ctx: ctx}, strings.NewReplacer(
-		"_xlfn.", "", ".", "dot").Replace(opfStack.Peek().(efp.Token).TValue),
+		"_xlfn.", "", "_xlws.", "", ".", "dot").Replace(opfStack.Peek().(efp.Token).TValue),
 		[]reflect.Value{reflect.ValueOf(argsStack.Peek().(*list.List))})
 	if arg.Type == ArgError && opfStack.Len() == 1 {
 		return arg
@@ -1073,10 +1888,6 @@ func (f *File) evalInfixExpFunc(ctx *calcContext, sheet, cell string, token, nex
 		argsStack.Peek().(*list.List).PushBack(arg)
 		return newEmptyFormulaArg()
 	}
-	if arg.Type == ArgMatrix && len(arg.Matrix) > 0 && len(arg.Matrix[0]) > 0 {
-		opdStack.Push(arg.Matrix[0][0])
-		return newEmptyFormulaArg()
-	}
 	opdStack.Push(arg)
 	return newEmptyFormulaArg()
 }
@@ -1274,6 +2085,62 @@ func calcDiv(rOpd, lOpd formulaArg, opdStack *Stack) error {
 	return nil
 }
 
+// calcMatrixElement returns the element of the array operand by given row
+// and column index, the single value or the single row or column array will be
+// expanded to the size of the other operand.
+func calcMatrixElement(opd formulaArg, row, col int) formulaArg {
+	if opd.Type != ArgMatrix {
+		return opd
+	}
+	if len(opd.Matrix) == 1 {
+		row = 0
+	}
+	if row < len(opd.Matrix) && len(opd.Matrix[row]) == 1 {
+		col = 0
+	}
+	if row < len(opd.Matrix) && col < len(opd.Matrix[row]) {
+		return opd.Matrix[row][col]
+	}
+	return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
+}
+
+// calcMatrix evaluate the arithmetic operation element-wise by given operands
+// and operator, at least one of the operands is an array, returns an array of
+// the results.
+func calcMatrix(rOpd, lOpd formulaArg, opt efp.Token) formulaArg {
+	var rows, cols int
+	for _, opd := range []formulaArg{lOpd, rOpd} {
+		if opd.Type != ArgMatrix {
+			continue
+		}
+		if len(opd.Matrix) > rows {
+			rows = len(opd.Matrix)
+		}
+		for _, row := range opd.Matrix {
+			if len(row) > cols {
+				cols = len(row)
+			}
+		}
+	}
+	mtx := make([][]formulaArg, rows)
+	for row := 0; row < rows; row++ {
+		mtx[row] = make([]formulaArg, cols)
+		for col := 0; col < cols; col++ {
+			opdStack := NewStack()
+			if opt.TType != efp.TokenTypeOperatorPrefix {
+				opdStack.Push(calcMatrixElement(lOpd, row, col))
+			}
+			opdStack.Push(calcMatrixElement(rOpd, row, col))
+			if err := calculate(opdStack, opt); err != nil {
+				mtx[row][col] = newErrorFormulaArg(err.Error(), err.Error())
+				continue
+			}
+			mtx[row][col] = opdStack.Pop().(formulaArg)
+		}
+	}
+	return newMatrixFormulaArg(mtx)
+}
+
 // calculate evaluate basic arithmetic operations.
 func calculate(opdStack *Stack, opt efp.Token) error {
 	if opt.TValue == "-" && opt.TType == efp.TokenTypeOperatorPrefix {
@@ -1281,6 +2148,10 @@ func calculate(opdStack *Stack, opt efp.Token) error {
 			return ErrInvalidFormula
 		}
 		opd := opdStack.Pop().(formulaArg)
+		if opd.Type == ArgMatrix {
+			opdStack.Push(calcMatrix(opd, newEmptyFormulaArg(), opt))
+			return nil
+		}
 		opdStack.Push(newNumberFormulaArg(0 - opd.ToNumber().Number))
 	}
 	if opt.TValue == "-" && opt.TType == efp.TokenTypeOperatorInfix {
@@ -1289,6 +2160,10 @@ func calculate(opdStack *Stack, opt efp.Token) error {
 		}
 		rOpd := opdStack.Pop().(formulaArg)
 		lOpd := opdStack.Pop().(formulaArg)
+		if rOpd.Type == ArgMatrix || lOpd.Type == ArgMatrix {
+			opdStack.Push(calcMatrix(rOpd, lOpd, opt))
+			return nil
+		}
 		if err := calcSubtract(rOpd, lOpd, opdStack); err != nil {
 			return err
 		}
@@ -1312,6 +2187,10 @@ func calculate(opdStack *Stack, opt efp.Token) error {
 		}
 		rOpd := opdStack.Pop().(formulaArg)
 		lOpd := opdStack.Pop().(formulaArg)
+		if rOpd.Type == ArgMatrix || lOpd.Type == ArgMatrix {
+			opdStack.Push(calcMatrix(rOpd, lOpd, opt))
+			return nil
+		}
 		if opt.TValue != "&" {
 			if rOpd.Value() == "" {
 				rOpd = newNumberFormulaArg(0)
@@ -1424,17 +2303,20 @@ func formulaArgToToken(arg formulaArg) efp.Token {
 
 // parseToken parse basic arithmetic operator priority and evaluate based on
 // operators and operands.
-func (f *File) parseToken(ctx *calcContext, sheet string, token efp.Token, opdStack, optStack *Stack) error {
+func (f *File) parseToken(ctx *calcContext, sheet, cell string, token efp.Token, opdStack, optStack *Stack) error {
 	// parse reference: must reference at here
 	if token.TSubType == efp.TokenSubTypeRange {
-		refTo := f.getDefinedNameRefTo(token.TValue, sheet)
-		if refTo != "" {
-			token.TValue = refTo
+		result, err := f.parseDefinedNameOrReference(ctx, sheet, cell, token.TValue)
+		if err == ErrCircularReference {
+			return err
 		}
-		result, err := f.parseReference(ctx, sheet, token.TValue)
 		if err != nil {
 			return errors.New(formulaErrorNAME)
 		}
+		if result.Type == ArgMatrix {
+			opdStack.Push(result)
+			return nil
+		}
 		token = formulaArgToToken(result)
 	}
 	if isOperatorPrefixToken(token) {
@@ -1515,7 +2397,60 @@ func (cr *cellRange) prepareCellRange(col, row bool, cellRef cellRef) error {
 	if cr.To.Row < cellRef.Row {
 		cr.To.Row = cellRef.Row
 	}
-	return nil
+	return nil
+}
+
+// parseDefinedNameOrReference parse the reference or the defined name by
+// given worksheet name, cell reference and token value. The defined name
+// which refers to a formula or constant will be evaluated on the cell.
+func (f *File) parseDefinedNameOrReference(ctx *calcContext, sheet, cell, reference string) (formulaArg, error) {
+	refTo := strings.TrimPrefix(f.getDefinedNameRefTo(reference, sheet), "=")
+	if refTo == "" {
+		return f.parseReference(ctx, sheet, reference)
+	}
+	ps := efp.ExcelParser()
+	tokens := ps.Parse(refTo)
+	if len(tokens) == 1 && tokens[0].TSubType == efp.TokenSubTypeRange {
+		return f.parseReference(ctx, sheet, refTo)
+	}
+	if ctx == nil {
+		return f.evalInfixExp(ctx, sheet, cell, tokens)
+	}
+	ref := "name:" + sheet + "!" + strings.ToUpper(reference)
+	ctx.mu.Lock()
+	if ctx.evaluating[ref] {
+		ctx.mu.Unlock()
+		return newErrorFormulaArg(formulaErrorREF, ErrCircularReference.Error()), ErrCircularReference
+	}
+	ctx.evaluating[ref] = true
+	ctx.mu.Unlock()
+	defer func() {
+		ctx.mu.Lock()
+		delete(ctx.evaluating, ref)
+		ctx.mu.Unlock()
+	}()
+	return f.evalInfixExp(ctx, sheet, cell, tokens)
+}
+
+// parseFuncReference parse the reference or defined name in the arguments of
+// the formula function by given context, worksheet name, cell reference, token
+// value and function stack. The formula cells in the reference will not be
+// calculated if the function only uses the reference of the argument, such as
+// ROW and COLUMNS.
+func (f *File) parseFuncReference(ctx *calcContext, sheet, cell, reference string, opfStack *Stack) (formulaArg, error) {
+	if ctx == nil || !referenceFuncs[strings.TrimPrefix(opfStack.Peek().(efp.Token).TValue, "_xlfn.")] {
+		return f.parseDefinedNameOrReference(ctx, sheet, cell, reference)
+	}
+	ctx.mu.Lock()
+	referenceOnly := ctx.referenceOnly
+	ctx.referenceOnly = true
+	ctx.mu.Unlock()
+	defer func() {
+		ctx.mu.Lock()
+		ctx.referenceOnly = referenceOnly
+		ctx.mu.Unlock()
+	}()
+	return f.parseDefinedNameOrReference(ctx, sheet, cell, reference)
 }
 
 // parseReference parse reference and extract values by given reference
@@ -1596,26 +2531,65 @@ func prepareValueRef(cr cellRef, valueRange []int) {
 // cellResolver calc cell value by given worksheet name, cell reference and context.
 func (f *File) cellResolver(ctx *calcContext, sheet, cell string) (formulaArg, error) {
 	var (
-		arg   formulaArg
-		value string
-		err   error
+		arg formulaArg
+		err error
 	)
 	ref := fmt.Sprintf("%s!%s", sheet, cell)
-	if formula, _ := f.GetCellFormula(sheet, cell); len(formula) != 0 {
+	if formula, _ := f.GetCellFormula(sheet, cell); len(formula) != 0 || f.getCalcArrayFormula(ctx, sheet, cell) != nil {
 		ctx.mu.Lock()
-		if ctx.entry != ref {
-			if ctx.iterations[ref] <= f.options.MaxCalcIterations {
-				ctx.iterations[ref]++
-				ctx.mu.Unlock()
-				arg, _ = f.calcCellValue(ctx, sheet, cell)
-				ctx.iterationsCache[ref] = arg
-				return arg, nil
+		if ctx.referenceOnly {
+			ctx.mu.Unlock()
+			return f.cellValueResolver(sheet, cell)
+		}
+		if arg, ok := ctx.iterations[ref]; ok && ctx.maxCalcIterations == 0 {
+			ctx.mu.Unlock()
+			return arg, nil
+		}
+		if !ctx.evaluating[ref] {
+			current := ctx.current
+			ctx.evaluating[ref], ctx.current = true, ref
+			ctx.mu.Unlock()
+			arg, err = f.calcCellValue(ctx, sheet, cell)
+			ctx.mu.Lock()
+			delete(ctx.evaluating, ref)
+			ctx.current = current
+			if err == nil || ctx.maxCalcIterations > 0 {
+				ctx.iterations[ref] = arg
+			}
+			ctx.mu.Unlock()
+			if err != ErrCircularReference {
+				err = nil
 			}
+			return arg, err
+		}
+		// the formula refers to its own cell directly uses the current value
+		// of the cell when the iterative calculation is disabled
+		if ref == ctx.current && ctx.maxCalcIterations == 0 {
+			ctx.mu.Unlock()
+			return f.cellValueResolver(sheet, cell)
+		}
+		ctx.circular = true
+		if ctx.maxCalcIterations == 0 {
 			ctx.mu.Unlock()
-			return ctx.iterationsCache[ref], nil
+			return newErrorFormulaArg(formulaErrorREF, ErrCircularReference.Error()), ErrCircularReference
+		}
+		if arg, ok := ctx.iterationsCache[ref]; ok {
+			ctx.mu.Unlock()
+			return arg, nil
 		}
 		ctx.mu.Unlock()
 	}
+	return f.cellValueResolver(sheet, cell)
+}
+
+// cellValueResolver returns the current value of the cell by given worksheet
+// name and cell reference without calculating the formula.
+func (f *File) cellValueResolver(sheet, cell string) (formulaArg, error) {
+	var (
+		arg   formulaArg
+		value string
+		err   error
+	)
 	if value, err = f.GetCellValue(sheet, cell, Options{RawCellValue: true}); err != nil {
 		return arg, err
 	}
@@ -5803,12 +6777,13 @@ func (fn *formulaFuncs) SUBTOTAL(argsList *list.List) formulaArg {
 //
 //	SUM(number1,[number2],...)
 func (fn *formulaFuncs) SUM(argsList *list.List) formulaArg {
+	if errArg := fn.aggregateErrorArg(argsList); errArg.Type == ArgError {
+		return errArg
+	}
 	var sum float64
 	for arg := argsList.Front(); arg != nil; arg = arg.Next() {
 		token := arg.Value.(formulaArg)
 		switch token.Type {
-		case ArgError:
-			return token
 		case ArgString:
 			if num := token.ToNumber(); num.Type == ArgNumber {
 				sum += num.Number
@@ -6147,6 +7122,9 @@ func (fn *formulaFuncs) AVEDEV(argsList *list.List) formulaArg {
 //
 //	AVERAGE(number1,[number2],...)
 func (fn *formulaFuncs) AVERAGE(argsList *list.List) formulaArg {
+	if errArg := fn.aggregateErrorArg(argsList); errArg.Type == ArgError {
+		return errArg
+	}
 	var args []formulaArg
 	for arg := argsList.Front(); arg != nil; arg = arg.Next() {
 		args = append(args, arg.Value.(formulaArg))
@@ -6163,6 +7141,9 @@ func (fn *formulaFuncs) AVERAGE(argsList *list.List) formulaArg {
 //
 //	AVERAGEA(number1,[number2],...)
 func (fn *formulaFuncs) AVERAGEA(argsList *list.List) formulaArg {
+	if errArg := fn.aggregateErrorArg(argsList); errArg.Type == ArgError {
+		return errArg
+	}
 	var args []formulaArg
 	for arg := argsList.Front(); arg != nil; arg = arg.Next() {
 		args = append(args, arg.Value.(formulaArg))
@@ -10046,6 +11027,9 @@ func calcListMatrixMax(maxa bool, max float64, arg formulaArg) float64 {
 
 // max is an implementation of the formula functions MAX and MAXA.
 func (fn *formulaFuncs) max(maxa bool, argsList *list.List) formulaArg {
+	if errArg := fn.aggregateErrorArg(argsList); errArg.Type == ArgError {
+		return errArg
+	}
 	max := -math.MaxFloat64
 	for token := argsList.Front(); token != nil; token = token.Next() {
 		arg := token.Value.(formulaArg)
@@ -10070,8 +11054,6 @@ func (fn *formulaFuncs) max(maxa bool, argsList *list.List) formulaArg {
 			}
 		case ArgList, ArgMatrix:
 			max = calcListMatrixMax(maxa, max, arg)
-		case ArgError:
-			return arg
 		}
 	}
 	if max == -math.MaxFloat64 {
@@ -10189,6 +11171,9 @@ func calcListMatrixMin(mina bool, min float64, arg formulaArg) float64 {
 
 // min is an implementation of the formula functions MIN and MINA.
 func (fn *formulaFuncs) min(mina bool, argsList *list.List) formulaArg {
+	if errArg := fn.aggregateErrorArg(argsList); errArg.Type == ArgError {
+		return errArg
+	}
 	min := math.MaxFloat64
 	for token := argsList.Front(); token != nil; token = token.Next() {
 		arg := token.Value.(formulaArg)
@@ -10213,8 +11198,6 @@ func (fn *formulaFuncs) min(mina bool, argsList *list.List) formulaArg {
 			}
 		case ArgList, ArgMatrix:
 			min = calcListMatrixMin(mina, min, arg)
-		case ArgError:
-			return arg
 		}
 	}
 	if min == math.MaxFloat64 {
@@ -14079,7 +15062,11 @@ func (fn *formulaFuncs) TEXT(argsList *list.List) formulaArg {
 	if num := value.ToNumber(); num.Type != ArgNumber {
 		cellType = CellTypeSharedString
 	}
-	return newStringFormulaArg(format(value.Value(), fmtText.Value(), false, cellType, nil))
+	date1904, err := fn.f.getDate1904()
+	if err != nil {
+		return newErrorFormulaArg(formulaErrorVALUE, err.Error())
+	}
+	return newStringFormulaArg(format(value.Value(), fmtText.Value(), date1904, cellType, nil))
 }
 
 // prepareTextAfterBefore checking and prepare arguments for the formula
@@ -14251,7 +15238,14 @@ func (fn *formulaFuncs) TEXTJOIN(argsList *list.List) formulaArg {
 	if argsList.Len() > 252 {
 		return newErrorFormulaArg(formulaErrorVALUE, "TEXTJOIN accepts at most 252 arguments")
 	}
-	delimiter := argsList.Front().Value.(formulaArg)
+	delimiter, delimiters := argsList.Front().Value.(formulaArg), []string{}
+	if delimiter.Type == ArgMatrix || delimiter.Type == ArgList {
+		for _, cell := range delimiter.ToList() {
+			delimiters = append(delimiters, cell.Value())
+		}
+	} else {
+		delimiters = append(delimiters, delimiter.Value())
+	}
 	ignoreEmpty := argsList.Front().Next().Value.(formulaArg)
 	if ignoreEmpty.Type != ArgNumber || !ignoreEmpty.Boolean {
 		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
@@ -14260,7 +15254,14 @@ func (fn *formulaFuncs) TEXTJOIN(argsList *list.List) formulaArg {
 	if ok.Type != ArgNumber {
 		return ok
 	}
-	result := strings.Join(args, delimiter.Value())
+	var buf strings.Builder
+	for i, arg := range args {
+		if i > 0 && len(delimiters) > 0 {
+			buf.WriteString(delimiters[(i-1)%len(delimiters)])
+		}
+		buf.WriteString(arg)
+	}
+	result := buf.String()
 	if len(result) > TotalCellChars {
 		return newErrorFormulaArg(formulaErrorVALUE, fmt.Sprintf("TEXTJOIN function exceeds %d characters", TotalCellChars))
 	}
@@ -14849,38 +15850,97 @@ func (fn *formulaFuncs) HYPERLINK(argsList *list.List) formulaArg {
 	return newStringFormulaArg(argsList.Back().Value.(formulaArg).Value())
 }
 
-// calcMatch returns the position of the value by given match type, criteria
-// and lookup array for the formula function MATCH.
-func calcMatch(matchType int, criteria *formulaCriteria, lookupArray []formulaArg) formulaArg {
+// matchWildcardToRegExp convert the lookup text with the '*' and '?' wildcards
+// to the case-insensitive regular expression for the exact match of the
+// formula function MATCH, the tilde (~) escapes the next wildcard character.
+func matchWildcardToRegExp(text string) *regexp.Regexp {
+	var (
+		exp    strings.Builder
+		escape bool
+	)
+	exp.WriteString("(?is)^")
+	for _, char := range text {
+		switch {
+		case escape:
+			exp.WriteString(regexp.QuoteMeta(string(char)))
+			escape = false
+		case char == '~':
+			escape = true
+		case char == '*':
+			exp.WriteString(".*")
+		case char == '?':
+			exp.WriteString(".")
+		default:
+			exp.WriteString(regexp.QuoteMeta(string(char)))
+		}
+	}
+	if escape {
+		exp.WriteString("~")
+	}
+	exp.WriteString("$")
+	return regexp.MustCompile(exp.String())
+}
+
+// compareMatchValue compares the value in the lookup array and the lookup
+// value for the formula function MATCH, the text will be compared in
+// case-insensitive, returns criteriaErr if the types of the values are
+// different.
+func compareMatchValue(lhs, rhs formulaArg) byte {
+	if lhs.Type != rhs.Type || lhs.Boolean != rhs.Boolean {
+		return criteriaErr
+	}
+	switch lhs.Type {
+	case ArgNumber:
+		if lhs.Number == rhs.Number {
+			return criteriaEq
+		}
+		if lhs.Number < rhs.Number {
+			return criteriaL
+		}
+		return criteriaG
+	case ArgString:
+		return map[int]byte{1: criteriaG, -1: criteriaL, 0: criteriaEq}[strings.Compare(strings.ToLower(lhs.Value()), strings.ToLower(rhs.Value()))]
+	}
+	return criteriaErr
+}
+
+// calcMatch returns the position of the value by given match type, lookup
+// value and lookup array for the formula function MATCH. The wildcards are
+// only supported in exact match of the text. If the match type is 1, the
+// lookup array should be sorted in ascending order, and the position of the
+// largest value that is less than or equal to the lookup value will be
+// returned. If the match type is -1, the lookup array should be sorted in
+// descending order, and the position of the smallest value that is greater
+// than or equal to the lookup value will be returned.
+func calcMatch(matchType int, lookupValue formulaArg, lookupArray []formulaArg) formulaArg {
+	if lookupValue.Type == ArgError {
+		return lookupValue
+	}
 	idx := -1
 	switch matchType {
 	case 0:
+		var exp *regexp.Regexp
+		if lookupValue.Type == ArgString {
+			exp = matchWildcardToRegExp(lookupValue.Value())
+		}
 		for i, arg := range lookupArray {
-			if ok, _ := formulaCriteriaEval(arg, criteria); ok {
+			if exp != nil && arg.Type == ArgString && exp.MatchString(arg.Value()) ||
+				exp == nil && compareMatchValue(arg, lookupValue) == criteriaEq {
 				return newNumberFormulaArg(float64(i + 1))
 			}
 		}
-	case -1:
+	case -1, 1:
 		for i, arg := range lookupArray {
-			if ok, _ := formulaCriteriaEval(arg, &formulaCriteria{
-				Type: criteriaGe, Condition: criteria.Condition,
-			}); ok {
-				idx = i
+			result := compareMatchValue(arg, lookupValue)
+			if result == criteriaErr {
 				continue
 			}
-			if criteria.Condition.Type == ArgNumber {
-				break
-			}
-		}
-	case 1:
-		for i, arg := range lookupArray {
-			if ok, _ := formulaCriteriaEval(arg, &formulaCriteria{
-				Type: criteriaLe, Condition: criteria.Condition,
-			}); ok {
+			if result == criteriaEq || (matchType == 1 && result == criteriaL) ||
+				(matchType == -1 && result == criteriaG) {
 				idx = i
 				continue
 			}
-			if criteria.Condition.Type == ArgNumber {
+			if lookupValue.Type == ArgNumber {
 				break
 			}
 		}
@@ -14913,8 +15973,11 @@ func (fn *formulaFuncs) MATCH(argsList *list.List) formulaArg {
 		if matchTypeArg.Type != ArgNumber {
 			return newErrorFormulaArg(formulaErrorVALUE, "MATCH requires numeric match_type argument")
 		}
-		if matchTypeArg.Number == -1 || matchTypeArg.Number == 0 {
-			matchType = int(matchTypeArg.Number)
+		if matchTypeArg.Number < 0 {
+			matchType = -1
+		}
+		if matchTypeArg.Number == 0 {
+			matchType = 0
 		}
 	}
 	switch lookupArrayArg.Type {
@@ -14926,7 +15989,7 @@ func (fn *formulaFuncs) MATCH(argsList *list.List) formulaArg {
 	default:
 		return newErrorFormulaArg(formulaErrorNA, lookupArrayErr)
 	}
-	return calcMatch(matchType, formulaCriteriaParser(argsList.Front().Value.(formulaArg)), lookupArray)
+	return calcMatch(matchType, argsList.Front().Value.(formulaArg), lookupArray)
 }
 
 // TRANSPOSE function 'transposes' an array of cells (i.e. the function copies
@@ -14960,6 +16023,276 @@ func (fn *formulaFuncs) TRANSPOSE(argsList *list.List) formulaArg {
 	return newMatrixFormulaArg(mtx)
 }
 
+// formulaArgToMatrix converts the formula argument to a two-dimensional array,
+// the list will be converted to a single row array, and the single value will
+// be converted to a one by one array.
+func formulaArgToMatrix(arg formulaArg) [][]formulaArg {
+	switch arg.Type {
+	case ArgMatrix:
+		return arg.Matrix
+	case ArgList:
+		return [][]formulaArg{arg.List}
+	default:
+		return [][]formulaArg{{arg}}
+	}
+}
+
+// FILTER function filters a range of data based on the supplied criteria, and
+// returns an array of the rows or columns that meet the criteria. The syntax
+// of the function is:
+//
+//	FILTER(array,include,[if_empty])
+func (fn *formulaFuncs) FILTER(argsList *list.List) formulaArg {
+	if argsList.Len() < 2 || argsList.Len() > 3 {
+		return newErrorFormulaArg(formulaErrorVALUE, "FILTER requires 2 or 3 arguments")
+	}
+	array := formulaArgToMatrix(argsList.Front().Value.(formulaArg))
+	include := formulaArgToMatrix(argsList.Front().Next().Value.(formulaArg))
+	if len(array) == 0 || len(include) == 0 {
+		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+	}
+	rows, cols := len(array), len(array[0])
+	var byCol bool
+	switch {
+	case len(include) == rows && len(include[0]) == 1:
+	case len(include) == 1 && len(include[0]) == cols:
+		byCol = true
+	default:
+		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+	}
+	var matched []int
+	for i, row := range include {
+		for j, cond := range row {
+			if cond.Type == ArgError {
+				return cond
+			}
+			if cond.Type == ArgString {
+				return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+			}
+			if cond.Type == ArgNumber && cond.Number != 0 {
+				matched = append(matched, i+j)
+			}
+		}
+	}
+	if len(matched) == 0 {
+		if argsList.Len() == 3 {
+			return argsList.Back().Value.(formulaArg)
+		}
+		return newErrorFormulaArg(formulaErrorCALC, formulaErrorCALC)
+	}
+	var mtx [][]formulaArg
+	if !byCol {
+		for _, idx := range matched {
+			mtx = append(mtx, array[idx])
+		}
+		return newMatrixFormulaArg(mtx)
+	}
+	for _, row := range array {
+		var mtxRow []formulaArg
+		for _, idx := range matched {
+			mtxRow = append(mtxRow, row[idx])
+		}
+		mtx = append(mtx, mtxRow)
+	}
+	return newMatrixFormulaArg(mtx)
+}
+
+// SEQUENCE function generates a list of sequential numbers in an array. The
+// syntax of the function is:
+//
+//	SEQUENCE(rows,[columns],[start],[step])
+func (fn *formulaFuncs) SEQUENCE(argsList *list.List) formulaArg {
+	if argsList.Len() < 1 || argsList.Len() > 4 {
+		return newErrorFormulaArg(formulaErrorVALUE, "SEQUENCE requires at least 1 argument and at most 4 arguments")
+	}
+	args := []formulaArg{newNumberFormulaArg(1), newNumberFormulaArg(1), newNumberFormulaArg(1), newNumberFormulaArg(1)}
+	i := 0
+	for arg := argsList.Front(); arg != nil; arg = arg.Next() {
+		if arg.Value.(formulaArg).Type != ArgEmpty {
+			if args[i] = arg.Value.(formulaArg).ToNumber(); args[i].Type != ArgNumber {
+				return args[i]
+			}
+		}
+		i++
+	}
+	rows, cols, start, step := int(args[0].Number), int(args[1].Number), args[2].Number, args[3].Number
+	if rows < 1 || cols < 1 {
+		return newErrorFormulaArg(formulaErrorCALC, formulaErrorCALC)
+	}
+	if rows > TotalRows || cols > MaxColumns {
+		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+	}
+	if rows*cols > TotalRows*MaxColumns/1024 {
+		return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
+	}
+	mtx := make([][]formulaArg, rows)
+	for r := 0; r < rows; r++ {
+		mtx[r] = make([]formulaArg, cols)
+		for c := 0; c < cols; c++ {
+			mtx[r][c] = newNumberFormulaArg(start + step*float64(r*cols+c))
+		}
+	}
+	return newMatrixFormulaArg(mtx)
+}
+
+// compareSortValue compares two values in the sort order of the spreadsheet
+// application: numbers, text, logical values, errors and then empty values.
+// The text will be compared case-insensitively.
+func compareSortValue(lhs, rhs formulaArg) int {
+	rank := func(arg formulaArg) int {
+		switch arg.Type {
+		case ArgNumber:
+			if arg.Boolean {
+				return 2
+			}
+			return 0
+		case ArgString:
+			return 1
+		case ArgError:
+			return 3
+		}
+		return 4
+	}
+	if l, r := rank(lhs), rank(rhs); l != r {
+		return l - r
+	}
+	switch lhs.Type {
+	case ArgNumber:
+		if lhs.Number < rhs.Number {
+			return -1
+		}
+		if lhs.Number > rhs.Number {
+			return 1
+		}
+	case ArgString:
+		return strings.Compare(strings.ToLower(lhs.String), strings.ToLower(rhs.String))
+	}
+	return 0
+}
+
+// transposeMatrix transposes the rows and columns of the two-dimensional
+// array.
+func transposeMatrix(mtx [][]formulaArg) [][]formulaArg {
+	if len(mtx) == 0 {
+		return mtx
+	}
+	transposed := make([][]formulaArg, len(mtx[0]))
+	for c := range transposed {
+		transposed[c] = make([]formulaArg, len(mtx))
+		for r := range mtx {
+			if c < len(mtx[r]) {
+				transposed[c][r] = mtx[r][c]
+			}
+		}
+	}
+	return transposed
+}
+
+// getArrayFuncBoolArg returns the boolean value of the optional argument of
+// the dynamic array functions, the empty argument will be treated as FALSE.
+func getArrayFuncBoolArg(arg formulaArg) formulaArg {
+	switch arg.Type {
+	case ArgEmpty:
+		return newBoolFormulaArg(false)
+	case ArgString:
+		return arg.ToBool()
+	case ArgNumber:
+		return newBoolFormulaArg(arg.Number != 0)
+	}
+	return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+}
+
+// SORT function sorts the contents of a range or array in ascending or
+// descending order. The syntax of the function is:
+//
+//	SORT(array,[sort_index],[sort_order],[by_col])
+func (fn *formulaFuncs) SORT(argsList *list.List) formulaArg {
+	if argsList.Len() < 1 || argsList.Len() > 4 {
+		return newErrorFormulaArg(formulaErrorVALUE, "SORT requires at least 1 argument and at most 4 arguments")
+	}
+	args := []formulaArg{argsList.Front().Value.(formulaArg), newNumberFormulaArg(1), newNumberFormulaArg(1), newBoolFormulaArg(false)}
+	for arg, i := argsList.Front().Next(), 1; arg != nil; arg, i = arg.Next(), i+1 {
+		if arg.Value.(formulaArg).Type == ArgEmpty {
+			continue
+		}
+		if args[i] = arg.Value.(formulaArg).ToNumber(); i == 3 {
+			args[i] = getArrayFuncBoolArg(arg.Value.(formulaArg))
+		}
+		if args[i].Type != ArgNumber {
+			return args[i]
+		}
+	}
+	mtx, byCol := formulaArgToMatrix(args[0]), args[3].Number == 1
+	if byCol {
+		mtx = transposeMatrix(mtx)
+	}
+	sortIdx, sortOrder := int(args[1].Number), args[2].Number
+	if len(mtx) == 0 || sortIdx < 1 || sortIdx > len(mtx[0]) || (sortOrder != 1 && sortOrder != -1) {
+		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+	}
+	sorted := make([][]formulaArg, len(mtx))
+	copy(sorted, mtx)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sortOrder == -1 {
+			return compareSortValue(sorted[i][sortIdx-1], sorted[j][sortIdx-1]) > 0
+		}
+		return compareSortValue(sorted[i][sortIdx-1], sorted[j][sortIdx-1]) < 0
+	})
+	if byCol {
+		sorted = transposeMatrix(sorted)
+	}
+	return newMatrixFormulaArg(sorted)
+}
+
+// UNIQUE function returns a list of unique values in a list or range. The
+// syntax of the function is:
+//
+//	UNIQUE(array,[by_col],[exactly_once])
+func (fn *formulaFuncs) UNIQUE(argsList *list.List) formulaArg {
+	if argsList.Len() < 1 || argsList.Len() > 3 {
+		return newErrorFormulaArg(formulaErrorVALUE, "UNIQUE requires at least 1 argument and at most 3 arguments")
+	}
+	args := []formulaArg{argsList.Front().Value.(formulaArg), newBoolFormulaArg(false), newBoolFormulaArg(false)}
+	for arg, i := argsList.Front().Next(), 1; arg != nil; arg, i = arg.Next(), i+1 {
+		if args[i] = getArrayFuncBoolArg(arg.Value.(formulaArg)); args[i].Type != ArgNumber {
+			return args[i]
+		}
+	}
+	mtx, byCol, exactlyOnce := formulaArgToMatrix(args[0]), args[1].Number == 1, args[2].Number == 1
+	if byCol {
+		mtx = transposeMatrix(mtx)
+	}
+	var (
+		keys   []string
+		counts = map[string]int{}
+		rows   = map[string][]formulaArg{}
+	)
+	for _, row := range mtx {
+		var key strings.Builder
+		for _, cell := range row {
+			key.WriteString(fmt.Sprintf("%d:%s\x00", cell.Type, strings.ToLower(cell.Value())))
+		}
+		if _, ok := counts[key.String()]; !ok {
+			keys = append(keys, key.String())
+			rows[key.String()] = row
+		}
+		counts[key.String()]++
+	}
+	var unique [][]formulaArg
+	for _, key := range keys {
+		if !exactlyOnce || counts[key] == 1 {
+			unique = append(unique, rows[key])
+		}
+	}
+	if len(unique) == 0 {
+		return newErrorFormulaArg(formulaErrorCALC, formulaErrorCALC)
+	}
+	if byCol {
+		unique = transposeMatrix(unique)
+	}
+	return newMatrixFormulaArg(unique)
+}
+
 // lookupLinearSearch sequentially checks each look value of the lookup array until
 // a match is found or the whole list has been searched.
 func lookupLinearSearch(vertical bool, lookupValue, lookupArray, matchMode, searchMode formulaArg) (int, bool) {
@@ -14972,7 +16305,7 @@ func lookupLinearSearch(vertical bool, lookupValue, lookupArray, matchMode, sear
 		tableArray = lookupArray.Matrix[0]
 	}
 	matchIdx, wasExact := -1, false
-start:
+	var closest formulaArg
 	for i, cell := range tableArray {
 		lhs := cell
 		if lookupValue.Type == ArgNumber {
@@ -14984,17 +16317,30 @@ start:
 		} else if lookupArray.Type == ArgString {
 			lhs = newStringFormulaArg(cell.Value())
 		}
-		if compareFormulaArg(lhs, lookupValue, matchMode, false) == criteriaEq {
-			matchIdx = i
-			wasExact = true
+		result := compareFormulaArg(lhs, lookupValue, matchMode, false)
+		if result == criteriaEq {
+			matchIdx, wasExact = i, true
 			if searchMode.Number == searchModeLinear {
-				break start
+				break
 			}
+			continue
 		}
-		if matchMode.Number == matchModeMinGreater || matchMode.Number == matchModeMaxLess {
-			matchIdx = int(calcMatch(int(matchMode.Number), formulaCriteriaParser(lookupValue), tableArray).Number)
+		if wasExact || cell.Type == ArgEmpty {
 			continue
 		}
+		if (matchMode.Number == matchModeMaxLess && result == criteriaL) ||
+			(matchMode.Number == matchModeMinGreater && result == criteriaG) {
+			if matchIdx != -1 {
+				// Keep the closest item, the last one wins on reverse search
+				order := compareFormulaArg(lhs, closest, matchMode, false)
+				if (matchMode.Number == matchModeMaxLess && order == criteriaL) ||
+					(matchMode.Number == matchModeMinGreater && order == criteriaG) ||
+					(order == criteriaEq && searchMode.Number == searchModeLinear) {
+					continue
+				}
+			}
+			matchIdx, closest = i, lhs
+		}
 	}
 	return matchIdx, wasExact
 }
@@ -15364,6 +16710,16 @@ func (fn *formulaFuncs) INDIRECT(argsList *list.List) formulaArg {
 		cell, err = CoordinatesToCellName(col, row)
 		return
 	}
+	sheet := fn.sheet
+	if idx := strings.LastIndex(refText, "!"); idx != -1 {
+		sheet, refText = refText[:idx], refText[idx+1:]
+		if len(sheet) > 1 && strings.HasPrefix(sheet, "'") && strings.HasSuffix(sheet, "'") {
+			sheet = strings.ReplaceAll(sheet[1:len(sheet)-1], "''", "'")
+		}
+		if _, ok := fn.f.getSheetXMLPath(sheet); !ok {
+			return newErrorFormulaArg(formulaErrorREF, formulaErrorREF)
+		}
+	}
 	refs := strings.Split(refText, ":")
 	fromRef, toRef := refs[0], ""
 	if len(refs) == 2 {
@@ -15384,13 +16740,19 @@ func (fn *formulaFuncs) INDIRECT(argsList *list.List) formulaArg {
 		}
 	}
 	if len(refs) == 1 {
-		value, err := fn.f.GetCellValue(fn.sheet, fromRef)
+		if _, _, err := CellNameToCoordinates(fromRef); err != nil {
+			return newErrorFormulaArg(formulaErrorREF, formulaErrorREF)
+		}
+		arg, err := fn.f.parseReference(fn.ctx, sheet, fromRef)
 		if err != nil {
 			return newErrorFormulaArg(formulaErrorREF, formulaErrorREF)
 		}
-		return newStringFormulaArg(value)
+		return arg
+	}
+	arg, err := fn.f.parseReference(fn.ctx, sheet, fromRef+":"+toRef)
+	if err != nil {
+		return newErrorFormulaArg(formulaErrorREF, formulaErrorREF)
 	}
-	arg, _ := fn.f.parseReference(fn.ctx, fn.sheet, fromRef+":"+toRef)
 	return arg
 }
 
@@ -15438,6 +16800,72 @@ func lookupCol(arr formulaArg, idx int) []formulaArg {
 	return col
 }
 
+// OFFSET function returns a reference to a range that is a specified number
+// of rows and columns from a cell or range of cells. The syntax of the
+// function is:
+//
+//	OFFSET(reference,rows,cols,[height],[width])
+func (fn *formulaFuncs) OFFSET(argsList *list.List) formulaArg {
+	if argsList.Len() < 3 || argsList.Len() > 5 {
+		return newErrorFormulaArg(formulaErrorVALUE, "OFFSET requires 3 to 5 arguments")
+	}
+	reference := argsList.Front().Value.(formulaArg)
+	var rng cellRange
+	if reference.cellRanges != nil && reference.cellRanges.Len() > 0 {
+		rng = reference.cellRanges.Front().Value.(cellRange)
+	} else if reference.cellRefs != nil && reference.cellRefs.Len() > 0 {
+		cr := reference.cellRefs.Front().Value.(cellRef)
+		rng = cellRange{From: cr, To: cr}
+	} else {
+		return newErrorFormulaArg(formulaErrorVALUE, "invalid reference")
+	}
+	coordinates := []int{rng.From.Col, rng.From.Row, rng.To.Col, rng.To.Row}
+	_ = sortCoordinates(coordinates)
+	var args []int
+	for arg := argsList.Front().Next(); arg != nil; arg = arg.Next() {
+		num := arg.Value.(formulaArg).ToNumber()
+		if num.Type != ArgNumber {
+			return num
+		}
+		args = append(args, int(num.Number))
+	}
+	height, width := coordinates[3]-coordinates[1]+1, coordinates[2]-coordinates[0]+1
+	if len(args) > 2 {
+		height = args[2]
+	}
+	if len(args) > 3 {
+		width = args[3]
+	}
+	if height == 0 || width == 0 {
+		return newErrorFormulaArg(formulaErrorREF, formulaErrorREF)
+	}
+	fromRow, fromCol := coordinates[1]+args[0], coordinates[0]+args[1]
+	toRow, toCol := fromRow+height-1, fromCol+width-1
+	if height < 0 {
+		fromRow, toRow = fromRow+height+1, fromRow
+	}
+	if width < 0 {
+		fromCol, toCol = fromCol+width+1, fromCol
+	}
+	if fromRow < 1 || fromCol < 1 || toRow > TotalRows || toCol > MaxColumns {
+		return newErrorFormulaArg(formulaErrorREF, formulaErrorREF)
+	}
+	ref, _ := CoordinatesToCellName(fromCol, fromRow)
+	if fromRow != toRow || fromCol != toCol {
+		to, _ := CoordinatesToCellName(toCol, toRow)
+		ref += ":" + to
+	}
+	sheet := rng.From.Sheet
+	if sheet == "" {
+		sheet = fn.sheet
+	}
+	arg, err := fn.f.parseReference(fn.ctx, sheet, ref)
+	if err != nil {
+		return newErrorFormulaArg(formulaErrorREF, formulaErrorREF)
+	}
+	return arg
+}
+
 // ROW function returns the first row number within a supplied reference or
 // the number of the current row. The syntax of the function is:
 //
@@ -16840,7 +18268,18 @@ func (fn *formulaFuncs) NPV(argsList *list.List) formulaArg {
 	}
 	val, i := 0.0, 1
 	for arg := argsList.Front().Next(); arg != nil; arg = arg.Next() {
-		num := arg.Value.(formulaArg).ToNumber()
+		token := arg.Value.(formulaArg)
+		if token.Type == ArgMatrix || token.Type == ArgList {
+			for _, cell := range token.ToList() {
+				if cell.Type != ArgNumber {
+					continue
+				}
+				val += cell.Number / math.Pow(1+rate.Number, float64(i))
+				i++
+			}
+			continue
+		}
+		num := token.ToNumber()
 		if num.Type != ArgNumber {
 			continue
 		}
@@ -17649,7 +19088,8 @@ func (fn *formulaFuncs) PV(argsList *list.List) formulaArg {
 	return newNumberFormulaArg((((1-math.Pow(1+rate.Number, nper.Number))/rate.Number)*pmt.Number*(1+rate.Number*t.Number) - fv.Number) / math.Pow(1+rate.Number, nper.Number))
 }
 
-// rate is an implementation of the formula function RATE.
+// rate is an implementation of the formula function RATE, it returns #NUM!
+// error if the result doesn't converge after 100 iterations.
 func (fn *formulaFuncs) rate(nper, pmt, pv, fv, t, guess formulaArg) formulaArg {
 	maxIter, iter, isClose, epsMax, rate := 100, 0, false, 1e-6, guess.Number
 	for iter < maxIter && !isClose {
@@ -17669,6 +19109,9 @@ func (fn *formulaFuncs) rate(nper, pmt, pv, fv, t, guess formulaArg) formulaArg
 		iter++
 		rate -= delta
 	}
+	if !isClose || math.IsNaN(rate) || math.IsInf(rate, 0) {
+		return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
+	}
 	return newNumberFormulaArg(rate)
 }
 
@@ -18068,9 +19511,12 @@ func (fn *formulaFuncs) prepareXArgs(values, dates formulaArg) (valuesArg, dates
 	}
 	args, date := list.New(), 0.0
 	for _, arg := range dates.ToList() {
-		args.Init()
-		args.PushBack(arg)
-		dateValue := fn.DATEVALUE(args)
+		dateValue := arg.ToNumber()
+		if arg.Type != ArgNumber {
+			args.Init()
+			args.PushBack(arg)
+			dateValue = fn.DATEVALUE(args)
+		}
 		if dateValue.Type != ArgNumber {
 			err = newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 			return
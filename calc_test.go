@@ -2,6 +2,7 @@ package excelize
 
 import (
 	"container/list"
+	"fmt"
 	"math"
 	"path/filepath"
 	"strings"
@@ -1890,6 +1891,9 @@ func TestCalcCellValue(t *testing.T) {
 		"=TEXTJOIN(\",\",FALSE,A1:C2)":   "1,4,,2,5,",
 		"=TEXTJOIN(\",\",TRUE,A1:C2)":    "1,4,2,5",
 		"=TEXTJOIN(\",\",TRUE,MUNIT(2))": "1,0,0,1",
+		"=TEXTJOIN(A1:B1,TRUE,D1:F1)":    "Month1Team4Sales",
+		"=TEXTJOIN(A1:B1,FALSE,C1:F1)":   "1Month4Team1Sales",
+		"=TEXTJOIN(C1:C2,TRUE,D1:F1)":    "MonthTeamSales",
 		// TRIM
 		"=TRIM(\" trim text \")": "trim text",
 		"=TRIM(0)":               "0",
@@ -2023,6 +2027,15 @@ func TestCalcCellValue(t *testing.T) {
 		"=LOOKUP(F4+1,F3:F4,F3:F4)":    "53321",
 		"=LOOKUP(1,MUNIT(1))":          "1",
 		"=LOOKUP(1,MUNIT(1),MUNIT(1))": "1",
+		// OFFSET
+		"=OFFSET(A1,1,0)":                 "2",
+		"=OFFSET(A1:A1,2,4)":              "North 2",
+		"=SUM(OFFSET(A1,0,0,3,2))":        "15",
+		"=SUM(OFFSET(A1:B2,1,0))":         "10",
+		"=SUM(OFFSET(B3,-1,-1,-2,2))":     "12",
+		"=SUM(OFFSET(D2,0,2,COUNT(F:F)))": "304113",
+		"=ROW(OFFSET(A1,2,0))":            "3",
+		"=COLUMN(OFFSET(A1,0,3))":         "4",
 		// ROW
 		"=ROW()":                "1",
 		"=ROW(Sheet1!A1)":       "1",
@@ -2174,6 +2187,7 @@ func TestCalcCellValue(t *testing.T) {
 		// PMT
 		"=PMT(0,8,0,5000,1)":       "-625",
 		"=PMT(0.035/4,8,0,5000,1)": "-600.852027180466",
+		"=PMT(6%/12,360,200000)":   "-1199.10105030552",
 		// PRICE
 		"=PRICE(\"04/01/2012\",\"02/01/2020\",12%,10%,100,2)":   "110.655105178443",
 		"=PRICE(\"04/01/2012\",\"02/01/2020\",12%,10%,100,2,4)": "110.655105178443",
@@ -2197,6 +2211,7 @@ func TestCalcCellValue(t *testing.T) {
 		"=RATE(60,-1000,50000)":       "0.0061834131621292",
 		"=RATE(24,-800,0,20000,1)":    "0.00325084350160374",
 		"=RATE(48,-200,8000,3,1,0.5)": "0.0080412665831637",
+		"=RATE(360,-1199.1,200000)":   "0.00499999319311929",
 		// RECEIVED
 		"=RECEIVED(\"04/01/2011\",\"03/31/2016\",1000,4.5%)":   "1290.32258064516",
 		"=RECEIVED(\"04/01/2011\",\"03/31/2016\",1000,4.5%,0)": "1290.32258064516",
@@ -4069,6 +4084,17 @@ func TestCalcCellValue(t *testing.T) {
 		"=LOOKUP(D2,D1,D2,FALSE)":       {"#VALUE!", "LOOKUP requires at most 3 arguments"},
 		"=LOOKUP(1,MUNIT(0))":           {"#VALUE!", "LOOKUP requires not empty range as second argument"},
 		"=LOOKUP(D1,MUNIT(1),MUNIT(1))": {"#N/A", "LOOKUP no result found"},
+		// OFFSET
+		"=OFFSET()":             {"#VALUE!", "OFFSET requires 3 to 5 arguments"},
+		"=OFFSET(A1,0,0,1,1,1)": {"#VALUE!", "OFFSET requires 3 to 5 arguments"},
+		"=OFFSET(1,0,0)":        {"#VALUE!", "invalid reference"},
+		"=OFFSET(A1,\"\",0)":    {"#VALUE!", "strconv.ParseFloat: parsing \"\": invalid syntax"},
+		"=OFFSET(A1,-1,0)":      {"#REF!", "#REF!"},
+		"=OFFSET(A1,0,-1)":      {"#REF!", "#REF!"},
+		"=OFFSET(A1,1048576,0)": {"#REF!", "#REF!"},
+		"=OFFSET(A1,0,0,0)":     {"#REF!", "#REF!"},
+		"=OFFSET(A1,0,0,1,0)":   {"#REF!", "#REF!"},
+		"=OFFSET(A1,0,16384)":   {"#REF!", "#REF!"},
 		// ROW
 		"=ROW(1,2)":          {"#VALUE!", "ROW requires at most 1 argument"},
 		"=ROW(\"\")":         {"#VALUE!", "invalid reference"},
@@ -4507,6 +4533,7 @@ func TestCalcCellValue(t *testing.T) {
 		"=RATE(48,-200,8000,\"\",1,0.5)": {"#VALUE!", "strconv.ParseFloat: parsing \"\": invalid syntax"},
 		"=RATE(48,-200,8000,3,\"\",0.5)": {"#VALUE!", "strconv.ParseFloat: parsing \"\": invalid syntax"},
 		"=RATE(48,-200,8000,3,1,\"\")":   {"#VALUE!", "strconv.ParseFloat: parsing \"\": invalid syntax"},
+		"=RATE(10,1000,1000)":            {"#NUM!", "#NUM!"},
 		// RECEIVED
 		"=RECEIVED()": {"#VALUE!", "RECEIVED requires at least 4 arguments"},
 		"=RECEIVED(\"04/01/2011\",\"03/31/2016\",1000,4.5%,1,0)":  {"#VALUE!", "RECEIVED allows at most 5 arguments"},
@@ -4731,6 +4758,37 @@ func TestCalcWithDefinedName(t *testing.T) {
 	result, err = f.CalcCellValue("Sheet1", "D1")
 	assert.NoError(t, err)
 	assert.Equal(t, "YES", result, "=IF(\"B1_as_string\"=defined_name1,\"YES\",\"NO\")")
+
+	// Test calculate the defined names refer to formulas and constants
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Rate", RefersTo: "0.5"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Rate", RefersTo: "2", Scope: "Sheet1"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Label", RefersTo: "\"Total: \""}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "=defined_name2*rate"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "CurrentRow", RefersTo: "ROW()"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Loop", RefersTo: "Loop+1"}))
+	for formula, expected := range map[string]string{
+		"=Amount":           "246",
+		"=SUM(Amount,Rate)": "248",
+		"=Label&Amount":     "Total: 246",
+		"=CurrentRow*10":    "10",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "D1", formula))
+		result, err = f.CalcCellValue("Sheet1", "D1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	// Test calculate the defined name with worksheet scope on other worksheet
+	assert.NoError(t, f.SetCellFormula("Sheet2", "A1", "=Amount"))
+	result, err = f.CalcCellValue("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "61.5", result)
+	// Test calculate the defined name with circular reference
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "=Loop"))
+	result, err = f.CalcCellValue("Sheet1", "D1")
+	assert.Equal(t, ErrCircularReference, err)
+	assert.Equal(t, formulaErrorREF, result)
 }
 
 func TestCalcISBLANK(t *testing.T) {
@@ -4834,6 +4892,167 @@ func TestCalcTRANSPOSE(t *testing.T) {
 	assert.NoError(t, err, formula)
 }
 
+func TestCalcArrayFormula(t *testing.T) {
+	f := prepareCalcData([][]interface{}{
+		{1, 4},
+		{2, 5},
+		{3, 6},
+	})
+	formulaType := STCellFormulaTypeArray
+	for cell, ref := range map[string]string{"C1": "C1:C3", "D1": "D1:D4"} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", cell, "A1:A3*B1:B3", FormulaOpts{Type: &formulaType, Ref: &ref}))
+	}
+	ref := "E1:F2"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E1", "1+1", FormulaOpts{Type: &formulaType, Ref: &ref}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "G1", "SUM(A1:A3*B1:B3)"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "G2", "C2+1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "G3", "-A1:A3"))
+	for cell, expected := range map[string]string{
+		"C1": "4", "C2": "10", "C3": "18", "D4": "#N/A",
+		"E1": "2", "F2": "2", "G1": "32", "G2": "11", "G3": "-1",
+	} {
+		result, err := f.CalcCellValue("Sheet1", cell)
+		assert.NoError(t, err, cell)
+		assert.Equal(t, expected, result, cell)
+	}
+	// Test calculate worksheet with legacy array formulas
+	assert.NoError(t, f.CalcSheet("Sheet1"))
+	for cell, expected := range map[string]string{"C3": "18", "D3": "18", "D4": "#N/A", "F1": "2"} {
+		result, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err, cell)
+		assert.Equal(t, expected, result, cell)
+	}
+	// Test calculate array formula with circular reference
+	ref = "H1:H3"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "H1", "H1:H3+1", FormulaOpts{Type: &formulaType, Ref: &ref}))
+	_, err := f.CalcCellValue("Sheet1", "H2")
+	assert.Equal(t, ErrCircularReference, err)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "H1", ""))
+
+	// Test calculate worksheet with dynamic array formulas
+	assert.NoError(t, f.SetCellFormula("Sheet1", "J1", "SEQUENCE(3,2)", FormulaOpts{Dynamic: true}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "L1", "_xlfn._xlws.SORT(A1:A3,1,-1)", FormulaOpts{Dynamic: true}))
+	result, err := f.CalcCellValue("Sheet1", "J1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", result)
+	assert.NoError(t, f.CalcSheet("Sheet1"))
+	for cell, expected := range map[string]string{"J1": "J1:K3", "L1": "L1:L3"} {
+		ref, err := f.GetArrayFormulaRange("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, ref)
+	}
+	for cell, expected := range map[string]string{"K3": "6", "L1": "3", "L3": "1"} {
+		result, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err, cell)
+		assert.Equal(t, expected, result, cell)
+	}
+	// Test read the cells in the spill range of the dynamic array formula
+	assert.NoError(t, f.SetCellFormula("Sheet1", "M1", "SUM(J1:K3)"))
+	result, err = f.CalcCellValue("Sheet1", "M1")
+	assert.NoError(t, err)
+	assert.Equal(t, "21", result)
+	result, err = f.CalcCellValue("Sheet1", "K2")
+	assert.NoError(t, err)
+	assert.Equal(t, "4", result)
+	// Test shrink the spill range of the dynamic array formula
+	assert.NoError(t, f.SetCellFormula("Sheet1", "J1", "SEQUENCE(2)", FormulaOpts{Dynamic: true}))
+	assert.NoError(t, f.CalcSheet("Sheet1"))
+	ref, err = f.GetArrayFormulaRange("Sheet1", "J1")
+	assert.NoError(t, err)
+	assert.Equal(t, "J1:J2", ref)
+	result, err = f.GetCellValue("Sheet1", "K3")
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+	// Test calculate dynamic array formula with blocked spill range
+	assert.NoError(t, f.SetCellValue("Sheet1", "J4", "X"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "J1", "SEQUENCE(5)", FormulaOpts{Dynamic: true}))
+	assert.EqualError(t, f.CalcSheet("Sheet1"), "failed to calculate cell Sheet1!J1: #SPILL!")
+	result, err = f.GetCellValue("Sheet1", "J1")
+	assert.NoError(t, err)
+	assert.Equal(t, formulaErrorSPILL, result)
+	ref, err = f.GetArrayFormulaRange("Sheet1", "J1")
+	assert.NoError(t, err)
+	assert.Equal(t, "J1", ref)
+	// Test calculate dynamic array formula with single value result
+	assert.NoError(t, f.SetCellFormula("Sheet1", "J1", "1+1", FormulaOpts{Dynamic: true}))
+	assert.NoError(t, f.CalcSheet("Sheet1"))
+	result, err = f.GetCellValue("Sheet1", "J1")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", result)
+	assert.Equal(t, newEmptyFormulaArg(), arrayFormulaElement(newNumberFormulaArg(2), 1, 0, true))
+	assert.Equal(t, newEmptyFormulaArg(), arrayFormulaElement(newMatrixFormulaArg([][]formulaArg{{newNumberFormulaArg(2)}}), 1, 0, true))
+}
+
+func TestCalcDynamicArrayFunctions(t *testing.T) {
+	fn := formulaFuncs{f: NewFile()}
+	num := func(values ...float64) []formulaArg {
+		var args []formulaArg
+		for _, v := range values {
+			args = append(args, newNumberFormulaArg(v))
+		}
+		return args
+	}
+	newList := func(args ...formulaArg) *list.List {
+		argsList := list.New()
+		for _, arg := range args {
+			argsList.PushBack(arg)
+		}
+		return argsList
+	}
+	data := newMatrixFormulaArg([][]formulaArg{
+		{newStringFormulaArg("b"), newNumberFormulaArg(2)},
+		{newStringFormulaArg("a"), newNumberFormulaArg(1)},
+		{newStringFormulaArg("B"), newNumberFormulaArg(3)},
+		{newStringFormulaArg("a"), newNumberFormulaArg(1)},
+	})
+	column := newMatrixFormulaArg([][]formulaArg{num(1), num(0), num(1), num(0)})
+	// Test FILTER function
+	assert.Equal(t, newMatrixFormulaArg([][]formulaArg{data.Matrix[0], data.Matrix[2]}), fn.FILTER(newList(data, column)))
+	assert.Equal(t, newMatrixFormulaArg([][]formulaArg{{data.Matrix[0][1]}, {data.Matrix[1][1]}, {data.Matrix[2][1]}, {data.Matrix[3][1]}}),
+		fn.FILTER(newList(data, newMatrixFormulaArg([][]formulaArg{{newBoolFormulaArg(false), newBoolFormulaArg(true)}}))))
+	empty := newMatrixFormulaArg([][]formulaArg{num(0), num(0), num(0), num(0)})
+	assert.Equal(t, newStringFormulaArg("none"), fn.FILTER(newList(data, empty, newStringFormulaArg("none"))))
+	assert.Equal(t, formulaErrorCALC, fn.FILTER(newList(data, empty)).String)
+	assert.Equal(t, formulaErrorVALUE, fn.FILTER(newList(data, newNumberFormulaArg(1))).String)
+	assert.Equal(t, formulaErrorVALUE, fn.FILTER(newList(data, newMatrixFormulaArg([][]formulaArg{{newStringFormulaArg("x")}, num(0), num(0), num(0)}))).String)
+	assert.Equal(t, formulaErrorNA, fn.FILTER(newList(data, newMatrixFormulaArg([][]formulaArg{{newErrorFormulaArg(formulaErrorNA, formulaErrorNA)}, num(0), num(0), num(0)}))).String)
+	assert.Equal(t, formulaErrorVALUE, fn.FILTER(newList(newMatrixFormulaArg(nil), column)).String)
+	assert.Equal(t, "FILTER requires 2 or 3 arguments", fn.FILTER(newList(data)).Error)
+	// Test SEQUENCE function
+	assert.Equal(t, newMatrixFormulaArg([][]formulaArg{num(10, 8), num(6, 4)}),
+		fn.SEQUENCE(newList(newNumberFormulaArg(2), newNumberFormulaArg(2), newNumberFormulaArg(10), newNumberFormulaArg(-2))))
+	assert.Equal(t, newMatrixFormulaArg([][]formulaArg{num(1), num(2)}), fn.SEQUENCE(newList(newNumberFormulaArg(2), newEmptyFormulaArg())))
+	assert.Equal(t, formulaErrorCALC, fn.SEQUENCE(newList(newNumberFormulaArg(0))).String)
+	assert.Equal(t, formulaErrorVALUE, fn.SEQUENCE(newList(newNumberFormulaArg(TotalRows+1))).String)
+	assert.Equal(t, formulaErrorNUM, fn.SEQUENCE(newList(newNumberFormulaArg(TotalRows), newNumberFormulaArg(MaxColumns))).String)
+	assert.Equal(t, formulaErrorVALUE, fn.SEQUENCE(newList(newStringFormulaArg("x"))).String)
+	assert.Equal(t, "SEQUENCE requires at least 1 argument and at most 4 arguments", fn.SEQUENCE(newList()).Error)
+	// Test SORT function
+	assert.Equal(t, newMatrixFormulaArg([][]formulaArg{data.Matrix[1], data.Matrix[3], data.Matrix[0], data.Matrix[2]}), fn.SORT(newList(data)))
+	assert.Equal(t, newMatrixFormulaArg([][]formulaArg{data.Matrix[2], data.Matrix[0], data.Matrix[1], data.Matrix[3]}),
+		fn.SORT(newList(data, newNumberFormulaArg(2), newNumberFormulaArg(-1))))
+	assert.Equal(t, newMatrixFormulaArg([][]formulaArg{{newNumberFormulaArg(1), newStringFormulaArg("a"), newBoolFormulaArg(true), newEmptyFormulaArg()}}),
+		fn.SORT(newList(newMatrixFormulaArg([][]formulaArg{{newEmptyFormulaArg(), newStringFormulaArg("a"), newBoolFormulaArg(true), newNumberFormulaArg(1)}}),
+			newEmptyFormulaArg(), newEmptyFormulaArg(), newBoolFormulaArg(true))))
+	assert.Equal(t, formulaErrorVALUE, fn.SORT(newList(data, newNumberFormulaArg(3))).String)
+	assert.Equal(t, formulaErrorVALUE, fn.SORT(newList(data, newNumberFormulaArg(1), newNumberFormulaArg(0))).String)
+	assert.Equal(t, formulaErrorVALUE, fn.SORT(newList(data, newStringFormulaArg("x"))).String)
+	assert.Equal(t, formulaErrorVALUE, fn.SORT(newList(data, newNumberFormulaArg(1), newNumberFormulaArg(1), newStringFormulaArg("x"))).String)
+	assert.Equal(t, "SORT requires at least 1 argument and at most 4 arguments", fn.SORT(newList()).Error)
+	assert.Equal(t, 0, compareSortValue(newErrorFormulaArg(formulaErrorNA, formulaErrorNA), newErrorFormulaArg(formulaErrorNA, formulaErrorNA)))
+	// Test UNIQUE function
+	assert.Equal(t, newMatrixFormulaArg([][]formulaArg{data.Matrix[0], data.Matrix[1], data.Matrix[2]}), fn.UNIQUE(newList(data)))
+	assert.Equal(t, newMatrixFormulaArg([][]formulaArg{data.Matrix[0], data.Matrix[2]}),
+		fn.UNIQUE(newList(data, newBoolFormulaArg(false), newBoolFormulaArg(true))))
+	assert.Equal(t, newMatrixFormulaArg([][]formulaArg{num(1, 2)}),
+		fn.UNIQUE(newList(newMatrixFormulaArg([][]formulaArg{num(1, 2, 1)}), newStringFormulaArg("TRUE"))))
+	assert.Equal(t, formulaErrorCALC, fn.UNIQUE(newList(newMatrixFormulaArg([][]formulaArg{num(1), num(1)}), newEmptyFormulaArg(), newBoolFormulaArg(true))).String)
+	assert.Equal(t, formulaErrorVALUE, fn.UNIQUE(newList(data, newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE))).String)
+	assert.Equal(t, "UNIQUE requires at least 1 argument and at most 3 arguments", fn.UNIQUE(newList()).Error)
+	assert.Empty(t, transposeMatrix(nil))
+	assert.Equal(t, [][]formulaArg{{{Type: ArgString, String: "a"}}}, formulaArgToMatrix(newListFormulaArg([]formulaArg{newStringFormulaArg("a")})))
+}
+
 func TestCalcVLOOKUP(t *testing.T) {
 	cellData := [][]interface{}{
 		{nil, nil, nil, nil, nil, nil},
@@ -5300,9 +5519,11 @@ func TestCalcIRR(t *testing.T) {
 	cellData := [][]interface{}{{-1}, {0.2}, {0.24}, {0.288}, {0.3456}, {0.4147}}
 	f := prepareCalcData(cellData)
 	formulaList := map[string]string{
-		"=IRR(A1:A4)":      "-0.136189509034157",
-		"=IRR(A1:A6)":      "0.130575760006905",
-		"=IRR(A1:A4,-0.1)": "-0.136189514994621",
+		"=IRR(A1:A4)":        "-0.136189509034157",
+		"=IRR(A1:A6)":        "0.130575760006905",
+		"=IRR(A1:A4,-0.1)":   "-0.136189514994621",
+		"=NPV(0.1,A1:A4)":    "-0.366778225531043",
+		"=NPV(0.1,A1,A2:A4)": "-0.366778225531043",
 	}
 	for formula, expected := range formulaList {
 		assert.NoError(t, f.SetCellFormula("Sheet1", "B1", formula))
@@ -5427,10 +5648,10 @@ func TestCalcSUMIFSAndAVERAGEIFS(t *testing.T) {
 
 func TestCalcXIRR(t *testing.T) {
 	cellData := [][]interface{}{
-		{-100.00, "01/01/2016"},
-		{20.00, "04/01/2016"},
-		{40.00, "10/01/2016"},
-		{25.00, "02/01/2017"},
+		{-100.00, "01/01/2016", nil, 42370},
+		{20.00, "04/01/2016", nil, 42461},
+		{40.00, "10/01/2016", nil, 42644},
+		{25.00, "02/01/2017", nil, 42767},
 		{8.00, "03/01/2017"},
 		{15.00, "06/01/2017"},
 		{-1e-10, "09/01/2017"},
@@ -5438,6 +5659,7 @@ func TestCalcXIRR(t *testing.T) {
 	f := prepareCalcData(cellData)
 	formulaList := map[string]string{
 		"=XIRR(A1:A4,B1:B4)":     "-0.196743861298328",
+		"=XIRR(A1:A4,D1:D4)":     "-0.196743861298328",
 		"=XIRR(A1:A6,B1:B6,0.5)": "0.0944390744445204",
 	}
 	for formula, expected := range formulaList {
@@ -5536,11 +5758,15 @@ func TestCalcXLOOKUP(t *testing.T) {
 		// Test match mode with partial match (wildcards)
 		"=XLOOKUP(\"*p*\",B2:B9,C2:C9,NA(),2)": "30",
 		// Test match mode with approximate match in vertical (next larger item)
-		"=XLOOKUP(32,B2:B9,C2:C9,NA(),1)": "30",
+		"=XLOOKUP(32,C2:C9,B2:B9,NA(),1)": "Pears",
+		"=XLOOKUP(42,C2:C9,B2:B9,NA(),1)": "Oranges",
+		"=XLOOKUP(16,C2:C9,B2:B9,NA(),1)": "Peaches",
 		// Test match mode with approximate match in horizontal (next larger item)
 		"=XLOOKUP(30,C2:F2,C3:F3,NA(),1)": "25",
 		// Test match mode with approximate match in vertical (next smaller item)
 		"=XLOOKUP(40,C2:C9,B2:B9,NA(),-1)": "Pears",
+		"=XLOOKUP(42,C2:C9,B2:B9,NA(),-1)": "Pears",
+		"=XLOOKUP(16,C2:C9,B2:B9,NA(),-1)": "Grapes",
 		// Test match mode with approximate match in horizontal (next smaller item)
 		"=XLOOKUP(29,C2:F2,C3:F3,NA(),-1)": "D3",
 		// Test search mode
@@ -5549,8 +5775,13 @@ func TestCalcXLOOKUP(t *testing.T) {
 		"=XLOOKUP(\"L\",A2:A9,C2:C9,NA(),0,2)":  "50",
 		"=XLOOKUP(\"L\",A2:A9,C2:C9,NA(),0,-2)": "45",
 		// Test match mode and search mode
-		"=XLOOKUP(29,C2:H2,C3:H3,NA(),-1,-1)": "D3",
-		"=XLOOKUP(29,C2:H2,C3:H3,NA(),-1,1)":  "D3",
+		"=XLOOKUP(29,C2:H2,C3:H3,NA(),-1,-1)":     "D3",
+		"=XLOOKUP(29,C2:H2,C3:H3,NA(),-1,1)":      "D3",
+		"=XLOOKUP(20,C2:C9,B2:B9,NA(),1,-1)":      "Oranges",
+		"=XLOOKUP(\"Or*\",B2:B9,C2:C9,NA(),2,-1)": "45",
+		// Test if not found with approximate match
+		"=XLOOKUP(10,C2:C9,B2:B9,\"None\",-1)": "None",
+		"=XLOOKUP(60,C2:C9,B2:B9,\"None\",1)":  "None",
 	}
 	for formula, expected := range formulaList {
 		assert.NoError(t, f.SetCellFormula("Sheet1", "D4", formula))
@@ -5561,6 +5792,8 @@ func TestCalcXLOOKUP(t *testing.T) {
 	calcError = map[string][]string{
 		// Test match mode with exact match
 		"=XLOOKUP(\"*p*\",B2:B9,C2:C9,NA(),0)": {"#N/A", "#N/A"},
+		// Test match mode with approximate match of different data types
+		"=XLOOKUP(32,B2:B9,C2:C9,NA(),1)": {"#N/A", "#N/A"},
 	}
 	for formula, expected := range calcError {
 		assert.NoError(t, f.SetCellFormula("Sheet1", "D3", formula))
@@ -5573,12 +5806,12 @@ func TestCalcXLOOKUP(t *testing.T) {
 func TestCalcXNPV(t *testing.T) {
 	cellData := [][]interface{}{
 		{nil, 0.05},
-		{"01/01/2016", -10000, nil},
-		{"02/01/2016", 2000},
-		{"05/01/2016", 2400},
-		{"07/01/2016", 2900},
-		{"11/01/2016", 3500},
-		{"01/01/2017", 4100},
+		{"01/01/2016", -10000, nil, 42370},
+		{"02/01/2016", 2000, nil, 42401},
+		{"05/01/2016", 2400, nil, 42491},
+		{"07/01/2016", 2900, nil, 42552},
+		{"11/01/2016", 3500, nil, 42675},
+		{"01/01/2017", 4100, nil, 42736},
 		{},
 		{"02/01/2016"},
 		{"01/01/2016"},
@@ -5586,6 +5819,7 @@ func TestCalcXNPV(t *testing.T) {
 	f := prepareCalcData(cellData)
 	formulaList := map[string]string{
 		"=XNPV(B1,B2:B7,A2:A7)": "4447.93800944052",
+		"=XNPV(B1,B2:B7,D2:D7)": "4447.93800944052",
 	}
 	for formula, expected := range formulaList {
 		assert.NoError(t, f.SetCellFormula("Sheet1", "C1", formula))
@@ -5615,26 +5849,51 @@ func TestCalcXNPV(t *testing.T) {
 func TestCalcMATCH(t *testing.T) {
 	f := NewFile()
 	for cell, row := range map[string][]interface{}{
-		"A1": {"cccc", 7, 4, 16},
-		"A2": {"dddd", 2, 6, 11},
-		"A3": {"aaaa", 4, 7, 10},
-		"A4": {"bbbb", 1, 10, 7},
-		"A5": {"eeee", 8, 11, 6},
-		"A6": {nil, 11, 16, 4},
+		"A1":  {"cccc", 7, 4, 16},
+		"A2":  {"dddd", 2, 6, 11},
+		"A3":  {"aaaa", 4, 7, 10},
+		"A4":  {"bbbb", 1, 10, 7},
+		"A5":  {"eeee", 8, 11, 6},
+		"A6":  {nil, 11, 16, 4},
+		"A7":  {"a*b", "TRUE", true, 0},
+		"A8":  {"a?c~", 1, ">5", "apple"},
+		"A9":  {"Banana", 2, "b.n", "cherry"},
+		"A11": {"a", "c", 1},
+		"A12": {"b", "b", "x"},
+		"A13": {"c", "a", 2},
+		"A14": {nil, nil, 3},
 	} {
 		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
 	}
 	formulaList := map[string]string{
-		"=MATCH(\"aaaa\",A1:A6,0)": "3",
-		"=MATCH(\"*b\",A1:A5,0)":   "4",
-		"=MATCH(\"?eee\",A1:A5,0)": "5",
-		"=MATCH(\"?*?e\",A1:A5,0)": "5",
-		"=MATCH(\"aaaa\",A1:A6,1)": "3",
-		"=MATCH(10,B1:B6)":         "5",
-		"=MATCH(8,C1:C6,1)":        "3",
-		"=MATCH(6,B1:B6,-1)":       "1",
-		"=MATCH(10,D1:D6,-1)":      "3",
-		"=MATCH(-10,D1:D6,-1)":     "6",
+		// Test exact match with wildcards and case-insensitive
+		"=MATCH(\"AAAA\",A1:A6,0)":   "3",
+		"=MATCH(\"b*\",A1:A9,0)":     "4",
+		"=MATCH(\"a~*b\",A1:A9,0)":   "7",
+		"=MATCH(\"a~?c~~\",A1:A9,0)": "8",
+		"=MATCH(\"?an*\",A1:A9,0)":   "9",
+		"=MATCH(\"b.n\",C1:C9,0)":    "9",
+		"=MATCH(\">5\",C1:C9,0)":     "8",
+		"=MATCH(TRUE,C1:C9,0)":       "7",
+		"=MATCH(\"TRUE\",B1:B9,0)":   "7",
+		"=MATCH(1,B1:B9,0)":          "4",
+		"=MATCH(\"app*\",D1:D9,0)":   "8",
+		"=MATCH(\"aaaa\",A1:A6,0)":   "3",
+		"=MATCH(\"*b\",A1:A5,0)":     "4",
+		"=MATCH(\"?eee\",A1:A5,0)":   "5",
+		"=MATCH(\"?*?e\",A1:A5,0)":   "5",
+		"=MATCH(\"aaaa\",A1:A6,1)":   "3",
+		"=MATCH(10,B1:B6)":           "5",
+		"=MATCH(8,C1:C6,1)":          "3",
+		"=MATCH(6,B1:B6,-1)":         "1",
+		"=MATCH(10,D1:D6,-1)":        "3",
+		"=MATCH(-10,D1:D6,-1)":       "6",
+		// Test approximate match with sorted values and different types
+		"=MATCH(12,C1:C9,2)":         "5",
+		"=MATCH(5,D1:D9,-2)":         "5",
+		"=MATCH(\"BBBB\",A11:A13,1)": "2",
+		"=MATCH(\"bz\",B11:B13,-1)":  "1",
+		"=MATCH(2,C11:C14,1)":        "3",
 	}
 	for formula, expected := range formulaList {
 		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
@@ -5645,6 +5904,13 @@ func TestCalcMATCH(t *testing.T) {
 	calcError := map[string]string{
 		"=MATCH(3,C1:C6,1)":  "#N/A",
 		"=MATCH(5,C1:C6,-1)": "#N/A",
+		// Test exact match with different types
+		"=MATCH(1,C1:C9,0)":       "#N/A",
+		"=MATCH(\"1\",B1:B9,0)":   "#N/A",
+		"=MATCH(\"app\",D1:D9,0)": "#N/A",
+		"=MATCH(NA(),D1:D9,0)":    "#N/A",
+		// Test approximate match with text
+		"=MATCH(\"a\",A1:A5,1)": "#N/A",
 	}
 	for formula, expected := range calcError {
 		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
@@ -5652,7 +5918,38 @@ func TestCalcMATCH(t *testing.T) {
 		assert.EqualError(t, err, expected, formula)
 		assert.Equal(t, expected, result, formula)
 	}
-	assert.Equal(t, newErrorFormulaArg(formulaErrorNA, formulaErrorNA), calcMatch(2, nil, []formulaArg{}))
+	assert.Equal(t, newErrorFormulaArg(formulaErrorNA, formulaErrorNA), calcMatch(2, newEmptyFormulaArg(), []formulaArg{}))
+}
+
+func TestCalcINDIRECTAndOFFSET(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet 2")
+	assert.NoError(t, err)
+	for idx, value := range []int{10, 20, 30, 40} {
+		assert.NoError(t, f.SetCellValue("Sheet 2", fmt.Sprintf("A%d", idx+1), value))
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet 2", "B1", "=SUM(A1:A4)"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 3))
+	formulaList := map[string]string{
+		"=INDIRECT(\"'Sheet 2'!A2\")":                       "20",
+		"=INDIRECT(\"'Sheet 2'!B1\")":                       "100",
+		"=SUM(INDIRECT(\"'Sheet 2'!A1:A\"&A1))":             "60",
+		"=SUM(INDIRECT(\"'Sheet 2'!R2C1:R4C1\",FALSE))":     "90",
+		"=OFFSET('Sheet 2'!A1,A1,0)":                        "40",
+		"=SUM(OFFSET('Sheet 2'!A1,1,0,A1))":                 "90",
+		"=SUM(OFFSET(INDIRECT(\"'Sheet 2'!A1\"),0,0,A1,1))": "60",
+	}
+	for formula, expected := range formulaList {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "C1", formula))
+		result, err := f.CalcCellValue("Sheet1", "C1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	// Test INDIRECT with not exists worksheet
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "=INDIRECT(\"SheetN!A1\")"))
+	result, err := f.CalcCellValue("Sheet1", "C1")
+	assert.EqualError(t, err, formulaErrorREF)
+	assert.Equal(t, formulaErrorREF, result)
 }
 
 func TestCalcISFORMULA(t *testing.T) {
@@ -6227,6 +6524,149 @@ func TestCalcCellResolver(t *testing.T) {
 	}
 }
 
+func TestCalcCircularReference(t *testing.T) {
+	f := NewFile()
+	for cell, formula := range map[string]string{
+		"A1": "B1+1",
+		"B1": "A1+1",
+		"C1": "SUM(A1:B1)",
+		"D1": "D1+1",
+		"E1": "E2/2+1",
+		"E2": "E1",
+		"F1": "COLUMNS(1:1)",
+		"F2": "ROW(F2)",
+		"G1": "ISFORMULA(G2)",
+		"G2": "G1",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", cell, formula))
+	}
+	// Test calculate circular reference without iterative calculation
+	for _, cell := range []string{"A1", "B1", "C1", "E1"} {
+		result, err := f.CalcCellValue("Sheet1", cell)
+		assert.Equal(t, ErrCircularReference, err, cell)
+		assert.Equal(t, formulaErrorREF, result, cell)
+	}
+	// Test calculate the formula which refers to its own cell directly or only
+	// uses the references of the cells
+	for cell, expected := range map[string]string{
+		"D1": "1", "F1": "16384", "F2": "2", "G1": "TRUE", "G2": "TRUE",
+	} {
+		result, err := f.CalcCellValue("Sheet1", cell)
+		assert.NoError(t, err, cell)
+		assert.Equal(t, expected, result, cell)
+	}
+	// Test calculate circular reference with iterative calculation
+	for cell, expected := range map[string]string{
+		"A1": "20", "B1": "20", "C1": "39", "D1": "10", "E1": "1.998046875",
+	} {
+		result, err := f.CalcCellValue("Sheet1", cell, Options{MaxCalcIterations: 10})
+		assert.NoError(t, err, cell)
+		assert.Equal(t, expected, result, cell)
+	}
+	// Test iterative calculation stops when the maximum change reached
+	result, err := f.CalcCellValue("Sheet1", "E1", Options{MaxCalcIterations: 100, MaxCalcChange: 0.1})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.96875", result)
+	// Test iterative calculation with the options of the spreadsheet
+	f.options.MaxCalcIterations = 5
+	result, err = f.CalcCellValue("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "5", result)
+	// Test get the maximum change with non-numeric values
+	assert.Equal(t, 0.0, calcMaxChange(map[string]formulaArg{"A1": newStringFormulaArg("A")}, map[string]formulaArg{"A1": newStringFormulaArg("A")}))
+	assert.Equal(t, math.Inf(1), calcMaxChange(map[string]formulaArg{"A1": newStringFormulaArg("A")}, map[string]formulaArg{"A1": newStringFormulaArg("B")}))
+	assert.Equal(t, math.Inf(1), calcMaxChange(map[string]formulaArg{}, map[string]formulaArg{"A1": newNumberFormulaArg(1)}))
+}
+
+func TestCalcIgnoreErrors(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, nil, 3}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "NA()"))
+	formulas := map[string]string{
+		"D1":  "SUM(A1,B1,C1)",
+		"D2":  "MAX(A1,B1,C1)",
+		"D3":  "MAXA(A1,B1,C1)",
+		"D4":  "MIN(A1,B1,C1)",
+		"D5":  "MINA(A1,B1,C1)",
+		"D6":  "SUM(A1:C1,NA())",
+		"D7":  "AVERAGE(A1,B1,C1)",
+		"D8":  "AVERAGEA(A1,B1,C1)",
+		"D9":  "AVERAGE(A1:C1,NA())",
+		"D10": "SUM(A1:C1)",
+		"D11": "AVERAGE(A1:C1)",
+		"D12": "AVERAGEA(A1:C1)",
+		"D13": "MAX(A1:C1)",
+		"D14": "MAXA(A1:C1)",
+		"D15": "MIN(A1:C1)",
+		"D16": "MINA(A1:C1)",
+	}
+	for cell, formula := range formulas {
+		assert.NoError(t, f.SetCellFormula("Sheet1", cell, formula))
+	}
+	// Test calculate formulas with error propagation by default
+	for cell := range formulas {
+		result, err := f.CalcCellValue("Sheet1", cell)
+		assert.EqualError(t, err, formulaErrorNA, cell)
+		assert.Equal(t, formulaErrorNA, result, cell)
+	}
+	// Test calculate formulas with ignoring the error values
+	for cell, expected := range map[string]string{
+		"D1": "4", "D2": "3", "D3": "3", "D4": "1", "D5": "1", "D6": "4", "D7": "2", "D8": "2",
+		"D9": "2", "D10": "4", "D11": "2", "D12": "2", "D13": "3", "D14": "3", "D15": "1", "D16": "1",
+	} {
+		result, err := f.CalcCellValue("Sheet1", cell, Options{IgnoreErrors: true})
+		assert.NoError(t, err, cell)
+		assert.Equal(t, expected, result, cell)
+	}
+}
+
+func TestCalcSheet(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, 2, "A"}))
+	for cell, formula := range map[string]string{
+		"B1": "B2*2",
+		"B2": "A1+A2",
+		"B3": "C1&A1",
+		"B4": "A1>0",
+		"B6": "SUM(B1:B2)",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", cell, formula))
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A2", "A1+1"))
+	assert.NoError(t, f.CalcSheet("Sheet1"))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	for cell, expected := range map[string][]string{
+		"A1": {"", "1"},
+		"A2": {"", "2"},
+		"C1": {"s", "0"},
+		"B1": {"", "6"},
+		"B2": {"", "3"},
+		"B3": {"str", "A1"},
+		"B4": {"b", "1"},
+		"B6": {"", "9"},
+	} {
+		col, row, err := CellNameToCoordinates(cell)
+		assert.NoError(t, err)
+		c := ws.(*xlsxWorksheet).SheetData.Row[row-1].C[col-1]
+		assert.Equal(t, expected, []string{c.T, c.V}, cell)
+	}
+	// Test calculate worksheet with formula error
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B2", "1/0"))
+	assert.EqualError(t, f.CalcSheet("Sheet1"), "failed to calculate cell Sheet1!B2: #DIV/0!")
+	c := ws.(*xlsxWorksheet).SheetData.Row[1].C[1]
+	assert.Equal(t, []string{"e", formulaErrorDIV}, []string{c.T, c.V})
+	// Test calculate worksheet with circular reference
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B2", "B6"))
+	assert.EqualError(t, f.CalcSheet("Sheet1"), "failed to calculate cell Sheet1!B1: circular reference detected")
+	c = ws.(*xlsxWorksheet).SheetData.Row[1].C[1]
+	assert.Equal(t, []string{"e", formulaErrorREF}, []string{c.T, c.V})
+	// Test calculate worksheet with iterative calculation
+	assert.NoError(t, f.CalcSheet("Sheet1", Options{MaxCalcIterations: 10}))
+	// Test calculate worksheet on not exists worksheet
+	assert.EqualError(t, f.CalcSheet("SheetN"), "sheet SheetN does not exist")
+}
+
 func TestEvalInfixExp(t *testing.T) {
 	f := NewFile()
 	arg, err := f.evalInfixExp(nil, "Sheet1", "A1", []efp.Token{
@@ -6238,7 +6678,129 @@ func TestEvalInfixExp(t *testing.T) {
 
 func TestParseToken(t *testing.T) {
 	f := NewFile()
-	assert.Equal(t, formulaErrorNAME, f.parseToken(nil, "Sheet1",
+	assert.Equal(t, formulaErrorNAME, f.parseToken(nil, "Sheet1", "A1",
 		efp.Token{TSubType: efp.TokenSubTypeRange, TValue: "1A"}, nil, nil,
 	).Error())
 }
+
+func TestCalcStructuredReferences(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sales Data")
+	assert.NoError(t, err)
+	for idx, row := range [][]interface{}{
+		{"Item", "Amount", "Qty", "Unit [pcs]"}, {"Apple", 10, 2, 1}, {"Orange", 20, 3, 2}, {"Pear", 30, 4, 3},
+	} {
+		assert.NoError(t, f.SetSheetRow("Sales Data", fmt.Sprintf("A%d", idx+1), &row))
+	}
+	assert.NoError(t, f.AddTable("Sales Data", &Table{
+		Range: "A1:E4", Name: "Sales", ShowTotalsRow: true,
+		Columns: []TableColumn{{Name: "Item", TotalsRowLabel: "Total"}, {Name: "Amount", TotalsRowFunction: "sum"}},
+	}))
+	// Test calculate the formulas with table name inside the table
+	for _, cell := range []string{"E2", "E3", "E4"} {
+		assert.NoError(t, f.SetCellFormula("Sales Data", cell, "[@Amount]*Sales[@Qty]"))
+	}
+	assert.NoError(t, f.SetCellFormula("Sales Data", "E5", "SUM(Sales[[#Data],[Column5]])"))
+	for cell, expected := range map[string]string{"E2": "20", "E3": "60", "E4": "120", "E5": "200", "B5": "60"} {
+		result, err := f.CalcCellValue("Sales Data", cell)
+		assert.NoError(t, err, cell)
+		assert.Equal(t, expected, result, cell)
+	}
+	// Test calculate the formulas with structured references on other worksheet
+	for cell, formula := range map[string]string{
+		"A1":  "SUM(Sales[Amount])",
+		"A2":  "SUM(sales[[#Totals],[Amount]])",
+		"A3":  "Sales[[#Headers],[Qty]]",
+		"A4":  "SUM(Sales[[Amount]:[Qty]])",
+		"A5":  "COUNTA(Sales[#All])",
+		"A6":  "SUM(Sales)",
+		"A7":  "ROWS(Sales[[#Headers],[#Data],[Amount]])",
+		"A8":  "SUM(Sales[Unit '[pcs']])",
+		"A9":  "SUM(Sales[ [Amount] , [Qty] ])",
+		"A10": "\"Sales[Amount]\"&LEN('Sales Data'!A1)",
+		"A11": "Sales[[#This Row],[Amount]]",
+		"A12": "SUM(Sales[Price])",
+		"A13": "SUM(Sales[[#Invalid],[Amount]])",
+		"A14": "SUM(Sales[])",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", cell, formula))
+	}
+	for cell, expected := range map[string]string{
+		"A1":  "60",
+		"A2":  "60",
+		"A3":  "Qty",
+		"A4":  "69",
+		"A5":  "23",
+		"A6":  "275",
+		"A7":  "4",
+		"A8":  "6",
+		"A9":  "69",
+		"A10": "Sales[Amount]4",
+		"A14": "275",
+	} {
+		result, err := f.CalcCellValue("Sheet1", cell)
+		assert.NoError(t, err, cell)
+		assert.Equal(t, expected, result, cell)
+	}
+	for cell, expected := range map[string]string{"A11": formulaErrorVALUE, "A12": formulaErrorREF, "A13": formulaErrorREF} {
+		result, err := f.CalcCellValue("Sheet1", cell)
+		assert.EqualError(t, err, expected, cell)
+		assert.Equal(t, expected, result, cell)
+	}
+	// Test resolve the structured references with the table without header and totals row
+	table := calcTable{sheet: "Sheet1", name: "Table1", coordinates: []int{1, 1, 2, 3}, columns: []string{"A", "B"}}
+	for spec, expected := range map[string]string{"": "'Sheet1'!$A$1:$B$3", "@[B]": "'Sheet1'!$B$2", "@": "'Sheet1'!$A$2:$B$2"} {
+		ref, err := table.resolve(spec, 2)
+		assert.NoError(t, err, spec)
+		assert.Equal(t, expected, ref, spec)
+	}
+	for _, spec := range []string{"#Headers", "#Totals", "[#Data],[A"} {
+		_, err := table.resolve(spec, 2)
+		assert.EqualError(t, err, formulaErrorREF, spec)
+	}
+	table.coordinates = []int{1, 1, 2, 1}
+	table.headerRow, table.totalsRow = true, true
+	_, err = table.resolve("", 1)
+	assert.EqualError(t, err, formulaErrorREF)
+	// Test convert the formula without tables and with unknown table names
+	for _, c := range []struct {
+		f                        *File
+		sheet, formula, expected string
+	}{
+		{NewFile(), "Sheet1", "SUM(Table1[A])", "SUM(Table1[A])"},
+		{f, "Sales Data", "SUM(Table1[A],[1]Sheet1!A1,\"a", "SUM(Table1[A],[1]Sheet1!A1,\"a"},
+		{f, "Sales Data", "[Amount]", "'Sales Data'!$B$2:$B$4"},
+		{f, "Sheet1", "[Amount]", "[Amount]"},
+	} {
+		formula, err := c.f.convertStructuredRefs(nil, c.sheet, "B2", c.formula)
+		assert.NoError(t, err, c.formula)
+		assert.Equal(t, c.expected, formula, c.formula)
+	}
+	// Test convert the formula without structured references, the tables
+	// should not be loaded
+	ctx := f.newCalcContext("Sheet1", "B2")
+	formula, err := f.convertStructuredRefs(ctx, "Sheet1", "B2", "SUM('Sales Data'!B2:B4)")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM('Sales Data'!B2:B4)", formula)
+	assert.False(t, ctx.tablesLoaded)
+	formula, err = f.convertStructuredRefs(ctx, "Sheet1", "B2", "SUM(Sales[Amount])")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM('Sales Data'!$B$2:$B$4)", formula)
+	assert.True(t, ctx.tablesLoaded)
+	for formula, expected := range map[string]bool{
+		"SUM(A1:B2,$C$3)":               false,
+		"Sheet1!A:A*2":                  false,
+		"IF(TRUE,\"Sales\",'Sales'!A1)": false,
+		"SUM(Sales)":                    true,
+		"[@Amount]*2":                   true,
+		"MyName+1":                      true,
+	} {
+		assert.Equal(t, expected, mayHaveStructuredRefs(formula), formula)
+	}
+	// Test calculate the array formula with invalid structured reference
+	formulaType, ref := STCellFormulaTypeArray, "B1"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "Sales[Price]", FormulaOpts{Type: &formulaType, Ref: &ref}))
+	result, err := f.CalcCellValue("Sheet1", "B1")
+	assert.EqualError(t, err, formulaErrorREF)
+	assert.Equal(t, formulaErrorREF, result)
+}
@@ -15,8 +15,10 @@ import (
 	"bytes"
 	"encoding/xml"
 	"fmt"
+	"io"
 	"os"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -65,7 +67,11 @@ var cellTypes = map[string]CellType{
 // converted to the 'string' data type. This function is concurrency safe. If
 // the cell format can be applied to the value of a cell, the applied value
 // will be returned, otherwise the original value will be returned. All cells'
-// values will be the same in a merged range.
+// values will be the same in a merged range. Set the RawCellValue option to
+// true to skip applying the number format and get the raw stored value, for
+// example, get the date and time serial number of the cell on Sheet1:
+//
+//	val, err := f.GetCellValue("Sheet1", "A1", excelize.Options{RawCellValue: true})
 func (f *File) GetCellValue(sheet, cell string, opts ...Options) (string, error) {
 	return f.getCellStringFunc(sheet, cell, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
 		sst, err := f.sharedStringsReader()
@@ -124,6 +130,21 @@ func (f *File) GetCellType(sheet, cell string) (CellType, error) {
 // times can not representation in Go language time.Time data type. Please set
 // the cell value as number 0 or 60, then create and bind the date-time number
 // format style for the cell.
+//
+// If the AutoHyperlink option of the workbook was enabled, the string value
+// which begins with http://, https:// or mailto: will also be set as an
+// external hyperlink of the cell, and the display text of the cell is the
+// URL.
+//
+// The numeric zero value will always be stored as the number 0 in the cell,
+// which is different from a blank cell in formulas such as COUNT and
+// ISBLANK. Set the cell value as nil to make the cell blank, which clears the
+// value and formula of the cell and keeps the cell style. Note that an empty
+// string value will be stored as an empty text string, it's not a blank cell.
+// For example, set the number zero in cell A1, and make cell A2 blank:
+//
+//	err := f.SetCellValue("Sheet1", "A1", 0)
+//	err = f.SetCellValue("Sheet1", "A2", nil)
 func (f *File) SetCellValue(sheet, cell string, value interface{}) error {
 	var err error
 	switch v := value.(type) {
@@ -134,9 +155,9 @@ func (f *File) SetCellValue(sheet, cell string, value interface{}) error {
 	case float64:
 		err = f.SetCellFloat(sheet, cell, v, -1, 64)
 	case string:
-		err = f.SetCellStr(sheet, cell, v)
+		err = f.setCellStrFunc(sheet, cell, v)
 	case []byte:
-		err = f.SetCellStr(sheet, cell, string(v))
+		err = f.setCellStrFunc(sheet, cell, string(v))
 	case time.Duration:
 		_, d := setCellDuration(v)
 		err = f.SetCellDefault(sheet, cell, d)
@@ -156,6 +177,72 @@ func (f *File) SetCellValue(sheet, cell string, value interface{}) error {
 	return err
 }
 
+// setCellStrFunc provides a method to set the value of a cell with string
+// type, and set the external hyperlink for the URL value if the AutoHyperlink
+// option of the workbook was enabled.
+func (f *File) setCellStrFunc(sheet, cell, value string) error {
+	if err := f.SetCellStr(sheet, cell, value); err != nil {
+		return err
+	}
+	if f.options == nil || !f.options.AutoHyperlink || !isHyperlinkURL(value) {
+		return nil
+	}
+	return f.SetCellHyperLink(sheet, cell, value, "External")
+}
+
+// isHyperlinkURL returns if the given string value could be used as an
+// external hyperlink automatically.
+func isHyperlinkURL(value string) bool {
+	if strings.ContainsAny(value, " \t\r\n") {
+		return false
+	}
+	for _, prefix := range []string{"http://", "https://", "mailto:"} {
+		if len(value) > len(prefix) && strings.EqualFold(value[:len(prefix)], prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetCellValueMultiSheet provides a function to set the same value of a cell
+// on multiple worksheets by given worksheet names, cell reference and value,
+// like editing the grouped sheets in the spreadsheet application. All
+// worksheets and the cell reference will be validated before setting the
+// value, so that no worksheet will be changed if any of them is invalid. The
+// supported data types of the value are the same as the SetCellValue. For
+// example, set the header for the monthly worksheets:
+//
+//	err := f.SetCellValueMultiSheet([]string{"Jan", "Feb", "Mar"}, "A1", "Sales")
+func (f *File) SetCellValueMultiSheet(sheets []string, cell string, value interface{}) error {
+	if err := f.checkMultiSheet(sheets, cell); err != nil {
+		return err
+	}
+	for _, sheet := range sheets {
+		if err := f.SetCellValue(sheet, cell, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkMultiSheet checks the given worksheet names and cell references before
+// applying the same change on multiple worksheets.
+func (f *File) checkMultiSheet(sheets []string, cells ...string) error {
+	for _, cell := range cells {
+		if _, _, err := CellNameToCoordinates(cell); err != nil {
+			return err
+		}
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, sheet := range sheets {
+		if _, err := f.workSheetReader(sheet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // String extracts characters from a string item.
 func (x xlsxSI) String() string {
 	var value strings.Builder
@@ -243,14 +330,11 @@ func (f *File) setCellTimeFunc(sheet, cell string, value time.Time) error {
 	ws.mu.Lock()
 	c.S = ws.prepareCellStyle(col, row, c.S)
 	ws.mu.Unlock()
-	var date1904, isNum bool
-	wb, err := f.workbookReader()
+	date1904, err := f.getDate1904()
 	if err != nil {
 		return err
 	}
-	if wb != nil && wb.WorkbookPr != nil {
-		date1904 = wb.WorkbookPr.Date1904
-	}
+	var isNum bool
 	if isNum, err = c.setCellTime(value, date1904); err != nil {
 		return err
 	}
@@ -412,8 +496,22 @@ func setCellFloat(value float64, precision, bitSize int) (t string, v string) {
 }
 
 // SetCellStr provides a function to set string type value of a cell. Total
-// number of characters that a cell can contain 32767 characters.
+// number of characters that a cell can contain 32767 characters. The value
+// will be stored in the shared strings table with the string data type by
+// default, or stored as an inline string if the InlineStrings option of the
+// workbook was enabled, so the numeric looking text such as "01234" will be
+// kept as is.
 func (f *File) SetCellStr(sheet, cell, value string) error {
+	return f.setCellStr(sheet, cell, value, nil)
+}
+
+// setCellStr provides a function to set string type value of a cell by given
+// worksheet name, cell reference, cell value and the function which returns
+// the style index of the cell by given worksheet, column and row number and
+// the existing style index of the cell. The function will be called with the
+// worksheet locked, and the existing style index will be kept if the function
+// is nil.
+func (f *File) setCellStr(sheet, cell, value string, fn func(ws *xlsxWorksheet, col, row, styleID int) (int, error)) error {
 	f.mu.Lock()
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -427,26 +525,155 @@ func (f *File) SetCellStr(sheet, cell, value string) error {
 	if err != nil {
 		return err
 	}
-	c.S = ws.prepareCellStyle(col, row, c.S)
-	if c.T, c.V, err = f.setCellString(value); err != nil {
+	if c.S = ws.prepareCellStyle(col, row, c.S); fn != nil {
+		if c.S, err = fn(ws, col, row, c.S); err != nil {
+			return err
+		}
+	}
+	if err = f.setCellString(c, value); err != nil {
 		return err
 	}
-	c.IS = nil
 	return f.removeFormula(c, ws, sheet)
 }
 
-// setCellString provides a function to set string type to shared string table.
-func (f *File) setCellString(value string) (t, v string, err error) {
+// SetCellQuotePrefix provides a function to set string type value of a cell
+// with the quote prefix by given worksheet name, cell reference and cell
+// value. The quote prefix flag will be merged with the existing style of the
+// cell, which makes the cell value be treated as literal text, even if it
+// begins with "=", "+" or "-". For example, set the text "=1+2" in the cell
+// Sheet1!A1:
+//
+//	err := f.SetCellQuotePrefix("Sheet1", "A1", "=1+2")
+func (f *File) SetCellQuotePrefix(sheet, cell, value string) error {
+	return f.setCellStr(sheet, cell, value, func(_ *xlsxWorksheet, _, _, styleID int) (int, error) {
+		return f.getQuotePrefixStyleID(styleID)
+	})
+}
+
+// SetCellTextNumber provides a function to set the string value of a cell
+// with the text number format "@" by given worksheet name, cell reference and
+// cell value. The text number format will be merged with the existing style
+// of the cell, and the literal value will be stored, so the numeric looking
+// text such as ZIP codes or account numbers longer than 15 significant
+// digits will not be reinterpreted as number when editing the cell. For
+// example, set the account number "12345678901234567890" in the cell
+// Sheet1!A1:
+//
+//	err := f.SetCellTextNumber("Sheet1", "A1", "12345678901234567890")
+func (f *File) SetCellTextNumber(sheet, cell, value string) error {
+	return f.setCellStr(sheet, cell, value, func(_ *xlsxWorksheet, _, _, styleID int) (int, error) {
+		return f.getTextNumFmtStyleID(styleID)
+	})
+}
+
+// SetCellCode provides a function to set the exact string value of a cell as
+// text by given worksheet name, cell reference and cell value, such as ZIP
+// codes or product codes with leading zeros. The "number stored as text"
+// error of the cell will be ignored, so the spreadsheet application will not
+// prompt to convert it to a number. For example, set the code "00501" in the
+// cell Sheet1!A1:
+//
+//	err := f.SetCellCode("Sheet1", "A1", "00501")
+func (f *File) SetCellCode(sheet, cell, value string) error {
+	return f.setCellStr(sheet, cell, value, func(ws *xlsxWorksheet, col, row, styleID int) (int, error) {
+		ws.ignoreNumberStoredAsText(col, row)
+		return styleID, nil
+	})
+}
+
+// ignoreNumberStoredAsText provides a function to record the cell by given
+// column and row number, the "number stored as text" error of the recorded
+// cells will be ignored when the worksheet is saved.
+func (ws *xlsxWorksheet) ignoreNumberStoredAsText(col, row int) {
+	ws.numberStoredAsText = append(ws.numberStoredAsText, []int{col, row})
+}
+
+// squashIgnoredErrors provides a function to merge the cells recorded by the
+// ignoreNumberStoredAsText function into the ignored errors of the worksheet.
+func (f *File) squashIgnoredErrors(ws *xlsxWorksheet) {
+	if len(ws.numberStoredAsText) == 0 {
+		return
+	}
+	var (
+		ignoredError *xlsxIgnoredError
+		refs, cells  [][]int
+		sqref        []string
+	)
+	if ws.IgnoredErrors != nil {
+		for _, ie := range ws.IgnoredErrors.IgnoredError {
+			if !ie.NumberStoredAsText {
+				continue
+			}
+			for _, ref := range strings.Fields(ie.Sqref) {
+				if !strings.Contains(ref, ":") {
+					ref += ":" + ref
+				}
+				if coordinates, err := rangeRefToCoordinates(ref); err == nil {
+					refs = append(refs, coordinates)
+				}
+			}
+			if *ie == (xlsxIgnoredError{Sqref: ie.Sqref, NumberStoredAsText: true}) {
+				ignoredError = ie
+			}
+		}
+	}
+	for _, cell := range ws.numberStoredAsText {
+		var ignored bool
+		for _, coordinates := range refs {
+			if ignored = cellInRange(cell, coordinates); ignored {
+				break
+			}
+		}
+		if !ignored {
+			cells = append(cells, cell)
+		}
+	}
+	ws.numberStoredAsText = nil
+	sort.Slice(cells, func(i, j int) bool {
+		if cells[i][0] == cells[j][0] {
+			return cells[i][1] < cells[j][1]
+		}
+		return cells[i][0] < cells[j][0]
+	})
+	for l, r := 0, 0; l < len(cells); l = r {
+		for r = l; r < len(cells) && cells[r][0] == cells[l][0]; r++ {
+		}
+		sqref = append(sqref, f.squashSqref(cells[l:r])...)
+	}
+	if len(sqref) == 0 {
+		return
+	}
+	if ignoredError != nil {
+		ignoredError.Sqref += " " + strings.Join(sqref, " ")
+		return
+	}
+	if ws.IgnoredErrors == nil {
+		ws.IgnoredErrors = &xlsxIgnoredErrors{}
+	}
+	ws.IgnoredErrors.IgnoredError = append(ws.IgnoredErrors.IgnoredError, &xlsxIgnoredError{
+		Sqref: strings.Join(sqref, " "), NumberStoredAsText: true,
+	})
+}
+
+// setCellString provides a function to set string type value of the cell by
+// the index of the shared string table, the identical strings share the same
+// item in the table. The value will be stored as an inline string if the
+// InlineStrings option of the workbook was enabled.
+func (f *File) setCellString(c *xlsxC, value string) error {
 	if utf8.RuneCountInString(value) > TotalCellChars {
 		value = string([]rune(value)[:TotalCellChars])
 	}
-	t = "s"
-	var si int
-	if si, err = f.setSharedString(value); err != nil {
-		return
+	if f.options != nil && f.options.InlineStrings {
+		c.T, c.V, c.IS = "inlineStr", "", &xlsxSI{T: &xlsxT{}}
+		c.IS.T.Val, c.IS.T.Space = trimCellValue(value, false)
+		return nil
 	}
-	v = strconv.Itoa(si)
-	return
+	si, err := f.setSharedString(value)
+	if err != nil {
+		return err
+	}
+	c.T, c.V, c.IS = "s", strconv.Itoa(si), nil
+	return err
 }
 
 // sharedStringsLoader load shared string table from system temporary file to
@@ -673,8 +900,192 @@ func (f *File) GetCellFormula(sheet, cell string) (string, error) {
 
 // FormulaOpts can be passed to SetCellFormula to use other formula types.
 type FormulaOpts struct {
-	Type *string // Formula type
-	Ref  *string // Shared formula ref
+	Type    *string // Formula type
+	Ref     *string // Shared formula ref
+	Dynamic bool    // Dynamic array formula
+}
+
+// GetCellFormulaOpts provides a function to get the formula type, reference
+// range of the array or shared formula, and whether the array formula is a
+// dynamic array formula of the cell by given worksheet name and cell
+// reference.
+func (f *File) GetCellFormulaOpts(sheet, cell string) (FormulaOpts, error) {
+	var opts FormulaOpts
+	_, err := f.getCellStringFunc(sheet, cell, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
+		if c.F == nil {
+			return "", false, nil
+		}
+		if c.F.T != "" {
+			opts.Type = stringPtr(c.F.T)
+		}
+		if c.F.Ref != "" {
+			opts.Ref = stringPtr(c.F.Ref)
+		}
+		opts.Dynamic = c.F.T == STCellFormulaTypeArray && c.Cm != nil
+		return "", true, nil
+	})
+	return opts, err
+}
+
+// SharedFormula directly maps the sharing structure of a shared formula.
+// Master specifies the reference of the cell that contains the base formula,
+// Ref specifies the range reference of the shared formula, Formula specifies
+// the base formula and Dependents specifies the references of the cells that
+// share the base formula, excluding the master cell.
+type SharedFormula struct {
+	Master     string
+	Ref        string
+	Formula    string
+	Dependents []string
+}
+
+// GetSharedFormulas provides a function to get the shared formulas in the
+// worksheet by given worksheet name. The returned map uses the shared group
+// index (si) of each shared formula as the key. For example, get the master
+// cell of the shared formula with index 0 on Sheet1:
+//
+//	formulas, err := f.GetSharedFormulas("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	fmt.Println(formulas[0].Master)
+func (f *File) GetSharedFormulas(sheet string) (map[int]SharedFormula, error) {
+	formulas := make(map[int]SharedFormula)
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return formulas, err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	for _, row := range ws.SheetData.Row {
+		for _, c := range row.C {
+			if c.F == nil || c.F.T != STCellFormulaTypeShared || c.F.Si == nil {
+				continue
+			}
+			formula := formulas[*c.F.Si]
+			if c.F.Ref != "" && formula.Master == "" {
+				formula.Master, formula.Ref, formula.Formula = c.R, c.F.Ref, c.F.Content
+			} else {
+				formula.Dependents = append(formula.Dependents, c.R)
+			}
+			formulas[*c.F.Si] = formula
+		}
+	}
+	return formulas, err
+}
+
+// ArrayFormula directly maps the array formula which a cell participates in.
+// Master specifies the reference of the cell that contains the array formula,
+// Ref specifies the range reference of the array or the spill range of the
+// dynamic array formula, and Dynamic specifies if the array formula is a
+// dynamic array formula, the legacy array formula (CSE formula) if not.
+type ArrayFormula struct {
+	Master  string
+	Ref     string
+	Dynamic bool
+}
+
+// GetArrayFormulaRange provides a function to get the range reference of the
+// array formula or the spill range of the dynamic array formula which the
+// cell participates in by given worksheet name and cell reference. This
+// function returns an empty string if the cell is not part of an array
+// formula. For example, check if the cell "B2" on "Sheet1" is within a spill
+// range before writing into it:
+//
+//	ref, err := f.GetArrayFormulaRange("Sheet1", "B2")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if ref != "" {
+//	    fmt.Println("B2 is within the array formula range", ref)
+//	}
+func (f *File) GetArrayFormulaRange(sheet, cell string) (string, error) {
+	formula, err := f.GetArrayFormula(sheet, cell)
+	return formula.Ref, err
+}
+
+// GetArrayFormula provides a function to get the array formula which the cell
+// participates in by given worksheet name and cell reference, including the
+// master cell, the range reference and whether it is a dynamic array formula
+// or a legacy array formula. This function returns an empty ArrayFormula if
+// the cell is not part of an array formula.
+func (f *File) GetArrayFormula(sheet, cell string) (ArrayFormula, error) {
+	var formula ArrayFormula
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return formula, err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return formula, err
+	}
+	var cm *uint
+	ws.mu.Lock()
+	for _, r := range ws.SheetData.Row {
+		for _, c := range r.C {
+			if c.F == nil || c.F.T != STCellFormulaTypeArray || c.F.Ref == "" {
+				continue
+			}
+			ref := c.F.Ref
+			if !strings.Contains(ref, ":") {
+				ref += ":" + ref
+			}
+			coordinates, err := rangeRefToCoordinates(ref)
+			if err != nil {
+				ws.mu.Unlock()
+				return formula, err
+			}
+			_ = sortCoordinates(coordinates)
+			if cellInRange([]int{col, row}, coordinates) {
+				formula.Master, formula.Ref, cm = c.R, c.F.Ref, c.Cm
+				break
+			}
+		}
+		if formula.Master != "" {
+			break
+		}
+	}
+	ws.mu.Unlock()
+	if cm != nil {
+		formula.Dynamic, err = f.isDynamicArrayCellMetadata(*cm)
+	}
+	return formula, err
+}
+
+// isDynamicArrayCellMetadata provides a function to check if the cell
+// metadata by given 1-based index references the dynamic array properties.
+func (f *File) isDynamicArrayCellMetadata(cm uint) (bool, error) {
+	var metadata xlsxMetadata
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathMetadata)))).
+		Decode(&metadata); err != nil && err != io.EOF {
+		return false, err
+	}
+	if cm == 0 || metadata.MetadataTypes == nil || metadata.CellMetadata == nil ||
+		int(cm) > len(metadata.CellMetadata.Bk) {
+		return false, nil
+	}
+	for _, rc := range metadata.CellMetadata.Bk[cm-1].Rc {
+		if rc.T < 1 || rc.T > len(metadata.MetadataTypes.MetadataType) ||
+			metadata.MetadataTypes.MetadataType[rc.T-1].Name != "XLDAPR" {
+			continue
+		}
+		for _, future := range metadata.FutureMetadata {
+			if future.Name != "XLDAPR" || rc.V < 0 || rc.V >= len(future.Bk) {
+				continue
+			}
+			if extLst := future.Bk[rc.V].ExtLst; extLst != nil &&
+				strings.Contains(extLst.Content, `fDynamic="1"`) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
 }
 
 // SetCellFormula provides a function to set formula on the cell is taken
@@ -712,14 +1123,20 @@ type FormulaOpts struct {
 //	err := f.SetCellFormula("Sheet1", "A3", "=A1:A2",
 //	       excelize.FormulaOpts{Ref: &ref, Type: &formulaType})
 //
-// Example 6, set shared formula "=A1+B1" for the cell "C1:C5"
+// Example 6, set dynamic array formula "=SORT(A1:A2)" for the cell "B1" on
+// "Sheet1", the result of the formula will be spilled into the adjacent cells:
+//
+//	err := f.SetCellFormula("Sheet1", "B1", "=_xlfn._xlws.SORT(A1:A2)",
+//	    excelize.FormulaOpts{Dynamic: true})
+//
+// Example 7, set shared formula "=A1+B1" for the cell "C1:C5"
 // on "Sheet1", "C1" is the master cell:
 //
 //	formulaType, ref := excelize.STCellFormulaTypeShared, "C1:C5"
 //	err := f.SetCellFormula("Sheet1", "C1", "=A1+B1",
 //	    excelize.FormulaOpts{Ref: &ref, Type: &formulaType})
 //
-// Example 7, set table formula "=SUM(Table1[[A]:[B]])" for the cell "C2"
+// Example 8, set table formula "=SUM(Table1[[A]:[B]])" for the cell "C2"
 // on "Sheet1":
 //
 //	package main
@@ -760,6 +1177,19 @@ type FormulaOpts struct {
 //	    }
 //	}
 func (f *File) SetCellFormula(sheet, cell, formula string, opts ...FormulaOpts) error {
+	var (
+		cm  *uint
+		err error
+	)
+	for _, opt := range opts {
+		if opt.Dynamic && formula != "" && cm == nil {
+			var idx uint
+			if idx, err = f.getDynamicArrayCellMetadata(); err != nil {
+				return err
+			}
+			cm = &idx
+		}
+	}
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
@@ -794,11 +1224,114 @@ func (f *File) SetCellFormula(sheet, cell, formula string, opts ...FormulaOpts)
 		if opt.Ref != nil {
 			c.F.Ref = *opt.Ref
 		}
+		if opt.Dynamic {
+			if c.F.T = STCellFormulaTypeArray; c.F.Ref == "" {
+				c.F.Ref = cell
+			}
+			c.Cm = cm
+		}
 	}
 	c.T, c.IS = "str", nil
 	return err
 }
 
+// getDynamicArrayCellMetadata provides a function to get the index of the
+// cell metadata for the dynamic array formula by 1-based, the metadata will
+// be created if it doesn't exist in the workbook. This function takes the
+// workbook lock, so it should be called before locking any worksheet.
+func (f *File) getDynamicArrayCellMetadata() (uint, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var metadata xlsxMetadata
+	_, ok := f.Pkg.Load(defaultXMLPathMetadata)
+	if _, loaded := f.xmlAttr.Load(defaultXMLPathMetadata); !loaded {
+		attrs := []xml.Attr{NameSpaceSpreadSheet}
+		if ok {
+			attrs = getRootElement(f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathMetadata)))))
+		}
+		f.xmlAttr.Store(defaultXMLPathMetadata, attrs)
+	}
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathMetadata)))).
+		Decode(&metadata); err != nil && err != io.EOF {
+		return 0, err
+	}
+	if metadata.MetadataTypes == nil {
+		metadata.MetadataTypes = new(xlsxMetadataTypes)
+	}
+	typeIdx := -1
+	for idx, metadataType := range metadata.MetadataTypes.MetadataType {
+		if metadataType.Name == "XLDAPR" {
+			typeIdx = idx
+			break
+		}
+	}
+	if typeIdx == -1 {
+		metadata.MetadataTypes.MetadataType = append(metadata.MetadataTypes.MetadataType, xlsxMetadataType{
+			Name: "XLDAPR", MinSupportedVersion: 120000, Copy: true, PasteAll: true,
+			PasteValues: true, Merge: true, SplitFirst: true, RowColShift: true,
+			ClearFormats: true, ClearComments: true, Assign: true, Coerce: true,
+			CellMeta: true,
+		})
+		typeIdx = len(metadata.MetadataTypes.MetadataType) - 1
+	}
+	metadata.MetadataTypes.Count = len(metadata.MetadataTypes.MetadataType)
+	futureIdx := -1
+	for idx := range metadata.FutureMetadata {
+		if metadata.FutureMetadata[idx].Name == "XLDAPR" {
+			futureIdx = idx
+			break
+		}
+	}
+	if futureIdx == -1 {
+		metadata.FutureMetadata = append(metadata.FutureMetadata, xlsxFutureMetadata{Name: "XLDAPR"})
+		futureIdx = len(metadata.FutureMetadata) - 1
+	}
+	future, blockIdx := &metadata.FutureMetadata[futureIdx], -1
+	for idx, bk := range future.Bk {
+		if bk.ExtLst != nil && strings.Contains(bk.ExtLst.Content, ExtURIDynamicArrayProperties) &&
+			strings.Contains(bk.ExtLst.Content, `fDynamic="1"`) {
+			blockIdx = idx
+			break
+		}
+	}
+	if blockIdx == -1 {
+		future.Bk = append(future.Bk, xlsxFutureMetadataBlock{ExtLst: &xlsxInnerXML{
+			Content: fmt.Sprintf(`<ext uri="%s"><xda:dynamicArrayProperties fDynamic="1" fCollapsed="0"/></ext>`, ExtURIDynamicArrayProperties),
+		}})
+		blockIdx = len(future.Bk) - 1
+	}
+	future.Count = len(future.Bk)
+	if metadata.CellMetadata == nil {
+		metadata.CellMetadata = new(xlsxMetadataBlocks)
+	}
+	cellIdx := -1
+	for idx, bk := range metadata.CellMetadata.Bk {
+		if len(bk.Rc) == 1 && bk.Rc[0].T == typeIdx+1 && bk.Rc[0].V == blockIdx {
+			cellIdx = idx
+			break
+		}
+	}
+	if cellIdx == -1 {
+		metadata.CellMetadata.Bk = append(metadata.CellMetadata.Bk, xlsxMetadataBlock{
+			Rc: []xlsxMetadataRecord{{T: typeIdx + 1, V: blockIdx}},
+		})
+		cellIdx = len(metadata.CellMetadata.Bk) - 1
+	}
+	metadata.CellMetadata.Count = len(metadata.CellMetadata.Bk)
+	if attrs, _ := f.xmlAttr.Load(defaultXMLPathMetadata); getXMLNamespace(NameSpaceDynamicArray.Value, attrs.([]xml.Attr)) != NameSpaceDynamicArray.Name.Local {
+		f.xmlAttr.Store(defaultXMLPathMetadata, append(attrs.([]xml.Attr), NameSpaceDynamicArray))
+	}
+	output, _ := xml.Marshal(metadata)
+	f.saveFileList(defaultXMLPathMetadata, f.replaceNameSpaceBytes(defaultXMLPathMetadata, output))
+	if !ok {
+		if err := f.addContentTypePart(0, "metadata"); err != nil {
+			return 0, err
+		}
+		f.addRels(f.getWorkbookRelsPath(), SourceRelationshipSheetMetadata, "/xl/metadata.xml", "")
+	}
+	return uint(cellIdx + 1), nil
+}
+
 // setSharedFormula set shared formula for the cells.
 func (ws *xlsxWorksheet) setSharedFormula(ref string) error {
 	coordinates, err := rangeRefToCoordinates(ref)
@@ -975,8 +1508,9 @@ func getCellRichText(si *xlsxSI) (runs []RichTextRun) {
 		runs = append(runs, RichTextRun{Text: si.T.Val})
 	}
 	for _, v := range si.R {
-		run := RichTextRun{
-			Text: v.T.Val,
+		var run RichTextRun
+		if v.T != nil {
+			run.Text = v.T.Val
 		}
 		if v.RPr != nil {
 			run.Font = newFont(v.RPr)
@@ -987,25 +1521,46 @@ func getCellRichText(si *xlsxSI) (runs []RichTextRun) {
 }
 
 // GetCellRichText provides a function to get rich text of cell by given
-// worksheet.
+// worksheet. The rich text runs will be read from the shared strings table or
+// the inline string of the cell, and the font of each run will be populated
+// by the run properties, the font of the run without run properties will be
+// nil. For example, get rich text runs of the cell A1 on Sheet1:
+//
+//	runs, err := f.GetCellRichText("Sheet1", "A1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, run := range runs {
+//	    if run.Font != nil {
+//	        fmt.Println(run.Text, run.Font.Bold, run.Font.Color)
+//	    }
+//	}
 func (f *File) GetCellRichText(sheet, cell string) (runs []RichTextRun, err error) {
+	f.mu.Lock()
 	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
 	if err != nil {
 		return
 	}
+	ws.mu.Lock()
 	c, _, _, err := ws.prepareCell(cell)
 	if err != nil {
+		ws.mu.Unlock()
 		return
 	}
 	if c.T == "inlineStr" && c.IS != nil {
 		runs = getCellRichText(c.IS)
+		ws.mu.Unlock()
 		return
 	}
-	if c.T == "" {
+	cellType, cellValue := c.T, c.V
+	ws.mu.Unlock()
+	if cellType == "" {
 		return
 	}
-	siIdx, err := strconv.Atoi(c.V)
-	if err != nil || c.T != "s" {
+	siIdx, err := strconv.Atoi(cellValue)
+	if err != nil || cellType != "s" {
 		return
 	}
 	sst, err := f.sharedStringsReader()
@@ -1066,6 +1621,9 @@ func newFont(rPr *xlsxRPr) *Font {
 		font.Size = *rPr.Sz.Val
 	}
 	font.Strike = rPr.Strike != nil
+	if rPr.VertAlign != nil && rPr.VertAlign.Val != nil {
+		font.VertAlign = *rPr.VertAlign.Val
+	}
 	if rPr.Color != nil {
 		font.Color = strings.TrimPrefix(rPr.Color.RGB, "FF")
 		if rPr.Color.Theme != nil {
@@ -1397,14 +1955,10 @@ func (f *File) formattedValue(c *xlsxC, raw bool, cellType CellType) (string, er
 	if styleSheet.CellXfs.Xf[c.S].NumFmtID != nil {
 		numFmtID = *styleSheet.CellXfs.Xf[c.S].NumFmtID
 	}
-	date1904 := false
-	wb, err := f.workbookReader()
+	date1904, err := f.getDate1904()
 	if err != nil {
 		return c.V, err
 	}
-	if wb != nil && wb.WorkbookPr != nil {
-		date1904 = wb.WorkbookPr.Date1904
-	}
 	if fmtCode, ok := styleSheet.getCustomNumFmtCode(numFmtID); ok {
 		return format(c.V, fmtCode, date1904, cellType, f.options), err
 	}
@@ -1513,14 +2067,8 @@ func cellInRange(cell, ref []int) bool {
 
 // isOverlap find if the given two rectangles overlap or not.
 func isOverlap(rect1, rect2 []int) bool {
-	return cellInRange([]int{rect1[0], rect1[1]}, rect2) ||
-		cellInRange([]int{rect1[2], rect1[1]}, rect2) ||
-		cellInRange([]int{rect1[0], rect1[3]}, rect2) ||
-		cellInRange([]int{rect1[2], rect1[3]}, rect2) ||
-		cellInRange([]int{rect2[0], rect2[1]}, rect1) ||
-		cellInRange([]int{rect2[2], rect2[1]}, rect1) ||
-		cellInRange([]int{rect2[0], rect2[3]}, rect1) ||
-		cellInRange([]int{rect2[2], rect2[3]}, rect1)
+	return rect1[0] <= rect2[2] && rect2[0] <= rect1[2] &&
+		rect1[1] <= rect2[3] && rect2[1] <= rect1[3]
 }
 
 // parseSharedFormula generate dynamic part of shared formula for target cell
@@ -1577,22 +2125,29 @@ func getSharedFormula(ws *xlsxWorksheet, si int, cell string) string {
 	for _, r := range ws.SheetData.Row {
 		for _, c := range r.C {
 			if c.F != nil && c.F.Ref != "" && c.F.T == STCellFormulaTypeShared && c.F.Si != nil && *c.F.Si == si {
-				col, row, _ := CellNameToCoordinates(cell)
-				sharedCol, sharedRow, _ := CellNameToCoordinates(c.R)
-				dCol := col - sharedCol
-				dRow := row - sharedRow
-				orig := []byte(c.F.Content)
-				res, start := parseSharedFormula(dCol, dRow, orig)
-				if start < len(orig) {
-					res += string(orig[start:])
-				}
-				return res
+				return shiftSharedFormula(c.R, c.F.Content, cell)
 			}
 		}
 	}
 	return ""
 }
 
+// shiftSharedFormula returns the formula of the cell which shares the base
+// formula of the master cell by given master cell reference, base formula and
+// cell reference.
+func shiftSharedFormula(master, formula, cell string) string {
+	col, row, _ := CellNameToCoordinates(cell)
+	sharedCol, sharedRow, _ := CellNameToCoordinates(master)
+	dCol := col - sharedCol
+	dRow := row - sharedRow
+	orig := []byte(formula)
+	res, start := parseSharedFormula(dCol, dRow, orig)
+	if start < len(orig) {
+		res += string(orig[start:])
+	}
+	return res
+}
+
 // shiftCell returns the cell shifted according to dCol and dRow taking into
 // consideration absolute references with dollar sign ($)
 func shiftCell(cellID string, dCol, dRow int) string {
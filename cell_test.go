@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"encoding/xml"
 	"fmt"
 	_ "image/jpeg"
 	"math"
@@ -76,6 +77,11 @@ func TestConcurrency(t *testing.T) {
 			styleID, err := f.GetColStyle("Sheet1", "D")
 			assert.NoError(t, err)
 			assert.Equal(t, style, styleID)
+			// Concurrency set rows style
+			assert.NoError(t, f.SetRowStyle("Sheet1", 6, 8, style))
+			// Concurrency set rows and columns group
+			assert.NoError(t, f.SetRowGroup("Sheet1", 10, 11, 1, false))
+			assert.NoError(t, f.SetColGroup("Sheet1", "F:G", 1, false))
 			// Concurrency set columns width
 			assert.NoError(t, f.SetColWidth("Sheet1", "A", "B", 10))
 			// Concurrency get columns width
@@ -279,6 +285,179 @@ func TestSetCellValue(t *testing.T) {
 	assert.Equal(t, "b", val)
 }
 
+func TestSetCellValueAutoHyperlink(t *testing.T) {
+	f := NewFile(Options{AutoHyperlink: true})
+	for cell, value := range map[string]interface{}{
+		"A1": "https://github.com/xuri/excelize",
+		"A2": []byte("HTTP://example.com"),
+		"A3": "mailto:someone@example.com",
+	} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+		link, target, err := f.GetCellHyperLink("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.True(t, link)
+		assert.Equal(t, fmt.Sprintf("%s", value), target)
+	}
+	for cell, value := range map[string]interface{}{
+		"B1": "see https://github.com/xuri/excelize",
+		"B2": "https://",
+		"B3": "ftp://example.com",
+		"B4": "https://example.com/a b",
+		"B5": 100,
+	} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+		link, _, err := f.GetCellHyperLink("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.False(t, link)
+	}
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://github.com/xuri/excelize", val)
+	// Test set cell value without auto hyperlink
+	f = NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "https://github.com/xuri/excelize"))
+	link, _, err := f.GetCellHyperLink("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.False(t, link)
+	// Test set cell value with auto hyperlink on not exists worksheet
+	f = NewFile(Options{AutoHyperlink: true})
+	assert.EqualError(t, f.SetCellValue("SheetN", "A1", "https://github.com/xuri/excelize"), "sheet SheetN does not exist")
+}
+
+func TestSetCellValueZeroAndBlank(t *testing.T) {
+	f := NewFile()
+	for cell, value := range map[string]interface{}{
+		"A1": 0, "A2": 0.0, "A3": int64(0), "A4": float32(0), "A5": uint8(0), "A6": time.Duration(0),
+	} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	// Test make cells blank with the formula and style
+	style, err := f.NewStyle(&Style{NumFmt: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "1+1"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", 10))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B2", style))
+	for _, cell := range []string{"B1", "B2"} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, nil))
+	}
+	assert.NoError(t, f.SetCellValue("Sheet1", "B3", ""))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "COUNT(A1:B6)"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C2", "ISBLANK(A1)"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C3", "ISBLANK(B1)"))
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	sw, err := f.NewStreamWriter("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{0, 0.0, nil, Cell{StyleID: style}, Cell{Value: 0}}))
+	assert.NoError(t, sw.Flush())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellValueZeroAndBlank.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestSetCellValueZeroAndBlank.xlsx"))
+	assert.NoError(t, err)
+	sheetXML := string(f.readXML("xl/worksheets/sheet1.xml"))
+	assert.Contains(t, string(f.readXML("xl/worksheets/sheet2.xml")),
+		fmt.Sprintf(`<row r="1"><c r="A1"><v>0</v></c><c r="B1"><v>0</v></c><c r="D1" s="%d"></c><c r="E1"><v>0</v></c></row>`, style))
+	for _, cell := range []string{"A1", "A2", "A3", "A4", "A5"} {
+		assert.Contains(t, sheetXML, fmt.Sprintf(`<c r="%s"><v>0</v></c>`, cell))
+	}
+	for _, cell := range []string{"B1", "B2"} {
+		assert.Contains(t, sheetXML, fmt.Sprintf(`<c r="%s" s="%d"></c>`, cell, style))
+	}
+	for _, cell := range []string{"A1", "A2", "A3", "A4", "A5"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, "0", val, cell)
+	}
+	for cell, expected := range map[string]string{"B1": "", "B2": "", "B3": "", "C1": "6", "C2": "FALSE", "C3": "TRUE"} {
+		val, err := f.CalcCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	formula, err := f.GetCellFormula("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+	cellType, err := f.GetCellType("Sheet1", "B3")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeSharedString, cellType)
+	rows, err := f.GetRows("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"0", "0", "", "", "0"}}, rows)
+	assert.NoError(t, f.Close())
+}
+
+func TestSetCellValueInlineStrings(t *testing.T) {
+	// Test set the identical strings into the shared string table
+	f := NewFile()
+	for _, cell := range []string{"A1", "A2", "A3"} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, "Status"))
+	}
+	assert.NoError(t, f.SetCellValue("Sheet1", "A4", "Country"))
+	sst, err := f.sharedStringsReader()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, sst.UniqueCount)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	for i, v := range []string{"0", "0", "0", "1"} {
+		assert.Equal(t, "s", ws.(*xlsxWorksheet).SheetData.Row[i].C[0].T)
+		assert.Equal(t, v, ws.(*xlsxWorksheet).SheetData.Row[i].C[0].V)
+	}
+	assert.NoError(t, f.Close())
+	// Test set string values as inline strings
+	f = NewFile(Options{InlineStrings: true})
+	values := []string{"Status", "Status", " a & b ", strings.Repeat("c", TotalCellChars+1)}
+	for i, value := range values {
+		assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("A%d", i+1), value))
+	}
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	for i := range values {
+		c := ws.(*xlsxWorksheet).SheetData.Row[i].C[0]
+		assert.Equal(t, "inlineStr", c.T)
+		assert.Empty(t, c.V)
+	}
+	sst, err = f.sharedStringsReader()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sst.UniqueCount)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellValueInlineStrings.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestSetCellValueInlineStrings.xlsx"))
+	assert.NoError(t, err)
+	values[3] = values[3][:TotalCellChars]
+	for i, value := range values {
+		val, err := f.GetCellValue("Sheet1", fmt.Sprintf("A%d", i+1))
+		assert.NoError(t, err)
+		assert.Equal(t, value, val)
+	}
+	assert.NoError(t, f.Close())
+}
+
+func TestSetCellValueMultiSheet(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValueMultiSheet([]string{"Sheet1", "Sheet2"}, "A1", "Sales"))
+	for _, sheet := range []string{"Sheet1", "Sheet2"} {
+		val, err := f.GetCellValue(sheet, "A1")
+		assert.NoError(t, err)
+		assert.Equal(t, "Sales", val)
+	}
+	// Test set cell value on multiple worksheets with not exists worksheet
+	assert.EqualError(t, f.SetCellValueMultiSheet([]string{"Sheet1", "SheetN"}, "B1", 100), "sheet SheetN does not exist")
+	val, err := f.GetCellValue("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Empty(t, val)
+	// Test set cell value on multiple worksheets with invalid sheet name
+	assert.EqualError(t, f.SetCellValueMultiSheet([]string{"Sheet1", "Sheet:1"}, "B1", 100), ErrSheetNameInvalid.Error())
+	// Test set cell value on multiple worksheets with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetCellValueMultiSheet([]string{"Sheet1"}, "A", 100))
+	// Test set cell value on multiple worksheets with unsupported charset
+	f.Sheet.Delete("xl/worksheets/sheet2.xml")
+	f.Pkg.Store("xl/worksheets/sheet2.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellValueMultiSheet([]string{"Sheet1", "Sheet2"}, "B1", 100), "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestSetCellValues(t *testing.T) {
 	f := NewFile()
 	err := f.SetCellValue("Sheet1", "A1", time.Date(2010, time.December, 31, 0, 0, 0, 0, time.UTC))
@@ -304,6 +483,217 @@ func TestSetCellBool(t *testing.T) {
 	assert.EqualError(t, f.SetCellBool("Sheet:1", "A1", true), ErrSheetNameInvalid.Error())
 }
 
+func TestSetCellCode(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellCode("Sheet1", "A1", "00501"))
+	assert.NoError(t, f.SetCellCode("Sheet1", "A2", "007"))
+	// Test set code for the cell which error has been ignored
+	assert.NoError(t, f.SetCellCode("Sheet1", "A1", "0012"))
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "0012", val)
+	cellType, err := f.GetCellType("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeSharedString, cellType)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Nil(t, ws.(*xlsxWorksheet).IgnoredErrors)
+	f.squashIgnoredErrors(ws.(*xlsxWorksheet))
+	assert.Equal(t, &xlsxIgnoredErrors{IgnoredError: []*xlsxIgnoredError{
+		{Sqref: "A1:A2", NumberStoredAsText: true},
+	}}, ws.(*xlsxWorksheet).IgnoredErrors)
+	// Test set code for the cells in multiple columns
+	for _, cell := range []string{"E3", "D2", "D1", "E1", "D5"} {
+		assert.NoError(t, f.SetCellCode("Sheet1", cell, "0001"))
+	}
+	f.squashIgnoredErrors(ws.(*xlsxWorksheet))
+	assert.Equal(t, &xlsxIgnoredErrors{IgnoredError: []*xlsxIgnoredError{
+		{Sqref: "A1:A2 D1:D2 D5 E1 E3", NumberStoredAsText: true},
+	}}, ws.(*xlsxWorksheet).IgnoredErrors)
+	// Test set code for the cell in the ignored range
+	ws.(*xlsxWorksheet).IgnoredErrors.IgnoredError = []*xlsxIgnoredError{
+		{Sqref: "B1:B10", NumberStoredAsText: true, TwoDigitTextYear: true},
+	}
+	assert.NoError(t, f.SetCellCode("Sheet1", "B2", "0001"))
+	f.squashIgnoredErrors(ws.(*xlsxWorksheet))
+	assert.Equal(t, []*xlsxIgnoredError{
+		{Sqref: "B1:B10", NumberStoredAsText: true, TwoDigitTextYear: true},
+	}, ws.(*xlsxWorksheet).IgnoredErrors.IgnoredError)
+	assert.NoError(t, f.SetCellCode("Sheet1", "C1", "0002"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellCode.xlsx")))
+	sheet, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []*xlsxIgnoredError{
+		{Sqref: "B1:B10", NumberStoredAsText: true, TwoDigitTextYear: true},
+		{Sqref: "C1", NumberStoredAsText: true},
+	}, sheet.IgnoredErrors.IgnoredError)
+	// Test duplicate the worksheet with the cells which error will be ignored
+	assert.NoError(t, f.SetCellCode("Sheet1", "F1", "0003"))
+	assert.NoError(t, f.DuplicateSheet("Sheet1", "Sheet2"))
+	sheet, err = f.workSheetReader("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, []*xlsxIgnoredError{
+		{Sqref: "B1:B10", NumberStoredAsText: true, TwoDigitTextYear: true},
+		{Sqref: "C1 F1", NumberStoredAsText: true},
+	}, sheet.IgnoredErrors.IgnoredError)
+	// Test set code with not exist worksheet
+	assert.EqualError(t, f.SetCellCode("SheetN", "A1", "00501"), "sheet SheetN does not exist")
+	// Test set code with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetCellCode("Sheet1", "A", "00501"))
+	// Test set code with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellCode("Sheet1", "A1", "00501"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestSetCellQuotePrefix(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellQuotePrefix("Sheet1", "A1", "=1+2"))
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "=1+2", val)
+	formula, err := f.GetCellFormula("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+	styleID, err := f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.True(t, *f.Styles.CellXfs.Xf[styleID].QuotePrefix)
+	// Test set the quote prefix with reusing the existing cell style
+	assert.NoError(t, f.SetCellQuotePrefix("Sheet1", "A2", "+1"))
+	quotePrefixStyleID, err := f.GetCellStyle("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, styleID, quotePrefixStyleID)
+	assert.NoError(t, f.SetCellQuotePrefix("Sheet1", "A2", "-1"))
+	quotePrefixStyleID, err = f.GetCellStyle("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, styleID, quotePrefixStyleID)
+	// Test set the quote prefix with merging the existing cell style
+	styleID, err = f.NewStyle(&Style{Font: &Font{Bold: true}, Alignment: &Alignment{Horizontal: "center"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B1", styleID))
+	assert.NoError(t, f.SetCellQuotePrefix("Sheet1", "B1", "=SUM(A1:A2)"))
+	quotePrefixStyleID, err = f.GetCellStyle("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.NotEqual(t, styleID, quotePrefixStyleID)
+	style, err := f.GetStyle(quotePrefixStyleID)
+	assert.NoError(t, err)
+	assert.True(t, style.Font.Bold)
+	assert.Equal(t, "center", style.Alignment.Horizontal)
+	assert.True(t, *f.Styles.CellXfs.Xf[quotePrefixStyleID].QuotePrefix)
+	assert.Nil(t, f.Styles.CellXfs.Xf[styleID].QuotePrefix)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellQuotePrefix.xlsx")))
+	// Test set the quote prefix with invalid cell reference
+	assert.EqualError(t, f.SetCellQuotePrefix("Sheet1", "A", "=1"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test set the quote prefix with invalid sheet name
+	assert.EqualError(t, f.SetCellQuotePrefix("Sheet:1", "A1", "=1"), ErrSheetNameInvalid.Error())
+	// Test set the quote prefix with invalid style ID
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].S = 100
+	assert.EqualError(t, f.SetCellQuotePrefix("Sheet1", "A1", "=1"), newInvalidStyleID(100).Error())
+	// Test set the quote prefix with exceeds the cell styles limit
+	f = NewFile()
+	f.Styles, err = f.stylesReader()
+	assert.NoError(t, err)
+	for len(f.Styles.CellXfs.Xf) < MaxCellStyles {
+		f.Styles.CellXfs.Xf = append(f.Styles.CellXfs.Xf, xlsxXf{})
+	}
+	assert.Equal(t, ErrCellStyles, f.SetCellQuotePrefix("Sheet1", "A1", "=1"))
+	// Test set the quote prefix with unsupported charset style sheet
+	f = NewFile()
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellQuotePrefix("Sheet1", "A1", "=1"), "XML syntax error on line 1: invalid UTF-8")
+	// Test set the quote prefix with unsupported charset shared strings table
+	f = NewFile()
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellQuotePrefix("Sheet1", "A1", "=1"), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestSetCellTextNumber(t *testing.T) {
+	f := NewFile()
+	for cell, value := range map[string]string{"A1": "01234", "A2": "12345678901234567890", "A3": "1.0E+5"} {
+		assert.NoError(t, f.SetCellTextNumber("Sheet1", cell, value))
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, value, val)
+		cellType, err := f.GetCellType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, CellTypeSharedString, cellType)
+	}
+	styleID, err := f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, 49, *f.Styles.CellXfs.Xf[styleID].NumFmtID)
+	// Test set the text number with reusing the existing cell style
+	textStyleID, err := f.GetCellStyle("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, styleID, textStyleID)
+	assert.NoError(t, f.SetCellTextNumber("Sheet1", "A2", "00501"))
+	textStyleID, err = f.GetCellStyle("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, styleID, textStyleID)
+	// Test set the text number with merging the existing cell style
+	styleID, err = f.NewStyle(&Style{Font: &Font{Bold: true}, NumFmt: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B1", styleID))
+	assert.NoError(t, f.SetCellTextNumber("Sheet1", "B1", "0.10"))
+	textStyleID, err = f.GetCellStyle("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.NotEqual(t, styleID, textStyleID)
+	style, err := f.GetStyle(textStyleID)
+	assert.NoError(t, err)
+	assert.True(t, style.Font.Bold)
+	assert.Equal(t, 49, style.NumFmt)
+	val, err := f.GetCellValue("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "0.10", val)
+	// Test set the string value with numeric looking text
+	assert.NoError(t, f.SetCellStr("Sheet1", "C1", "01234"))
+	val, err = f.GetCellValue("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "01234", val)
+	// Test set the string value with numeric looking text as inline string
+	f.options.InlineStrings = true
+	assert.NoError(t, f.SetCellStr("Sheet1", "C2", "012345678901234567890"))
+	f.options.InlineStrings = false
+	val, err = f.GetCellValue("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, "012345678901234567890", val)
+	cellType, err := f.GetCellType("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeInlineString, cellType)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellTextNumber.xlsx")))
+	// Test set the text number with invalid cell reference
+	assert.EqualError(t, f.SetCellTextNumber("Sheet1", "A", "1"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test set the text number with invalid sheet name
+	assert.EqualError(t, f.SetCellTextNumber("Sheet:1", "A1", "1"), ErrSheetNameInvalid.Error())
+	// Test set the text number with invalid style ID
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].S = 100
+	assert.EqualError(t, f.SetCellTextNumber("Sheet1", "A1", "1"), newInvalidStyleID(100).Error())
+	// Test set the text number with exceeds the cell styles limit
+	f = NewFile()
+	f.Styles, err = f.stylesReader()
+	assert.NoError(t, err)
+	for len(f.Styles.CellXfs.Xf) < MaxCellStyles {
+		f.Styles.CellXfs.Xf = append(f.Styles.CellXfs.Xf, xlsxXf{})
+	}
+	assert.Equal(t, ErrCellStyles, f.SetCellTextNumber("Sheet1", "A1", "1"))
+	// Test set the text number with unsupported charset style sheet
+	f = NewFile()
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellTextNumber("Sheet1", "A1", "1"), "XML syntax error on line 1: invalid UTF-8")
+	// Test set the text number with unsupported charset shared strings table
+	f = NewFile()
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellTextNumber("Sheet1", "A1", "1"), "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestSetCellTime(t *testing.T) {
 	date, err := time.Parse(time.RFC3339Nano, "2009-11-10T23:00:00Z")
 	assert.NoError(t, err)
@@ -323,6 +713,29 @@ func TestSetCellTime(t *testing.T) {
 	}
 }
 
+func TestGetCellRawValue(t *testing.T) {
+	f := NewFile()
+	dateStyle, err := f.NewStyle(&Style{NumFmt: 14})
+	assert.NoError(t, err)
+	numStyle, err := f.NewStyle(&Style{NumFmt: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", time.Date(2023, 11, 28, 12, 0, 0, 0, time.UTC)))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", dateStyle))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", 123.456789012345))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B1", numStyle))
+	for cell, expected := range map[string][]string{
+		"A1": {"11-28-23", "45258.5"},
+		"B1": {"123.46", "123.456789012345"},
+	} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[0], val)
+		val, err = f.GetCellValue("Sheet1", cell, Options{RawCellValue: true})
+		assert.NoError(t, err)
+		assert.Equal(t, expected[1], val)
+	}
+}
+
 func TestGetCellValue(t *testing.T) {
 	// Test get cell value without r attribute of the row
 	f := NewFile()
@@ -592,6 +1005,39 @@ func BenchmarkSetCellValue(b *testing.B) {
 	}
 }
 
+func BenchmarkSetCellValueRepeatedStrings(b *testing.B) {
+	values := []string{"Australia", "Brazil", "Canada", "Denmark", "Egypt", "France"}
+	for _, c := range []struct {
+		name string
+		opts Options
+	}{
+		{name: "SharedStrings"},
+		{name: "InlineStrings", opts: Options{InlineStrings: true}},
+	} {
+		opts := c.opts
+		b.Run(c.name, func(b *testing.B) {
+			var size int
+			for i := 0; i < b.N; i++ {
+				f := NewFile(opts)
+				for row := 1; row <= 10000; row++ {
+					for col := 1; col <= len(values); col++ {
+						cell, _ := CoordinatesToCellName(col, row)
+						if err := f.SetCellValue("Sheet1", cell, values[(row+col)%len(values)]); err != nil {
+							b.Error(err)
+						}
+					}
+				}
+				buf, err := f.WriteToBuffer()
+				if err != nil {
+					b.Error(err)
+				}
+				size = buf.Len()
+			}
+			b.ReportMetric(float64(size), "bytes/file")
+		})
+	}
+}
+
 func TestOverflowNumericCell(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "OverflowNumericCell.xlsx"))
 	if !assert.NoError(t, err) {
@@ -661,6 +1107,155 @@ func TestSetCellFormula(t *testing.T) {
 	formulaType = STCellFormulaTypeDataTable
 	assert.NoError(t, f.SetCellFormula("Sheet1", "C2", "=SUM(Table1[[A]:[B]])", FormulaOpts{Type: &formulaType}))
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellFormula6.xlsx")))
+
+	// Test set dynamic array formula for the cells
+	f = NewFile()
+	assert.NoError(t, f.SetSheetCol("Sheet1", "A1", &[]interface{}{3, 1, 2}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "_xlfn._xlws.SORT(A1:A3)", FormulaOpts{Dynamic: true}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "_xlfn.UNIQUE(A1:A3)", FormulaOpts{Dynamic: true}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	for _, c := range ws.(*xlsxWorksheet).SheetData.Row[0].C[1:] {
+		assert.Equal(t, uint(1), *c.Cm)
+		assert.Equal(t, STCellFormulaTypeArray, c.F.T)
+		assert.Equal(t, c.R, c.F.Ref)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellFormula7.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test set dynamic array formula with existing metadata in the workbook
+	f = NewFile()
+	f.Pkg.Store(defaultXMLPathMetadata, []byte(`<metadata xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><metadataTypes count="1"><metadataType name="XLRICHVALUE" minSupportedVersion="120000"/></metadataTypes><futureMetadata name="XLRICHVALUE" count="1"><bk><extLst><ext uri="{3e2802c4-a4d2-4d8b-9148-e3be6c30e623}"/></extLst></bk></futureMetadata><cellMetadata count="1"><bk><rc t="1" v="0"/></bk></cellMetadata><valueMetadata count="1"><bk><rc t="1" v="0"/></bk></valueMetadata></metadata>`))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "_xlfn._xlws.SORT(B1:B3)", FormulaOpts{Dynamic: true}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A2", "_xlfn._xlws.SORT(B1:B3)", FormulaOpts{Dynamic: true}))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, uint(2), *ws.(*xlsxWorksheet).SheetData.Row[0].C[0].Cm)
+	assert.Equal(t, uint(2), *ws.(*xlsxWorksheet).SheetData.Row[1].C[0].Cm)
+	var metadata xlsxMetadata
+	assert.NoError(t, xml.Unmarshal(f.readXML(defaultXMLPathMetadata), &metadata))
+	assert.Len(t, metadata.MetadataTypes.MetadataType, 2)
+	assert.Len(t, metadata.FutureMetadata, 2)
+	assert.Equal(t, []xlsxMetadataRecord{{T: 2, V: 0}}, metadata.CellMetadata.Bk[1].Rc)
+	assert.NotNil(t, metadata.ValueMetadata)
+
+	// Test set dynamic array formula with foreign namespace in the metadata
+	f = NewFile()
+	f.Pkg.Store(defaultXMLPathMetadata, []byte(`<metadata xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006" xmlns:xlrd="http://schemas.microsoft.com/office/spreadsheetml/2017/richdata" mc:Ignorable="xlrd"><metadataTypes count="1"><metadataType name="XLRICHVALUE" minSupportedVersion="120000"/></metadataTypes></metadata>`))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "_xlfn._xlws.SORT(B1:B3)", FormulaOpts{Dynamic: true}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A2", "_xlfn._xlws.SORT(B1:B3)", FormulaOpts{Dynamic: true}))
+	assert.Contains(t, string(f.readXML(defaultXMLPathMetadata)), `<metadata xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006" xmlns:xlrd="http://schemas.microsoft.com/office/spreadsheetml/2017/richdata" mc:Ignorable="xlrd" xmlns:xda="http://schemas.microsoft.com/office/spreadsheetml/2017/dynamicarray">`)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellFormula8.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestSetCellFormula8.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A3", "_xlfn._xlws.SORT(B1:B3)", FormulaOpts{Dynamic: true}))
+	assert.Contains(t, string(f.readXML(defaultXMLPathMetadata)), `xmlns:xlrd="http://schemas.microsoft.com/office/spreadsheetml/2017/richdata" mc:Ignorable="xlrd" xmlns:xda="http://schemas.microsoft.com/office/spreadsheetml/2017/dynamicarray">`)
+	assert.Equal(t, 1, strings.Count(string(f.readXML(defaultXMLPathMetadata)), "xmlns:xda"))
+	assert.NoError(t, f.Close())
+
+	// Test set dynamic array formula with unsupported charset metadata
+	f = NewFile()
+	f.Pkg.Store(defaultXMLPathMetadata, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellFormula("Sheet1", "A1", "_xlfn._xlws.SORT(B1:B3)", FormulaOpts{Dynamic: true}), "XML syntax error on line 1: invalid UTF-8")
+	// Test set dynamic array formula with unsupported charset content types
+	f = NewFile()
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellFormula("Sheet1", "A1", "_xlfn._xlws.SORT(B1:B3)", FormulaOpts{Dynamic: true}), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestGetCellFormulaOpts(t *testing.T) {
+	f := NewFile()
+	formulaType, ref := STCellFormulaTypeArray, "A3:A3"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A3", "=A1:A2", FormulaOpts{Ref: &ref, Type: &formulaType}))
+	opts, err := f.GetCellFormulaOpts("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Equal(t, FormulaOpts{Type: &formulaType, Ref: &ref}, opts)
+	// Test get formula settings of the dynamic array formula
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "_xlfn._xlws.SORT(A1:A2)", FormulaOpts{Dynamic: true}))
+	opts, err = f.GetCellFormulaOpts("Sheet1", "B1")
+	assert.NoError(t, err)
+	ref = "B1"
+	assert.Equal(t, FormulaOpts{Type: &formulaType, Ref: &ref, Dynamic: true}, opts)
+	// Test get formula settings of the normal formula and the cell without formula
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "=SUM(A1:A2)"))
+	for _, cell := range []string{"C1", "D1"} {
+		opts, err = f.GetCellFormulaOpts("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, FormulaOpts{}, opts)
+	}
+	// Test get formula settings with invalid sheet name
+	_, err = f.GetCellFormulaOpts("Sheet:1", "A1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+}
+
+func TestGetArrayFormula(t *testing.T) {
+	f := NewFile()
+	formulaType, ref := STCellFormulaTypeArray, "A3:B4"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A3", "=A1:B2", FormulaOpts{Ref: &ref, Type: &formulaType}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "_xlfn._xlws.SORT(A1:A2)", FormulaOpts{Dynamic: true}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[3].F.Ref = "D1:D2"
+	for _, c := range []struct {
+		cell    string
+		formula ArrayFormula
+	}{
+		{cell: "A3", formula: ArrayFormula{Master: "A3", Ref: "A3:B4"}},
+		{cell: "B4", formula: ArrayFormula{Master: "A3", Ref: "A3:B4"}},
+		{cell: "D2", formula: ArrayFormula{Master: "D1", Ref: "D1:D2", Dynamic: true}},
+		{cell: "C1", formula: ArrayFormula{}},
+	} {
+		formula, err := f.GetArrayFormula("Sheet1", c.cell)
+		assert.NoError(t, err)
+		assert.Equal(t, c.formula, formula, c.cell)
+		rangeRef, err := f.GetArrayFormulaRange("Sheet1", c.cell)
+		assert.NoError(t, err)
+		assert.Equal(t, c.formula.Ref, rangeRef, c.cell)
+	}
+	// Test get array formula with invalid cell reference
+	_, err := f.GetArrayFormulaRange("Sheet1", "A")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test get array formula with invalid sheet name
+	_, err = f.GetArrayFormulaRange("Sheet:1", "A1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+	// Test get array formula with invalid range reference
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[3].F.Ref = "D:D2"
+	_, err = f.GetArrayFormulaRange("Sheet1", "D2")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("D", newInvalidCellNameError("D")).Error())
+	// Test get array formula with unsupported charset metadata
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[3].F.Ref = "D1:D2"
+	f.Pkg.Store(defaultXMLPathMetadata, MacintoshCyrillicCharset)
+	_, err = f.GetArrayFormulaRange("Sheet1", "D2")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestGetSharedFormulas(t *testing.T) {
+	f := NewFile()
+	formulaType, ref := STCellFormulaTypeShared, "C1:C3"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "A1+B1", FormulaOpts{Ref: &ref, Type: &formulaType}))
+	ref = "D1:E2"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "A1*2", FormulaOpts{Ref: &ref, Type: &formulaType}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "F1", "SUM(A1:B1)"))
+	formulas, err := f.GetSharedFormulas("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]SharedFormula{
+		0: {Master: "C1", Ref: "C1:C3", Formula: "A1+B1", Dependents: []string{"C2", "C3"}},
+		1: {Master: "D1", Ref: "D1:E2", Formula: "A1*2", Dependents: []string{"E1", "D2", "E2"}},
+	}, formulas)
+	// Test get shared formulas on the worksheet without shared formula
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	formulas, err = f.GetSharedFormulas("Sheet2")
+	assert.NoError(t, err)
+	assert.Empty(t, formulas)
+	// Test get shared formulas with not exist worksheet
+	_, err = f.GetSharedFormulas("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get shared formulas with invalid sheet name
+	_, err = f.GetSharedFormulas("Sheet:1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
 }
 
 func TestGetCellRichText(t *testing.T) {
@@ -716,6 +1311,20 @@ func TestGetCellRichText(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, []RichTextRun{{Text: "A"}, {Text: "1"}}, runs)
 
+	// Test get cell rich text with run properties but without text
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0] = xlsxC{
+		T:  "inlineStr",
+		IS: &xlsxSI{R: []xlsxR{{RPr: &xlsxRPr{B: stringPtr("")}}}},
+	}
+	runs, err = f.GetCellRichText("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, []RichTextRun{{Font: &Font{Bold: true, Underline: "none"}}}, runs)
+	// Test get cell rich text with a single unformatted run
+	assert.NoError(t, f.SetCellRichText("Sheet1", "B1", []RichTextRun{{Text: "plain"}}))
+	runs, err = f.GetCellRichText("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, []RichTextRun{{Text: "plain"}}, runs)
+
 	// Test get cell rich text when string item index overflow
 	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
 	assert.True(t, ok)
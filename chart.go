@@ -12,8 +12,10 @@
 package excelize
 
 import (
+	"bytes"
 	"encoding/xml"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 )
@@ -269,6 +271,23 @@ var (
 		Bubble:                      0,
 		Bubble3D:                    0,
 	}
+	chartPlotGroupTypes = map[string][]ChartType{
+		"areaChart":      {Area, AreaStacked, AreaPercentStacked},
+		"area3DChart":    {Area3D, Area3DStacked, Area3DPercentStacked},
+		"barChart":       {Col, ColStacked, ColPercentStacked, Bar, BarStacked, BarPercentStacked},
+		"bar3DChart":     {Col3DClustered, Col3D, Col3DStacked, Col3DPercentStacked, Col3DCone, Col3DConeClustered, Col3DConeStacked, Col3DConePercentStacked, Col3DPyramid, Col3DPyramidClustered, Col3DPyramidStacked, Col3DPyramidPercentStacked, Col3DCylinder, Col3DCylinderClustered, Col3DCylinderStacked, Col3DCylinderPercentStacked, Bar3DClustered, Bar3DStacked, Bar3DPercentStacked, Bar3DConeClustered, Bar3DConeStacked, Bar3DConePercentStacked, Bar3DPyramidClustered, Bar3DPyramidStacked, Bar3DPyramidPercentStacked, Bar3DCylinderClustered, Bar3DCylinderStacked, Bar3DCylinderPercentStacked},
+		"bubbleChart":    {Bubble, Bubble3D},
+		"doughnutChart":  {Doughnut},
+		"lineChart":      {Line},
+		"line3DChart":    {Line3D},
+		"pieChart":       {Pie},
+		"pie3DChart":     {Pie3D},
+		"ofPieChart":     {PieOfPie, BarOfPie},
+		"radarChart":     {Radar},
+		"scatterChart":   {Scatter},
+		"surface3DChart": {Surface3D, WireframeSurface3D},
+		"surfaceChart":   {Contour, WireframeContour},
+	}
 	chartLegendPosition = map[string]string{
 		"bottom":    "b",
 		"left":      "l",
@@ -527,9 +546,43 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 	if opts.ShowBlanksAs == "" {
 		opts.ShowBlanksAs = defaultChartShowBlanksAs
 	}
+	for _, series := range opts.Series {
+		if err := series.Marker.validate(); err != nil {
+			return opts, err
+		}
+	}
+	for _, fill := range []Fill{opts.Fill, opts.Border.Fill, opts.PlotArea.Fill, opts.PlotArea.Border.Fill} {
+		if err := validateChartFill(fill); err != nil {
+			return opts, err
+		}
+	}
 	return opts, nil
 }
 
+// validate provides a function to check the marker symbol and size of the
+// chart series.
+func (marker *ChartMarker) validate() error {
+	if marker.Symbol != "" && inStrSlice(supportedChartMarkerSymbols, marker.Symbol, true) == -1 {
+		return newUnsupportedChartMarkerSymbol(marker.Symbol)
+	}
+	if marker.Size != 0 && (marker.Size < MinChartMarkerSize || marker.Size > MaxChartMarkerSize) {
+		return ErrChartMarkerSize
+	}
+	return nil
+}
+
+// validateChartFill provides a function to check the fill colors of the chart
+// area, plot area and borders, the color should be in hex format.
+func validateChartFill(fill Fill) error {
+	for _, color := range fill.Color {
+		hex := strings.TrimPrefix(color, "#")
+		if _, err := strconv.ParseUint(hex, 16, 32); err != nil || len(hex) != 6 {
+			return newInvalidChartFillColorError(color)
+		}
+	}
+	return nil
+}
+
 // parseTitle parse the title settings of the chart with default value.
 func (opts *Chart) parseTitle() {
 	for i := range opts.Title {
@@ -717,8 +770,9 @@ func (opts *Chart) parseTitle() {
 // value of width is outside the range, the default width of the line is 2pt.
 //
 // Marker: This sets the marker of the line chart and scatter chart. The range
-// of optional field 'Size' is 2-72 (default value is 5). The enumeration value
-// of optional field 'Symbol' are (default value is 'auto'):
+// of optional field 'Size' is 2-72 (default value is 5), an error will be
+// returned if the size or symbol is invalid. The enumeration value of optional
+// field 'Symbol' are (default value is 'auto'):
 //
 //	circle
 //	dash
@@ -776,6 +830,15 @@ func (opts *Chart) parseTitle() {
 // Specifies that each data marker in the series has a different color by
 // 'VaryColors'. The default value is true.
 //
+// Set the fill of the chart area by 'Fill' and the border of the chart area by
+// 'Border'. Use the 'pattern' fill type with pattern 1 and a color in hex
+// format to set a solid fill, or use the 'pattern' fill type with pattern 0 to
+// set no fill for a transparent chart area. The border color can be set by the
+// 'Fill' of the 'Border' in the same way. For example:
+//
+//	Fill:   excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"#FFF2CC"}},
+//	Border: excelize.ChartLine{Width: 1.5, Fill: excelize.Fill{Color: []string{"#BF9000"}}},
+//
 // Set chart offset, scale, aspect ratio setting and print settings by format,
 // same as function 'AddPicture'.
 //
@@ -789,6 +852,8 @@ func (opts *Chart) parseTitle() {
 //	ShowPercent
 //	ShowSerName
 //	ShowVal
+//	Fill
+//	Border
 //
 // SecondPlotValues: Specifies the values in second plot for the 'pieOfPie' and
 // 'barOfPie' chart.
@@ -811,6 +876,12 @@ func (opts *Chart) parseTitle() {
 // ShowVal: Specifies that the value shall be shown in a data label.
 // The 'ShowVal' property is optional. The default value is false.
 //
+// Fill: Specifies the fill of the plot area, same as the 'Fill' of the chart
+// area. The 'Fill' property is optional. The default is automatic fill.
+//
+// Border: Specifies the border of the plot area, same as the 'Border' of the
+// chart area. The 'Border' property is optional. The default is no border.
+//
 // Set the primary horizontal and vertical axis options by 'XAxis' and 'YAxis'.
 // The properties of 'XAxis' that can be set are:
 //
@@ -852,7 +923,11 @@ func (opts *Chart) parseTitle() {
 //
 // Secondary: Specifies the current series vertical axis as the secondary axis,
 // this only works for the second and later chart in the combo chart. The
-// default value is false.
+// secondary axes will be created once when any chart in the combo chart
+// requests it, and shared by all charts which use the secondary axis. The
+// scale, title and number format of the secondary axis are specified by the
+// 'YAxis' of the first chart which requests it, so the series on the
+// secondary axis could have independent scales. The default value is false.
 //
 // TickLabelSkip: Specifies how many tick labels to skip between label that is
 // drawn. The 'TickLabelSkip' property is optional. The default value is auto.
@@ -1131,16 +1206,361 @@ func (f *File) DeleteChart(sheet, cell string) error {
 	return err
 }
 
+// GetCharts provides a function to get the definitions of all charts in the
+// worksheet or chart sheet by given sheet name. The chart type, series
+// formula references, titles, legend and axes settings will be returned, and
+// the other styling details of the charts could not be recovered. For the
+// combo chart, the chart type and axes of the first plot group will be
+// returned, and the series of all plot groups will be returned in order. For
+// example, print the data references of the series of the charts on Sheet1:
+//
+//	charts, err := f.GetCharts("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, chart := range charts {
+//	    for _, series := range chart.Series {
+//	        fmt.Println(series.Name, series.Categories, series.Values)
+//	    }
+//	}
+//
+// The returned chart definitions could be used to update the series of a
+// template chart instead of recreating it from scratch. For example, change
+// the values reference of the first series of the first chart anchored at cell
+// E1 on Sheet1:
+//
+//	charts, err := f.GetCharts("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	chart := charts[0]
+//	chart.Series[0].Values = "Sheet1!$B$2:$D$2"
+//	if err := f.DeleteChart("Sheet1", "E1"); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := f.AddChart("Sheet1", "E1", chart); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) GetCharts(sheet string) ([]*Chart, error) {
+	var charts []*Chart
+	err := f.rangeSheetCharts(sheet, func(from *decodeFrom, chartXML string) error {
+		chart, err := f.getChart(chartXML)
+		if err != nil {
+			return err
+		}
+		charts = append(charts, chart)
+		return nil
+	})
+	return charts, err
+}
+
+// GetChartSeries provides a function to get the series of the charts anchored
+// at the given cell in the worksheet by given worksheet name and cell
+// reference. The name, categories, values and bubble sizes formula references
+// of each series will be returned as stored in the chart, so they could be
+// resolved against the worksheets. For the combo chart, the series of all plot
+// groups will be returned in order. For example, print the data references of
+// the series of the chart anchored at cell E1 on Sheet1:
+//
+//	series, err := f.GetChartSeries("Sheet1", "E1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, ser := range series {
+//	    fmt.Println(ser.Name, ser.Categories, ser.Values)
+//	}
+func (f *File) GetChartSeries(sheet, cell string) ([]ChartSeries, error) {
+	var series []ChartSeries
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return series, err
+	}
+	err = f.rangeSheetCharts(sheet, func(from *decodeFrom, chartXML string) error {
+		if from == nil || from.Col != col-1 || from.Row != row-1 {
+			return nil
+		}
+		chart, err := f.getChart(chartXML)
+		if err != nil {
+			return err
+		}
+		series = append(series, chart.Series...)
+		return nil
+	})
+	return series, err
+}
+
+// rangeSheetCharts provides a function to call the given function for each
+// chart in the worksheet or chart sheet by given sheet name, with the start
+// anchor cell and the chart part path of the chart. The start anchor cell
+// will be nil for the chart with absolute anchor.
+func (f *File) rangeSheetCharts(sheet string, fn func(from *decodeFrom, chartXML string) error) error {
+	if err := checkSheetName(sheet); err != nil {
+		return err
+	}
+	sheetXMLPath, ok := f.getSheetXMLPath(sheet)
+	if !ok {
+		return ErrSheetNotExist{sheet}
+	}
+	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+	if strings.HasPrefix(sheetXMLPath, "xl/chartsheets/") {
+		sheetRels = "xl/chartsheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/chartsheets/") + ".rels"
+	}
+	rels, err := f.relsReader(sheetRels)
+	if err != nil || rels == nil {
+		return err
+	}
+	for _, rel := range rels.Relationships {
+		if rel.Type != SourceRelationshipDrawingML {
+			continue
+		}
+		drawingXML := strings.TrimPrefix(strings.ReplaceAll(rel.Target, "..", "xl"), "/")
+		drawingRels := "xl/drawings/_rels/" + strings.TrimPrefix(drawingXML, "xl/drawings/") + ".rels"
+		wsDr, _, err := f.drawingParser(drawingXML)
+		if err != nil {
+			return err
+		}
+		var anchors []*xdrCellAnchor
+		anchors = append(append(append(anchors, wsDr.AbsoluteAnchor...), wsDr.OneCellAnchor...), wsDr.TwoCellAnchor...)
+		for _, anchor := range anchors {
+			deChartAnchor := decodeChartAnchor{}
+			if err = f.xmlNewDecoder(strings.NewReader("<decodeChartAnchor>" + anchor.GraphicFrame + "</decodeChartAnchor>")).
+				Decode(&deChartAnchor); err != nil && err != io.EOF {
+				return err
+			}
+			if deChartAnchor.Chart == nil {
+				continue
+			}
+			target := f.getDrawingRelationships(drawingRels, deChartAnchor.Chart.RID)
+			if target == nil {
+				continue
+			}
+			if anchor.From != nil {
+				deChartAnchor.From = &decodeFrom{Col: anchor.From.Col, Row: anchor.From.Row}
+			}
+			if err = fn(deChartAnchor.From, strings.TrimPrefix(strings.ReplaceAll(target.Target, "..", "xl"), "/")); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// getChart provides a function to get the chart definition by given chart
+// part path.
+func (f *File) getChart(chartXML string) (*Chart, error) {
+	var (
+		chart       = &Chart{Legend: ChartLegend{Position: "none"}}
+		chartSpace  decodeChartSpace
+		axes        []*decodeChartAxis
+		firstGroup  = true
+		getAxisByID = func(axID []*attrValInt, idx int) *decodeChartAxis {
+			if idx >= len(axID) || axID[idx].Val == nil {
+				return nil
+			}
+			for _, axis := range axes {
+				if axis.AxID != nil && axis.AxID.Val != nil && *axis.AxID.Val == *axID[idx].Val {
+					return axis
+				}
+			}
+			return nil
+		}
+	)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(chartXML)))).
+		Decode(&chartSpace); err != nil && err != io.EOF {
+		return nil, err
+	}
+	chart.Title = extractChartTitle(chartSpace.Chart.Title)
+	chart.ShowBlanksAs = chartSpace.Chart.DispBlanksAs.Value()
+	if legend := chartSpace.Chart.Legend; legend != nil {
+		chart.Legend.Position = "right"
+		for position, val := range chartLegendPosition {
+			if val == legend.LegendPos.Value() {
+				chart.Legend.Position = position
+			}
+		}
+	}
+	plotArea := chartSpace.Chart.PlotArea
+	axes = append(append(append(axes, plotArea.CatAx...), plotArea.ValAx...), plotArea.SerAx...)
+	for _, group := range plotArea.Groups {
+		chartType, ok := f.getChartType(&group)
+		if !ok {
+			continue
+		}
+		if firstGroup {
+			chart.Type, firstGroup = chartType, false
+			if group.VaryColors != nil {
+				chart.VaryColors = group.VaryColors.Val
+			}
+			if group.HoleSize != nil && group.HoleSize.Val != nil {
+				chart.HoleSize = *group.HoleSize.Val
+			}
+			if group.SplitPos != nil && group.SplitPos.Val != nil {
+				chart.PlotArea.SecondPlotValues = *group.SplitPos.Val
+			}
+			dLbls := group.DLbls
+			if dLbls == nil && len(group.Ser) > 0 {
+				dLbls = group.Ser[0].DLbls
+			}
+			if dLbls != nil {
+				chart.Legend.ShowLegendKey = dLbls.ShowLegendKey.Value()
+				chart.PlotArea.ShowBubbleSize = dLbls.ShowBubbleSize.Value()
+				chart.PlotArea.ShowCatName = dLbls.ShowCatName.Value()
+				chart.PlotArea.ShowLeaderLines = dLbls.ShowLeaderLines.Value()
+				chart.PlotArea.ShowPercent = dLbls.ShowPercent.Value()
+				chart.PlotArea.ShowSerName = dLbls.ShowSerName.Value()
+				chart.PlotArea.ShowVal = dLbls.ShowVal.Value()
+				if dLbls.NumFmt != nil {
+					chart.PlotArea.NumFmt = ChartNumFmt{CustomNumFmt: dLbls.NumFmt.FormatCode, SourceLinked: dLbls.NumFmt.SourceLinked}
+				}
+			}
+			chart.XAxis = extractChartAxis(getAxisByID(group.AxID, 0))
+			chart.YAxis = extractChartAxis(getAxisByID(group.AxID, 1))
+		}
+		for _, ser := range group.Ser {
+			chart.Series = append(chart.Series, extractChartSeries(ser))
+		}
+	}
+	return chart, nil
+}
+
+// getChartType provides a function to get the chart type by given plot group
+// of the chart part, the first chart type for the plot group element will be
+// returned if no chart type exactly matched.
+func (f *File) getChartType(group *decodeChartGroup) (ChartType, bool) {
+	chartTypes, ok := chartPlotGroupTypes[group.XMLName.Local]
+	if !ok {
+		return 0, false
+	}
+	shape := group.Shape.Value()
+	if shape == "box" {
+		shape = ""
+	}
+	bubble3D := len(group.Ser) > 0 && group.Ser[0].Bubble3D.Value()
+	for _, chartType := range chartTypes {
+		if grouping, ok := plotAreaChartGrouping[chartType]; ok && grouping != group.Grouping.Value() {
+			continue
+		}
+		if barDir, ok := plotAreaChartBarDir[chartType]; ok && barDir != group.BarDir.Value() {
+			continue
+		}
+		if f.drawChartShape(&Chart{Type: chartType}).Value() != shape {
+			continue
+		}
+		if ofPieType, ok := map[ChartType]string{PieOfPie: "pie", BarOfPie: "bar"}[chartType]; ok && ofPieType != group.OfPieType.Value() {
+			continue
+		}
+		if (chartType == WireframeSurface3D || chartType == WireframeContour) != group.Wireframe.Value() {
+			continue
+		}
+		if (chartType == Bubble3D) != bubble3D {
+			continue
+		}
+		return chartType, true
+	}
+	return chartTypes[0], true
+}
+
+// extractChartTitle provides a function to extract the rich text runs by
+// given chart title or axis title.
+func extractChartTitle(title *decodeChartTitle) []RichTextRun {
+	var runs []RichTextRun
+	if title == nil {
+		return runs
+	}
+	for _, p := range title.P {
+		for _, r := range p.R {
+			run := RichTextRun{Text: r.T}
+			if rPr := r.RPr; rPr != nil && (rPr.B || rPr.I || rPr.Sz > 0 || rPr.SolidFill != nil) {
+				run.Font = &Font{Bold: rPr.B, Italic: rPr.I, Size: rPr.Sz / 100}
+				if rPr.SolidFill != nil {
+					run.Font.Color = rPr.SolidFill.SrgbClr.Value()
+				}
+			}
+			runs = append(runs, run)
+		}
+	}
+	return runs
+}
+
+// extractChartAxis provides a function to extract the chart axis settings by
+// given axis of the chart part.
+func extractChartAxis(axis *decodeChartAxis) ChartAxis {
+	var opts ChartAxis
+	if axis == nil {
+		return opts
+	}
+	opts.None = axis.Delete.Value()
+	opts.MajorGridLines = axis.MajorGridlines != nil
+	opts.MinorGridLines = axis.MinorGridlines != nil
+	opts.MajorUnit = axis.MajorUnit.Value()
+	if axis.TickLblSkip != nil && axis.TickLblSkip.Val != nil {
+		opts.TickLabelSkip = *axis.TickLblSkip.Val
+	}
+	if scaling := axis.Scaling; scaling != nil {
+		opts.ReverseOrder = scaling.Orientation.Value() == orientation[true]
+		opts.LogBase = scaling.LogBase.Value()
+		if scaling.Max != nil {
+			opts.Maximum = scaling.Max.Val
+		}
+		if scaling.Min != nil {
+			opts.Minimum = scaling.Min.Val
+		}
+	}
+	if axis.NumFmt != nil {
+		opts.NumFmt = ChartNumFmt{CustomNumFmt: axis.NumFmt.FormatCode, SourceLinked: axis.NumFmt.SourceLinked}
+	}
+	opts.Title = extractChartTitle(axis.Title)
+	return opts
+}
+
+// extractChartSeries provides a function to extract the chart series formula
+// references by given series of the chart part.
+func extractChartSeries(ser decodeChartSer) ChartSeries {
+	var series ChartSeries
+	getRef := func(data ...*decodeChartSerData) string {
+		for _, d := range data {
+			if d != nil && d.StrRef != nil {
+				return d.StrRef.F
+			}
+			if d != nil && d.NumRef != nil {
+				return d.NumRef.F
+			}
+		}
+		return ""
+	}
+	if ser.Tx != nil {
+		if series.Name = ser.Tx.V; ser.Tx.StrRef != nil {
+			series.Name = ser.Tx.StrRef.F
+		}
+	}
+	series.Categories = getRef(ser.Cat, ser.XVal)
+	series.Values = getRef(ser.Val, ser.YVal)
+	series.Sizes = getRef(ser.BubbleSize)
+	return series
+}
+
 // countCharts provides a function to get chart files count storage in the
-// folder xl/charts.
+// folder xl/charts. The largest chart file ID will be returned if the chart
+// files are not numbered continuously, such as after a chart sheet has been
+// deleted.
 func (f *File) countCharts() int {
-	count := 0
+	count, maxID := 0, 0
 	f.Pkg.Range(func(k, v interface{}) bool {
-		if strings.Contains(k.(string), "xl/charts/chart") {
+		if name := k.(string); strings.Contains(name, "xl/charts/chart") {
 			count++
+			if id, err := strconv.Atoi(strings.TrimSuffix(name[strings.LastIndex(name, "chart")+5:], ".xml")); err == nil && id > maxID {
+				maxID = id
+			}
 		}
 		return true
 	})
+	if maxID > count {
+		return maxID
+	}
 	return count
 }
 
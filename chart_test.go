@@ -294,6 +294,18 @@ func TestAddChart(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChart.xlsx")))
 	// Test with invalid sheet name
 	assert.EqualError(t, f.AddChart("Sheet:1", "A1", &Chart{Type: Col, Series: series[:1]}), ErrSheetNameInvalid.Error())
+	// Test add chart with marker symbol and size
+	f2 := NewFile()
+	assert.NoError(t, f2.AddChart("Sheet1", "A1", &Chart{Type: Scatter, Series: []ChartSeries{{Values: "Sheet1!$B$30:$D$30", Marker: ChartMarker{Symbol: "triangle", Size: 8}}}}))
+	chart, ok := f2.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(chart.([]byte)), `<marker><symbol val="triangle"></symbol><size val="8"></size>`)
+	// Test add chart with invalid marker symbol
+	assert.EqualError(t, f.AddChart("Sheet1", "A1", &Chart{Type: Line, Series: []ChartSeries{{Values: "Sheet1!$B$30:$D$30", Marker: ChartMarker{Symbol: "unknown"}}}}), newUnsupportedChartMarkerSymbol("unknown").Error())
+	// Test add chart with invalid marker size
+	for _, size := range []int{1, 73} {
+		assert.Equal(t, ErrChartMarkerSize, f.AddChart("Sheet1", "A1", &Chart{Type: Scatter, Series: []ChartSeries{{Values: "Sheet1!$B$30:$D$30", Marker: ChartMarker{Symbol: "square", Size: size}}}}))
+	}
 	// Test with illegal cell reference
 	assert.EqualError(t, f.AddChart("Sheet2", "A", &Chart{Type: Col, Series: series, Format: format, Legend: legend, Title: []RichTextRun{{Text: "2D Column Chart"}}, PlotArea: plotArea, ShowBlanksAs: "zero"}), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
 	// Test with unsupported chart type
@@ -349,6 +361,31 @@ func TestAddChartSheet(t *testing.T) {
 	assert.NoError(t, f.UpdateLinkedValue())
 
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartSheet.xlsx")))
+	// Test delete chart sheet clean up the drawing and chart parts
+	assert.NoError(t, f.AddChartSheet("Chart2", &Chart{Type: Line, Series: series}))
+	assert.NoError(t, f.DeleteSheet("Chart1"))
+	assert.Equal(t, []string{"Sheet1", "Chart2"}, f.GetSheetList())
+	for _, name := range []string{
+		"xl/chartsheets/sheet2.xml", "xl/chartsheets/_rels/sheet2.xml.rels",
+		"xl/drawings/drawing1.xml", "xl/drawings/_rels/drawing1.xml.rels", "xl/charts/chart1.xml",
+	} {
+		_, ok := f.Pkg.Load(name)
+		assert.False(t, ok, name)
+		_, ok = f.Relationships.Load(name)
+		assert.False(t, ok, name)
+	}
+	_, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.False(t, ok)
+	for _, override := range f.ContentTypes.Overrides {
+		assert.NotContains(t, []string{"/xl/chartsheets/sheet2.xml", "/xl/drawings/drawing1.xml", "/xl/charts/chart1.xml"}, override.PartName)
+	}
+	// Test add chart sheet after delete chart sheet
+	assert.NoError(t, f.AddChartSheet("Chart3", &Chart{Type: Col, Series: series}))
+	_, ok = f.Pkg.Load("xl/charts/chart3.xml")
+	assert.True(t, ok)
+	_, ok = f.Drawings.Load("xl/drawings/drawing3.xml")
+	assert.True(t, ok)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeleteChartSheet.xlsx")))
 	// Test add chart sheet with unsupported charset content types
 	f = NewFile()
 	f.ContentTypes = nil
@@ -503,3 +540,275 @@ func TestChartWithLogarithmicBase(t *testing.T) {
 		}
 	}
 }
+
+func TestChartAreaAndPlotAreaFill(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{nil, "Apple"}, {"Small", 2}, {"Normal", 5}} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	series := []ChartSeries{{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$3", Values: "Sheet1!$B$2:$B$3"}}
+	assert.NoError(t, f.AddChart("Sheet1", "D1", &Chart{
+		Type:   Col,
+		Series: series,
+		Fill:   Fill{Type: "pattern", Pattern: 1, Color: []string{"#fff2cc"}},
+		Border: ChartLine{Width: 1.5, Fill: Fill{Color: []string{"BF9000"}}},
+		PlotArea: ChartPlotArea{
+			Fill:   Fill{Type: "pattern", Pattern: 0},
+			Border: ChartLine{Fill: Fill{Color: []string{"#000000"}}},
+		},
+	}))
+	assert.NoError(t, f.AddChart("Sheet1", "D20", &Chart{Type: Col, Series: series}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartAreaAndPlotAreaFill.xlsx")))
+
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	chart := string(content.([]byte))
+	assert.Contains(t, chart, `<spPr><a:noFill></a:noFill><a:ln algn="ctr" cap="flat" cmpd="sng" w="9525"><a:solidFill><a:srgbClr val="000000"></a:srgbClr></a:solidFill></a:ln></spPr></plotArea>`)
+	assert.Contains(t, chart, `<spPr><a:solidFill><a:srgbClr val="FFF2CC"></a:srgbClr></a:solidFill><a:ln algn="ctr" cap="flat" cmpd="sng" w="19050"><a:solidFill><a:srgbClr val="BF9000"></a:srgbClr></a:solidFill></a:ln></spPr>`)
+
+	// Test the chart and plot area with default fill and border
+	content, ok = f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	chart = string(content.([]byte))
+	assert.Contains(t, chart, `<spPr><a:solidFill><a:schemeClr val="bg1"></a:schemeClr></a:solidFill>`)
+	assert.NotContains(t, chart, `</spPr></plotArea>`)
+
+	// Test add chart without changing the border settings of the plot area
+	opts := &Chart{Type: Col, Series: series, PlotArea: ChartPlotArea{Border: ChartLine{Fill: Fill{Color: []string{"#000000"}}}}}
+	assert.NoError(t, f.AddChart("Sheet1", "L1", opts))
+	assert.Zero(t, opts.PlotArea.Border.Width)
+
+	// Test add chart with invalid fill color
+	for _, opts := range []*Chart{
+		{Type: Col, Series: series, Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"#FFF"}}},
+		{Type: Col, Series: series, Border: ChartLine{Fill: Fill{Color: []string{"red"}}}},
+		{Type: Col, Series: series, PlotArea: ChartPlotArea{Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"#GGGGGG"}}}},
+		{Type: Col, Series: series, PlotArea: ChartPlotArea{Border: ChartLine{Fill: Fill{Color: []string{""}}}}},
+	} {
+		assert.Error(t, f.AddChart("Sheet1", "D40", opts))
+	}
+	assert.Equal(t, newInvalidChartFillColorError("#FFF"), f.AddChart("Sheet1", "D40", &Chart{
+		Type: Col, Series: series, Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"#FFF"}},
+	}))
+}
+
+func TestComboChartAxes(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{nil, "Revenue", "Margin", "Cost"}, {"Q1", 10, 0.2, 8}, {"Q2", 12, 0.25, 9}} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	series := func(col string) []ChartSeries {
+		return []ChartSeries{{Name: "Sheet1!$" + col + "$1", Categories: "Sheet1!$A$2:$A$3", Values: "Sheet1!$" + col + "$2:$" + col + "$3"}}
+	}
+	maximum := 1.0
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{Type: Col, Series: series("B")},
+		&Chart{Type: Line, Series: series("C"), YAxis: ChartAxis{
+			Secondary: true, Maximum: &maximum, MajorUnit: 0.25, LogBase: 10,
+			Title: []RichTextRun{{Text: "Margin"}}, NumFmt: ChartNumFmt{CustomNumFmt: "0%"},
+		}},
+		&Chart{Type: Area, Series: series("D")},
+		&Chart{Type: Scatter, Series: series("D"), YAxis: ChartAxis{Secondary: true}},
+	))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestComboChartAxes.xlsx")))
+	var chartSpace xlsxChartSpace
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	var axIDs []int
+	for _, axs := range [][]*cAxs{chartSpace.Chart.PlotArea.CatAx, chartSpace.Chart.PlotArea.ValAx} {
+		for _, ax := range axs {
+			axIDs = append(axIDs, *ax.AxID.Val)
+		}
+	}
+	assert.Equal(t, []int{100000000, 100000003, 100000001, 100000004}, axIDs)
+	// Test the scale, title and number format of the secondary vertical axis
+	secondaryAx := chartSpace.Chart.PlotArea.ValAx[1]
+	assert.Equal(t, 1.0, *secondaryAx.Scaling.Max.Val)
+	assert.Equal(t, 10.0, *secondaryAx.Scaling.LogBase.Val)
+	assert.Equal(t, 0.25, *secondaryAx.MajorUnit.Val)
+	assert.Equal(t, "0%", secondaryAx.NumFmt.FormatCode)
+	assert.NotNil(t, secondaryAx.Title)
+	assert.Contains(t, string(content.([]byte)), "Margin")
+	assert.False(t, *secondaryAx.Delete.Val)
+	assert.Nil(t, chartSpace.Chart.PlotArea.ValAx[0].Title)
+	// Test the series of each plot group in the combo chart
+	assert.Len(t, *chartSpace.Chart.PlotArea.BarChart.Ser, 1)
+	assert.Len(t, *chartSpace.Chart.PlotArea.LineChart.Ser, 1)
+	assert.Len(t, *chartSpace.Chart.PlotArea.AreaChart.Ser, 1)
+	assert.Len(t, *chartSpace.Chart.PlotArea.ScatterChart.Ser, 1)
+}
+
+func TestGetCharts(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{nil, "Apple", "Orange", "Pear"}, {"Small", 2, 3, 3}, {"Normal", 5, 2, 4}, {"Large", 6, 7, 8},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	series := []ChartSeries{
+		{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"},
+		{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3"},
+	}
+	maximum := 10.0
+	expected := []*Chart{
+		{
+			Type: Col3DCylinderStacked, Series: series, Legend: ChartLegend{Position: "top"},
+			Title:        []RichTextRun{{Text: "Fruit "}, {Text: "Chart", Font: &Font{Bold: true, Size: 12, Color: "FF0000"}}},
+			XAxis:        ChartAxis{ReverseOrder: true, MajorGridLines: true, Title: []RichTextRun{{Text: "Size"}}},
+			YAxis:        ChartAxis{Maximum: &maximum, LogBase: 10, MajorUnit: 2, NumFmt: ChartNumFmt{CustomNumFmt: "0.00", SourceLinked: true}},
+			ShowBlanksAs: "zero",
+		},
+		{Type: BarOfPie, Series: series[:1], Legend: ChartLegend{Position: "none"}, PlotArea: ChartPlotArea{ShowVal: true}},
+		{Type: Bubble3D, Series: []ChartSeries{{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2", Sizes: "Sheet1!$B$4:$D$4"}}},
+	}
+	for idx, chart := range expected {
+		cell, err := CoordinatesToCellName(6, idx*15+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.AddChart("Sheet1", cell, chart))
+	}
+	// Test get charts with combo chart
+	assert.NoError(t, f.AddChart("Sheet1", "P1", &Chart{Type: Col, Series: series[:1]}, &Chart{Type: Line, Series: series[1:]}))
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{Type: WireframeContour, Series: series}))
+	// Test get charts after delete chart
+	assert.NoError(t, f.AddChart("Sheet1", "P20", &Chart{Type: Radar, Series: series}))
+	assert.NoError(t, f.DeleteChart("Sheet1", "P20"))
+
+	check := func(f *File) {
+		charts, err := f.GetCharts("Sheet1")
+		assert.NoError(t, err)
+		assert.Len(t, charts, 4)
+		for idx, chart := range expected {
+			assert.Equal(t, chart.Type, charts[idx].Type)
+			assert.Equal(t, chart.Series, charts[idx].Series)
+		}
+		assert.Equal(t, expected[0].Title, charts[0].Title)
+		assert.Equal(t, "top", charts[0].Legend.Position)
+		assert.Equal(t, "zero", charts[0].ShowBlanksAs)
+		assert.True(t, charts[0].XAxis.ReverseOrder)
+		assert.True(t, charts[0].XAxis.MajorGridLines)
+		assert.Equal(t, expected[0].XAxis.Title, charts[0].XAxis.Title)
+		assert.Equal(t, maximum, *charts[0].YAxis.Maximum)
+		assert.Nil(t, charts[0].YAxis.Minimum)
+		assert.Equal(t, 10.0, charts[0].YAxis.LogBase)
+		assert.Equal(t, 2.0, charts[0].YAxis.MajorUnit)
+		assert.Equal(t, expected[0].YAxis.NumFmt, charts[0].YAxis.NumFmt)
+		assert.Equal(t, "none", charts[1].Legend.Position)
+		assert.True(t, charts[1].PlotArea.ShowVal)
+		assert.Equal(t, Col, charts[3].Type)
+		assert.Equal(t, series, charts[3].Series)
+
+		charts, err = f.GetCharts("Chart1")
+		assert.NoError(t, err)
+		assert.Len(t, charts, 1)
+		assert.Equal(t, WireframeContour, charts[0].Type)
+		assert.Equal(t, series, charts[0].Series)
+	}
+	check(f)
+	// Test get charts from saved workbook
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetCharts.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err := OpenFile(filepath.Join("test", "TestGetCharts.xlsx"))
+	assert.NoError(t, err)
+	check(f)
+	// Test update the series of the chart by recreating it with the returned definition
+	charts, err := f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	charts[0].Series[1].Values = "Sheet1!$B$4:$D$4"
+	assert.NoError(t, f.DeleteChart("Sheet1", "F1"))
+	assert.NoError(t, f.AddChart("Sheet1", "F1", charts[0]))
+	chartSeries, err := f.GetChartSeries("Sheet1", "F1")
+	assert.NoError(t, err)
+	assert.Equal(t, []ChartSeries{series[0], {Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$4:$D$4"}}, chartSeries)
+	// Test get charts on the worksheet without charts
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	charts, err = f.GetCharts("Sheet2")
+	assert.NoError(t, err)
+	assert.Empty(t, charts)
+	// Test get charts with invalid sheet name
+	_, err = f.GetCharts("Sheet:1")
+	assert.Equal(t, ErrSheetNameInvalid, err)
+	// Test get charts on not exists worksheet
+	_, err = f.GetCharts("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+
+	// Test get charts created by the spreadsheet application
+	f, err = OpenFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	charts, err = f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 2)
+	assert.Equal(t, Doughnut, charts[0].Type)
+	assert.Equal(t, []ChartSeries{{Name: "Sheet2!$A$1", Categories: "Sheet2!$A$2:$A$5", Values: "Sheet2!$B$2:$B$5"}}, charts[0].Series)
+	// Test get charts with unsupported charset chart part
+	f.Pkg.Store("xl/charts/chart1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetCharts("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get charts with unsupported charset drawing part
+	f.Drawings.Delete("xl/drawings/drawing1.xml")
+	f.Pkg.Store("xl/drawings/drawing1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetCharts("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get charts with unsupported charset worksheet relationships
+	f.Relationships.Delete("xl/worksheets/_rels/sheet1.xml.rels")
+	f.Pkg.Store("xl/worksheets/_rels/sheet1.xml.rels", MacintoshCyrillicCharset)
+	_, err = f.GetCharts("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestGetChartSeries(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{nil, "Apple", "Orange", "Pear"}, {"Small", 2, 3, 3}, {"Normal", 5, 2, 4}, {"Large", 6, 7, 8},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	series := []ChartSeries{
+		{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"},
+		{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3"},
+		{Name: "Sheet1!$A$4", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$4:$D$4"},
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{Type: Col, Series: series[:2]}))
+	assert.NoError(t, f.AddChart("Sheet1", "E20", &Chart{Type: Col, Series: series[:1]}, &Chart{Type: Line, Series: series[2:]}))
+	chartSeries, err := f.GetChartSeries("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Equal(t, series[:2], chartSeries)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetChartSeries.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test get chart series from saved workbook
+	f, err = OpenFile(filepath.Join("test", "TestGetChartSeries.xlsx"))
+	assert.NoError(t, err)
+	chartSeries, err = f.GetChartSeries("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Equal(t, series[:2], chartSeries)
+	// Test get series of the combo chart
+	chartSeries, err = f.GetChartSeries("Sheet1", "E20")
+	assert.NoError(t, err)
+	assert.Equal(t, []ChartSeries{series[0], series[2]}, chartSeries)
+	// Test get chart series on the cell without chart
+	chartSeries, err = f.GetChartSeries("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Empty(t, chartSeries)
+	// Test get chart series with invalid cell reference
+	_, err = f.GetChartSeries("Sheet1", "A")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test get chart series on not exists worksheet
+	_, err = f.GetChartSeries("SheetN", "E1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get chart series with unsupported charset chart part
+	f.Pkg.Store("xl/charts/chart1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetChartSeries("Sheet1", "E1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
@@ -17,6 +17,7 @@ import (
 	"math"
 	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/mohae/deepcopy"
 )
@@ -414,10 +415,110 @@ func (f *File) SetColOutlineLevel(sheet, col string, level uint8) error {
 	return err
 }
 
+// SetColGroup provides a function to group columns by given worksheet name,
+// columns range, outline level and collapsed state. The value of parameter
+// 'level' is 1-7. The columns in the group will be hidden if the group is
+// collapsed, and the collapsed state will be stored on the summary column of
+// the group, which is the next column to the right of the group by default,
+// or the previous column to the left of the group if the summary columns are
+// not to the right of detail. This function is concurrency safe. For
+// example, group the columns B to D in Sheet1 to level 1 and collapse the
+// group:
+//
+//	err := f.SetColGroup("Sheet1", "B:D", 1, true)
+func (f *File) SetColGroup(sheet, columns string, level int, collapsed bool) error {
+	minVal, maxVal, err := f.parseColRange(columns)
+	if err != nil {
+		return err
+	}
+	if level > 7 || level < 1 {
+		return ErrOutlineLevel
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	summary := maxVal + 1
+	if ws.SheetPr != nil && ws.SheetPr.OutlinePr != nil &&
+		ws.SheetPr.OutlinePr.SummaryRight != nil && !*ws.SheetPr.OutlinePr.SummaryRight {
+		summary = minVal - 1
+	}
+	if ws.Cols == nil {
+		ws.Cols = &xlsxCols{}
+	}
+	ws.Cols.Col = flatCols(xlsxCol{
+		Min:          minVal,
+		Max:          maxVal,
+		OutlineLevel: uint8(level),
+		Hidden:       collapsed,
+	}, ws.Cols.Col, func(fc, c xlsxCol) xlsxCol {
+		fc.BestFit = c.BestFit
+		fc.Collapsed = c.Collapsed
+		fc.CustomWidth = c.CustomWidth
+		fc.Phonetic = c.Phonetic
+		fc.Style = c.Style
+		fc.Width = c.Width
+		return fc
+	})
+	if summary < 1 || summary > MaxColumns {
+		return err
+	}
+	ws.Cols.Col = flatCols(xlsxCol{
+		Min:       summary,
+		Max:       summary,
+		Collapsed: collapsed,
+	}, ws.Cols.Col, func(fc, c xlsxCol) xlsxCol {
+		fc.BestFit = c.BestFit
+		fc.CustomWidth = c.CustomWidth
+		fc.Hidden = c.Hidden
+		fc.OutlineLevel = c.OutlineLevel
+		fc.Phonetic = c.Phonetic
+		fc.Style = c.Style
+		fc.Width = c.Width
+		return fc
+	})
+	return err
+}
+
+// GetColCollapsed provides a function to get the collapsed state of the
+// group which summary column is the given column name on the worksheet. For
+// example, get collapsed state of the group on the summary column E in
+// Sheet1:
+//
+//	collapsed, err := f.GetColCollapsed("Sheet1", "E")
+func (f *File) GetColCollapsed(sheet, col string) (bool, error) {
+	var collapsed bool
+	colNum, err := ColumnNameToNumber(col)
+	if err != nil {
+		return collapsed, err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return collapsed, err
+	}
+	if ws.Cols == nil {
+		return collapsed, err
+	}
+	for c := range ws.Cols.Col {
+		colData := &ws.Cols.Col[c]
+		if colData.Min <= colNum && colNum <= colData.Max {
+			collapsed = colData.Collapsed
+		}
+	}
+	return collapsed, err
+}
+
 // SetColStyle provides a function to set style of columns by given worksheet
 // name, columns range and style ID. This function is concurrency safe. Note
 // that this will overwrite the existing styles for the columns, it won't
-// append or merge style with existing styles.
+// append or merge style with existing styles. The cells written in these
+// columns later will inherit the column style, unless a style has been
+// specified for the cell or the row.
 //
 // For example set style of column H on Sheet1:
 //
@@ -483,6 +584,35 @@ func (f *File) SetColStyle(sheet, columns string, styleID int) error {
 	return err
 }
 
+// SetColFormula provides a function to set the formula of the cells in a
+// column from the start row to the end row as a shared formula by given
+// worksheet name, column name, rows range and the formula of the first cell.
+// The relative references in the formula will be adjusted for each cell, and
+// only the first cell stores the formula in the worksheet, which is much
+// smaller than setting the same formula for each cell. For example, set the
+// formula "=A1*B1" for the cells from C1 to C100 on Sheet1:
+//
+//	err := f.SetColFormula("Sheet1", "C", 1, 100, "=A1*B1")
+func (f *File) SetColFormula(sheet, col string, startRow, endRow int, formula string) error {
+	colNum, err := ColumnNameToNumber(col)
+	if err != nil {
+		return err
+	}
+	if startRow > endRow {
+		startRow, endRow = endRow, startRow
+	}
+	if startRow < 1 {
+		return newInvalidRowNumberError(startRow)
+	}
+	if endRow > TotalRows {
+		return ErrMaxRows
+	}
+	cell, _ := CoordinatesToCellName(colNum, startRow)
+	ref, _ := f.coordinatesToRangeRef([]int{colNum, startRow, colNum, endRow})
+	formulaType := STCellFormulaTypeShared
+	return f.SetCellFormula(sheet, cell, formula, FormulaOpts{Type: &formulaType, Ref: &ref})
+}
+
 // SetColWidth provides a function to set the width of a single column or
 // multiple columns. This function is concurrency safe. For example:
 //
@@ -528,6 +658,176 @@ func (f *File) SetColWidth(sheet, startCol, endCol string, width float64) error
 	return err
 }
 
+// AutoFitColumns provides a function to set the width of a single column or
+// multiple columns to fit the widest formatted cell value in the column by
+// given worksheet name and columns range. The width of each cell value is
+// approximated by the character widths of the default font, and scaled by
+// the font size and bold of the cell style, so it doesn't exactly match the
+// width calculated by the spreadsheet application. The merged cells across
+// multiple columns are ignored, and the columns without any cell value will
+// keep their width. The optional MaxWidth specifies the maximum width of the
+// columns. For example, auto-fit the columns from A to H on Sheet1 with the
+// maximum width of 50 characters:
+//
+//	err := f.AutoFitColumns("Sheet1", "A:H", excelize.AutoFitColumnOptions{
+//	    MaxWidth: 50,
+//	})
+func (f *File) AutoFitColumns(sheet, cols string, opts ...AutoFitColumnOptions) error {
+	minVal, maxVal, err := f.parseColRange(cols)
+	if err != nil {
+		return err
+	}
+	maxWidth := float64(MaxColumnWidth)
+	for _, opt := range opts {
+		if opt.MaxWidth > 0 {
+			maxWidth = opt.MaxWidth
+		}
+	}
+	if maxWidth > MaxColumnWidth {
+		return ErrColumnWidth
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	styleSheet, err := f.stylesReader()
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	widths := map[int]float64{}
+	for rowIdx := range ws.SheetData.Row {
+		for _, c := range ws.SheetData.Row[rowIdx].C {
+			col, row, err := CellNameToCoordinates(c.R)
+			if err != nil {
+				ws.mu.Unlock()
+				return err
+			}
+			if col < minVal || col > maxVal || ws.isMergedAcrossCols(col, row) {
+				continue
+			}
+			val, err := c.getValueFrom(f, sst, false)
+			if err != nil {
+				ws.mu.Unlock()
+				return err
+			}
+			if val == "" {
+				continue
+			}
+			if width := math.Min(getTextWidth(val, styleSheet.getCellFont(c.S)), maxWidth); width > widths[col] {
+				widths[col] = width
+			}
+		}
+	}
+	ws.mu.Unlock()
+	for col := minVal; col <= maxVal; col++ {
+		width, ok := widths[col]
+		if !ok {
+			continue
+		}
+		colName, _ := ColumnNumberToName(col)
+		if err = f.SetColWidth(sheet, colName, colName, width); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// isMergedAcrossCols provides a function to check if the cell by given
+// coordinates is in a merged range across multiple columns.
+func (ws *xlsxWorksheet) isMergedAcrossCols(col, row int) bool {
+	if ws.MergeCells == nil {
+		return false
+	}
+	for _, mergeCell := range ws.MergeCells.Cells {
+		if mergeCell == nil {
+			continue
+		}
+		rect, err := rangeRefToCoordinates(mergeCell.Ref)
+		if err != nil {
+			continue
+		}
+		_ = sortCoordinates(rect)
+		if rect[0] != rect[2] && rect[0] <= col && col <= rect[2] && rect[1] <= row && row <= rect[3] {
+			return true
+		}
+	}
+	return false
+}
+
+// getCellFont provides a function to get the font of the cell by given cell
+// style index, the default font will be returned if the style doesn't exist.
+func (styleSheet *xlsxStyleSheet) getCellFont(styleIdx int) *xlsxFont {
+	if styleSheet.Fonts == nil || len(styleSheet.Fonts.Font) == 0 {
+		return nil
+	}
+	fontID := 0
+	if styleSheet.CellXfs != nil && styleIdx >= 0 && styleIdx < len(styleSheet.CellXfs.Xf) &&
+		styleSheet.CellXfs.Xf[styleIdx].FontID != nil {
+		fontID = *styleSheet.CellXfs.Xf[styleIdx].FontID
+	}
+	if fontID < 0 || fontID >= len(styleSheet.Fonts.Font) {
+		fontID = 0
+	}
+	return styleSheet.Fonts.Font[fontID]
+}
+
+// getTextWidth provides a function to approximate the column width in
+// characters to fit the given text with the given font. The width of each
+// character in pixels is based on the default font Calibri with size 11, and
+// the maximum digit width of 7 pixels.
+func getTextWidth(text string, font *xlsxFont) float64 {
+	var scale float64 = 1
+	if font != nil {
+		if font.Sz != nil && font.Sz.Val != nil && *font.Sz.Val > 0 {
+			scale = *font.Sz.Val / 11
+		}
+		if font.B != nil && (font.B.Val == nil || *font.B.Val) {
+			scale *= 1.1
+		}
+	}
+	var maxPixels float64
+	for _, line := range strings.Split(text, "\n") {
+		var pixels float64
+		for _, r := range line {
+			pixels += getCharPixels(r)
+		}
+		maxPixels = math.Max(maxPixels, pixels)
+	}
+	width := (maxPixels*scale + 5) / 7
+	return math.Ceil(width*256) / 256
+}
+
+// getCharPixels provides a function to get the approximate width in pixels of
+// the character with the default font.
+func getCharPixels(r rune) float64 {
+	switch {
+	case strings.ContainsRune(" .,:;'!|iljI", r):
+		return 3
+	case strings.ContainsRune("ftr()[]{}-/\\\"`", r):
+		return 4
+	case strings.ContainsRune("mw%", r):
+		return 11
+	case strings.ContainsRune("MW@", r):
+		return 12
+	case r >= 'A' && r <= 'Z':
+		return 8
+	case r >= 0x1100 && unicode.In(r, unicode.Han, unicode.Hangul, unicode.Hiragana, unicode.Katakana):
+		return 14
+	case unicode.Is(unicode.Mn, r):
+		return 0
+	}
+	return 7
+}
+
 // flatCols provides a method for the column's operation functions to flatten
 // and check the worksheet columns.
 func flatCols(col xlsxCol, cols []xlsxCol, replacer func(fc, c xlsxCol) xlsxCol) []xlsxCol {
@@ -329,6 +329,64 @@ func TestOutlineLevel(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestSetColGroup(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetColWidth("Sheet1", "C", "C", 20))
+	assert.NoError(t, f.SetColGroup("Sheet1", "B:D", 1, false))
+	assert.NoError(t, f.SetColGroup("Sheet1", "D:C", 2, true))
+	for col, expected := range map[string][]interface{}{
+		"B": {uint8(1), false, true}, "C": {uint8(2), false, false},
+		"D": {uint8(2), false, false}, "E": {uint8(0), true, true},
+	} {
+		level, err := f.GetColOutlineLevel("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[0], level, col)
+		collapsed, err := f.GetColCollapsed("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[1], collapsed, col)
+		visible, err := f.GetColVisible("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[2], visible, col)
+	}
+	// Test set column group keeps the existing column width
+	width, err := f.GetColWidth("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+	// Test set column group with summary columns to the left of detail
+	assert.NoError(t, f.SetSheetProps("Sheet1", &SheetPropsOptions{OutlineSummaryRight: boolPtr(false)}))
+	assert.NoError(t, f.SetColGroup("Sheet1", "H:I", 1, true))
+	collapsed, err := f.GetColCollapsed("Sheet1", "G")
+	assert.NoError(t, err)
+	assert.True(t, collapsed)
+	assert.NoError(t, f.SetColGroup("Sheet1", "A", 1, true))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetColGroup.xlsx")))
+	// Test set column group at the last column of the worksheet
+	f = NewFile()
+	assert.NoError(t, f.SetColGroup("Sheet1", "XFD", 1, true))
+	collapsed, err = f.GetColCollapsed("Sheet1", "XFD")
+	assert.NoError(t, err)
+	assert.False(t, collapsed)
+	// Test get column collapsed state on a worksheet without columns
+	f = NewFile()
+	collapsed, err = f.GetColCollapsed("Sheet1", "A")
+	assert.NoError(t, err)
+	assert.False(t, collapsed)
+	// Test set column group with invalid column name and outline level
+	assert.Equal(t, newInvalidColumnNameError("*"), f.SetColGroup("Sheet1", "*", 1, false))
+	assert.Equal(t, ErrOutlineLevel, f.SetColGroup("Sheet1", "A:B", 0, false))
+	assert.Equal(t, ErrOutlineLevel, f.SetColGroup("Sheet1", "A:B", 8, false))
+	_, err = f.GetColCollapsed("Sheet1", "*")
+	assert.Equal(t, newInvalidColumnNameError("*"), err)
+	// Test set and get column group on not exists worksheet
+	assert.EqualError(t, f.SetColGroup("SheetN", "A:B", 1, false), "sheet SheetN does not exist")
+	_, err = f.GetColCollapsed("SheetN", "A")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test set and get column group with invalid sheet name
+	assert.EqualError(t, f.SetColGroup("Sheet:1", "A:B", 1, false), ErrSheetNameInvalid.Error())
+	_, err = f.GetColCollapsed("Sheet:1", "A")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+}
+
 func TestSetColStyle(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "Hello"))
@@ -361,6 +419,19 @@ func TestSetColStyle(t *testing.T) {
 	cellStyleID, err := f.GetCellStyle("Sheet1", "C2")
 	assert.NoError(t, err)
 	assert.Equal(t, styleID, cellStyleID)
+	// Test cell inheritance columns style
+	assert.NoError(t, f.SetCellValue("Sheet1", "D10", "World"))
+	cellStyleID, err = f.GetCellStyle("Sheet1", "D10")
+	assert.NoError(t, err)
+	assert.Equal(t, styleID, cellStyleID)
+	// Test cell style takes precedence over columns style
+	style2, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "D11", "D11", style2))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D11", "World"))
+	cellStyleID, err = f.GetCellStyle("Sheet1", "D11")
+	assert.NoError(t, err)
+	assert.Equal(t, style2, cellStyleID)
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetColStyle.xlsx")))
 	// Test set column style with unsupported charset style sheet
 	f.Styles = nil
@@ -418,6 +489,156 @@ func TestColWidth(t *testing.T) {
 	convertRowHeightToPixels(0)
 }
 
+func TestAutoFitColumns(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Name"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", "A much longer text value"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", 1234567.891))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C1", "Large"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D1", "Large"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "E1", "Merged cell with a long text"))
+	assert.NoError(t, f.MergeCell("Sheet1", "E1", "F1"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "H1", "line\nwith a longer second line"))
+	style, err := f.NewStyle(&Style{NumFmt: 4})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B1", style))
+	style, err = f.NewStyle(&Style{Font: &Font{Size: 22, Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "D1", "D1", style))
+	assert.NoError(t, f.AutoFitColumns("Sheet1", "A:H"))
+
+	widthA, err := f.GetColWidth("Sheet1", "A")
+	assert.NoError(t, err)
+	assert.Greater(t, widthA, defaultColWidth)
+	widthB, err := f.GetColWidth("Sheet1", "B")
+	assert.NoError(t, err)
+	// Test the width fits the formatted value "1,234,567.89"
+	assert.Equal(t, getTextWidth("1,234,567.89", nil), widthB)
+	widthC, err := f.GetColWidth("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.Less(t, widthC, defaultColWidth)
+	widthD, err := f.GetColWidth("Sheet1", "D")
+	assert.NoError(t, err)
+	assert.Greater(t, widthD, 2*widthC)
+	// Test the merged cells and columns without cell values keep the width
+	for _, col := range []string{"E", "F", "G"} {
+		width, err := f.GetColWidth("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, defaultColWidth, width)
+	}
+	widthH, err := f.GetColWidth("Sheet1", "H")
+	assert.NoError(t, err)
+	assert.Equal(t, getTextWidth("with a longer second line", nil), widthH)
+	assert.Equal(t, getTextWidth("漢字", nil), getTextWidth("0000", nil))
+
+	// Test auto-fit columns with the maximum width
+	assert.NoError(t, f.AutoFitColumns("Sheet1", "A", AutoFitColumnOptions{MaxWidth: 10}))
+	widthA, err = f.GetColWidth("Sheet1", "A")
+	assert.NoError(t, err)
+	assert.Equal(t, 10.0, widthA)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAutoFitColumns.xlsx")))
+
+	// Test auto-fit columns with invalid maximum width
+	assert.Equal(t, ErrColumnWidth, f.AutoFitColumns("Sheet1", "A", AutoFitColumnOptions{MaxWidth: MaxColumnWidth + 1}))
+	// Test auto-fit columns with illegal column name
+	assert.EqualError(t, f.AutoFitColumns("Sheet1", "*"), newInvalidColumnNameError("*").Error())
+	// Test auto-fit columns on not exists worksheet
+	assert.EqualError(t, f.AutoFitColumns("SheetN", "A"), "sheet SheetN does not exist")
+	// Test auto-fit columns with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AutoFitColumns("Sheet1", "A"), "XML syntax error on line 1: invalid UTF-8")
+	// Test auto-fit columns with unsupported charset style sheet
+	f = NewFile()
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AutoFitColumns("Sheet1", "A"), "XML syntax error on line 1: invalid UTF-8")
+	// Test auto-fit columns with invalid cell reference
+	f = NewFile()
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row = []xlsxRow{{R: intPtr(1), C: []xlsxC{{R: "*"}}}}
+	assert.EqualError(t, f.AutoFitColumns("Sheet1", "A"), newCellNameToCoordinatesError("*", newInvalidCellNameError("*")).Error())
+}
+
+func TestSetColFormula(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 5; row++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row), &[]int{row, row * 2}))
+	}
+	assert.NoError(t, f.SetColFormula("Sheet1", "C", 5, 1, "A1*B1"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetColFormula.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err := OpenFile(filepath.Join("test", "TestSetColFormula.xlsx"))
+	assert.NoError(t, err)
+	for row := 1; row <= 5; row++ {
+		cell := fmt.Sprintf("C%d", row)
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("A%d*B%d", row, row), formula)
+		result, err := f.CalcCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprint(row*row*2), result)
+	}
+	opts, err := f.GetCellFormulaOpts("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, STCellFormulaTypeShared, *opts.Type)
+	assert.Equal(t, "C1:C5", *opts.Ref)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, &xlsxF{T: STCellFormulaTypeShared, Si: intPtr(0)}, ws.(*xlsxWorksheet).SheetData.Row[4].C[2].F)
+	// Test set column formula with another shared formula index
+	assert.NoError(t, f.SetColFormula("Sheet1", "D", 1, 5, "C1+1"))
+	assert.Equal(t, 1, *ws.(*xlsxWorksheet).SheetData.Row[4].C[3].F.Si)
+	formula, err := f.GetCellFormula("Sheet1", "D5")
+	assert.NoError(t, err)
+	assert.Equal(t, "C5+1", formula)
+	// Test set column formula with invalid arguments
+	assert.Equal(t, newInvalidColumnNameError("*"), f.SetColFormula("Sheet1", "*", 1, 5, "A1"))
+	assert.Equal(t, newInvalidRowNumberError(0), f.SetColFormula("Sheet1", "E", 0, 5, "A1"))
+	assert.Equal(t, ErrMaxRows, f.SetColFormula("Sheet1", "E", 1, TotalRows+1, "A1"))
+	// Test set column formula on not exists worksheet
+	assert.EqualError(t, f.SetColFormula("SheetN", "E", 1, 5, "A1"), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func BenchmarkSetColFormula(b *testing.B) {
+	for _, c := range []struct {
+		name string
+		fn   func(f *File) error
+	}{
+		{name: "SharedFormula", fn: func(f *File) error {
+			return f.SetColFormula("Sheet1", "C", 1, 10000, "A1*B1")
+		}},
+		{name: "CellFormula", fn: func(f *File) error {
+			for row := 1; row <= 10000; row++ {
+				if err := f.SetCellFormula("Sheet1", fmt.Sprintf("C%d", row), fmt.Sprintf("A%d*B%d", row, row)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}},
+	} {
+		fn := c.fn
+		b.Run(c.name, func(b *testing.B) {
+			var size int
+			for i := 0; i < b.N; i++ {
+				f := NewFile()
+				if err := fn(f); err != nil {
+					b.Error(err)
+				}
+				buf, err := f.WriteToBuffer()
+				if err != nil {
+					b.Error(err)
+				}
+				size = buf.Len()
+			}
+			b.ReportMetric(float64(size), "bytes/file")
+		})
+	}
+}
+
 func TestGetColStyle(t *testing.T) {
 	f := NewFile()
 	styleID, err := f.GetColStyle("Sheet1", "A")
@@ -15,6 +15,7 @@ import (
 	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/hmac"
 	"crypto/md5"
 	"crypto/rand"
 	"crypto/sha1"
@@ -23,6 +24,7 @@ import (
 	"encoding/base64"
 	"encoding/binary"
 	"encoding/xml"
+	"fmt"
 	"hash"
 	"math"
 	"path/filepath"
@@ -38,6 +40,11 @@ import (
 
 var (
 	blockKey                    = []byte{0x14, 0x6e, 0x0b, 0xe7, 0xab, 0xac, 0xd0, 0xd6} // Block keys used for encryption
+	verifierHashInputBlockKey   = []byte{0xfe, 0xa7, 0xd2, 0x76, 0x3b, 0x4b, 0x9e, 0x79} // Block keys used for password verifier input
+	verifierHashValueBlockKey   = []byte{0xd7, 0xaa, 0x0f, 0x6d, 0x30, 0x61, 0x34, 0x4e} // Block keys used for password verifier hash value
+	integrityKeyBlockKey        = []byte{0x5f, 0xb2, 0xad, 0x01, 0x0c, 0xb9, 0xe1, 0xf6} // Block keys used for data integrity HMAC key
+	integrityValueBlockKey      = []byte{0xa0, 0x67, 0x7f, 0x02, 0xb2, 0x2c, 0x84, 0x33} // Block keys used for data integrity HMAC value
+	agileEncryptionSpinCount    = 100000
 	oleIdentifier               = []byte{0xd0, 0xcf, 0x11, 0xe0, 0xa1, 0xb1, 0x1a, 0xe1}
 	headerCLSID                 = make([]byte, 16)
 	difSect                     = -4
@@ -129,14 +136,6 @@ type StandardEncryptionVerifier struct {
 	EncryptedVerifierHash []byte
 }
 
-// encryptionInfo structure is used for standard encryption with SHA1
-// cryptographic algorithm.
-type encryption struct {
-	BlockSize, SaltSize                                                                  int
-	EncryptedKeyValue, EncryptedVerifierHashInput, EncryptedVerifierHashValue, SaltValue []byte
-	KeyBits                                                                              uint32
-}
-
 // Decrypt API decrypts the CFB file format with ECMA-376 agile encryption and
 // standard encryption. Support cryptographic algorithm: MD4, MD5, RIPEMD-160,
 // SHA1, SHA256, SHA384 and SHA512 currently.
@@ -156,25 +155,16 @@ func Decrypt(raw []byte, opts *Options) (packageBuf []byte, err error) {
 	return standardDecrypt(encryptionInfoBuf, encryptedPackageBuf, opts)
 }
 
-// Encrypt API encrypt data with the password.
+// Encrypt API encrypt data with the password by ECMA-376 agile encryption,
+// with AES-256 cipher algorithm and SHA512 hash algorithm.
 func Encrypt(raw []byte, opts *Options) ([]byte, error) {
-	encryptor := encryption{
-		EncryptedVerifierHashInput: make([]byte, 16),
-		EncryptedVerifierHashValue: make([]byte, 32),
-		SaltValue:                  make([]byte, 16),
-		BlockSize:                  16,
-		KeyBits:                    128,
-		SaltSize:                   16,
-	}
-	// Key Encryption
-	encryptionInfoBuffer, err := encryptor.standardKeyEncryption(opts.Password)
+	if len(opts.Password) == 0 || len(opts.Password) > MaxFieldLength {
+		return nil, ErrPasswordLengthInvalid
+	}
+	encryptionInfoBuffer, encryptedPackage, err := agileEncrypt(raw, opts.Password)
 	if err != nil {
 		return nil, err
 	}
-	// Package Encryption
-	encryptedPackage := make([]byte, 8)
-	binary.LittleEndian.PutUint64(encryptedPackage, uint64(len(raw)))
-	encryptedPackage = append(encryptedPackage, encryptor.encrypt(raw)...)
 	// Create a new CFB
 	compoundFile := &cfb{
 		paths:   []string{"Root Entry/"},
@@ -259,6 +249,11 @@ func standardDecrypt(encryptionInfoBuf, encryptedPackageBuf []byte, opts *Option
 	if err != nil {
 		return nil, err
 	}
+	if algorithm == "AES" {
+		if err = standardVerifyPasswd(secretKey, verifier); err != nil {
+			return nil, err
+		}
+	}
 	// decrypted data
 	x := encryptedPackageBuf[8:]
 	blob, err := aes.NewCipher(secretKey)
@@ -289,6 +284,28 @@ func standardEncryptionVerifier(algorithm string, blob []byte) StandardEncryptio
 	return verifier
 }
 
+// standardVerifyPasswd verify the password by the encrypted verifier and
+// verifier hash in the standard encryption info with given secret key.
+func standardVerifyPasswd(secretKey []byte, verifier StandardEncryptionVerifier) error {
+	blob, err := aes.NewCipher(secretKey)
+	if err != nil {
+		return err
+	}
+	if len(verifier.EncryptedVerifier) != aes.BlockSize || len(verifier.EncryptedVerifierHash)%aes.BlockSize != 0 {
+		return ErrWorkbookPassword
+	}
+	verifierValue := make([]byte, len(verifier.EncryptedVerifier))
+	blob.Decrypt(verifierValue, verifier.EncryptedVerifier)
+	verifierHash := make([]byte, len(verifier.EncryptedVerifierHash))
+	for bs := 0; bs < len(verifierHash); bs += aes.BlockSize {
+		blob.Decrypt(verifierHash[bs:bs+aes.BlockSize], verifier.EncryptedVerifierHash[bs:bs+aes.BlockSize])
+	}
+	if !bytes.Equal(hashing("sha1", verifierValue), verifierHash[:sha1.Size]) {
+		return ErrWorkbookPassword
+	}
+	return nil
+}
+
 // standardConvertPasswdToKey generate intermediate key from given password.
 func standardConvertPasswdToKey(header StandardEncryptionHeader, verifier StandardEncryptionVerifier, opts *Options) ([]byte, error) {
 	encoder := unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM).NewEncoder()
@@ -329,80 +346,121 @@ func standardXORBytes(a, b []byte) []byte {
 	return buf
 }
 
-// encrypt provides a function to encrypt given value with AES cryptographic
-// algorithm.
-func (e *encryption) encrypt(input []byte) []byte {
-	inputBytes := len(input)
-	if pad := inputBytes % e.BlockSize; pad != 0 {
-		inputBytes += e.BlockSize - pad
-	}
-	var output, chunk []byte
-	encryptedChunk := make([]byte, e.BlockSize)
-	for i := 0; i < inputBytes; i += e.BlockSize {
-		if i+e.BlockSize <= len(input) {
-			chunk = input[i : i+e.BlockSize]
-		} else {
-			chunk = input[i:]
+// ECMA-376 Agile Encryption
+
+// agileEncryptionInfo is the XML template of the agile encryption info.
+const agileEncryptionInfo = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\r\n" +
+	`<encryption xmlns="http://schemas.microsoft.com/office/2006/encryption" xmlns:p="http://schemas.microsoft.com/office/2006/keyEncryptor/password" xmlns:c="http://schemas.microsoft.com/office/2006/keyEncryptor/certificate">` +
+	`<keyData saltSize="%[1]d" blockSize="%[2]d" keyBits="%[3]d" hashSize="%[4]d" cipherAlgorithm="%[5]s" cipherChaining="%[6]s" hashAlgorithm="%[7]s" saltValue="%[8]s"/>` +
+	`<dataIntegrity encryptedHmacKey="%[9]s" encryptedHmacValue="%[10]s"/>` +
+	`<keyEncryptors><keyEncryptor uri="http://schemas.microsoft.com/office/2006/keyEncryptor/password">` +
+	`<p:encryptedKey spinCount="%[11]d" saltSize="%[1]d" blockSize="%[2]d" keyBits="%[3]d" hashSize="%[4]d" cipherAlgorithm="%[5]s" cipherChaining="%[6]s" hashAlgorithm="%[7]s" saltValue="%[12]s" encryptedVerifierHashInput="%[13]s" encryptedVerifierHashValue="%[14]s" encryptedKeyValue="%[15]s"/>` +
+	`</keyEncryptor></keyEncryptors></encryption>`
+
+// agileEncrypt encrypt the data with ECMA-376 agile encryption by given
+// password, and returns the EncryptionInfo and EncryptedPackage streams.
+func agileEncrypt(raw []byte, password string) (encryptionInfoBuf, encryptedPackageBuf []byte, err error) {
+	keyData := KeyData{
+		SaltSize: 16, BlockSize: 16, KeyBits: 256, HashSize: 64,
+		CipherAlgorithm: "AES", CipherChaining: "ChainingModeCBC", HashAlgorithm: "SHA512",
+	}
+	randBufs := make([][]byte, 5)
+	for i, size := range []int{keyData.SaltSize, keyData.SaltSize, keyData.KeyBits / 8, keyData.SaltSize, keyData.HashSize} {
+		if randBufs[i], err = randomBytes(size); err != nil {
+			return
 		}
-		chunk = append(chunk, make([]byte, e.BlockSize-len(chunk))...)
-		c, _ := aes.NewCipher(e.EncryptedKeyValue)
-		c.Encrypt(encryptedChunk, chunk)
-		output = append(output, encryptedChunk...)
 	}
-	return output
-}
-
-// standardKeyEncryption encrypt convert the password to an encryption key.
-func (e *encryption) standardKeyEncryption(password string) ([]byte, error) {
-	if len(password) == 0 || len(password) > MaxFieldLength {
-		return nil, ErrPasswordLengthInvalid
+	keyDataSalt, passwdSalt, packageKey, verifierHashInput, hmacKey := randBufs[0], randBufs[1], randBufs[2], randBufs[3], randBufs[4]
+	encryption := Encryption{KeyData: keyData}
+	encryption.KeyData.SaltValue = base64.StdEncoding.EncodeToString(keyDataSalt)
+	encryptedKey := EncryptedKey{SpinCount: agileEncryptionSpinCount, KeyData: keyData}
+	encryptedKey.SaltValue = base64.StdEncoding.EncodeToString(passwdSalt)
+	encryption.KeyEncryptors.KeyEncryptor = []KeyEncryptor{{EncryptedKey: encryptedKey}}
+	// Convert the password into the keys, and encrypt the password verifier
+	// and the package key.
+	passwdHash, err := hashPasswd(password, encryption)
+	if err != nil {
+		return
+	}
+	encryptedVerifierHashInput, err := encrypt(deriveKey(passwdHash, verifierHashInputBlockKey, encryption), passwdSalt, verifierHashInput)
+	if err != nil {
+		return
+	}
+	encryptedVerifierHashValue, err := encrypt(deriveKey(passwdHash, verifierHashValueBlockKey, encryption), passwdSalt, hashing(keyData.HashAlgorithm, verifierHashInput))
+	if err != nil {
+		return
+	}
+	encryptedKeyValue, err := encrypt(deriveKey(passwdHash, blockKey, encryption), passwdSalt, packageKey)
+	if err != nil {
+		return
+	}
+	// Encrypt the package in segments with the package key.
+	if encryptedPackageBuf, err = encryptPackage(packageKey, raw, encryption); err != nil {
+		return
+	}
+	// Generate the HMAC of the encrypted package for data integrity.
+	iv, _ := createIV(integrityKeyBlockKey, encryption)
+	encryptedHmacKey, err := encrypt(packageKey, iv, hmacKey)
+	if err != nil {
+		return
+	}
+	h := hmac.New(sha512.New, hmacKey)
+	_, _ = h.Write(encryptedPackageBuf)
+	iv, _ = createIV(integrityValueBlockKey, encryption)
+	encryptedHmacValue, err := encrypt(packageKey, iv, h.Sum(nil))
+	if err != nil {
+		return
 	}
 	var storage cfb
-	storage.writeUint16(0x0003)
-	storage.writeUint16(0x0002)
-	storage.writeUint32(0x24)
-	storage.writeUint32(0xA4)
-	storage.writeUint32(0x24)
-	storage.writeUint32(0x00)
-	storage.writeUint32(0x660E)
-	storage.writeUint32(0x8004)
-	storage.writeUint32(0x80)
-	storage.writeUint32(0x18)
-	storage.writeUint64(0x00)
-	providerName := "Microsoft Enhanced RSA and AES Cryptographic Provider (Prototype)"
-	storage.writeStrings(providerName)
-	storage.writeUint16(0x00)
-	storage.writeUint32(0x10)
-	keyDataSaltValue, _ := randomBytes(16)
-	verifierHashInput, _ := randomBytes(16)
-	e.SaltValue = keyDataSaltValue
-	e.EncryptedKeyValue, _ = standardConvertPasswdToKey(
-		StandardEncryptionHeader{KeySize: e.KeyBits},
-		StandardEncryptionVerifier{Salt: e.SaltValue},
-		&Options{Password: password})
-	verifierHashInputKey := hashing("sha1", verifierHashInput)
-	e.EncryptedVerifierHashInput = e.encrypt(verifierHashInput)
-	e.EncryptedVerifierHashValue = e.encrypt(verifierHashInputKey)
-	storage.writeBytes(e.SaltValue)
-	storage.writeBytes(e.EncryptedVerifierHashInput)
-	storage.writeUint32(0x14)
-	storage.writeBytes(e.EncryptedVerifierHashValue)
-	storage.position = 0
-	return storage.stream, nil
+	storage.writeUint16(0x0004)
+	storage.writeUint16(0x0004)
+	storage.writeUint32(0x40)
+	storage.writeBytes([]byte(fmt.Sprintf(agileEncryptionInfo, keyData.SaltSize, keyData.BlockSize,
+		keyData.KeyBits, keyData.HashSize, keyData.CipherAlgorithm, keyData.CipherChaining,
+		keyData.HashAlgorithm, encryption.KeyData.SaltValue,
+		base64.StdEncoding.EncodeToString(encryptedHmacKey), base64.StdEncoding.EncodeToString(encryptedHmacValue),
+		agileEncryptionSpinCount, encryptedKey.SaltValue,
+		base64.StdEncoding.EncodeToString(encryptedVerifierHashInput),
+		base64.StdEncoding.EncodeToString(encryptedVerifierHashValue),
+		base64.StdEncoding.EncodeToString(encryptedKeyValue))))
+	return storage.stream, encryptedPackageBuf, err
 }
 
-// ECMA-376 Agile Encryption
+// encryptPackage encrypt the package in 4096 bytes segments by given package
+// key and encryption info, the size of the package will be written in the
+// first 8 bytes of the encrypted package.
+func encryptPackage(packageKey, input []byte, encryption Encryption) ([]byte, error) {
+	output := make([]byte, packageOffset, packageOffset+len(input)+encryption.KeyData.BlockSize)
+	binary.LittleEndian.PutUint64(output, uint64(len(input)))
+	for i, start := 0, 0; start < len(input); i, start = i+1, start+packageEncryptionChunkSize {
+		end := start + packageEncryptionChunkSize
+		if end > len(input) {
+			end = len(input)
+		}
+		iv, err := createIV(i, encryption)
+		if err != nil {
+			return nil, err
+		}
+		outputChunk, err := encrypt(packageKey, iv, input[start:end])
+		if err != nil {
+			return nil, err
+		}
+		output = append(output, outputChunk...)
+	}
+	return output, nil
+}
 
 // agileDecrypt decrypt the CFB file format with ECMA-376 agile encryption.
 // Support cryptographic algorithm: MD4, MD5, RIPEMD-160, SHA1, SHA256,
-// SHA384 and SHA512.
+// SHA384 and SHA512. The ErrWorkbookPassword will be returned if the password
+// verifier doesn't match the given password.
 func agileDecrypt(encryptionInfoBuf, encryptedPackageBuf []byte, opts *Options) (packageBuf []byte, err error) {
 	var encryptionInfo Encryption
 	if encryptionInfo, err = parseEncryptionInfo(encryptionInfoBuf[8:]); err != nil {
 		return
 	}
 	// Convert the password into an encryption key.
-	key, err := convertPasswdToKey(opts.Password, blockKey, encryptionInfo)
+	passwdHash, err := hashPasswd(opts.Password, encryptionInfo)
 	if err != nil {
 		return
 	}
@@ -412,17 +470,69 @@ func agileDecrypt(encryptionInfoBuf, encryptedPackageBuf []byte, opts *Options)
 	if err != nil {
 		return
 	}
+	if err = verifyAgilePasswd(passwdHash, saltValue, encryptionInfo); err != nil {
+		return
+	}
 	encryptedKeyValue, err := base64.StdEncoding.DecodeString(encryptedKey.EncryptedKeyValue)
 	if err != nil {
 		return
 	}
-	packageKey, _ := decrypt(key, saltValue, encryptedKeyValue)
+	packageKey, _ := decrypt(deriveKey(passwdHash, blockKey, encryptionInfo), saltValue, encryptedKeyValue)
 	// Use the package key to decrypt the package.
-	return decryptPackage(packageKey, encryptedPackageBuf, encryptionInfo)
+	if packageBuf, err = decryptPackage(packageKey, encryptedPackageBuf, encryptionInfo); err != nil {
+		return
+	}
+	// Truncate the padding by the stream size in the first 8 bytes.
+	if len(encryptedPackageBuf) >= packageOffset {
+		if size := binary.LittleEndian.Uint64(encryptedPackageBuf[:packageOffset]); size < uint64(len(packageBuf)) {
+			packageBuf = packageBuf[:size]
+		}
+	}
+	return
+}
+
+// verifyAgilePasswd verify the password by the encrypted verifier hash input
+// and value in the agile encryption info with given password hash.
+func verifyAgilePasswd(passwdHash, saltValue []byte, encryption Encryption) error {
+	encryptedKey := encryption.KeyEncryptors.KeyEncryptor[0].EncryptedKey
+	if encryptedKey.EncryptedVerifierHashInput == "" || encryptedKey.EncryptedVerifierHashValue == "" {
+		return nil
+	}
+	hashInput, err := base64.StdEncoding.DecodeString(encryptedKey.EncryptedVerifierHashInput)
+	if err != nil {
+		return err
+	}
+	hashValue, err := base64.StdEncoding.DecodeString(encryptedKey.EncryptedVerifierHashValue)
+	if err != nil {
+		return err
+	}
+	if hashInput, err = decrypt(deriveKey(passwdHash, verifierHashInputBlockKey, encryption), saltValue, hashInput); err != nil {
+		return err
+	}
+	if hashValue, err = decrypt(deriveKey(passwdHash, verifierHashValueBlockKey, encryption), saltValue, hashValue); err != nil {
+		return err
+	}
+	if encryptedKey.SaltSize > 0 && encryptedKey.SaltSize <= len(hashInput) {
+		hashInput = hashInput[:encryptedKey.SaltSize]
+	}
+	hash := hashing(encryptedKey.HashAlgorithm, hashInput)
+	if len(hash) == 0 || len(hashValue) < len(hash) || !bytes.Equal(hash, hashValue[:len(hash)]) {
+		return ErrWorkbookPassword
+	}
+	return nil
 }
 
 // convertPasswdToKey convert the password into an encryption key.
 func convertPasswdToKey(passwd string, blockKey []byte, encryption Encryption) (key []byte, err error) {
+	if key, err = hashPasswd(passwd, encryption); err != nil {
+		return
+	}
+	return deriveKey(key, blockKey, encryption), err
+}
+
+// hashPasswd generate the iterated hash of the password with the salt and
+// spin count in the key encryptor, which is used to derive the keys.
+func hashPasswd(passwd string, encryption Encryption) (key []byte, err error) {
 	var b bytes.Buffer
 	saltValue, err := base64.StdEncoding.DecodeString(encryption.KeyEncryptors.KeyEncryptor[0].EncryptedKey.SaltValue)
 	if err != nil {
@@ -442,8 +552,14 @@ func convertPasswdToKey(passwd string, blockKey []byte, encryption Encryption) (
 		iterator := createUInt32LEBuffer(i, 4)
 		key = hashing(encryption.KeyData.HashAlgorithm, iterator, key)
 	}
+	return
+}
+
+// deriveKey derive the encryption key from the password hash by given block
+// key, and truncate or pad it to the key bits of the key encryptor.
+func deriveKey(passwdHash, blockKey []byte, encryption Encryption) (key []byte) {
 	// Now generate the final hash.
-	key = hashing(encryption.KeyData.HashAlgorithm, key, blockKey)
+	key = hashing(encryption.KeyData.HashAlgorithm, passwdHash, blockKey)
 	// Truncate or pad as needed to get to length of keyBits.
 	keyBytes := encryption.KeyEncryptors.KeyEncryptor[0].EncryptedKey.KeyBits / 8
 	if len(key) < keyBytes {
@@ -502,6 +618,23 @@ func decrypt(key, iv, input []byte) (packageKey []byte, err error) {
 	return input, nil
 }
 
+// encrypt provides a function to encrypt input by AES cipher algorithm with
+// CBC cipher chaining by given key and initialization vector, the input will
+// be padded to the multiple of the block size with zero.
+func encrypt(key, iv, input []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	output := make([]byte, len(input))
+	copy(output, input)
+	if remainder := len(output) % block.BlockSize(); remainder != 0 {
+		output = append(output, make([]byte, block.BlockSize()-remainder)...)
+	}
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(output, output)
+	return output, nil
+}
+
 // decryptPackage decrypt package by given packageKey and encryption
 // info.
 func decryptPackage(packageKey, input []byte, encryption Encryption) (outputChunks []byte, err error) {
@@ -944,7 +1077,7 @@ func (c *cfb) writeSectorChains(location []int) sector {
 		if sectorSize = len(sector.content); sectorSize == 0 || sectorSize >= 0x1000 {
 			continue
 		}
-		sector.start = offset
+		c.sectors[j].start = offset
 		offset = writeSectorChain((sectorSize+0x3F)>>6, offset)
 	}
 	for c.position&0x1FF != 0 {
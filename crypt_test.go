@@ -13,7 +13,12 @@ package excelize
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/base64"
 	"encoding/binary"
+	"encoding/xml"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -27,6 +32,12 @@ func TestEncrypt(t *testing.T) {
 	// Test decrypt spreadsheet with incorrect password
 	_, err := OpenFile(filepath.Join("test", "encryptSHA1.xlsx"), Options{Password: "passwd"})
 	assert.EqualError(t, err, ErrWorkbookPassword.Error())
+	// Test decrypt spreadsheet without password
+	_, err = OpenFile(filepath.Join("test", "encryptSHA1.xlsx"))
+	assert.Equal(t, ErrWorkbookPassword, err)
+	// Test decrypt spreadsheet with standard encryption and incorrect password
+	_, err = OpenFile(filepath.Join("test", "encryptAES.xlsx"), Options{Password: "passwd"})
+	assert.Equal(t, ErrWorkbookPassword, err)
 	// Test decrypt spreadsheet with password
 	f, err := OpenFile(filepath.Join("test", "encryptSHA1.xlsx"), Options{Password: "password"})
 	assert.NoError(t, err)
@@ -75,6 +86,102 @@ func TestEncrypt(t *testing.T) {
 	assert.EqualError(t, err, "illegal base64 data at input byte 0")
 }
 
+func TestVerifyPasswd(t *testing.T) {
+	encryption := Encryption{KeyEncryptors: KeyEncryptors{KeyEncryptor: []KeyEncryptor{{EncryptedKey: EncryptedKey{
+		EncryptedVerifierHashInput: "==", EncryptedVerifierHashValue: "AAAAAAAAAAAAAAAAAAAAAA==",
+		KeyData: KeyData{KeyBits: 128, HashAlgorithm: "SHA512"},
+	}}}}}
+	// Test verify agile encryption password with invalid verifier
+	assert.EqualError(t, verifyAgilePasswd(nil, nil, encryption), "illegal base64 data at input byte 0")
+	encryption.KeyEncryptors.KeyEncryptor[0].EncryptedKey.EncryptedVerifierHashInput = "AAAAAAAAAAAAAAAAAAAAAA=="
+	encryption.KeyEncryptors.KeyEncryptor[0].EncryptedKey.EncryptedVerifierHashValue = "=="
+	assert.EqualError(t, verifyAgilePasswd(nil, nil, encryption), "illegal base64 data at input byte 0")
+	encryption.KeyEncryptors.KeyEncryptor[0].EncryptedKey.EncryptedVerifierHashValue = "AAAAAAAAAAAAAAAAAAAAAA=="
+	assert.EqualError(t, verifyAgilePasswd(nil, make([]byte, 16), encryption), "crypto/aes: invalid key size 54")
+	encryption.KeyData.HashAlgorithm = "SHA512"
+	assert.Equal(t, ErrWorkbookPassword, verifyAgilePasswd(nil, make([]byte, 16), encryption))
+	// Test verify agile encryption password without verifier
+	assert.NoError(t, verifyAgilePasswd(nil, nil, Encryption{KeyEncryptors: KeyEncryptors{KeyEncryptor: []KeyEncryptor{{}}}}))
+	// Test verify standard encryption password with invalid verifier
+	assert.EqualError(t, standardVerifyPasswd(nil, StandardEncryptionVerifier{}), "crypto/aes: invalid key size 0")
+	assert.Equal(t, ErrWorkbookPassword, standardVerifyPasswd(make([]byte, 16), StandardEncryptionVerifier{}))
+}
+
+func TestAgileEncrypt(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "SECRET"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A5000", strings.Repeat("*", 8192)))
+	// Test save workbook with agile encryption
+	path := filepath.Join("test", "TestAgileEncrypt.xlsx")
+	assert.NoError(t, f.SaveAs(path, Options{Password: "passwd"}))
+	assert.NoError(t, f.Close())
+	raw, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(raw, oleIdentifier))
+	doc, err := mscfb.New(bytes.NewReader(raw))
+	assert.NoError(t, err)
+	encryptionInfoBuf, encryptedPackageBuf := extractPart(doc)
+	mechanism, err := encryptionMechanism(encryptionInfoBuf)
+	assert.NoError(t, err)
+	assert.Equal(t, "agile", mechanism)
+	// Test verify the data integrity of the encrypted package
+	var encryption Encryption
+	assert.NoError(t, xml.Unmarshal(encryptionInfoBuf[8:], &encryption))
+	assert.Equal(t, agileEncryptionSpinCount, encryption.KeyEncryptors.KeyEncryptor[0].EncryptedKey.SpinCount)
+	passwdHash, err := hashPasswd("passwd", encryption)
+	assert.NoError(t, err)
+	encryptedKey := encryption.KeyEncryptors.KeyEncryptor[0].EncryptedKey
+	saltValue, err := base64.StdEncoding.DecodeString(encryptedKey.SaltValue)
+	assert.NoError(t, err)
+	encryptedKeyValue, err := base64.StdEncoding.DecodeString(encryptedKey.EncryptedKeyValue)
+	assert.NoError(t, err)
+	packageKey, err := decrypt(deriveKey(passwdHash, blockKey, encryption), saltValue, encryptedKeyValue)
+	assert.NoError(t, err)
+	encryptedHmacKey, err := base64.StdEncoding.DecodeString(encryption.DataIntegrity.EncryptedHmacKey)
+	assert.NoError(t, err)
+	iv, err := createIV(integrityKeyBlockKey, encryption)
+	assert.NoError(t, err)
+	hmacKey, err := decrypt(packageKey, iv, encryptedHmacKey)
+	assert.NoError(t, err)
+	encryptedHmacValue, err := base64.StdEncoding.DecodeString(encryption.DataIntegrity.EncryptedHmacValue)
+	assert.NoError(t, err)
+	iv, err = createIV(integrityValueBlockKey, encryption)
+	assert.NoError(t, err)
+	hmacValue, err := decrypt(packageKey, iv, encryptedHmacValue)
+	assert.NoError(t, err)
+	h := hmac.New(sha512.New, hmacKey[:encryption.KeyData.HashSize])
+	_, _ = h.Write(encryptedPackageBuf)
+	assert.Equal(t, h.Sum(nil), hmacValue[:encryption.KeyData.HashSize])
+	// Test open the encrypted workbook with correct password
+	f, err = OpenFile(path, Options{Password: "passwd"})
+	assert.NoError(t, err)
+	cell, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "SECRET", cell)
+	cell, err = f.GetCellValue("Sheet1", "A5000")
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("*", 8192), cell)
+	assert.NoError(t, f.Close())
+	// Test open the encrypted workbook with incorrect password
+	_, err = OpenFile(path, Options{Password: "password"})
+	assert.Equal(t, ErrWorkbookPassword, err)
+	// Test open the encrypted workbook without password
+	_, err = OpenFile(path)
+	assert.Equal(t, ErrWorkbookPassword, err)
+	// Test save workbook with empty password as a plain ZIP package
+	f = NewFile()
+	assert.NoError(t, f.SaveAs(path, Options{Password: ""}))
+	assert.NoError(t, f.Close())
+	raw, err = os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(raw, []byte("PK")))
+	// Test encrypt with invalid key size
+	_, err = encrypt(nil, nil, nil)
+	assert.EqualError(t, err, "crypto/aes: invalid key size 0")
+	_, err = encryptPackage(nil, []byte{0}, Encryption{})
+	assert.EqualError(t, err, "crypto/aes: invalid key size 0")
+}
+
 func TestEncryptionMechanism(t *testing.T) {
 	mechanism, err := encryptionMechanism([]byte{3, 0, 3, 0})
 	assert.Equal(t, mechanism, "extensible")
@@ -102,3 +209,30 @@ func TestGenISOPasswdHash(t *testing.T) {
 		assert.Equal(t, expected[1], saltValue)
 	}
 }
+
+func TestCompoundFileMiniStreams(t *testing.T) {
+	compoundFile := &cfb{
+		paths:   []string{"Root Entry/"},
+		sectors: []sector{{name: "Root Entry", typeID: 5}},
+	}
+	// Test write multiple streams which stored in the mini stream
+	expected := map[string][]byte{
+		"Stream1": bytes.Repeat([]byte{1}, 100),
+		"Stream2": bytes.Repeat([]byte{2}, 200),
+		"Stream3": bytes.Repeat([]byte{3}, 0x1000),
+	}
+	for _, name := range []string{"Stream1", "Stream2", "Stream3"} {
+		compoundFile.put(name, expected[name])
+	}
+	doc, err := mscfb.New(bytes.NewReader(compoundFile.write()))
+	assert.NoError(t, err)
+	var count int
+	for entry, err := doc.Next(); err == nil; entry, err = doc.Next() {
+		buf := make([]byte, entry.Size)
+		_, err = io.ReadFull(entry, buf)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[entry.Name], buf, entry.Name)
+		count++
+	}
+	assert.Equal(t, len(expected), count)
+}
@@ -0,0 +1,232 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import (
+	"encoding/csv"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSVImportOptions directly maps the settings of importing the CSV or TSV
+// data into a worksheet.
+//
+// Delimiter specifies the field delimiter, the default delimiter is comma,
+// use '\t' for the TSV data.
+//
+// HeaderRow specifies if the first row is the header row, the cells in the
+// header row will be written as text without type detection.
+//
+// DisableTypeDetection specifies if disable the numbers, dates and booleans
+// auto-detection, all values will be written as text, except the columns
+// which specified in the ColumnTypes.
+//
+// ColumnTypes specifies the cell value type of the columns by column name,
+// for example "A". The supported types are CellTypeBool, CellTypeDate,
+// CellTypeNumber, CellTypeInlineString and CellTypeSharedString. The value
+// which can't be parsed as the specified type will be written as text.
+type CSVImportOptions struct {
+	Delimiter            rune
+	HeaderRow            bool
+	DisableTypeDetection bool
+	ColumnTypes          map[string]CellType
+}
+
+var (
+	// csvNumberExp defined the regular expression for detecting the decimal
+	// number in the CSV data.
+	csvNumberExp = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?$`)
+	// csvDateLayouts defined the date and time layouts for detecting the date
+	// in the CSV data, the layouts without time component will be formatted
+	// with the short date number format.
+	csvDateLayouts = []struct {
+		layout   string
+		dateOnly bool
+	}{
+		{layout: "2006-01-02", dateOnly: true},
+		{layout: "2006/01/02", dateOnly: true},
+		{layout: "2006-01-02 15:04:05"},
+		{layout: "2006/01/02 15:04:05"},
+		{layout: "2006-01-02T15:04:05"},
+		{layout: time.RFC3339},
+	}
+)
+
+// SetSheetFromCSV provides a function to import the CSV or TSV data into a
+// worksheet by given worksheet name, data reader and import options. The data
+// will be written by the stream writer for memory efficiency, so the existing
+// cells of the worksheet will be replaced. The numbers, dates and booleans
+// will be detected and written as typed cells, the numbers with leading zeros
+// or more than 15 significant digits will be kept as text to avoid losing
+// precision, and the malformed values will fall back to text. For example,
+// import the TSV data with a header row into Sheet1, and treat the column A
+// as text:
+//
+//	file, err := os.Open("data.tsv")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	defer file.Close()
+//	err = f.SetSheetFromCSV("Sheet1", file, excelize.CSVImportOptions{
+//	    Delimiter:   '\t',
+//	    HeaderRow:   true,
+//	    ColumnTypes: map[string]excelize.CellType{"A": excelize.CellTypeSharedString},
+//	})
+func (f *File) SetSheetFromCSV(sheet string, r io.Reader, opts CSVImportOptions) error {
+	columnTypes := map[int]CellType{}
+	for name, cellType := range opts.ColumnTypes {
+		col, err := ColumnNameToNumber(name)
+		if err != nil {
+			return err
+		}
+		switch cellType {
+		case CellTypeBool, CellTypeDate, CellTypeNumber, CellTypeInlineString, CellTypeSharedString:
+		default:
+			return ErrParameterInvalid
+		}
+		columnTypes[col] = cellType
+	}
+	sw, err := f.NewStreamWriter(sheet)
+	if err != nil {
+		return err
+	}
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	if opts.Delimiter != 0 {
+		reader.Comma = opts.Delimiter
+	}
+	dateStyleID := -1
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		values := make([]interface{}, len(record))
+		for idx, field := range record {
+			if field == "" {
+				continue
+			}
+			if opts.HeaderRow && row == 1 {
+				values[idx] = field
+				continue
+			}
+			cellType, ok := columnTypes[idx+1]
+			if !ok && opts.DisableTypeDetection {
+				cellType = CellTypeSharedString
+			}
+			value, dateOnly := parseCSVField(field, cellType)
+			if dateOnly {
+				if dateStyleID == -1 {
+					if dateStyleID, err = f.NewStyle(&Style{NumFmt: 14}); err != nil {
+						return err
+					}
+				}
+				value = Cell{StyleID: dateStyleID, Value: value}
+			}
+			values[idx] = value
+		}
+		cell, err := CoordinatesToCellName(1, row)
+		if err != nil {
+			return err
+		}
+		if err = sw.SetRow(cell, values); err != nil {
+			return err
+		}
+	}
+	return sw.Flush()
+}
+
+// parseCSVField provides a function to parse the field of the CSV data by
+// given cell value type, the value type will be detected if the cell value
+// type is unset. The field will be returned as text if it can't be parsed as
+// the specified type, and returns true if the value is a date without time.
+func parseCSVField(field string, cellType CellType) (interface{}, bool) {
+	switch cellType {
+	case CellTypeBool:
+		if value, ok := parseCSVBool(field); ok {
+			return value, false
+		}
+	case CellTypeDate:
+		if value, dateOnly, ok := parseCSVDate(field); ok {
+			return value, dateOnly
+		}
+	case CellTypeNumber:
+		if value, ok := parseCSVNumber(field); ok {
+			return value, false
+		}
+	case CellTypeUnset:
+		if value, ok := parseCSVBool(field); ok {
+			return value, false
+		}
+		if value, ok := parseCSVNumber(field); ok {
+			return value, false
+		}
+		if value, dateOnly, ok := parseCSVDate(field); ok {
+			return value, dateOnly
+		}
+	}
+	return field, false
+}
+
+// parseCSVBool provides a function to parse the boolean value of the field.
+func parseCSVBool(field string) (bool, bool) {
+	if strings.EqualFold(field, "true") {
+		return true, true
+	}
+	if strings.EqualFold(field, "false") {
+		return false, true
+	}
+	return false, false
+}
+
+// parseCSVDate provides a function to parse the date and time value of the
+// field, and returns true if the value is a date without time.
+func parseCSVDate(field string) (time.Time, bool, bool) {
+	for _, layout := range csvDateLayouts {
+		if value, err := time.Parse(layout.layout, field); err == nil {
+			return value, layout.dateOnly, true
+		}
+	}
+	return time.Time{}, false, false
+}
+
+// parseCSVNumber provides a function to parse the numeric value of the field,
+// the number with leading zeros or more than 15 significant digits will not
+// be parsed to avoid losing the leading zeros or precision.
+func parseCSVNumber(field string) (float64, bool) {
+	if !csvNumberExp.MatchString(field) {
+		return 0, false
+	}
+	mantissa := strings.TrimLeft(field, "+-")
+	if idx := strings.IndexAny(mantissa, "eE"); idx != -1 {
+		mantissa = mantissa[:idx]
+	}
+	if len(mantissa) > 1 && mantissa[0] == '0' && mantissa[1] != '.' {
+		return 0, false
+	}
+	digits := strings.TrimLeft(strings.Replace(mantissa, ".", "", 1), "0")
+	if strings.Contains(mantissa, ".") {
+		digits = strings.TrimRight(digits, "0")
+	}
+	if len(digits) > 15 {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(field, 64)
+	return value, err == nil
+}
@@ -0,0 +1,107 @@
+package excelize
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetSheetFromCSV(t *testing.T) {
+	f := NewFile()
+	data := strings.Join([]string{
+		"Code\tName\tAmount\tDate\tActive\tUpdated",
+		"01234\tApple\t12.5\t2023-01-02\tTRUE\t2023-01-02 15:04:05",
+		"12345678901234567890\tOrange\t1,000\t2023/13/01\tyes\t",
+		"42\t\"Pear\"\t-1e3\t2023/02/03\tfalse",
+	}, "\n")
+	assert.NoError(t, f.SetSheetFromCSV("Sheet1", strings.NewReader(data), CSVImportOptions{
+		Delimiter:   '\t',
+		HeaderRow:   true,
+		ColumnTypes: map[string]CellType{"A": CellTypeSharedString},
+	}))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Code", "Name", "Amount", "Date", "Active", "Updated"},
+		{"01234", "Apple", "12.5", "01-02-23", "TRUE", "1/2/23 15:04"},
+		{"12345678901234567890", "Orange", "1,000", "2023/13/01", "yes"},
+		{"42", "Pear", "-1000", "02-03-23", "FALSE"},
+	}, rows)
+	for cell, expected := range map[string]CellType{
+		"A1": CellTypeInlineString, "A4": CellTypeInlineString, "C2": CellTypeUnset,
+		"C3": CellTypeInlineString, "E2": CellTypeBool, "F2": CellTypeUnset,
+	} {
+		cellType, err := f.GetCellType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, cellType, cell)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetSheetFromCSV.xlsx")))
+
+	// Test import CSV data with disabled type detection and column type override
+	f = NewFile()
+	assert.NoError(t, f.SetSheetFromCSV("Sheet1", strings.NewReader("1,2,true,x\n3,4,false,2023-01-02"), CSVImportOptions{
+		DisableTypeDetection: true,
+		ColumnTypes:          map[string]CellType{"b": CellTypeNumber, "C": CellTypeBool, "D": CellTypeDate},
+	}))
+	for cell, expected := range map[string]CellType{
+		"A1": CellTypeInlineString, "B1": CellTypeUnset, "C1": CellTypeBool, "D1": CellTypeInlineString, "D2": CellTypeUnset,
+	} {
+		cellType, err := f.GetCellType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, cellType, cell)
+	}
+	// Test import CSV data with invalid column name
+	assert.Equal(t, newInvalidColumnNameError("-"), f.SetSheetFromCSV("Sheet1", strings.NewReader(""), CSVImportOptions{
+		ColumnTypes: map[string]CellType{"-": CellTypeNumber},
+	}))
+	// Test import CSV data with unsupported column type
+	assert.Equal(t, ErrParameterInvalid, f.SetSheetFromCSV("Sheet1", strings.NewReader(""), CSVImportOptions{
+		ColumnTypes: map[string]CellType{"A": CellTypeFormula},
+	}))
+	// Test import CSV data on not exists worksheet
+	assert.EqualError(t, f.SetSheetFromCSV("SheetN", strings.NewReader(""), CSVImportOptions{}), "sheet SheetN does not exist")
+	// Test import malformed CSV data
+	err = f.SetSheetFromCSV("Sheet1", strings.NewReader("\"a\"b"), CSVImportOptions{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "extraneous or missing \" in quoted-field")
+	// Test import CSV data with exceeds the maximum columns limit
+	assert.Equal(t, ErrColumnNumber, f.SetSheetFromCSV("Sheet1", strings.NewReader(strings.Repeat("1,", MaxColumns)+"1"), CSVImportOptions{}))
+	// Test import CSV data with unsupported charset style sheet
+	f = NewFile()
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetSheetFromCSV("Sheet1", strings.NewReader("2023-01-02"), CSVImportOptions{}), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestParseCSVField(t *testing.T) {
+	for field, expected := range map[string]bool{
+		"0":                true,
+		"0.5":              true,
+		".5":               true,
+		"-12.50":           true,
+		"+1E-3":            true,
+		"123456789012345":  true,
+		"1234567890.12300": true,
+		"007":              false,
+		"1e400":            false,
+		"0x1F":             false,
+		"NaN":              false,
+		"Inf":              false,
+		"1_000":            false,
+		"1234567890123456": false,
+	} {
+		_, ok := parseCSVNumber(field)
+		assert.Equal(t, expected, ok, field)
+	}
+	value, dateOnly := parseCSVField("2023-01-02T15:04:05Z", CellTypeUnset)
+	assert.Equal(t, time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC), value)
+	assert.False(t, dateOnly)
+	value, dateOnly = parseCSVField("007", CellTypeNumber)
+	assert.Equal(t, "007", value)
+	assert.False(t, dateOnly)
+	value, _ = parseCSVField("yes", CellTypeBool)
+	assert.Equal(t, "yes", value)
+}
@@ -12,9 +12,12 @@
 package excelize
 
 import (
+	"encoding/xml"
 	"fmt"
+	"io"
 	"math"
 	"strings"
+	"time"
 	"unicode/utf16"
 )
 
@@ -193,6 +196,75 @@ func (dv *DataValidation) SetRange(f1, f2 interface{}, t DataValidationType, o D
 	return err
 }
 
+// SetDateRange provides a function to set data validation which only allows
+// the dates in the given range, the wall clock date and time of the given
+// values will be used. For example, only allows the dates in 2023 on
+// Sheet1!A1:A10:
+//
+//	dv := excelize.NewDataValidation(true)
+//	dv.Sqref = "A1:A10"
+//	err := dv.SetDateRange(
+//	    time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+//	    time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC),
+//	    excelize.DataValidationOperatorBetween)
+func (dv *DataValidation) SetDateRange(start, end time.Time, o DataValidationOperator) error {
+	var values [2]float64
+	for i, t := range []time.Time{start, end} {
+		excelTime, err := timeToExcelTime(time.Date(t.Year(), t.Month(), t.Day(),
+			t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC), false)
+		if err != nil {
+			return err
+		}
+		values[i] = excelTime
+	}
+	return dv.SetRange(values[0], values[1], DataValidationTypeDate, o)
+}
+
+// SetTimeRange provides a function to set data validation which only allows
+// the times of day in the given range, the date part of the given values will
+// be ignored. For example, only allows the working hours:
+//
+//	err := dv.SetTimeRange(
+//	    time.Date(0, 1, 1, 9, 0, 0, 0, time.UTC),
+//	    time.Date(0, 1, 1, 17, 30, 0, 0, time.UTC),
+//	    excelize.DataValidationOperatorBetween)
+func (dv *DataValidation) SetTimeRange(start, end time.Time, o DataValidationOperator) error {
+	toExcelTime := func(t time.Time) float64 {
+		return float64(time.Duration(t.Hour())*time.Hour+time.Duration(t.Minute())*time.Minute+
+			time.Duration(t.Second())*time.Second+time.Duration(t.Nanosecond())) / float64(dayNanoseconds)
+	}
+	return dv.SetRange(toExcelTime(start), toExcelTime(end), DataValidationTypeTime, o)
+}
+
+// SetTextLength provides a function to set data validation which limits the
+// number of characters of the cell value. For example, only allows the text
+// with the length between 2 and 10:
+//
+//	err := dv.SetTextLength(2, 10, excelize.DataValidationOperatorBetween)
+func (dv *DataValidation) SetTextLength(min, max int, o DataValidationOperator) error {
+	return dv.SetRange(min, max, DataValidationTypeTextLength, o)
+}
+
+// SetCustomFormula provides a function to set data validation which allows
+// the cell value when the given formula evaluates to TRUE, the leading equal
+// sign of the formula is optional. For example, only allows unique values in
+// the range A1:A10:
+//
+//	err := dv.SetCustomFormula("=COUNTIF($A$1:$A$10,A1)=1")
+func (dv *DataValidation) SetCustomFormula(formula string) error {
+	formula = strings.TrimPrefix(formula, "=")
+	if formula == "" {
+		return ErrParameterInvalid
+	}
+	if MaxFieldLength < len(utf16.Encode([]rune(formula))) {
+		return ErrDataValidationFormulaLength
+	}
+	dv.Formula1, dv.Formula2 = formulaEscaper.Replace(formula), ""
+	dv.Type = dataValidationTypeMap[DataValidationTypeCustom]
+	dv.Operator = ""
+	return nil
+}
+
 // SetSqrefDropList provides set data validation on a range with source
 // reference range of the worksheet by given data validation object and
 // worksheet name. The data validation object can be created by
@@ -207,8 +279,15 @@ func (dv *DataValidation) SetRange(f1, f2 interface{}, t DataValidationType, o D
 //	dv.Sqref = "A7:B8"
 //	dv.SetSqrefDropList("$E$1:$E$3")
 //	err := f.AddDataValidation("Sheet1", dv)
+//
+// The list source could also be a range on another worksheet or a defined
+// name, the leading equal sign is optional. For example, use the cells
+// Lists!A1:A10 or the defined name MyList as the list source:
+//
+//	dv.SetSqrefDropList("=Lists!$A$1:$A$10")
+//	dv.SetSqrefDropList("MyList")
 func (dv *DataValidation) SetSqrefDropList(sqref string) {
-	dv.Formula1 = sqref
+	dv.Formula1 = formulaEscaper.Replace(strings.TrimPrefix(sqref, "="))
 	dv.Type = dataValidationTypeMap[DataValidationTypeList]
 }
 
@@ -251,14 +330,80 @@ func (dv *DataValidation) SetSqref(sqref string) {
 //	dv.Sqref = "A5:B6"
 //	dv.SetDropList([]string{"1", "2", "3"})
 //	err = f.AddDataValidation("Sheet1", dv)
+//
+// The data validations which formulas reference the cells on other worksheets
+// will be stored in the worksheet extension list, the same as the spreadsheet
+// application does. Note that the GetDataValidations function returns these
+// data validations after the others, regardless of the order in which they
+// were added.
 func (f *File) AddDataValidation(sheet string, dv *DataValidation) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
 	}
+	if isCrossSheetFormula(sheet, dv.Formula1) || isCrossSheetFormula(sheet, dv.Formula2) {
+		dvs, err := f.getX14DataValidations(ws)
+		if err != nil {
+			return err
+		}
+		return f.setX14DataValidations(sheet, ws, append(dvs, newX14DataValidation(dv)))
+	}
 	if nil == ws.DataValidations {
 		ws.DataValidations = new(xlsxDataValidations)
 	}
+	ws.DataValidations.DataValidation = append(ws.DataValidations.DataValidation, newXlsxDataValidation(dv))
+	ws.DataValidations.Count = len(ws.DataValidations.DataValidation)
+	return err
+}
+
+// SetDataValidations provides a function to replace all data validations of
+// the worksheet by given worksheet name and data validations list. The
+// worksheet will be kept unchanged if any data validation in the list is
+// invalid, and all data validations will be removed if the list is empty.
+// For example, rebuild the data validations on Sheet1:
+//
+//	dv1 := excelize.NewDataValidation(true)
+//	dv1.Sqref = "A1:A10"
+//	dv1.SetDropList([]string{"1", "2", "3"})
+//	dv2 := excelize.NewDataValidation(true)
+//	dv2.Sqref = "B1:B10"
+//	dv2.SetSqrefDropList("Lists!$A$1:$A$10")
+//	err := f.SetDataValidations("Sheet1", []*excelize.DataValidation{dv1, dv2})
+func (f *File) SetDataValidations(sheet string, dvs []*DataValidation) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	var (
+		dataValidations = new(xlsxDataValidations)
+		extDVs          []*decodeX14DataValidation
+	)
+	for _, dv := range dvs {
+		if dv == nil {
+			return ErrParameterInvalid
+		}
+		if _, err = f.flatSqref(dv.Sqref); err != nil {
+			return err
+		}
+		if isCrossSheetFormula(sheet, dv.Formula1) || isCrossSheetFormula(sheet, dv.Formula2) {
+			extDVs = append(extDVs, newX14DataValidation(dv))
+			continue
+		}
+		dataValidations.DataValidation = append(dataValidations.DataValidation, newXlsxDataValidation(dv))
+	}
+	if err = f.setX14DataValidations(sheet, ws, extDVs); err != nil {
+		return err
+	}
+	ws.DataValidations = nil
+	if dataValidations.Count = len(dataValidations.DataValidation); dataValidations.Count > 0 {
+		ws.DataValidations = dataValidations
+	}
+	return err
+}
+
+// newXlsxDataValidation returns the data validation element of the worksheet
+// by given data validation object.
+func newXlsxDataValidation(dv *DataValidation) *xlsxDataValidation {
 	dataValidation := &xlsxDataValidation{
 		AllowBlank:       dv.AllowBlank,
 		Error:            dv.Error,
@@ -279,24 +424,206 @@ func (f *File) AddDataValidation(sheet string, dv *DataValidation) error {
 	if dv.Formula2 != "" {
 		dataValidation.Formula2 = &xlsxInnerXML{Content: dv.Formula2}
 	}
-	ws.DataValidations.DataValidation = append(ws.DataValidations.DataValidation, dataValidation)
-	ws.DataValidations.Count = len(ws.DataValidations.DataValidation)
-	return err
+	return dataValidation
+}
+
+// GetDataValidation returns the data validation which applies to the given
+// cell by given worksheet name and cell reference, the first matched data
+// validation will be returned if there are more than one. This function
+// returns nil if the cell doesn't have data validation. For example, get the
+// data validation of the cell Sheet1!A1:
+//
+//	dv, err := f.GetDataValidation("Sheet1", "A1")
+func (f *File) GetDataValidation(sheet, cell string) (*DataValidation, error) {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return nil, err
+	}
+	dvs, err := f.GetDataValidations(sheet)
+	if err != nil {
+		return nil, err
+	}
+	for _, dv := range dvs {
+		for _, ref := range strings.Fields(dv.Sqref) {
+			if !strings.Contains(ref, ":") {
+				ref += ":" + ref
+			}
+			coordinates, err := rangeRefToCoordinates(ref)
+			if err != nil {
+				return nil, err
+			}
+			_ = sortCoordinates(coordinates)
+			if cellInRange([]int{col, row}, coordinates) {
+				return dv, nil
+			}
+		}
+	}
+	return nil, nil
 }
 
 // GetDataValidations returns data validations list by given worksheet name.
+// The data validations stored in the worksheet extension list, such as the
+// validations which reference the cells on other worksheets, will be returned
+// after the others. So the order of the returned list may be different from
+// the order in which the data validations were added.
 func (f *File) GetDataValidations(sheet string) ([]*DataValidation, error) {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return nil, err
 	}
-	if ws.DataValidations == nil || len(ws.DataValidations.DataValidation) == 0 {
-		return nil, err
-	}
 	var dvs []*DataValidation
-	for _, dv := range ws.DataValidations.DataValidation {
-		if dv != nil {
-			dataValidation := &DataValidation{
+	if ws.DataValidations != nil {
+		for _, dv := range ws.DataValidations.DataValidation {
+			if dv != nil {
+				dataValidation := &DataValidation{
+					AllowBlank:       dv.AllowBlank,
+					Error:            dv.Error,
+					ErrorStyle:       dv.ErrorStyle,
+					ErrorTitle:       dv.ErrorTitle,
+					Operator:         dv.Operator,
+					Prompt:           dv.Prompt,
+					PromptTitle:      dv.PromptTitle,
+					ShowDropDown:     dv.ShowDropDown,
+					ShowErrorMessage: dv.ShowErrorMessage,
+					ShowInputMessage: dv.ShowInputMessage,
+					Sqref:            dv.Sqref,
+					Type:             dv.Type,
+				}
+				if dv.Formula1 != nil {
+					dataValidation.Formula1 = unescapeDataValidationFormula(dv.Formula1.Content)
+				}
+				if dv.Formula2 != nil {
+					dataValidation.Formula2 = unescapeDataValidationFormula(dv.Formula2.Content)
+				}
+				dvs = append(dvs, dataValidation)
+			}
+		}
+	}
+	extDVs, err := f.getExtDataValidations(ws)
+	return append(dvs, extDVs...), err
+}
+
+// isCrossSheetFormula returns whether the given data validation formula
+// references the cells on other worksheets than the given worksheet. The
+// exclamation marks inside the string literals will be ignored.
+func isCrossSheetFormula(sheet, formula string) bool {
+	var inString, inQuote bool
+	quoteStart := -1
+	for i := 0; i < len(formula); i++ {
+		switch formula[i] {
+		case '"':
+			if !inQuote {
+				inString = !inString
+			}
+		case '\'':
+			if inString {
+				continue
+			}
+			if inQuote && i+1 < len(formula) && formula[i+1] == '\'' {
+				i++
+				continue
+			}
+			if inQuote = !inQuote; inQuote {
+				quoteStart = i
+			}
+		case '!':
+			if inString || inQuote {
+				continue
+			}
+			if i > 0 && formula[i-1] == '\'' && quoteStart != -1 {
+				name := strings.ReplaceAll(formula[quoteStart+1:i-1], "''", "'")
+				if strings.HasPrefix(name, "[") || !strings.EqualFold(name, sheet) {
+					return true
+				}
+				continue
+			}
+			start := i
+			for start > 0 && !strings.ContainsRune(" ,;:=+-*/^&<>()[]{}%", rune(formula[start-1])) {
+				start--
+			}
+			if start > 0 && formula[start-1] == ']' {
+				return true
+			}
+			if !strings.EqualFold(formula[start:i], sheet) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// newX14DataValidation returns the data validation element of the worksheet
+// extension list by given data validation object.
+func newX14DataValidation(dv *DataValidation) *decodeX14DataValidation {
+	dataValidation := &decodeX14DataValidation{
+		AllowBlank:       dv.AllowBlank,
+		Error:            dv.Error,
+		ErrorStyle:       dv.ErrorStyle,
+		ErrorTitle:       dv.ErrorTitle,
+		Operator:         dv.Operator,
+		Prompt:           dv.Prompt,
+		PromptTitle:      dv.PromptTitle,
+		ShowDropDown:     dv.ShowDropDown,
+		ShowErrorMessage: dv.ShowErrorMessage,
+		ShowInputMessage: dv.ShowInputMessage,
+		Sqref:            dv.Sqref,
+		Type:             dv.Type,
+	}
+	if dv.Formula1 != "" {
+		dataValidation.Formula1 = &decodeX14DVFormula{F: formulaUnescaper.Replace(dv.Formula1)}
+	}
+	if dv.Formula2 != "" {
+		dataValidation.Formula2 = &decodeX14DVFormula{F: formulaUnescaper.Replace(dv.Formula2)}
+	}
+	return dataValidation
+}
+
+// getX14DataValidations returns the data validations stored in the worksheet
+// extension list by given worksheet.
+func (f *File) getX14DataValidations(ws *xlsxWorksheet) ([]*decodeX14DataValidation, error) {
+	var dvs []*decodeX14DataValidation
+	if ws.ExtLst == nil {
+		return dvs, nil
+	}
+	decodeExtLst := new(decodeExtLst)
+	if err := f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+		Decode(decodeExtLst); err != nil && err != io.EOF {
+		return dvs, err
+	}
+	for _, ext := range decodeExtLst.Ext {
+		if ext.URI != ExtURIDataValidations {
+			continue
+		}
+		decodeDVs := new(decodeX14DataValidations)
+		if err := f.xmlNewDecoder(strings.NewReader(ext.Content)).Decode(decodeDVs); err != nil && err != io.EOF {
+			return dvs, err
+		}
+		dvs = append(dvs, decodeDVs.DataValidation...)
+	}
+	return dvs, nil
+}
+
+// setX14DataValidations replaces the data validations stored in the worksheet
+// extension list by given worksheet name, worksheet and data validations. The
+// data validations extension will be removed if the given list is empty.
+func (f *File) setX14DataValidations(sheet string, ws *xlsxWorksheet, dvs []*decodeX14DataValidation) error {
+	decodeExtLst := new(decodeExtLst)
+	if ws.ExtLst != nil {
+		if err := f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+			Decode(decodeExtLst); err != nil && err != io.EOF {
+			return err
+		}
+	}
+	var exts []*xlsxExt
+	for _, ext := range decodeExtLst.Ext {
+		if ext.URI != ExtURIDataValidations {
+			exts = append(exts, ext)
+		}
+	}
+	if len(dvs) > 0 {
+		x14DVs := &xlsxX14DataValidations{XMLNSXM: NameSpaceSpreadSheetExcel2006Main.Value, Count: len(dvs)}
+		for _, dv := range dvs {
+			dataValidation := &xlsxX14DataValidation{
 				AllowBlank:       dv.AllowBlank,
 				Error:            dv.Error,
 				ErrorStyle:       dv.ErrorStyle,
@@ -311,15 +638,156 @@ func (f *File) GetDataValidations(sheet string) ([]*DataValidation, error) {
 				Type:             dv.Type,
 			}
 			if dv.Formula1 != nil {
-				dataValidation.Formula1 = unescapeDataValidationFormula(dv.Formula1.Content)
+				dataValidation.Formula1 = &xlsxX14DVFormula{F: dv.Formula1.F}
 			}
 			if dv.Formula2 != nil {
-				dataValidation.Formula2 = unescapeDataValidationFormula(dv.Formula2.Content)
+				dataValidation.Formula2 = &xlsxX14DVFormula{F: dv.Formula2.F}
+			}
+			x14DVs.DataValidation = append(x14DVs.DataValidation, dataValidation)
+		}
+		x14DVsBytes, _ := xml.Marshal(x14DVs)
+		exts = append(exts, &xlsxExt{URI: ExtURIDataValidations, Content: string(x14DVsBytes)})
+	}
+	if len(exts) == 0 {
+		ws.ExtLst = nil
+		return nil
+	}
+	f.addSheetNameSpace(sheet, NameSpaceSpreadSheetX14)
+	sortExtLst(exts, worksheetExtURIPriority)
+	decodeExtLst.Ext = exts
+	extLstBytes, err := xml.Marshal(decodeExtLst)
+	ws.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
+	return err
+}
+
+// getExtDataValidations returns data validations list stored in the
+// worksheet extension list by given worksheet.
+func (f *File) getExtDataValidations(ws *xlsxWorksheet) ([]*DataValidation, error) {
+	var dvs []*DataValidation
+	decodeDVs, err := f.getX14DataValidations(ws)
+	if err != nil {
+		return dvs, err
+	}
+	for _, dv := range decodeDVs {
+		dataValidation := &DataValidation{
+			AllowBlank:       dv.AllowBlank,
+			Error:            dv.Error,
+			ErrorStyle:       dv.ErrorStyle,
+			ErrorTitle:       dv.ErrorTitle,
+			Operator:         dv.Operator,
+			Prompt:           dv.Prompt,
+			PromptTitle:      dv.PromptTitle,
+			ShowDropDown:     dv.ShowDropDown,
+			ShowErrorMessage: dv.ShowErrorMessage,
+			ShowInputMessage: dv.ShowInputMessage,
+			Sqref:            dv.Sqref,
+			Type:             dv.Type,
+		}
+		if dv.Formula1 != nil {
+			dataValidation.Formula1 = unescapeDataValidationFormula(dv.Formula1.F)
+		}
+		if dv.Formula2 != nil {
+			dataValidation.Formula2 = unescapeDataValidationFormula(dv.Formula2.F)
+		}
+		dvs = append(dvs, dataValidation)
+	}
+	return dvs, nil
+}
+
+// CopyDataValidations provides a function to copy all data validations from
+// the source worksheet to the destination worksheet by given worksheet names.
+// The reference sequence of each data validation will be kept unless an
+// offset is specified in the options, the formulas of the validation criteria
+// will not be adjusted. For example, copy data validations of Sheet1 to
+// Sheet2 and move them down by 10 rows:
+//
+//	err := f.CopyDataValidations("Sheet1", "Sheet2",
+//	    excelize.CopyDataValidationOptions{RowOffset: 10})
+func (f *File) CopyDataValidations(fromSheet, toSheet string, opts ...CopyDataValidationOptions) error {
+	from, err := f.workSheetReader(fromSheet)
+	if err != nil {
+		return err
+	}
+	to, err := f.workSheetReader(toSheet)
+	if err != nil {
+		return err
+	}
+	var options CopyDataValidationOptions
+	for _, opt := range opts {
+		options = opt
+	}
+	if err = f.copyExtDataValidations(from, to, toSheet, options); err != nil || from.DataValidations == nil {
+		return err
+	}
+	var dvs []*xlsxDataValidation
+	for _, dv := range from.DataValidations.DataValidation {
+		if dv == nil {
+			continue
+		}
+		dataValidation := *dv
+		if dataValidation.Sqref, err = offsetSqref(dv.Sqref, options.ColOffset, options.RowOffset); err != nil {
+			return err
+		}
+		if dv.Formula1 != nil {
+			dataValidation.Formula1 = &xlsxInnerXML{Content: dv.Formula1.Content}
+		}
+		if dv.Formula2 != nil {
+			dataValidation.Formula2 = &xlsxInnerXML{Content: dv.Formula2.Content}
+		}
+		dvs = append(dvs, &dataValidation)
+	}
+	if to.DataValidations == nil {
+		to.DataValidations = new(xlsxDataValidations)
+	}
+	to.DataValidations.DataValidation = append(to.DataValidations.DataValidation, dvs...)
+	to.DataValidations.Count = len(to.DataValidations.DataValidation)
+	return err
+}
+
+// copyExtDataValidations provides a function to copy the data validations
+// stored in the worksheet extension list from the source worksheet to the
+// destination worksheet by given worksheets, destination worksheet name and
+// copy options.
+func (f *File) copyExtDataValidations(from, to *xlsxWorksheet, toSheet string, opts CopyDataValidationOptions) error {
+	fromDVs, err := f.getX14DataValidations(from)
+	if err != nil || len(fromDVs) == 0 {
+		return err
+	}
+	toDVs, err := f.getX14DataValidations(to)
+	if err != nil {
+		return err
+	}
+	for _, dv := range fromDVs {
+		if dv.Sqref, err = offsetSqref(dv.Sqref, opts.ColOffset, opts.RowOffset); err != nil {
+			return err
+		}
+		toDVs = append(toDVs, dv)
+	}
+	return f.setX14DataValidations(toSheet, to, toDVs)
+}
+
+// offsetSqref moves each cell reference in the given reference sequence by
+// the given number of columns and rows.
+func offsetSqref(sqref string, colOffset, rowOffset int) (string, error) {
+	if colOffset == 0 && rowOffset == 0 {
+		return sqref, nil
+	}
+	var refs []string
+	for _, ref := range strings.Fields(sqref) {
+		var cells []string
+		for _, cell := range strings.Split(ref, ":") {
+			col, row, err := CellNameToCoordinates(cell)
+			if err != nil {
+				return sqref, err
+			}
+			if cell, err = CoordinatesToCellName(col+colOffset, row+rowOffset); err != nil {
+				return sqref, err
 			}
-			dvs = append(dvs, dataValidation)
+			cells = append(cells, cell)
 		}
+		refs = append(refs, strings.Join(cells, ":"))
 	}
-	return dvs, err
+	return strings.Join(refs, " "), nil
 }
 
 // DeleteDataValidation delete data validation by given worksheet name and
@@ -330,46 +798,76 @@ func (f *File) DeleteDataValidation(sheet string, sqref ...string) error {
 	if err != nil {
 		return err
 	}
-	if ws.DataValidations == nil {
-		return nil
-	}
 	if sqref == nil {
 		ws.DataValidations = nil
-		return nil
+		return f.deleteExtDataValidations(sheet, ws, nil)
 	}
 	delCells, err := f.flatSqref(sqref[0])
 	if err != nil {
 		return err
 	}
-	dv := ws.DataValidations
-	for i := 0; i < len(dv.DataValidation); i++ {
-		var applySqref []string
-		colCells, err := f.flatSqref(dv.DataValidation[i].Sqref)
-		if err != nil {
-			return err
-		}
-		for col, cells := range delCells {
-			for _, cell := range cells {
-				idx := inCoordinates(colCells[col], cell)
-				if idx != -1 {
-					colCells[col] = append(colCells[col][:idx], colCells[col][idx+1:]...)
-				}
+	if ws.DataValidations != nil {
+		dv := ws.DataValidations
+		for i := 0; i < len(dv.DataValidation); i++ {
+			if dv.DataValidation[i].Sqref, err = f.deleteSqrefCells(dv.DataValidation[i].Sqref, delCells); err != nil {
+				return err
 			}
+			if dv.DataValidation[i].Sqref == "" {
+				dv.DataValidation = append(dv.DataValidation[:i], dv.DataValidation[i+1:]...)
+				i--
+			}
+		}
+		dv.Count = len(dv.DataValidation)
+		if dv.Count == 0 {
+			ws.DataValidations = nil
+		}
+	}
+	return f.deleteExtDataValidations(sheet, ws, delCells)
+}
+
+// deleteExtDataValidations delete the data validations stored in the
+// worksheet extension list by given worksheet name, worksheet and cells
+// coordinates. All of them will be deleted if the cells coordinates is nil.
+func (f *File) deleteExtDataValidations(sheet string, ws *xlsxWorksheet, delCells map[int][][]int) error {
+	dvs, err := f.getX14DataValidations(ws)
+	if err != nil || len(dvs) == 0 {
+		return err
+	}
+	var applyDVs []*decodeX14DataValidation
+	for _, dv := range dvs {
+		if delCells == nil {
+			break
 		}
-		for _, col := range colCells {
-			applySqref = append(applySqref, f.squashSqref(col)...)
+		if dv.Sqref, err = f.deleteSqrefCells(dv.Sqref, delCells); err != nil {
+			return err
 		}
-		dv.DataValidation[i].Sqref = strings.Join(applySqref, " ")
-		if len(applySqref) == 0 {
-			dv.DataValidation = append(dv.DataValidation[:i], dv.DataValidation[i+1:]...)
-			i--
+		if dv.Sqref != "" {
+			applyDVs = append(applyDVs, dv)
 		}
 	}
-	dv.Count = len(dv.DataValidation)
-	if dv.Count == 0 {
-		ws.DataValidations = nil
+	return f.setX14DataValidations(sheet, ws, applyDVs)
+}
+
+// deleteSqrefCells returns the reference sequence which removed the given
+// cells coordinates from the given reference sequence.
+func (f *File) deleteSqrefCells(sqref string, delCells map[int][][]int) (string, error) {
+	var applySqref []string
+	colCells, err := f.flatSqref(sqref)
+	if err != nil {
+		return sqref, err
 	}
-	return nil
+	for col, cells := range delCells {
+		for _, cell := range cells {
+			idx := inCoordinates(colCells[col], cell)
+			if idx != -1 {
+				colCells[col] = append(colCells[col][:idx], colCells[col][idx+1:]...)
+			}
+		}
+	}
+	for _, col := range colCells {
+		applySqref = append(applySqref, f.squashSqref(col)...)
+	}
+	return strings.Join(applySqref, " "), nil
 }
 
 // squashSqref generates cell reference sequence by given cells coordinates list.
@@ -12,10 +12,12 @@
 package excelize
 
 import (
+	"fmt"
 	"math"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -170,6 +172,387 @@ func TestDataValidationError(t *testing.T) {
 	assert.EqualError(t, f.AddDataValidation("Sheet:1", nil), ErrSheetNameInvalid.Error())
 }
 
+func TestDataValidationTypedRange(t *testing.T) {
+	f := NewFile()
+	dv := NewDataValidation(true)
+	dv.Sqref = "A1:A10"
+	assert.NoError(t, dv.SetDateRange(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 12, 31, 12, 0, 0, 0, time.FixedZone("UTC+8", 8*60*60)), DataValidationOperatorBetween))
+	assert.Equal(t, "date", dv.Type)
+	assert.Equal(t, "between", dv.Operator)
+	assert.Equal(t, "44927", dv.Formula1)
+	assert.Equal(t, "45291.5", dv.Formula2)
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+
+	dv = NewDataValidation(true)
+	dv.Sqref = "B1:B10"
+	assert.NoError(t, dv.SetTimeRange(time.Date(2023, 1, 1, 9, 0, 0, 0, time.UTC),
+		time.Date(0, 1, 1, 18, 0, 0, 0, time.UTC), DataValidationOperatorNotBetween))
+	assert.Equal(t, "time", dv.Type)
+	assert.Equal(t, "notBetween", dv.Operator)
+	assert.Equal(t, "0.375", dv.Formula1)
+	assert.Equal(t, "0.75", dv.Formula2)
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+
+	dv = NewDataValidation(true)
+	dv.Sqref = "C1:C10"
+	assert.NoError(t, dv.SetTextLength(2, 10, DataValidationOperatorBetween))
+	assert.Equal(t, "textLength", dv.Type)
+	assert.Equal(t, "2", dv.Formula1)
+	assert.Equal(t, "10", dv.Formula2)
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+
+	dv = NewDataValidation(true)
+	dv.Sqref = "D1:D10"
+	assert.NoError(t, dv.SetRange(1, 2, DataValidationTypeWhole, DataValidationOperatorBetween))
+	assert.NoError(t, dv.SetCustomFormula("=AND(LEN(D1)<5,COUNTIF($D$1:$D$10,D1)=1)"))
+	assert.Equal(t, "custom", dv.Type)
+	assert.Empty(t, dv.Operator)
+	assert.Empty(t, dv.Formula2)
+	assert.Equal(t, "AND(LEN(D1)&lt;5,COUNTIF($D$1:$D$10,D1)=1)", dv.Formula1)
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+
+	dvs, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 4)
+	assert.Equal(t, "AND(LEN(D1)<5,COUNTIF($D$1:$D$10,D1)=1)", dvs[3].Formula1)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDataValidationTypedRange.xlsx")))
+
+	// Test set custom formula with invalid formula
+	assert.Equal(t, ErrParameterInvalid, dv.SetCustomFormula("="))
+	assert.Equal(t, ErrDataValidationFormulaLength, dv.SetCustomFormula(strings.Repeat("A", MaxFieldLength+1)))
+	assert.Equal(t, "AND(LEN(D1)&lt;5,COUNTIF($D$1:$D$10,D1)=1)", dv.Formula1)
+	assert.NoError(t, f.Close())
+}
+
+func TestDataValidationListSource(t *testing.T) {
+	f := NewFile()
+	for _, sheet := range []string{"Lists", "R&D"} {
+		_, err := f.NewSheet(sheet)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "MyList", RefersTo: "Lists!$B$1:$B$3"}))
+	// Test set data validation with defined name list source
+	dv := NewDataValidation(true)
+	dv.Sqref = "A1:A2"
+	dv.SetSqrefDropList("=MyList")
+	assert.Equal(t, "MyList", dv.Formula1)
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	// Test set data validations with list source on other worksheets
+	dv = NewDataValidation(true)
+	dv.Sqref = "B1:B2"
+	dv.SetSqrefDropList("=Lists!$A$1:$A$10")
+	assert.Equal(t, "Lists!$A$1:$A$10", dv.Formula1)
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	dv = NewDataValidation(false)
+	dv.Sqref = "C1"
+	dv.SetSqrefDropList("'R&D'!$A$1:$A$3")
+	dv.SetInput("input title", "input body")
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Len(t, ws.(*xlsxWorksheet).DataValidations.DataValidation, 1)
+	assert.Contains(t, ws.(*xlsxWorksheet).ExtLst.Ext, "<xm:f>&#39;R&amp;D&#39;!$A$1:$A$3</xm:f>")
+	dvs, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 3)
+	assert.Equal(t, "MyList", dvs[0].Formula1)
+	assert.Equal(t, "Lists!$A$1:$A$10", dvs[1].Formula1)
+	assert.Equal(t, "B1:B2", dvs[1].Sqref)
+	assert.Equal(t, "'R&D'!$A$1:$A$3", dvs[2].Formula1)
+	assert.Equal(t, "input body", *dvs[2].Prompt)
+	assert.False(t, dvs[2].AllowBlank)
+
+	// Test adjust data validations stored in the worksheet extension list
+	assert.NoError(t, f.InsertRows("Lists", 1, 1))
+	assert.NoError(t, f.InsertCols("Sheet1", "A", 1))
+	dvs, err = f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Lists!$A$2:$A$11", dvs[1].Formula1)
+	assert.Equal(t, "C1:C2", dvs[1].Sqref)
+	assert.Equal(t, "D1", dvs[2].Sqref)
+
+	resultFile := filepath.Join("test", "TestDataValidationListSource.xlsx")
+	assert.NoError(t, f.SaveAs(resultFile))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(resultFile)
+	assert.NoError(t, err)
+	dvs, err = f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 3)
+	assert.Equal(t, "'R&D'!$A$1:$A$3", dvs[2].Formula1)
+
+	// Test delete data validations stored in the worksheet extension list
+	assert.NoError(t, f.DeleteDataValidation("Sheet1", "C1 D1"))
+	dvs, err = f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 2)
+	assert.Equal(t, "C2", dvs[1].Sqref)
+	assert.NoError(t, f.DeleteDataValidation("Sheet1"))
+	dvs, err = f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 0)
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Nil(t, ws.(*xlsxWorksheet).ExtLst)
+
+	// Test add, delete and adjust data validations with invalid worksheet
+	// extension list
+	ws.(*xlsxWorksheet).ExtLst = &xlsxExtLst{Ext: "<ext"}
+	assert.Error(t, f.AddDataValidation("Sheet1", dv))
+	assert.Error(t, f.DeleteDataValidation("Sheet1"))
+	assert.Error(t, f.InsertRows("Sheet1", 1, 1))
+	assert.Error(t, f.setX14DataValidations("Sheet1", ws.(*xlsxWorksheet), nil))
+	// Test delete and adjust data validations with invalid reference sequence
+	ws.(*xlsxWorksheet).ExtLst = nil
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	ws.(*xlsxWorksheet).ExtLst.Ext = strings.ReplaceAll(ws.(*xlsxWorksheet).ExtLst.Ext, "<xm:sqref>C1</xm:sqref>", "<xm:sqref>A</xm:sqref>")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.DeleteDataValidation("Sheet1", "A1"))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.InsertRows("Sheet1", 1, 1))
+	assert.NoError(t, f.Close())
+}
+
+func TestIsCrossSheetFormula(t *testing.T) {
+	for formula, expected := range map[string]bool{
+		"":                           false,
+		"$A$1:$A$3":                  false,
+		"MyList":                     false,
+		`"Yes!,No!"`:                 false,
+		`ISNUMBER(SEARCH("!",A1))`:   false,
+		"Sheet1!$A$1:$A$3":           false,
+		"sheet1!$A$1":                false,
+		"'Sheet1'!$A$1":              false,
+		"Sheet2!$A$1:$A$3":           true,
+		"'R&D'!$A$1":                 true,
+		"'It''s'!$A$1":               true,
+		`AND(A1<>"!",Sheet2!A1>0)`:   true,
+		"SUM(Sheet1!A1,Sheet2!A1)>0": true,
+		"[1]Sheet1!$A$1":             true,
+		"'[1]Sheet1'!$A$1":           true,
+	} {
+		assert.Equal(t, expected, isCrossSheetFormula("Sheet1", formula), formula)
+	}
+	assert.False(t, isCrossSheetFormula("It's", "'It''s'!$A$1"))
+}
+
+func TestGetDataValidations(t *testing.T) {
+	f := NewFile()
+	dv := NewDataValidation(true)
+	dv.Sqref = "A1:A2"
+	assert.NoError(t, dv.SetDropList([]string{"1", "2"}))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	// Test get data validations stored in the worksheet extension list
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).ExtLst = &xlsxExtLst{Ext: fmt.Sprintf(`<ext uri="%s" xmlns:x14="%s"><x14:dataValidations count="1" xmlns:xm="%s"><x14:dataValidation type="list" allowBlank="1" showInputMessage="1" showErrorMessage="1" promptTitle="title" prompt="prompt"><x14:formula1><xm:f>Sheet2!$A$1:$A$3</xm:f></x14:formula1><xm:sqref>B1:B5 D1</xm:sqref></x14:dataValidation></x14:dataValidations></ext>`,
+		ExtURIDataValidations, NameSpaceSpreadSheetX14.Value, NameSpaceSpreadSheetExcel2006Main.Value)}
+	dvs, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 2)
+	assert.Equal(t, "A1:A2", dvs[0].Sqref)
+	assert.Equal(t, &DataValidation{
+		AllowBlank:       true,
+		Prompt:           stringPtr("prompt"),
+		PromptTitle:      stringPtr("title"),
+		ShowErrorMessage: true,
+		ShowInputMessage: true,
+		Sqref:            "B1:B5 D1",
+		Type:             "list",
+		Formula1:         "Sheet2!$A$1:$A$3",
+	}, dvs[1])
+	// Test get data validations with invalid worksheet extension list
+	ws.(*xlsxWorksheet).ExtLst.Ext = "<ext"
+	_, err = f.GetDataValidations("Sheet1")
+	assert.Error(t, err)
+	ws.(*xlsxWorksheet).ExtLst.Ext = fmt.Sprintf(`<ext uri="%s"><x14:dataValidations></ext>`, ExtURIDataValidations)
+	_, err = f.GetDataValidations("Sheet1")
+	assert.Error(t, err)
+	// Test get data validations on not exists worksheet
+	_, err = f.GetDataValidations("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get data validations with unsupported charset worksheet
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetDataValidations("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestGetDataValidation(t *testing.T) {
+	f := NewFile()
+	dv1 := NewDataValidation(true)
+	dv1.Sqref = "A1:B2 D4"
+	assert.NoError(t, dv1.SetTextLength(1, 5, DataValidationOperatorBetween))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv1))
+	dv2 := NewDataValidation(true)
+	dv2.Sqref = "E5:C3"
+	dv2.SetSqrefDropList("Sheet2!$A$1:$A$3")
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv2))
+	for cell, expected := range map[string]string{"A1": "A1:B2 D4", "B2": "A1:B2 D4", "D4": "A1:B2 D4", "E5": "E5:C3", "C3": "E5:C3"} {
+		dv, err := f.GetDataValidation("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, dv.Sqref, cell)
+	}
+	dv, err := f.GetDataValidation("Sheet1", "F6")
+	assert.NoError(t, err)
+	assert.Nil(t, dv)
+	// Test get data validation with invalid cell reference
+	_, err = f.GetDataValidation("Sheet1", "A")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test get data validation on not exists worksheet
+	_, err = f.GetDataValidation("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get data validation with invalid reference sequence
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).DataValidations.DataValidation[0].Sqref = "A1:A"
+	_, err = f.GetDataValidation("Sheet1", "A1")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+}
+
+func TestSetDataValidations(t *testing.T) {
+	f := NewFile()
+	dv := NewDataValidation(true)
+	dv.Sqref = "A1:A10"
+	assert.NoError(t, dv.SetRange(1, 10, DataValidationTypeWhole, DataValidationOperatorBetween))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+
+	dv1 := NewDataValidation(true)
+	dv1.Sqref = "B1:B10"
+	assert.NoError(t, dv1.SetDropList([]string{"1", "2", "3"}))
+	dv2 := NewDataValidation(true)
+	dv2.Sqref = "C1:C10"
+	dv2.SetSqrefDropList("Sheet2!$A$1:$A$3")
+	assert.NoError(t, f.SetDataValidations("Sheet1", []*DataValidation{dv1, dv2}))
+	dvs, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 2)
+	assert.Equal(t, "B1:B10", dvs[0].Sqref)
+	assert.Equal(t, "C1:C10", dvs[1].Sqref)
+	assert.Equal(t, "Sheet2!$A$1:$A$3", dvs[1].Formula1)
+
+	// Test set data validations with invalid data validations, the worksheet
+	// should be kept unchanged
+	dv.Sqref = "A"
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetDataValidations("Sheet1", []*DataValidation{dv1, dv}))
+	assert.Equal(t, ErrParameterInvalid, f.SetDataValidations("Sheet1", []*DataValidation{dv1, nil}))
+	dvs, err = f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 2)
+
+	// Test set data validations with empty list
+	assert.NoError(t, f.SetDataValidations("Sheet1", nil))
+	dvs, err = f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 0)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Nil(t, ws.(*xlsxWorksheet).DataValidations)
+	assert.Nil(t, ws.(*xlsxWorksheet).ExtLst)
+
+	// Test set data validations with invalid worksheet extension list
+	ws.(*xlsxWorksheet).ExtLst = &xlsxExtLst{Ext: "<ext"}
+	assert.Error(t, f.SetDataValidations("Sheet1", []*DataValidation{dv1}))
+	assert.Nil(t, ws.(*xlsxWorksheet).DataValidations)
+	// Test set data validations on not exists worksheet
+	assert.EqualError(t, f.SetDataValidations("SheetN", nil), "sheet SheetN does not exist")
+}
+
+func TestCopyDataValidations(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	// Test copy data validations without data validations in the worksheet
+	assert.NoError(t, f.CopyDataValidations("Sheet1", "Sheet2"))
+	dvs, err := f.GetDataValidations("Sheet2")
+	assert.NoError(t, err)
+	assert.Nil(t, dvs)
+
+	dv := NewDataValidation(true)
+	dv.Sqref = "A1:B2 D4"
+	assert.NoError(t, dv.SetRange(10, 20, DataValidationTypeWhole, DataValidationOperatorBetween))
+	dv.SetError(DataValidationErrorStyleStop, "error title", "error body")
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	dv = NewDataValidation(true)
+	dv.Sqref = "C1:C10"
+	assert.NoError(t, dv.SetDropList([]string{"A&B", "C<D"}))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+
+	assert.NoError(t, f.CopyDataValidations("Sheet1", "Sheet2"))
+	expected, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	dvs, err = f.GetDataValidations("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, dvs)
+	// Test copy data validations with offset
+	_, err = f.NewSheet("Sheet3")
+	assert.NoError(t, err)
+	assert.NoError(t, f.CopyDataValidations("Sheet1", "Sheet3", CopyDataValidationOptions{ColOffset: 1, RowOffset: 2}))
+	dvs, err = f.GetDataValidations("Sheet3")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 2)
+	assert.Equal(t, "B3:C4 E6", dvs[0].Sqref)
+	assert.Equal(t, "D3:D12", dvs[1].Sqref)
+	assert.Equal(t, expected[1].Formula1, dvs[1].Formula1)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCopyDataValidations.xlsx")))
+	// Test copy data validations with offset out of range
+	assert.Equal(t, ErrMaxRows, f.CopyDataValidations("Sheet1", "Sheet3", CopyDataValidationOptions{RowOffset: TotalRows}))
+	assert.EqualError(t, f.CopyDataValidations("Sheet1", "Sheet3", CopyDataValidationOptions{ColOffset: -1}),
+		newCoordinatesToCellNameError(0, 1).Error())
+	// Test copy data validations with invalid reference sequence
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).DataValidations.DataValidation = append([]*xlsxDataValidation{nil}, ws.(*xlsxWorksheet).DataValidations.DataValidation...)
+	ws.(*xlsxWorksheet).DataValidations.DataValidation[1].Sqref = "A"
+	assert.EqualError(t, f.CopyDataValidations("Sheet1", "Sheet3", CopyDataValidationOptions{RowOffset: 1}),
+		newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test copy data validations on not exists worksheet
+	assert.EqualError(t, f.CopyDataValidations("SheetN", "Sheet2"), "sheet SheetN does not exist")
+	assert.EqualError(t, f.CopyDataValidations("Sheet1", "SheetN"), "sheet SheetN does not exist")
+	// Test copy data validations with invalid sheet name
+	assert.EqualError(t, f.CopyDataValidations("Sheet:1", "Sheet2"), ErrSheetNameInvalid.Error())
+	assert.EqualError(t, f.CopyDataValidations("Sheet1", "Sheet:1"), ErrSheetNameInvalid.Error())
+
+	// Test copy data validations stored in the worksheet extension list
+	f = NewFile()
+	for _, sheet := range []string{"Sheet2", "Lists"} {
+		_, err = f.NewSheet(sheet)
+		assert.NoError(t, err)
+	}
+	dv = NewDataValidation(true)
+	dv.Sqref = "A1:A2"
+	dv.SetSqrefDropList("Lists!$A$1:$A$3")
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	dv = NewDataValidation(true)
+	dv.Sqref = "B1"
+	dv.SetSqrefDropList("Lists!$B$1:$B$3")
+	assert.NoError(t, f.AddDataValidation("Sheet2", dv))
+	assert.NoError(t, f.CopyDataValidations("Sheet1", "Sheet2", CopyDataValidationOptions{RowOffset: 2}))
+	dvs, err = f.GetDataValidations("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 2)
+	assert.Equal(t, "B1", dvs[0].Sqref)
+	assert.Equal(t, "A3:A4", dvs[1].Sqref)
+	assert.Equal(t, "Lists!$A$1:$A$3", dvs[1].Formula1)
+	dvs, err = f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:A2", dvs[0].Sqref)
+	// Test copy data validations with invalid worksheet extension list
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet2.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).ExtLst = &xlsxExtLst{Ext: "<ext"}
+	assert.Error(t, f.CopyDataValidations("Sheet1", "Sheet2"))
+	assert.Error(t, f.CopyDataValidations("Sheet2", "Sheet1"))
+	// Test copy data validations with invalid reference sequence in the
+	// worksheet extension list
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).ExtLst.Ext = strings.ReplaceAll(ws.(*xlsxWorksheet).ExtLst.Ext, "<xm:sqref>A1:A2</xm:sqref>", "<xm:sqref>A</xm:sqref>")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")),
+		f.CopyDataValidations("Sheet1", "Lists", CopyDataValidationOptions{RowOffset: 1}))
+	assert.NoError(t, f.Close())
+}
+
 func TestDeleteDataValidation(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.DeleteDataValidation("Sheet1", "A1:B2"))
@@ -15,7 +15,13 @@ import (
 	"bytes"
 	"encoding/xml"
 	"io"
+	"math"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 // SetAppProps provides a function to set document application properties. The
@@ -160,6 +166,14 @@ func (f *File) GetAppProps() (ret *AppProperties, err error) {
 //	                | represent in ISO 8601 UTC format, for example
 //	                | "2019-06-04T22:00:10Z".
 //	                |
+//	 CreatedTime    | The created time of the content of the resource, which
+//	                | will be used when the Created is empty. The timezone
+//	                | offset of the time will be preserved.
+//	                |
+//	 ModifiedTime   | The modified time of the content of the resource, which
+//	                | will be used when the Modified is empty. The timezone
+//	                | offset of the time will be preserved.
+//	                |
 //
 // For example:
 //
@@ -229,11 +243,11 @@ func (f *File) SetDocProps(docProperties *DocProperties) error {
 			mutable.FieldByName(field).SetString(val)
 		}
 	}
-	if docProperties.Created != "" {
-		newProps.Created = &xlsxDcTerms{Type: "dcterms:W3CDTF", Text: docProperties.Created}
+	if created := formatW3CDTF(docProperties.Created, docProperties.CreatedTime); created != "" {
+		newProps.Created = &xlsxDcTerms{Type: "dcterms:W3CDTF", Text: created}
 	}
-	if docProperties.Modified != "" {
-		newProps.Modified = &xlsxDcTerms{Type: "dcterms:W3CDTF", Text: docProperties.Modified}
+	if modified := formatW3CDTF(docProperties.Modified, docProperties.ModifiedTime); modified != "" {
+		newProps.Modified = &xlsxDcTerms{Type: "dcterms:W3CDTF", Text: modified}
 	}
 	output, err = xml.Marshal(newProps)
 	f.saveFileList(defaultXMLPathDocPropsCore, output)
@@ -241,7 +255,9 @@ func (f *File) SetDocProps(docProperties *DocProperties) error {
 	return err
 }
 
-// GetDocProps provides a function to get document core properties.
+// GetDocProps provides a function to get document core properties. The
+// CreatedTime and ModifiedTime will be the zero time if the created or
+// modified time are missing or not in the W3C date and time format.
 func (f *File) GetDocProps() (ret *DocProperties, err error) {
 	core := new(decodeCoreProperties)
 
@@ -265,9 +281,223 @@ func (f *File) GetDocProps() (ret *DocProperties, err error) {
 	}, nil
 	if core.Created != nil {
 		ret.Created = core.Created.Text
+		ret.CreatedTime = parseW3CDTF(core.Created.Text)
 	}
 	if core.Modified != nil {
 		ret.Modified = core.Modified.Text
+		ret.ModifiedTime = parseW3CDTF(core.Modified.Text)
 	}
 	return
 }
+
+// SetCustomDocProps provides a function to set document custom properties by
+// given map of the property name and value. The property with the same name
+// (case-insensitive) will be updated, and the property will be removed if the
+// value is nil. The supported value types and the variant types of the
+// property are:
+//
+//	 Value Type                  | Variant Type
+//	-----------------------------+--------------------------------------------
+//	 string                      | vt:lpwstr
+//	 bool                        | vt:bool
+//	 int, int8, int16, int32     | vt:i4, or vt:i8 if the value exceeds the
+//	 int64                       | 32-bit integer range
+//	 uint, uint8, uint16, uint32 | vt:ui4, or vt:ui8 if the value exceeds the
+//	 uint64                      | 32-bit unsigned integer range
+//	 float32, float64            | vt:r8
+//	 time.Time                   | vt:filetime
+//
+// For example:
+//
+//	err := f.SetCustomDocProps(map[string]interface{}{
+//	    "CostCenter":   "R&D",
+//	    "ReviewStatus": "Approved",
+//	    "Budget":       1250.5,
+//	    "Confidential": true,
+//	    "Reviewed":     time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+//	})
+func (f *File) SetCustomDocProps(props map[string]interface{}) error {
+	custom := new(xlsxCustomProperties)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathDocPropsCustom)))).
+		Decode(custom); err != nil && err != io.EOF {
+		return err
+	}
+	names := make([]string, 0, len(props))
+	for name := range props {
+		if name == "" || utf8.RuneCountInString(name) > MaxFieldLength {
+			return ErrParameterInvalid
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	pid := 1
+	for _, prop := range custom.Property {
+		if prop.PID > pid {
+			pid = prop.PID
+		}
+	}
+	for _, name := range names {
+		idx := -1
+		for i, prop := range custom.Property {
+			if strings.EqualFold(prop.Name, name) {
+				idx = i
+				break
+			}
+		}
+		if props[name] == nil {
+			if idx != -1 {
+				custom.Property = append(custom.Property[:idx], custom.Property[idx+1:]...)
+			}
+			continue
+		}
+		value, err := newCustomPropertyValue(props[name])
+		if err != nil {
+			return err
+		}
+		if idx != -1 {
+			custom.Property[idx].Value, custom.Property[idx].LinkTarget = value, ""
+			continue
+		}
+		pid++
+		custom.Property = append(custom.Property, xlsxCustomProperty{
+			FmtID: "{D5CDD505-2E9C-101B-9397-08002B2CF9AE}", PID: pid, Name: name, Value: value,
+		})
+	}
+	custom.Vt = NameSpaceDocumentPropertiesVariantTypes.Value
+	output, err := xml.Marshal(custom)
+	if err != nil {
+		return err
+	}
+	f.saveFileList(defaultXMLPathDocPropsCustom, output)
+	rels, err := f.relsReader("_rels/.rels")
+	if err != nil {
+		return err
+	}
+	var exist bool
+	if rels != nil {
+		rels.mu.Lock()
+		for _, rel := range rels.Relationships {
+			if rel.Type == SourceRelationshipCustomProperties {
+				exist = true
+				break
+			}
+		}
+		rels.mu.Unlock()
+	}
+	if !exist {
+		f.addRels("_rels/.rels", SourceRelationshipCustomProperties, defaultXMLPathDocPropsCustom, "")
+	}
+	return f.addContentTypePart(0, "customProperties")
+}
+
+// GetCustomDocProps provides a function to get document custom properties,
+// returns a map of the property name and value. The value will be a string
+// for the text variant types, a bool for vt:bool, an int for the signed
+// integer variant types, a uint for the unsigned integer variant types, a
+// float64 for the real number variant types and a time.Time for vt:filetime
+// and vt:date. The text content will be returned for other variant types.
+func (f *File) GetCustomDocProps() (map[string]interface{}, error) {
+	custom := new(xlsxCustomProperties)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathDocPropsCustom)))).
+		Decode(custom); err != nil && err != io.EOF {
+		return nil, err
+	}
+	props := make(map[string]interface{}, len(custom.Property))
+	for _, prop := range custom.Property {
+		value, err := parseCustomPropertyValue(prop.Value)
+		if err != nil {
+			return nil, err
+		}
+		props[prop.Name] = value
+	}
+	return props, nil
+}
+
+// newCustomPropertyValue returns the inner XML of the custom property with the
+// variant type element by given value.
+func newCustomPropertyValue(value interface{}) (string, error) {
+	var vt, text string
+	switch val := value.(type) {
+	case string:
+		vt, text = "lpwstr", val
+	case bool:
+		vt, text = "bool", strconv.FormatBool(val)
+	case int, int8, int16, int32, int64:
+		v := reflect.ValueOf(val).Int()
+		if vt, text = "i4", strconv.FormatInt(v, 10); v < math.MinInt32 || v > math.MaxInt32 {
+			vt = "i8"
+		}
+	case uint, uint8, uint16, uint32, uint64:
+		v := reflect.ValueOf(val).Uint()
+		if vt, text = "ui4", strconv.FormatUint(v, 10); v > math.MaxUint32 {
+			vt = "ui8"
+		}
+	case float32:
+		vt, text = "r8", strconv.FormatFloat(float64(val), 'f', -1, 32)
+	case float64:
+		vt, text = "r8", strconv.FormatFloat(val, 'f', -1, 64)
+	case time.Time:
+		vt, text = "filetime", val.UTC().Format(time.RFC3339)
+	default:
+		return "", ErrParameterInvalid
+	}
+	var buf bytes.Buffer
+	buf.WriteString("<vt:" + vt + ">")
+	if err := xml.EscapeText(&buf, []byte(text)); err != nil {
+		return "", err
+	}
+	buf.WriteString("</vt:" + vt + ">")
+	return buf.String(), nil
+}
+
+// parseCustomPropertyValue parse the inner XML of the custom property with
+// the variant type element, and returns the value in Go type.
+func parseCustomPropertyValue(content string) (interface{}, error) {
+	var value decodeCustomPropertyValue
+	if strings.TrimSpace(content) == "" {
+		return value.Val, nil
+	}
+	if err := xml.Unmarshal([]byte(content), &value); err != nil {
+		return nil, err
+	}
+	switch value.XMLName.Local {
+	case "bool":
+		return strconv.ParseBool(strings.TrimSpace(value.Val))
+	case "i1", "i2", "i4", "i8", "int":
+		return strconv.Atoi(strings.TrimSpace(value.Val))
+	case "ui1", "ui2", "ui4", "ui8", "uint":
+		v, err := strconv.ParseUint(strings.TrimSpace(value.Val), 10, 64)
+		return uint(v), err
+	case "r4", "r8", "decimal":
+		return strconv.ParseFloat(strings.TrimSpace(value.Val), 64)
+	case "filetime", "date":
+		return parseW3CDTF(value.Val), nil
+	}
+	return value.Val, nil
+}
+
+// formatW3CDTF returns the date time text in the W3C date and time format for
+// the core properties by given text and time, the text will be used if it is
+// not empty, and returns an empty string if both of them are empty.
+func formatW3CDTF(text string, t time.Time) string {
+	if text != "" || t.IsZero() {
+		return text
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+// parseW3CDTF parse the date time text in the W3C date and time format of the
+// core properties, the timezone offset will be preserved, and returns the zero
+// time if the text is empty or invalid.
+func parseW3CDTF(text string) time.Time {
+	text = strings.TrimSpace(text)
+	for _, layout := range []string{
+		time.RFC3339Nano, "2006-01-02T15:04Z07:00", "2006-01-02T15:04:05",
+		"2006-01-02", "2006-01", "2006",
+	} {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
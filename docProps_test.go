@@ -12,8 +12,12 @@
 package excelize
 
 import (
+	"encoding/xml"
+	"math"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -91,6 +95,27 @@ func TestSetDocProps(t *testing.T) {
 	assert.NoError(t, f.SetDocProps(&DocProperties{}))
 	assert.NoError(t, f.Close())
 
+	// Test set document properties with created and modified time
+	f = NewFile()
+	created := time.Date(2023, 3, 15, 8, 30, 0, 0, time.FixedZone("", 8*60*60))
+	modified := time.Date(2023, 3, 16, 9, 45, 30, 500000000, time.UTC)
+	assert.NoError(t, f.SetDocProps(&DocProperties{CreatedTime: created, ModifiedTime: modified}))
+	props, err := f.GetDocProps()
+	assert.NoError(t, err)
+	assert.Equal(t, "2023-03-15T08:30:00+08:00", props.Created)
+	assert.Equal(t, "2023-03-16T09:45:30.5Z", props.Modified)
+	assert.True(t, created.Equal(props.CreatedTime))
+	assert.True(t, modified.Equal(props.ModifiedTime))
+	_, offset := props.CreatedTime.Zone()
+	assert.Equal(t, 8*60*60, offset)
+	// Test the text of created and modified time takes precedence
+	assert.NoError(t, f.SetDocProps(&DocProperties{Created: "2019-06-04T22:00:10Z", CreatedTime: created}))
+	props, err = f.GetDocProps()
+	assert.NoError(t, err)
+	assert.Equal(t, "2019-06-04T22:00:10Z", props.Created)
+	assert.Equal(t, time.Date(2019, 6, 4, 22, 0, 10, 0, time.UTC), props.CreatedTime)
+	assert.Equal(t, "2023-03-16T09:45:30.5Z", props.Modified)
+
 	// Test unsupported charset
 	f = NewFile()
 	f.Pkg.Store(defaultXMLPathDocPropsCore, MacintoshCyrillicCharset)
@@ -105,9 +130,13 @@ func TestGetDocProps(t *testing.T) {
 	props, err := f.GetDocProps()
 	assert.NoError(t, err)
 	assert.Equal(t, props.Creator, "Microsoft Office User")
+	assert.False(t, props.CreatedTime.IsZero())
+	assert.False(t, props.ModifiedTime.IsZero())
 	f.Pkg.Store(defaultXMLPathDocPropsCore, nil)
-	_, err = f.GetDocProps()
+	props, err = f.GetDocProps()
 	assert.NoError(t, err)
+	assert.True(t, props.CreatedTime.IsZero())
+	assert.True(t, props.ModifiedTime.IsZero())
 	assert.NoError(t, f.Close())
 
 	// Test get workbook properties with unsupported charset
@@ -116,3 +145,115 @@ func TestGetDocProps(t *testing.T) {
 	_, err = f.GetDocProps()
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestCustomDocProps(t *testing.T) {
+	f := NewFile()
+	reviewed := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.NoError(t, f.SetCustomDocProps(map[string]interface{}{
+		"CostCenter":   "R&D",
+		"ReviewStatus": "Draft",
+		"Pages":        12,
+		"Size":         int64(math.MaxInt32) + 1,
+		"Revision":     uint8(3),
+		"Budget":       1250.5,
+		"Ratio":        float32(0.5),
+		"Confidential": true,
+		"Reviewed":     reviewed,
+	}))
+	// Test update the property by name without appending a duplicate
+	assert.NoError(t, f.SetCustomDocProps(map[string]interface{}{
+		"reviewstatus": "Approved",
+		"Pages":        nil,
+		"Ratio":        nil,
+	}))
+	props, err := f.GetCustomDocProps()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"CostCenter":   "R&D",
+		"ReviewStatus": "Approved",
+		"Size":         int(math.MaxInt32) + 1,
+		"Revision":     uint(3),
+		"Budget":       1250.5,
+		"Confidential": true,
+		"Reviewed":     reviewed,
+	}, props)
+	custom := new(xlsxCustomProperties)
+	assert.NoError(t, xml.Unmarshal(f.readXML(defaultXMLPathDocPropsCustom), custom))
+	assert.Len(t, custom.Property, 7)
+	assert.Equal(t, "<vt:lpwstr>R&amp;D</vt:lpwstr>", custom.Property[2].Value)
+	assert.Equal(t, "<vt:i8>2147483648</vt:i8>", custom.Property[6].Value)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCustomDocProps.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test get custom properties from the saved workbook
+	f, err = OpenFile(filepath.Join("test", "TestCustomDocProps.xlsx"))
+	assert.NoError(t, err)
+	rels, err := f.relsReader("_rels/.rels")
+	assert.NoError(t, err)
+	var count int
+	for _, rel := range rels.Relationships {
+		if rel.Type == SourceRelationshipCustomProperties {
+			count++
+		}
+	}
+	assert.Equal(t, 1, count)
+	assert.NoError(t, f.SetCustomDocProps(map[string]interface{}{"Pages": int8(1)}))
+	props, err = f.GetCustomDocProps()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, props["Pages"])
+	assert.Equal(t, "R&D", props["CostCenter"])
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	// Test get custom properties without the custom properties part
+	props, err = f.GetCustomDocProps()
+	assert.NoError(t, err)
+	assert.Empty(t, props)
+	// Test set custom properties with invalid property name and value type
+	assert.Equal(t, ErrParameterInvalid, f.SetCustomDocProps(map[string]interface{}{"": "x"}))
+	assert.Equal(t, ErrParameterInvalid, f.SetCustomDocProps(map[string]interface{}{strings.Repeat("c", MaxFieldLength+1): "x"}))
+	assert.Equal(t, ErrParameterInvalid, f.SetCustomDocProps(map[string]interface{}{"Complex": complex(1, 2)}))
+	// Test get custom properties with other variant types
+	f.Pkg.Store(defaultXMLPathDocPropsCustom, []byte(`<Properties xmlns="http://schemas.openxmlformats.org/officeDocument/2006/custom-properties" xmlns:vt="http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes"><property fmtid="{D5CDD505-2E9C-101B-9397-08002B2CF9AE}" pid="2" name="A"><vt:lpstr>a</vt:lpstr></property><property fmtid="{D5CDD505-2E9C-101B-9397-08002B2CF9AE}" pid="3" name="B"></property><property fmtid="{D5CDD505-2E9C-101B-9397-08002B2CF9AE}" pid="4" name="C"><vt:date>2023-01-02T03:04:05Z</vt:date></property></Properties>`))
+	props, err = f.GetCustomDocProps()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"A": "a", "B": "", "C": reviewed}, props)
+	// Test get custom properties with invalid value
+	f.Pkg.Store(defaultXMLPathDocPropsCustom, []byte(`<Properties xmlns="http://schemas.openxmlformats.org/officeDocument/2006/custom-properties"><property fmtid="{D5CDD505-2E9C-101B-9397-08002B2CF9AE}" pid="2" name="A"><vt:bool>x</vt:bool></property></Properties>`))
+	_, err = f.GetCustomDocProps()
+	assert.EqualError(t, err, `strconv.ParseBool: parsing "x": invalid syntax`)
+	_, err = parseCustomPropertyValue("<vt:bool>")
+	assert.EqualError(t, err, "XML syntax error on line 1: unexpected EOF")
+	// Test set and get custom properties with unsupported charset
+	f.Pkg.Store(defaultXMLPathDocPropsCustom, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCustomDocProps(map[string]interface{}{"A": "a"}), "XML syntax error on line 1: invalid UTF-8")
+	_, err = f.GetCustomDocProps()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test set custom properties with unsupported charset relationships
+	f.Pkg.Delete(defaultXMLPathDocPropsCustom)
+	f.Relationships.Delete("_rels/.rels")
+	f.Pkg.Store("_rels/.rels", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCustomDocProps(map[string]interface{}{"A": "a"}), "XML syntax error on line 1: invalid UTF-8")
+	// Test set custom properties with unsupported charset content types
+	f = NewFile()
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCustomDocProps(map[string]interface{}{"A": "a"}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestParseW3CDTF(t *testing.T) {
+	for text, expected := range map[string]time.Time{
+		"2019-06-04T22:00:10Z":         time.Date(2019, 6, 4, 22, 0, 10, 0, time.UTC),
+		"2019-06-04T22:00:10.25-05:00": time.Date(2019, 6, 4, 22, 0, 10, 250000000, time.FixedZone("", -5*60*60)),
+		"2019-06-04T22:00+01:00":       time.Date(2019, 6, 4, 22, 0, 0, 0, time.FixedZone("", 60*60)),
+		" 2019-06-04T22:00:10 ":        time.Date(2019, 6, 4, 22, 0, 10, 0, time.UTC),
+		"2019-06-04":                   time.Date(2019, 6, 4, 0, 0, 0, 0, time.UTC),
+		"2019-06":                      time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC),
+		"2019":                         time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+		"":                             {},
+		"invalid":                      {},
+	} {
+		assert.True(t, expected.Equal(parseW3CDTF(text)), text)
+	}
+}
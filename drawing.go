@@ -58,9 +58,10 @@ func (f *File) prepareChartSheetDrawing(cs *xlsxChartsheet, drawingID int, sheet
 // given format sets.
 func (f *File) addChart(opts *Chart, comboCharts []*Chart) {
 	count := f.countCharts()
+	date1904, _ := f.getDate1904()
 	xlsxChartSpace := xlsxChartSpace{
 		XMLNSa:         NameSpaceDrawingML.Value,
-		Date1904:       &attrValBool{Val: boolPtr(false)},
+		Date1904:       &attrValBool{Val: boolPtr(date1904)},
 		Lang:           &attrValString{Val: stringPtr("en-US")},
 		RoundedCorners: &attrValBool{Val: boolPtr(false)},
 		Chart: cChart{
@@ -90,12 +91,12 @@ func (f *File) addChart(opts *Chart, comboCharts []*Chart) {
 			DispBlanksAs:     &attrValString{Val: stringPtr(opts.ShowBlanksAs)},
 			ShowDLblsOverMax: &attrValBool{Val: boolPtr(false)},
 		},
-		SpPr: &cSpPr{
+		SpPr: f.drawChartFill(&opts.Fill, &cSpPr{
 			SolidFill: &aSolidFill{
 				SchemeClr: &aSchemeClr{Val: "bg1"},
 			},
 			Ln: f.drawChartLn(&opts.Border),
-		},
+		}),
 		PrintSettings: &cPrintSettings{
 			PageMargins: &cPageMargins{
 				B:      0.75,
@@ -174,6 +175,11 @@ func (f *File) addChart(opts *Chart, comboCharts []*Chart) {
 			if field.IsNil() {
 				continue
 			}
+			if axs, ok := field.Interface().([]*cAxs); ok {
+				target := immutable.FieldByName(mutable.Type().Field(i).Name)
+				target.Set(reflect.ValueOf(mergeChartAxes(target.Interface().([]*cAxs), axs)))
+				continue
+			}
 			immutable.FieldByName(mutable.Type().Field(i).Name).Set(field)
 		}
 	}
@@ -184,11 +190,31 @@ func (f *File) addChart(opts *Chart, comboCharts []*Chart) {
 		addChart(xlsxChartSpace.Chart.PlotArea, plotAreaFunc[comboCharts[idx].Type](comboCharts[idx]))
 		order += len(comboCharts[idx].Series)
 	}
+	xlsxChartSpace.Chart.PlotArea.SpPr = f.drawPlotAreaFill(&opts.PlotArea)
 	chart, _ := xml.Marshal(xlsxChartSpace)
 	media := "xl/charts/chart" + strconv.Itoa(count+1) + ".xml"
 	f.saveFileList(media, chart)
 }
 
+// mergeChartAxes provides a function to merge the axes of the combo chart into
+// the axes of the plot area, the axes with the same ID will be kept as the
+// existing one, so the secondary axes will not be lost or be duplicated when
+// there are multiple charts in the combo chart.
+func mergeChartAxes(axes, combo []*cAxs) []*cAxs {
+	for _, ax := range combo {
+		var exist bool
+		for _, existing := range axes {
+			if exist = existing.AxID != nil && ax.AxID != nil && *existing.AxID.Val == *ax.AxID.Val; exist {
+				break
+			}
+		}
+		if !exist {
+			axes = append(axes, ax)
+		}
+	}
+	return axes
+}
+
 // drawBaseChart provides a function to draw the c:plotArea element for bar,
 // and column series charts by given format sets.
 func (f *File) drawBaseChart(opts *Chart) *cPlotArea {
@@ -1075,12 +1101,15 @@ func (f *File) drawPlotAreaValAx(opts *Chart) []*cAxs {
 		axs = append(axs, &cAxs{
 			AxID: &attrValInt{Val: intPtr(opts.YAxis.axID)},
 			Scaling: &cScaling{
+				LogBase:     logBase,
 				Orientation: &attrValString{Val: stringPtr(orientation[opts.YAxis.ReverseOrder])},
 				Max:         max,
 				Min:         min,
 			},
-			Delete:        &attrValBool{Val: boolPtr(false)},
+			Delete:        &attrValBool{Val: boolPtr(opts.YAxis.None)},
 			AxPos:         &attrValString{Val: stringPtr("r")},
+			Title:         axs[0].Title,
+			NumFmt:        axs[0].NumFmt,
 			MajorTickMark: &attrValString{Val: stringPtr("none")},
 			MinorTickMark: &attrValString{Val: stringPtr("none")},
 			TickLblPos:    &attrValString{Val: stringPtr("nextTo")},
@@ -1089,6 +1118,7 @@ func (f *File) drawPlotAreaValAx(opts *Chart) []*cAxs {
 			CrossAx:       &attrValInt{Val: intPtr(opts.XAxis.axID)},
 			Crosses:       &attrValString{Val: stringPtr("max")},
 			CrossBetween:  &attrValString{Val: stringPtr(chartValAxCrossBetween[opts.Type])},
+			MajorUnit:     axs[0].MajorUnit,
 		})
 	}
 	return axs
@@ -1221,6 +1251,42 @@ func (f *File) drawPlotAreaTxPr(opts *ChartAxis) *cTxPr {
 	return cTxPr
 }
 
+// drawChartFill provides a function to set the fill of the c:spPr element by
+// given fill format sets. A pattern fill with one color will be drawn as the
+// solid fill, and a pattern fill without pattern will be drawn as no fill.
+func (f *File) drawChartFill(fill *Fill, spPr *cSpPr) *cSpPr {
+	if fill.Type != "pattern" {
+		return spPr
+	}
+	if fill.Pattern == 0 {
+		spPr.NoFill, spPr.SolidFill = stringPtr(""), nil
+		return spPr
+	}
+	if len(fill.Color) == 1 {
+		spPr.NoFill, spPr.SolidFill = nil, &aSolidFill{
+			SrgbClr: &attrValString{Val: stringPtr(strings.ToUpper(strings.TrimPrefix(fill.Color[0], "#")))},
+		}
+	}
+	return spPr
+}
+
+// drawPlotAreaFill provides a function to draw the c:spPr element of the plot
+// area by given format sets, returns nil if the fill and border of the plot
+// area are not specified.
+func (f *File) drawPlotAreaFill(opts *ChartPlotArea) *cSpPr {
+	spPr := f.drawChartFill(&opts.Fill, &cSpPr{})
+	if border := opts.Border; border.Type != ChartLineSolid || border.Width != 0 || len(border.Fill.Color) != 0 {
+		if border.Width == 0 {
+			border.Width = 0.75
+		}
+		spPr.Ln = f.drawChartLn(&border)
+	}
+	if spPr.NoFill == nil && spPr.SolidFill == nil && spPr.Ln == nil {
+		return nil
+	}
+	return spPr
+}
+
 // drawChartLn provides a function to draw the a:ln element.
 func (f *File) drawChartLn(opts *ChartLine) *aLn {
 	ln := &aLn{
@@ -1242,6 +1308,11 @@ func (f *File) drawChartLn(opts *ChartLine) *aLn {
 				},
 			},
 		}
+		if len(opts.Fill.Color) == 1 {
+			ln.SolidFill = &aSolidFill{
+				SrgbClr: &attrValString{Val: stringPtr(strings.ToUpper(strings.TrimPrefix(opts.Fill.Color[0], "#")))},
+			}
+		}
 		return ln
 	case ChartLineNone:
 		ln.NoFill = &attrValString{}
@@ -1280,6 +1351,11 @@ func (f *File) drawingParser(path string) (*xlsxWsDr, int, error) {
 					XMLNSMC: SourceRelationshipCompatibility.Value,
 				})
 			}
+			for _, v := range decodeWsDr.AbsoluteAnchor {
+				content.AbsoluteAnchor = append(content.AbsoluteAnchor, &xdrCellAnchor{
+					GraphicFrame: v.Content,
+				})
+			}
 			for _, v := range decodeWsDr.OneCellAnchor {
 				content.OneCellAnchor = append(content.OneCellAnchor, &xdrCellAnchor{
 					EditAs:       v.EditAs,
@@ -14,6 +14,7 @@ package excelize
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 var (
@@ -23,17 +24,30 @@ var (
 	// ErrAttrValBool defined the error message on marshal and unmarshal
 	// boolean type XML attribute.
 	ErrAttrValBool = errors.New("unexpected child of attrValBool")
+	// ErrAutoFilterCriteria defined the error message on receiving more than
+	// one type of filter criteria for the same auto filter column.
+	ErrAutoFilterCriteria = errors.New("only one type of filter criteria can be set for an auto filter column")
 	// ErrCellCharsLength defined the error message for receiving a cell
 	// characters length that exceeds the limit.
 	ErrCellCharsLength = fmt.Errorf("cell value must be 0-%d characters", TotalCellChars)
 	// ErrCellStyles defined the error message on cell styles exceeds the limit.
 	ErrCellStyles = fmt.Errorf("the cell styles exceeds the %d limit", MaxCellStyles)
+	// ErrChartMarkerSize defined the error message on receive an invalid chart
+	// marker size.
+	ErrChartMarkerSize = fmt.Errorf("the chart marker size must be between %d and %d", MinChartMarkerSize, MaxChartMarkerSize)
+	// ErrCircularReference defined the error message on the formula refers to
+	// its own cell directly or indirectly without iterative calculation
+	// enabled.
+	ErrCircularReference = errors.New("circular reference detected")
 	// ErrColumnNumber defined the error message on receive an invalid column
 	// number.
 	ErrColumnNumber = fmt.Errorf("the column number must be greater than or equal to %d and less than or equal to %d", MinColumns, MaxColumns)
 	// ErrColumnWidth defined the error message on receive an invalid column
 	// width.
 	ErrColumnWidth = fmt.Errorf("the width of the column must be less than or equal to %d characters", MaxColumnWidth)
+	// ErrCommentBoxSize defined the error message on receive an invalid width
+	// or height of the comment box.
+	ErrCommentBoxSize = fmt.Errorf("the width and height of the comment box must be less than or equal to %d pixels", MaxCommentBoxSize)
 	// ErrCoordinates defined the error message on invalid coordinates tuples
 	// length.
 	ErrCoordinates = errors.New("coordinates length must be 4")
@@ -55,6 +69,9 @@ var (
 	ErrExistsSheet = errors.New("the same name sheet already exists")
 	// ErrExistsTableName defined the error message on given table already exists.
 	ErrExistsTableName = errors.New("the same name table already exists")
+	// ErrFilteredHeaderRange defined the error message on the filtered header
+	// range without any data row.
+	ErrFilteredHeaderRange = errors.New("the filtered header range must contain a header row and at least one data row")
 	// ErrFontLength defined the error message on the length of the font
 	// family name overflow.
 	ErrFontLength = fmt.Errorf("the length of the font family name must be less than or equal to %d", MaxFontFamilyLength)
@@ -114,6 +131,9 @@ var (
 	// ErrSheetNameSingleQuote defined the error message on the first or last
 	// character of the sheet name was a single quote.
 	ErrSheetNameSingleQuote = errors.New("the first or last character of the sheet name can not be a single quote")
+	// ErrSortRangeMergeCell defined the error message on sorting the range
+	// which contains merged cells.
+	ErrSortRangeMergeCell = errors.New("can not sort the range which contains merged cells")
 	// ErrSparkline defined the error message on receive the invalid sparkline
 	// parameters.
 	ErrSparkline = errors.New("must have the same number of 'Location' and 'Range' parameters")
@@ -135,6 +155,9 @@ var (
 	// ErrStreamSetPanes defined the error message on set panes in stream
 	// writing mode.
 	ErrStreamSetPanes = errors.New("must call the SetPanes function before the SetRow function")
+	// ErrThreadedCommentExists defined the error message on add a threaded
+	// comment to the cell which already has a threaded comment.
+	ErrThreadedCommentExists = errors.New("the cell already has a threaded comment")
 	// ErrTotalSheetHyperlinks defined the error message on hyperlinks count
 	// overflow.
 	ErrTotalSheetHyperlinks = errors.New("over maximum limit hyperlinks in a worksheet")
@@ -168,6 +191,12 @@ var (
 	// ErrWorkbookPassword defined the error message on receiving the incorrect
 	// workbook password.
 	ErrWorkbookPassword = errors.New("the supplied open workbook password is not correct")
+	// ErrWriteToZipEncrypt defined the error message on write the workbook
+	// with password to the zip writer.
+	ErrWriteToZipEncrypt = errors.New("unsupported encryption when writing the workbook to the zip writer")
+	// ErrZoomScale defined the error message on receive an invalid zoom scale
+	// of the worksheet view.
+	ErrZoomScale = errors.New("zoom scale must be between 10 and 400")
 )
 
 // ErrSheetNotExist defined an error of sheet that does not exist.
@@ -180,6 +209,12 @@ func (err ErrSheetNotExist) Error() string {
 	return fmt.Sprintf("sheet %s does not exist", err.SheetName)
 }
 
+// newCalcCellError defined the error message on calculating the formula of
+// the cell failed.
+func newCalcCellError(sheet, cell string, err error) error {
+	return fmt.Errorf("failed to calculate cell %s!%s: %v", sheet, cell, err)
+}
+
 // newCellNameToCoordinatesError defined the error message on converts
 // alphanumeric cell name to coordinates.
 func newCellNameToCoordinatesError(cell string, err error) error {
@@ -222,12 +257,24 @@ func newInvalidCellNameError(cell string) error {
 	return fmt.Errorf("invalid cell name %q", cell)
 }
 
+// newInvalidChartFillColorError defined the error message on receiving the
+// invalid fill color of the chart.
+func newInvalidChartFillColorError(color string) error {
+	return fmt.Errorf("invalid chart fill color %q", color)
+}
+
 // newInvalidColumnNameError defined the error message on receiving the
 // invalid column name.
 func newInvalidColumnNameError(col string) error {
 	return fmt.Errorf("invalid column name %q", col)
 }
 
+// newInvalidCommentFillColorError defined the error message on receiving the
+// invalid fill color of the comment box.
+func newInvalidCommentFillColorError(color string) error {
+	return fmt.Errorf("invalid comment fill color %q", color)
+}
+
 // newInvalidExcelDateError defined the error message on receiving the data
 // with negative values.
 func newInvalidExcelDateError(dateValue float64) error {
@@ -258,18 +305,48 @@ func newInvalidSlicerNameError(name string) error {
 	return fmt.Errorf("invalid slicer name %q", name)
 }
 
+// newInvalidSortKeyColumnError defined the error message on receiving the
+// sort key column which is out of the sort range.
+func newInvalidSortKeyColumnError(col string) error {
+	return fmt.Errorf("sort key column %q is out of the sort range", col)
+}
+
 // newInvalidStyleID defined the error message on receiving the invalid style
 // ID.
 func newInvalidStyleID(styleID int) error {
 	return fmt.Errorf("invalid style ID %d", styleID)
 }
 
+// newMergeCellOverlapError defined the error message on merging cells
+// overlapped with the existing merged cells.
+func newMergeCellOverlapError(ref, overlapRef string) error {
+	return fmt.Errorf("the range %s overlaps with the existing merged cells %s", ref, overlapRef)
+}
+
+// newMergeCellsError defined the error message on merging multiple ranges of
+// cells with invalid or overlapped ranges.
+func newMergeCellsError(msgs []string) error {
+	return fmt.Errorf("failed to merge cells: %s", strings.Join(msgs, "; "))
+}
+
 // newNoExistTableError defined the error message on receiving the non existing
 // table name.
 func newNoExistTableError(name string) error {
 	return fmt.Errorf("table %s does not exist", name)
 }
 
+// newNoExistTableColumnError defined the error message on receiving the non
+// existing table column name.
+func newNoExistTableColumnError(name string) error {
+	return fmt.Errorf("table column %s does not exist", name)
+}
+
+// newNotEmptyTotalsRowError defined the error message on the totals row of
+// the table contains the non-empty cell.
+func newNotEmptyTotalsRowError(cell string) error {
+	return fmt.Errorf("cell %s in the totals row of the table is not empty", cell)
+}
+
 // newNotWorksheetError defined the error message on receiving a sheet which
 // not a worksheet.
 func newNotWorksheetError(name string) error {
@@ -306,6 +383,24 @@ func newUnsupportedChartType(chartType ChartType) error {
 	return fmt.Errorf("unsupported chart type %d", chartType)
 }
 
+// newUnsupportedChartMarkerSymbol defined the error message on receiving the
+// chart marker symbol are unsupported.
+func newUnsupportedChartMarkerSymbol(symbol string) error {
+	return fmt.Errorf("unsupported chart marker symbol %s", symbol)
+}
+
+// newUnsupportedGridLineColorError defined the error message on receiving the
+// grid lines color which not in the indexed color palette.
+func newUnsupportedGridLineColorError(color string) error {
+	return fmt.Errorf("grid lines color %s is not in the indexed color palette", color)
+}
+
+// newUnsupportedTotalsRowFunctionError defined the error message on receiving
+// the totals row function of the table column are unsupported.
+func newUnsupportedTotalsRowFunctionError(function string) error {
+	return fmt.Errorf("unsupported totals row function %s", function)
+}
+
 // newUnzipSizeLimitError defined the error message on unzip size exceeds the
 // limit.
 func newUnzipSizeLimitError(unzipSizeLimit int64) error {
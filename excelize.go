@@ -38,6 +38,7 @@ type File struct {
 	streams          map[string]*StreamWriter
 	tempFiles        sync.Map
 	xmlAttr          sync.Map
+	zipFiles         sync.Map
 	CalcChain        *xlsxCalcChain
 	CharsetReader    charsetTranscoderFn
 	Comments         map[string]*xlsxComments
@@ -64,9 +65,23 @@ type charsetTranscoderFn func(charset string, input io.Reader) (rdr io.Reader, e
 // Options define the options for opening and reading the spreadsheet.
 //
 // MaxCalcIterations specifies the maximum iterations for iterative
-// calculation, the default value is 0.
+// calculation, the default value is 0. The formula calculation functions return
+// the error ErrCircularReference when a formula refers to its own cell through
+// other formula cells and this value is 0, and the formula refers to its own
+// cell directly uses the current value of the cell. The functions which only
+// use the references of the arguments, such as ROW, COLUMNS and ISFORMULA, will
+// not cause the circular reference.
 //
-// Password specifies the password of the spreadsheet in plain text.
+// MaxCalcChange specifies the maximum amount of change between two iterations
+// for iterative calculation, the iterative calculation stops when all values
+// change by less than this amount, the default value is 0.001.
+//
+// Password specifies the password of the spreadsheet in plain text. The
+// encrypted spreadsheet will be decrypted on opening with this password, and
+// the error ErrWorkbookPassword will be returned if the password is not
+// correct or not specified. On saving, the spreadsheet will be encrypted
+// with this password by ECMA-376 agile encryption, and saved as a plain ZIP
+// package if the password is empty.
 //
 // RawCellValue specifies if apply the number format for the cell value or get
 // the raw value.
@@ -76,10 +91,11 @@ type charsetTranscoderFn func(charset string, input io.Reader) (rdr io.Reader, e
 // UnzipXMLSizeLimit, the default size limit is 16GB.
 //
 // UnzipXMLSizeLimit specifies the memory limit on unzipping worksheet and
-// shared string table in bytes, worksheet XML will be extracted to system
-// temporary directory when the file size is over this value, this value
-// should be less than or equal to UnzipSizeLimit, the default value is
-// 16MB.
+// shared string table in bytes, worksheet XML will not be unzipped when
+// opening the spreadsheet and will be decoded from the archive on demand, and
+// shared string table will be extracted to system temporary directory when the
+// file size is over this value, this value should be less than or equal to
+// UnzipSizeLimit, the default value is 16MB.
 //
 // ShortDatePattern specifies the short date number format code. In the
 // spreadsheet applications, date formats display date and time serial numbers
@@ -95,16 +111,51 @@ type charsetTranscoderFn func(charset string, input io.Reader) (rdr io.Reader, e
 //
 // CultureInfo specifies the country code for applying built-in language number
 // format code these effect by the system's local language settings.
+//
+// UpdateSheetDimension specifies if recalculate the used range of the
+// worksheets by scanning the non-blank cells on saving the spreadsheet, the
+// default value is false. The worksheets written by the stream writer will not
+// be recalculated.
+//
+// AutoHyperlink specifies if set the external hyperlink for the cell
+// automatically when setting the string value which begins with http://,
+// https:// or mailto: by the SetCellValue function, the default value is
+// false.
+//
+// IgnoreErrors specifies if the formula calculation functions skip the error
+// values in the arguments and the ranges of the AVERAGE, AVERAGEA, MAX, MAXA,
+// MIN, MINA and SUM functions instead of returning the error, the default
+// value is false. This option only works with the options of the
+// CalcCellValue and CalcSheet functions.
+//
+// InlineStrings specifies if store the string type cell values set by the
+// SetCellValue or SetCellStr functions as inline strings in the worksheet
+// instead of the shared string table, for the consumers which don't support
+// the shared string table, the default value is false. Note that the
+// identical strings are stored once in the shared string table when this
+// option was disabled, so enable this option may increase the file size.
+//
+// Indent specifies the indent string for pretty-printing the XML parts of the
+// spreadsheet on saving, such as two spaces or a tab, which makes the parts
+// human-readable for debugging and comparing. The default value is empty, and
+// the XML parts will be written in compact. The text of the elements will not
+// be altered by the indents.
 type Options struct {
-	MaxCalcIterations uint
-	Password          string
-	RawCellValue      bool
-	UnzipSizeLimit    int64
-	UnzipXMLSizeLimit int64
-	ShortDatePattern  string
-	LongDatePattern   string
-	LongTimePattern   string
-	CultureInfo       CultureName
+	MaxCalcIterations    uint
+	MaxCalcChange        float64
+	Password             string
+	RawCellValue         bool
+	UnzipSizeLimit       int64
+	UnzipXMLSizeLimit    int64
+	ShortDatePattern     string
+	LongDatePattern      string
+	LongTimePattern      string
+	CultureInfo          CultureName
+	UpdateSheetDimension bool
+	AutoHyperlink        bool
+	IgnoreErrors         bool
+	InlineStrings        bool
+	Indent               string
 }
 
 // OpenFile take the name of a spreadsheet file and returns a populated
@@ -113,6 +164,44 @@ type Options struct {
 //
 //	f, err := excelize.OpenFile("Book1.xlsx", excelize.Options{Password: "password"})
 //
+// The XLSB spreadsheet will be converted into the XLSX spreadsheet in memory
+// when opening. The converted spreadsheet could be read and modified as the
+// XLSX spreadsheet, and must be saved in the XLSX, XLSM, XLTX or XLTM format.
+// Note that the conversion is lossy, only the cell values, merged cells, row
+// heights, column widths and the visibility of rows, columns and sheets will
+// be kept:
+//
+//  1. Formulas in the binary worksheets will be kept as their cached results.
+//  2. Only the number formats of the cell styles will be kept, the fonts,
+//     fills, borders and alignments of all cells will be reset to the
+//     default style.
+//  3. Rich text and phonetic properties of the shared strings will be kept
+//     as plain text.
+//  4. Defined names, workbook views and calculation properties of the
+//     workbook will be dropped.
+//  5. Relationships parts of the binary worksheets will be removed, so the
+//     hyperlinks, comments, drawings, charts, pictures, tables and pivot
+//     tables in the worksheets will be dropped, and other binary parts which
+//     couldn't be converted will be removed from the workbook.
+//  6. Sheet views, panes, conditional formats, data validations and page
+//     settings of the worksheets will be dropped.
+//
+// For example, open the XLSB spreadsheet and save it as XLSX spreadsheet:
+//
+//	f, err := excelize.OpenFile("Book1.xlsb")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	value, err := f.GetCellValue("Sheet1", "A1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(value)
+//	if err := f.SaveAs("Book1.xlsx"); err != nil {
+//	    fmt.Println(err)
+//	}
+//
 // Close the file by Close function after opening the spreadsheet.
 func OpenFile(filename string, opts ...Options) (*File, error) {
 	file, err := os.Open(filepath.Clean(filename))
@@ -138,6 +227,7 @@ func newFile() *File {
 		checked:          sync.Map{},
 		sheetMap:         make(map[string]string),
 		tempFiles:        sync.Map{},
+		zipFiles:         sync.Map{},
 		Comments:         make(map[string]*xlsxComments),
 		Drawings:         sync.Map{},
 		sharedStringsMap: make(map[string]int),
@@ -182,8 +272,11 @@ func OpenReader(r io.Reader, opts ...Options) (*File, error) {
 	if err = f.checkOpenReaderOptions(); err != nil {
 		return nil, err
 	}
-	if bytes.Contains(b, oleIdentifier) {
+	if bytes.HasPrefix(b, oleIdentifier) {
 		if b, err = Decrypt(b, f.options); err != nil {
+			if err == ErrWorkbookPassword {
+				return nil, err
+			}
 			return nil, ErrWorkbookFileFormat
 		}
 	}
@@ -202,6 +295,9 @@ func OpenReader(r io.Reader, opts ...Options) (*File, error) {
 	for k, v := range file {
 		f.Pkg.Store(k, v)
 	}
+	if err = f.convertXLSB(); err != nil {
+		return f, err
+	}
 	if f.CalcChain, err = f.calcChainReader(); err != nil {
 		return f, err
 	}
@@ -1415,6 +1415,75 @@ func TestUnprotectSheet(t *testing.T) {
 	assert.EqualError(t, f.UnprotectSheet(sheetName, "wrongPassword"), "illegal base64 data at input byte 8")
 }
 
+func TestProtectedRange(t *testing.T) {
+	f := NewFile()
+	sddl := "O:WDG:WDD:(A;;CC;;;S-1-5-21-1234567890-1234567890-1234567890-1001)"
+	assert.NoError(t, f.AddProtectedRange("Sheet1", &ProtectedRangeOptions{Name: "Range1", Range: "B2:C3 E5", Password: "password"}))
+	assert.NoError(t, f.AddProtectedRange("Sheet1", &ProtectedRangeOptions{Name: "Range2", Range: "D1:D10", Password: "password", AlgorithmName: "SHA-512"}))
+	assert.NoError(t, f.AddProtectedRange("Sheet1", &ProtectedRangeOptions{Name: "Range3", Range: "F1", SecurityDescriptor: sddl}))
+	assert.NoError(t, f.ProtectSheet("Sheet1", &SheetProtectionOptions{Password: "password"}))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, genSheetPasswd("password"), ws.ProtectedRanges.ProtectedRange[0].Password)
+	hashValue, _, err := genISOPasswdHash("password", "SHA-512", ws.ProtectedRanges.ProtectedRange[1].SaltValue, int(sheetProtectionSpinCount))
+	assert.NoError(t, err)
+	assert.Equal(t, hashValue, ws.ProtectedRanges.ProtectedRange[1].HashValue)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestProtectedRange.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestProtectedRange.xlsx"))
+	assert.NoError(t, err)
+	protectedRanges, err := f.GetProtectedRanges("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []ProtectedRangeOptions{
+		{Name: "Range1", Range: "B2:C3 E5"},
+		{Name: "Range2", Range: "D1:D10", AlgorithmName: "SHA-512"},
+		{Name: "Range3", Range: "F1", SecurityDescriptor: sddl},
+	}, protectedRanges)
+	// Test replace protected range with the same name
+	assert.NoError(t, f.AddProtectedRange("Sheet1", &ProtectedRangeOptions{Name: "range1", Range: "A1"}))
+	protectedRanges, err = f.GetProtectedRanges("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, protectedRanges, 3)
+	assert.Equal(t, ProtectedRangeOptions{Name: "range1", Range: "A1"}, protectedRanges[0])
+	// Test get protected range with security descriptor attribute
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.ProtectedRanges.ProtectedRange[2].SecurityDescriptorElement = nil
+	ws.ProtectedRanges.ProtectedRange[2].SecurityDescriptorAttr = sddl
+	protectedRanges, err = f.GetProtectedRanges("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, sddl, protectedRanges[2].SecurityDescriptor)
+	// Test delete protected range
+	assert.NoError(t, f.DeleteProtectedRange("Sheet1", "Range2"))
+	assert.NoError(t, f.DeleteProtectedRange("Sheet1", "RangeN"))
+	protectedRanges, err = f.GetProtectedRanges("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, protectedRanges, 2)
+	assert.NoError(t, f.DeleteProtectedRange("Sheet1", "range1"))
+	assert.NoError(t, f.DeleteProtectedRange("Sheet1", "Range3"))
+	assert.Nil(t, ws.ProtectedRanges)
+	assert.NoError(t, f.AddProtectedRange("Sheet1", &ProtectedRangeOptions{Name: "Range1", Range: "A1"}))
+	assert.NoError(t, f.DeleteProtectedRange("Sheet1"))
+	assert.NoError(t, f.DeleteProtectedRange("Sheet1"))
+	protectedRanges, err = f.GetProtectedRanges("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, protectedRanges)
+	// Test add protected range with invalid options
+	assert.Equal(t, ErrParameterInvalid, f.AddProtectedRange("Sheet1", nil))
+	assert.Equal(t, ErrParameterRequired, f.AddProtectedRange("Sheet1", &ProtectedRangeOptions{Range: "A1"}))
+	assert.Equal(t, ErrParameterRequired, f.AddProtectedRange("Sheet1", &ProtectedRangeOptions{Name: "Range1"}))
+	assert.Equal(t, ErrNameLength, f.AddProtectedRange("Sheet1", &ProtectedRangeOptions{Name: strings.Repeat("s", MaxFieldLength+1), Range: "A1"}))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.AddProtectedRange("Sheet1", &ProtectedRangeOptions{Name: "Range1", Range: "A"}))
+	assert.Equal(t, ErrUnsupportedHashAlgorithm, f.AddProtectedRange("Sheet1", &ProtectedRangeOptions{Name: "Range1", Range: "A1", Password: "password", AlgorithmName: "RIPEMD-160"}))
+	// Test protected range on not exists worksheet
+	assert.EqualError(t, f.AddProtectedRange("SheetN", &ProtectedRangeOptions{Name: "Range1", Range: "A1"}), "sheet SheetN does not exist")
+	_, err = f.GetProtectedRanges("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.EqualError(t, f.DeleteProtectedRange("SheetN"), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
 func TestProtectWorkbook(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.ProtectWorkbook(nil))
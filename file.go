@@ -65,7 +65,12 @@ func (f *File) Save(opts ...Options) error {
 }
 
 // SaveAs provides a function to create or update to a spreadsheet at the
-// provided path.
+// provided path. The spreadsheet will be encrypted by ECMA-376 agile
+// encryption and saved in the OLE compound file container if the password
+// was specified in the options. For example, save the workbook with password
+// protection:
+//
+//	err := f.SaveAs("Book1.xlsx", excelize.Options{Password: "password"})
 func (f *File) SaveAs(name string, opts ...Options) error {
 	if len(name) > MaxFilePathLength {
 		return ErrMaxFilePathLength
@@ -108,19 +113,14 @@ func (f *File) Write(w io.Writer, opts ...Options) error {
 	return err
 }
 
-// WriteTo implements io.WriterTo to write the file.
+// WriteTo implements io.WriterTo to write the file, and returns the number of
+// bytes written. The workbook parts will be written to the writer directly
+// without buffering the whole archive in memory, unless the workbook needs to
+// be encrypted with password. The worksheets generated by the StreamWriter
+// will be copied from the temporary files to the writer directly.
 func (f *File) WriteTo(w io.Writer, opts ...Options) (int64, error) {
-	for i := range opts {
-		f.options = &opts[i]
-	}
-	if len(f.Path) != 0 {
-		contentType, ok := supportedContentTypes[strings.ToLower(filepath.Ext(f.Path))]
-		if !ok {
-			return 0, ErrWorkbookFileFormat
-		}
-		if err := f.setContentTypePartProjectExtensions(contentType); err != nil {
-			return 0, err
-		}
+	if err := f.prepareWrite(opts...); err != nil {
+		return 0, err
 	}
 	if f.options != nil && f.options.Password != "" {
 		buf, err := f.WriteToBuffer()
@@ -129,10 +129,73 @@ func (f *File) WriteTo(w io.Writer, opts ...Options) (int64, error) {
 		}
 		return buf.WriteTo(w)
 	}
-	if err := f.writeDirectToWriter(w); err != nil {
-		return 0, err
+	cw := &countWriter{w: w}
+	err := f.writeDirectToWriter(cw)
+	return cw.n, err
+}
+
+// countWriter is a writer that counts the number of bytes written to the
+// underlying writer.
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+// Write writes the bytes to the underlying writer and counts the number of
+// bytes written.
+func (cw *countWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+// WriteToZip provides a function to write all parts of the workbook to the
+// given zip.Writer, which allows streaming the workbook to a HTTP response
+// or other writer with constant memory. This function doesn't close the zip
+// writer, please call the Close function of the zip writer after this
+// function returns, and don't add any other entries into the zip writer, so
+// that the produced archive contains the workbook parts only. The parts of
+// the workbook have no ordering requirements in the archive, and the
+// worksheets generated by the StreamWriter will be copied from their
+// temporary storage. Note that encrypt the workbook with password is not
+// supported in this mode. For example, serve the workbook over HTTP:
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//	    f := excelize.NewFile()
+//	    defer f.Close()
+//	    w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+//	    zw := zip.NewWriter(w)
+//	    if err := f.WriteToZip(zw); err != nil {
+//	        fmt.Println(err)
+//	    }
+//	    if err := zw.Close(); err != nil {
+//	        fmt.Println(err)
+//	    }
+//	}
+func (f *File) WriteToZip(zw *zip.Writer, opts ...Options) error {
+	if err := f.prepareWrite(opts...); err != nil {
+		return err
+	}
+	if f.options != nil && f.options.Password != "" {
+		return ErrWriteToZipEncrypt
+	}
+	return f.writeToZip(zw)
+}
+
+// prepareWrite provides a function to apply the options and set the content
+// type of the workbook by the file extension before writing.
+func (f *File) prepareWrite(opts ...Options) error {
+	for i := range opts {
+		f.options = &opts[i]
 	}
-	return 0, nil
+	if len(f.Path) != 0 {
+		contentType, ok := supportedContentTypes[strings.ToLower(filepath.Ext(f.Path))]
+		if !ok {
+			return ErrWorkbookFileFormat
+		}
+		return f.setContentTypePartProjectExtensions(contentType)
+	}
+	return nil
 }
 
 // WriteToBuffer provides a function to get bytes.Buffer from the saved file,
@@ -186,45 +249,96 @@ func (f *File) writeToZip(zw *zip.Writer) error {
 	f.styleSheetWriter()
 	f.themeWriter()
 
+	// Write the package metadata parts first, so that the parts could be
+	// resolved while reading the archive sequentially.
+	metaParts := []string{defaultXMLPathContentTypes, "_rels/.rels"}
+	for _, path := range metaParts {
+		if content, ok := f.Pkg.Load(path); ok {
+			b, _ := content.([]byte)
+			if err := f.writeZipPart(zw, path, bytes.NewReader(b)); err != nil {
+				return err
+			}
+		}
+	}
 	for path, stream := range f.streams {
-		fi, err := zw.Create(path)
+		from, err := stream.rawData.Reader()
 		if err != nil {
-			return err
-		}
-		var from io.Reader
-		if from, err = stream.rawData.Reader(); err != nil {
 			_ = stream.rawData.Close()
 			return err
 		}
-		if _, err = io.Copy(fi, from); err != nil {
+		if err = f.writeZipPart(zw, path, from); err != nil {
 			return err
 		}
 	}
 	var err error
 	f.Pkg.Range(func(path, content interface{}) bool {
-		if err != nil {
-			return false
-		}
-		if _, ok := f.streams[path.(string)]; ok {
+		if _, ok := f.streams[path.(string)]; ok || inStrSlice(metaParts, path.(string), true) != -1 {
 			return true
 		}
-		var fi io.Writer
-		if fi, err = zw.Create(path.(string)); err != nil {
-			return false
+		b, _ := content.([]byte)
+		err = f.writeZipPart(zw, path.(string), bytes.NewReader(b))
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+	f.tempFiles.Range(func(path, _ interface{}) bool {
+		if _, ok := f.Pkg.Load(path); ok {
+			return true
 		}
-		_, err = fi.Write(content.([]byte))
-		return true
+		err = f.writeZipPart(zw, path.(string), bytes.NewReader(f.readBytes(path.(string))))
+		return err == nil
 	})
-	f.tempFiles.Range(func(path, content interface{}) bool {
+	if err != nil {
+		return err
+	}
+	f.zipFiles.Range(func(path, zipFile interface{}) bool {
 		if _, ok := f.Pkg.Load(path); ok {
 			return true
 		}
-		var fi io.Writer
-		if fi, err = zw.Create(path.(string)); err != nil {
+		if _, ok := f.streams[path.(string)]; ok {
+			return true
+		}
+		var from io.ReadCloser
+		if from, err = zipFile.(*zip.File).Open(); err != nil {
 			return false
 		}
-		_, err = fi.Write(f.readBytes(path.(string)))
-		return true
+		if err = f.writeZipPart(zw, path.(string), from); err != nil {
+			_ = from.Close()
+			return false
+		}
+		err = from.Close()
+		return err == nil
 	})
 	return err
 }
+
+// writeZipPart provides a function to create the part in the zip writer by
+// given part path, and copy the content of the part from the given reader.
+func (f *File) writeZipPart(zw *zip.Writer, path string, from io.Reader) error {
+	fi, err := zw.Create(path)
+	if err != nil {
+		return err
+	}
+	if f.options != nil && f.options.Indent != "" {
+		content, err := io.ReadAll(from)
+		if err != nil {
+			return err
+		}
+		from = bytes.NewReader(f.indentPart(path, content))
+	}
+	_, err = io.Copy(fi, from)
+	return err
+}
+
+// indentPart provides a function to pretty-print the XML part by given part
+// path and content if the indent option has been set.
+func (f *File) indentPart(path string, content []byte) []byte {
+	if f.options == nil || f.options.Indent == "" {
+		return content
+	}
+	if ext := strings.ToLower(filepath.Ext(path)); ext != ".xml" && ext != ".rels" {
+		return content
+	}
+	return indentXML(content, f.options.Indent)
+}
@@ -1,8 +1,10 @@
 package excelize
 
 import (
+	"archive/zip"
 	"bufio"
 	"bytes"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -90,6 +92,156 @@ func TestWriteTo(t *testing.T) {
 	}
 }
 
+func TestWriteToStreaming(t *testing.T) {
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"), Options{UnzipXMLSizeLimit: 128})
+	assert.NoError(t, err)
+	// Test write the workbook and returns the number of bytes written
+	buf := new(bytes.Buffer)
+	n, err := f.WriteTo(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+	assert.NoError(t, f.Close())
+	// Test the package metadata parts will be written first
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	assert.Equal(t, defaultXMLPathContentTypes, zr.File[0].Name)
+	assert.Equal(t, "_rels/.rels", zr.File[1].Name)
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	val, err := f.GetCellValue("Sheet1", "A19")
+	assert.NoError(t, err)
+	assert.Equal(t, "Total:", val)
+	assert.NoError(t, f.Close())
+	// Test write the encrypted workbook and returns the number of bytes written
+	f, buf = NewFile(), new(bytes.Buffer)
+	n, err = f.WriteTo(buf, Options{Password: "password"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+	assert.NoError(t, f.Close())
+}
+
+func TestWriteToZip(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Hello"))
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"Stream"}))
+	assert.NoError(t, sw.Flush())
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	assert.NoError(t, f.WriteToZip(zw))
+	assert.NoError(t, zw.Close())
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Stream", val)
+	assert.NoError(t, f.Close())
+	// Test write to zip writer with the worksheet which has not been extracted
+	f, err = OpenFile(filepath.Join("test", "Book1.xlsx"), Options{UnzipXMLSizeLimit: 128})
+	assert.NoError(t, err)
+	buf.Reset()
+	zw = zip.NewWriter(buf)
+	assert.NoError(t, f.WriteToZip(zw))
+	assert.NoError(t, zw.Close())
+	_, ok := f.Pkg.Load("xl/worksheets/sheet1.xml")
+	assert.False(t, ok)
+	// Test write to zip writer with unsupported compression method of the
+	// worksheet which has not been extracted
+	raw := new(bytes.Buffer)
+	rzw := zip.NewWriter(raw)
+	_, err = rzw.CreateRaw(&zip.FileHeader{Name: "xl/worksheets/sheet1.xml", Method: 99})
+	assert.NoError(t, err)
+	assert.NoError(t, rzw.Close())
+	rzr, err := zip.NewReader(bytes.NewReader(raw.Bytes()), int64(raw.Len()))
+	assert.NoError(t, err)
+	f.zipFiles.Store("xl/worksheets/sheet1.xml", rzr.File[0])
+	assert.Equal(t, zip.ErrAlgorithm, f.WriteToZip(zip.NewWriter(io.Discard)))
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(bytes.NewReader(buf.Bytes()), Options{UnzipXMLSizeLimit: 128})
+	assert.NoError(t, err)
+	val, err = f.GetCellValue("Sheet1", "A19")
+	assert.NoError(t, err)
+	assert.Equal(t, "Total:", val)
+	// Test write to zip writer after deleting the worksheet which has not been
+	// extracted
+	assert.NoError(t, f.DeleteSheet("Sheet2"))
+	buf.Reset()
+	zw = zip.NewWriter(buf)
+	assert.NoError(t, f.WriteToZip(zw))
+	assert.NoError(t, zw.Close())
+	assert.NoError(t, f.Close())
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	for _, file := range zr.File {
+		assert.NotEqual(t, "xl/worksheets/sheet2.xml", file.Name)
+	}
+	// Test write to zip writer with password
+	f = NewFile()
+	assert.Equal(t, ErrWriteToZipEncrypt, f.WriteToZip(zip.NewWriter(buf), Options{Password: "password"}))
+	// Test write to zip writer with unsupported workbook file format
+	f.Path = "Book1.xls"
+	assert.Equal(t, ErrWorkbookFileFormat, f.WriteToZip(zip.NewWriter(buf)))
+}
+
+func TestWriteWithIndent(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "  Hello  "))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", 100))
+	assert.NoError(t, f.SetCellRichText("Sheet1", "A3", []RichTextRun{{Text: " bold ", Font: &Font{Bold: true}}, {Text: "\ttext\n"}}))
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	sw, err := f.NewStreamWriter("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{" Stream "}))
+	assert.NoError(t, sw.Flush())
+	// Test the XML parts will be written in compact by default
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	for _, file := range zr.File {
+		if file.Name == "xl/worksheets/sheet1.xml" {
+			content, err := readFile(file)
+			assert.NoError(t, err)
+			assert.Equal(t, 1, strings.Count(string(content), "\n"))
+		}
+	}
+	buf.Reset()
+	assert.NoError(t, f.Write(buf, Options{Indent: "  "}))
+	assert.NoError(t, f.Close())
+	zr, err = zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	for _, file := range zr.File {
+		content, err := readFile(file)
+		assert.NoError(t, err)
+		switch file.Name {
+		case "xl/worksheets/sheet1.xml":
+			assert.Contains(t, string(content), "\n  <sheetData>\n    <row r=\"1\">\n      <c r=\"A1\" t=\"s\">\n        <v>0</v>\n      </c>")
+		case "xl/worksheets/sheet2.xml":
+			assert.Contains(t, string(content), "\n      <c r=\"A1\" t=\"inlineStr\">\n        <is>\n          <t xml:space=\"preserve\"> Stream </t>\n        </is>\n      </c>")
+		case "xl/sharedStrings.xml":
+			assert.Contains(t, string(content), "\n  <si>\n    <t xml:space=\"preserve\">  Hello  </t>\n  </si>")
+		}
+	}
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	for cell, expected := range map[string]string{"A1": "  Hello  ", "A2": "100", "A3": " bold \ttext\n"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val)
+	}
+	runs, err := f.GetCellRichText("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Len(t, runs, 2)
+	assert.Equal(t, " bold ", runs[0].Text)
+	val, err := f.GetCellValue("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, " Stream ", val)
+	assert.NoError(t, f.Close())
+}
+
 func TestClose(t *testing.T) {
 	f := NewFile()
 	f.tempFiles.Store("/d/", "/d/")
@@ -22,11 +22,15 @@ import (
 	"math/big"
 	"os"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 )
 
-// ReadZipReader extract spreadsheet with given options.
+// ReadZipReader extract spreadsheet with given options. The worksheets which
+// exceed the UnzipXMLSizeLimit will not be extracted, and will be decoded from
+// the given zip reader on demand, so the zip reader should be readable until
+// the spreadsheet closed.
 func (f *File) ReadZipReader(r *zip.Reader) (map[string][]byte, int, error) {
 	var (
 		err     error
@@ -60,13 +64,8 @@ func (f *File) ReadZipReader(r *zip.Reader) (map[string][]byte, int, error) {
 		if strings.HasPrefix(strings.ToLower(fileName), "xl/worksheets/sheet") {
 			worksheets++
 			if fileSize > f.options.UnzipXMLSizeLimit && !v.FileInfo().IsDir() {
-				tempFile, err := f.unzipToTemp(v)
-				if tempFile != "" {
-					f.tempFiles.Store(fileName, tempFile)
-				}
-				if err == nil {
-					continue
-				}
+				f.zipFiles.Store(fileName, v)
+				continue
 			}
 		}
 		if fileList[fileName], err = readFile(v); err != nil {
@@ -114,7 +113,7 @@ func (f *File) readBytes(name string) []byte {
 		return content
 	}
 	file, err := f.readTemp(name)
-	if err != nil {
+	if err != nil || file == nil {
 		return content
 	}
 	content, _ = io.ReadAll(file)
@@ -123,8 +122,12 @@ func (f *File) readBytes(name string) []byte {
 	return content
 }
 
-// readTemp read file from system temporary directory by given path.
-func (f *File) readTemp(name string) (file *os.File, err error) {
+// readTemp read file from system temporary directory or the archive entry
+// which has not been extracted by given path.
+func (f *File) readTemp(name string) (file io.ReadCloser, err error) {
+	if zipFile, ok := f.zipFiles.Load(name); ok {
+		return zipFile.(*zip.File).Open()
+	}
 	path, ok := f.tempFiles.Load(name)
 	if !ok {
 		return
@@ -139,6 +142,36 @@ func (f *File) saveFileList(name string, content []byte) {
 	f.Pkg.Store(name, append([]byte(xml.Header), content...))
 }
 
+// countPartsWithPrefix provides a function to get the count of the numbered
+// parts by given part path prefix, the largest part ID will be returned if it
+// is greater than the count.
+func (f *File) countPartsWithPrefix(prefix string) int {
+	parts := map[string]struct{}{}
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if name := k.(string); strings.HasPrefix(name, prefix) {
+			parts[name] = struct{}{}
+		}
+		return true
+	})
+	return countNumberedParts(parts, prefix, ".xml")
+}
+
+// countNumberedParts provides a function to get the count of the numbered
+// parts by given part paths, prefix and suffix of the part path, the largest
+// part ID will be returned if it is greater than the count.
+func countNumberedParts(parts map[string]struct{}, prefix, suffix string) int {
+	count, maxID := len(parts), 0
+	for name := range parts {
+		if id, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(name, prefix), suffix)); err == nil && id > maxID {
+			maxID = id
+		}
+	}
+	if maxID > count {
+		return maxID
+	}
+	return count
+}
+
 // Read file content as string in an archive file.
 func readFile(file *zip.File) ([]byte, error) {
 	rc, err := file.Open()
@@ -342,7 +375,7 @@ func (f *File) coordinatesToRangeRef(coordinates []int, abs ...bool) (string, er
 func (f *File) getDefinedNameRefTo(definedNameName, currentSheet string) (refTo string) {
 	var workbookRefTo, worksheetRefTo string
 	for _, definedName := range f.GetDefinedName() {
-		if definedName.Name == definedNameName {
+		if strings.EqualFold(definedName.Name, definedNameName) {
 			// worksheet scope takes precedence over scope workbook when both definedNames exist
 			if definedName.Scope == "Workbook" {
 				workbookRefTo = definedName.RefersTo
@@ -437,6 +470,15 @@ func uintPtr(i uint) *uint { return &i }
 // float64Ptr returns a pointer to a float64 with the given value.
 func float64Ptr(f float64) *float64 { return &f }
 
+// intValue returns the value of the given integer pointer, and returns 0 if
+// the pointer is nil.
+func intValue(i *int) int {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
+
 // stringPtr returns a pointer to a string with the given value.
 func stringPtr(s string) *string { return &s }
 
@@ -555,10 +597,27 @@ func (ext *xlsxExt) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	return nil
 }
 
+// sortExtLst provides a function to sort the extensions by the given URI
+// priority. Extensions with an unrecognized URI keep their original relative
+// order and are placed after the recognized extensions, so they could be
+// preserved as is on round-trip.
+func sortExtLst(ext []*xlsxExt, priority []string) {
+	rank := func(URI string) int {
+		if idx := inStrSlice(priority, URI, false); idx != -1 {
+			return idx
+		}
+		return len(priority)
+	}
+	sort.SliceStable(ext, func(i, j int) bool {
+		return rank(ext[i].URI) < rank(ext[j].URI)
+	})
+}
+
 // namespaceStrictToTransitional provides a method to convert Strict and
 // Transitional namespaces.
 func namespaceStrictToTransitional(content []byte) []byte {
 	namespaceTranslationDic := map[string]string{
+		StrictNameSpaceCustomProperties:               NameSpaceCustomProperties,
 		StrictNameSpaceDocumentPropertiesVariantTypes: NameSpaceDocumentPropertiesVariantTypes.Value,
 		StrictNameSpaceDrawingMLMain:                  NameSpaceDrawingMLMain,
 		StrictNameSpaceExtendedProperties:             NameSpaceExtendedProperties,
@@ -750,6 +809,91 @@ func (f *File) addSheetNameSpace(sheet string, ns xml.Attr) {
 	f.addNameSpaces(name, ns)
 }
 
+// indentXML provides a function to pretty-print the XML content by given
+// indent string. The line breaks and indents will be inserted between the
+// adjacent tags only, so the text of the elements will not be altered, and
+// the elements which contain text or have the xml:space="preserve" attribute
+// will be kept in a single line.
+func indentXML(content []byte, indent string) []byte {
+	type element struct{ inline bool }
+	var (
+		buf                bytes.Buffer
+		stack              []element
+		prevText, prevOpen bool
+	)
+	buf.Grow(len(content) + len(content)/4)
+	for i := 0; i < len(content); {
+		if content[i] != '<' {
+			end := bytes.IndexByte(content[i:], '<')
+			if end == -1 {
+				end = len(content) - i
+			}
+			text := content[i : i+end]
+			if len(stack) > 0 && len(bytes.TrimSpace(text)) > 0 {
+				stack[len(stack)-1].inline = true
+			}
+			buf.Write(text)
+			i, prevText = i+end, true
+			continue
+		}
+		var end int
+		switch {
+		case bytes.HasPrefix(content[i:], []byte("<!--")):
+			if end = bytes.Index(content[i:], []byte("-->")); end != -1 {
+				end += len("-->")
+			}
+		case bytes.HasPrefix(content[i:], []byte("<![CDATA[")):
+			if end = bytes.Index(content[i:], []byte("]]>")); end != -1 {
+				end += len("]]>")
+			}
+			if len(stack) > 0 {
+				stack[len(stack)-1].inline = true
+			}
+		default:
+			var quote byte
+			for end = 1; i+end < len(content); end++ {
+				if c := content[i+end]; quote != 0 {
+					if c == quote {
+						quote = 0
+					}
+				} else if c == '"' || c == '\'' {
+					quote = c
+				} else if c == '>' {
+					break
+				}
+			}
+			end++
+		}
+		if end <= 0 || i+end > len(content) {
+			buf.Write(content[i:])
+			break
+		}
+		tag := content[i : i+end]
+		isEnd, isStart := bytes.HasPrefix(tag, []byte("</")), false
+		if !isEnd && !bytes.HasSuffix(tag, []byte("/>")) && tag[1] != '?' && tag[1] != '!' {
+			isStart = true
+		}
+		level, inline := len(stack), len(stack) > 0 && stack[len(stack)-1].inline
+		if isEnd {
+			level--
+		}
+		if !prevText && buf.Len() > 0 && !inline && !(isEnd && prevOpen) && level >= 0 {
+			buf.WriteByte('\n')
+			buf.WriteString(strings.Repeat(indent, level))
+		}
+		buf.Write(tag)
+		if isEnd && len(stack) > 0 {
+			stack = stack[:len(stack)-1]
+		}
+		if isStart {
+			stack = append(stack, element{inline: inline ||
+				bytes.Contains(tag, []byte(`xml:space="preserve"`)) || bytes.Contains(tag, []byte(`xml:space='preserve'`))})
+		}
+		i, prevText, prevOpen = i+end, false, isStart
+	}
+	return buf.Bytes()
+}
+
 // isNumeric determines whether an expression is a valid numeric type and get
 // the precision for the numeric.
 func isNumeric(s string) (bool, int, float64) {
@@ -307,6 +307,24 @@ func TestStack(t *testing.T) {
 	assert.Equal(t, s.Pop(), nil)
 }
 
+func TestIndentXML(t *testing.T) {
+	for _, c := range [][]string{
+		{`<a><b x="1>2"/><c>text</c><d></d></a>`, "<a>\n  <b x=\"1>2\"/>\n  <c>text</c>\n  <d></d>\n</a>"},
+		{`<?xml version="1.0"?><a><!-- c --><b/></a>`, "<?xml version=\"1.0\"?>\n<a>\n  <!-- c -->\n  <b/>\n</a>"},
+		{`<si><t xml:space="preserve"> a </t><t xml:space="preserve"></t></si>`, "<si>\n  <t xml:space=\"preserve\"> a </t>\n  <t xml:space=\"preserve\"></t>\n</si>"},
+		{`<p><r><t>a</t></r><r><t xml:space='preserve'><x/><y/></t></r></p>`, "<p>\n  <r>\n    <t>a</t>\n  </r>\n  <r>\n    <t xml:space='preserve'><x/><y/></t>\n  </r>\n</p>"},
+		{`<a>text<b/><c/></a>`, `<a>text<b/><c/></a>`},
+		{`<a><![CDATA[<b></b>]]><b/></a>`, `<a><![CDATA[<b></b>]]><b/></a>`},
+		{"<a>\n <b/>\n</a>", "<a>\n <b/>\n</a>"},
+		{`<a><b`, "<a><b"},
+		{`<a><!-- b`, "<a><!-- b"},
+		{`<a><![CDATA[ b`, "<a><![CDATA[ b"},
+		{`</a></b>`, "</a></b>"},
+	} {
+		assert.Equal(t, c[1], string(indentXML([]byte(c[0]), "  ")), c[0])
+	}
+}
+
 func TestGenXMLNamespace(t *testing.T) {
 	assert.Equal(t, genXMLNamespace([]xml.Attr{
 		{Name: xml.Name{Space: NameSpaceXML, Local: "space"}, Value: "preserve"},
@@ -11,7 +11,10 @@
 
 package excelize
 
-import "strings"
+import (
+	"sort"
+	"strings"
+)
 
 // Rect gets merged cell rectangle coordinates sequence.
 func (mc *xlsxMergeCell) Rect() ([]int, error) {
@@ -34,21 +37,8 @@ func (mc *xlsxMergeCell) Rect() ([]int, error) {
 //	err := f.MergeCell("Sheet1", "D3", "E9")
 //
 // If you create a merged cell that overlaps with another existing merged cell,
-// those merged cells that already exist will be removed. The cell references
-// tuple after merging in the following range will be: A1(x3,y1) D1(x2,y1)
-// A8(x3,y4) D8(x2,y4)
-//
-//	             B1(x1,y1)      D1(x2,y1)
-//	           +------------------------+
-//	           |                        |
-//	A4(x3,y3)  |    C4(x4,y3)           |
-//	+------------------------+          |
-//	|          |             |          |
-//	|          |B5(x1,y2)    | D5(x2,y2)|
-//	|          +------------------------+
-//	|                        |
-//	|A8(x3,y4)      C8(x4,y4)|
-//	+------------------------+
+// an error will be returned, and merging the same range as an existing merged
+// cell will be ignored.
 func (f *File) MergeCell(sheet, hCell, vCell string) error {
 	rect, err := rangeRefToCoordinates(hCell + ":" + vCell)
 	if err != nil {
@@ -68,11 +58,133 @@ func (f *File) MergeCell(sheet, hCell, vCell string) error {
 	defer ws.mu.Unlock()
 	ref := hCell + ":" + vCell
 	if ws.MergeCells != nil {
+		for _, mergeCell := range ws.MergeCells.Cells {
+			if mergeCell == nil {
+				continue
+			}
+			mergeRect, err := mergeCell.Rect()
+			if err != nil {
+				return err
+			}
+			_ = sortCoordinates(mergeRect)
+			if mergeRect[0] == rect[0] && mergeRect[1] == rect[1] && mergeRect[2] == rect[2] && mergeRect[3] == rect[3] {
+				return err
+			}
+			if isOverlap(rect, mergeRect) {
+				return newMergeCellOverlapError(ref, mergeCell.Ref)
+			}
+		}
 		ws.MergeCells.Cells = append(ws.MergeCells.Cells, &xlsxMergeCell{Ref: ref, rect: rect})
 	} else {
 		ws.MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: ref, rect: rect}}}
 	}
-	ws.MergeCells.Count = len(ws.MergeCells.Cells)
+	ws.MergeCells.Count, ws.MergeCells.merged = len(ws.MergeCells.Cells), false
+	return err
+}
+
+// MergeCells provides a function to merge multiple ranges of cells in a
+// single pass by given worksheet name and range references, it is faster
+// than calling MergeCell for each range when adding a large number of merged
+// cells. The duplicate ranges and the ranges identical to the existing merged
+// cells will be ignored. If any range is invalid or overlaps with another
+// range or the existing merged cells, an error naming all these ranges will
+// be returned, and none of the ranges will be merged. For example, merge the
+// cells A1:C1 and A2:C2 on Sheet1:
+//
+//	err := f.MergeCells("Sheet1", []string{"A1:C1", "A2:C2"})
+func (f *File) MergeCells(sheet string, ranges []string) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	type mergeRange struct {
+		idx  int
+		ref  string
+		rect []int
+	}
+	var (
+		items, newItems []mergeRange
+		errs            = map[int]string{}
+		refs            = map[[4]int]bool{}
+	)
+	if ws.MergeCells != nil {
+		for _, mergeCell := range ws.MergeCells.Cells {
+			if mergeCell == nil {
+				continue
+			}
+			rect, err := mergeCell.Rect()
+			if err != nil {
+				return err
+			}
+			_ = sortCoordinates(rect)
+			refs[[4]int{rect[0], rect[1], rect[2], rect[3]}] = true
+			items = append(items, mergeRange{idx: -1, ref: mergeCell.Ref, rect: rect})
+		}
+	}
+	for idx, ref := range ranges {
+		if !strings.Contains(ref, ":") {
+			ref += ":" + ref
+		}
+		rect, err := rangeRefToCoordinates(ref)
+		if err != nil {
+			errs[idx] = err.Error()
+			continue
+		}
+		_ = sortCoordinates(rect)
+		if key := [4]int{rect[0], rect[1], rect[2], rect[3]}; !refs[key] {
+			refs[key] = true
+			hCell, _ := CoordinatesToCellName(rect[0], rect[1])
+			vCell, _ := CoordinatesToCellName(rect[2], rect[3])
+			newItems = append(newItems, mergeRange{idx: idx, ref: hCell + ":" + vCell, rect: rect})
+		}
+	}
+	// Check the overlap by sweeping the ranges sorted by the top row, only the
+	// ranges which haven't ended before the current top row are compared.
+	items = append(items, newItems...)
+	sort.SliceStable(items, func(i, j int) bool { return items[i].rect[1] < items[j].rect[1] })
+	var active []mergeRange
+	for _, item := range items {
+		n := 0
+		for _, a := range active {
+			if a.rect[3] < item.rect[1] {
+				continue
+			}
+			active[n] = a
+			n++
+			if item.rect[0] > a.rect[2] || a.rect[0] > item.rect[2] || (item.idx == -1 && a.idx == -1) {
+				continue
+			}
+			idx, ref, overlapRef := item.idx, item.ref, a.ref
+			if idx == -1 || (a.idx != -1 && a.idx > idx) {
+				idx, ref, overlapRef = a.idx, a.ref, item.ref
+			}
+			if _, ok := errs[idx]; !ok {
+				errs[idx] = newMergeCellOverlapError(ref, overlapRef).Error()
+			}
+		}
+		active = append(active[:n], item)
+	}
+	if len(errs) > 0 {
+		msgs := make([]string, 0, len(errs))
+		for idx := range ranges {
+			if msg, ok := errs[idx]; ok {
+				msgs = append(msgs, msg)
+			}
+		}
+		return newMergeCellsError(msgs)
+	}
+	if len(newItems) == 0 {
+		return err
+	}
+	if ws.MergeCells == nil {
+		ws.MergeCells = &xlsxMergeCells{}
+	}
+	for _, item := range newItems {
+		ws.MergeCells.Cells = append(ws.MergeCells.Cells, &xlsxMergeCell{Ref: item.ref, rect: item.rect})
+	}
+	ws.MergeCells.Count, ws.MergeCells.merged = len(ws.MergeCells.Cells), false
 	return err
 }
 
@@ -129,25 +241,48 @@ func (f *File) UnmergeCell(sheet, hCell, vCell string) error {
 }
 
 // GetMergeCells provides a function to get all merged cells from a worksheet
-// currently.
+// currently. The overlapped merged cells will be combined only once until the
+// merged cells in the worksheet have been modified, and the values of the
+// merged cells are read in a single pass of the worksheet data.
 func (f *File) GetMergeCells(sheet string) ([]MergeCell, error) {
 	var mergeCells []MergeCell
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return mergeCells, err
 	}
-	if ws.MergeCells != nil {
-		if err = f.mergeOverlapCells(ws); err != nil {
-			return mergeCells, err
-		}
-		mergeCells = make([]MergeCell, 0, len(ws.MergeCells.Cells))
-		for i := range ws.MergeCells.Cells {
-			ref := ws.MergeCells.Cells[i].Ref
-			cell := strings.Split(ref, ":")[0]
-			val, _ := f.GetCellValue(sheet, cell)
-			mergeCells = append(mergeCells, []string{ref, val})
+	if ws.MergeCells == nil {
+		return mergeCells, err
+	}
+	if err = f.mergeOverlapCells(ws); err != nil {
+		return mergeCells, err
+	}
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return mergeCells, err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	values := make(map[string]string, len(ws.MergeCells.Cells))
+	for _, mergeCell := range ws.MergeCells.Cells {
+		values[strings.ToUpper(strings.Split(mergeCell.Ref, ":")[0])] = ""
+	}
+	for rowIdx := range ws.SheetData.Row {
+		rowData := &ws.SheetData.Row[rowIdx]
+		for colIdx := range rowData.C {
+			colData := &rowData.C[colIdx]
+			if _, ok := values[colData.R]; !ok {
+				continue
+			}
+			if values[colData.R], err = colData.getValueFrom(f, sst, false); err != nil {
+				return mergeCells, err
+			}
 		}
 	}
+	mergeCells = make([]MergeCell, 0, len(ws.MergeCells.Cells))
+	for _, mergeCell := range ws.MergeCells.Cells {
+		ref := mergeCell.Ref
+		mergeCells = append(mergeCells, []string{ref, values[strings.ToUpper(strings.Split(ref, ":")[0])]})
+	}
 	return mergeCells, err
 }
 
@@ -214,8 +349,12 @@ func flatMergedCells(ws *xlsxWorksheet, matrix [][]*xlsxMergeCell) error {
 	return nil
 }
 
-// mergeOverlapCells merge overlap cells.
+// mergeOverlapCells merge overlap cells. The merged cells will be skipped if
+// they haven't been modified since the last merge.
 func (f *File) mergeOverlapCells(ws *xlsxWorksheet) error {
+	if ws.MergeCells.merged {
+		return nil
+	}
 	rows, cols, err := overlapRange(ws)
 	if err != nil {
 		return err
@@ -241,7 +380,7 @@ func (f *File) mergeOverlapCells(ws *xlsxWorksheet) error {
 			}
 		}
 	}
-	ws.MergeCells.Count, ws.MergeCells.Cells = len(mergeCells), mergeCells
+	ws.MergeCells.Count, ws.MergeCells.Cells, ws.MergeCells.merged = len(mergeCells), mergeCells, true
 	return nil
 }
 
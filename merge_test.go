@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"fmt"
 	"path/filepath"
 	"testing"
 
@@ -15,23 +16,30 @@ func TestMergeCell(t *testing.T) {
 	assert.EqualError(t, f.MergeCell("Sheet1", "A", "B"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
 	for _, cells := range [][]string{
 		{"D9", "D9"},
-		{"D9", "E9"},
+		{"E9", "F9"},
 		{"H14", "G13"},
-		{"C9", "D8"},
-		{"F11", "G13"},
-		{"H7", "B15"},
-		{"D11", "F13"},
+		{"C9", "C8"},
+		{"F11", "F13"},
+		{"D11", "E13"},
 		{"G10", "K12"},
 	} {
 		assert.NoError(t, f.MergeCell("Sheet1", cells[0], cells[1]))
 	}
+	// Test merge cells with the same range as an existing merged cell
+	assert.NoError(t, f.MergeCell("Sheet1", "K12", "G10"))
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 7)
+	// Test merge cells overlapped with the existing merged cells
+	assert.Equal(t, newMergeCellOverlapError("B7:H15", "D9:D9"), f.MergeCell("Sheet1", "H7", "B15"))
+	assert.Equal(t, newMergeCellOverlapError("F8:F14", "E9:F9"), f.MergeCell("Sheet1", "F8", "F14"))
 	assert.NoError(t, f.SetCellValue("Sheet1", "G11", "set value in merged cell"))
 	assert.NoError(t, f.SetCellInt("Sheet1", "H11", 100))
 	assert.NoError(t, f.SetCellValue("Sheet1", "I11", 0.5))
 	assert.NoError(t, f.SetCellHyperLink("Sheet1", "J11", "https://github.com/xuri/excelize", "External"))
 	assert.NoError(t, f.SetCellFormula("Sheet1", "G12", "SUM(Sheet1!B19,Sheet1!C19)"))
 	value, err := f.GetCellValue("Sheet1", "H11")
-	assert.Equal(t, "100", value)
+	assert.Equal(t, "0.5", value)
 	assert.NoError(t, err)
 	// Merged cell ref is single coordinate
 	value, err = f.GetCellValue("Sheet2", "A6")
@@ -47,24 +55,27 @@ func TestMergeCell(t *testing.T) {
 	for _, cells := range [][]string{
 		{"D11", "F13"},
 		{"G10", "K12"},
-		{"B1", "D5"}, // B1:D5
-		{"E1", "F5"}, // E1:F5
+		{"B1", "D5"},
+		{"E1", "F5"},
 		{"H2", "I5"},
-		{"I4", "J6"}, // H2:J6
 		{"M2", "N5"},
-		{"L4", "M6"}, // L2:N6
-		{"P4", "Q7"},
-		{"O2", "P5"}, // O2:Q7
+		{"O2", "P5"},
 		{"A9", "B12"},
-		{"B7", "C9"}, // A7:C12
 		{"E9", "F10"},
+		{"M8", "Q13"},
+	} {
+		assert.NoError(t, f.MergeCell("Sheet3", cells[0], cells[1]))
+	}
+	for _, cells := range [][]string{
+		{"I4", "J6"},
+		{"L4", "M6"},
+		{"P4", "Q7"},
+		{"B7", "C9"},
 		{"D8", "G12"},
 		{"I8", "I12"},
-		{"I10", "K10"},
-		{"M8", "Q13"},
 		{"N10", "O11"},
 	} {
-		assert.NoError(t, f.MergeCell("Sheet3", cells[0], cells[1]))
+		assert.Error(t, f.MergeCell("Sheet3", cells[0], cells[1]))
 	}
 
 	// Test merge cells on not exists worksheet
@@ -92,7 +103,17 @@ func TestMergeCell(t *testing.T) {
 func TestMergeCellOverlap(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.MergeCell("Sheet1", "A1", "C2"))
-	assert.NoError(t, f.MergeCell("Sheet1", "B2", "D3"))
+	assert.Equal(t, newMergeCellOverlapError("B2:D3", "A1:C2"), f.MergeCell("Sheet1", "B2", "D3"))
+	assert.NoError(t, f.MergeCell("Sheet1", "C4", "C6"))
+	// Test merge cells crossed with the existing merged cell
+	assert.Equal(t, newMergeCellOverlapError("A5:E5", "C4:C6"), f.MergeCell("Sheet1", "A5", "E5"))
+	// Test merge cells with invalid existing merged cell reference
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).MergeCells.Cells = append(ws.(*xlsxWorksheet).MergeCells.Cells, &xlsxMergeCell{Ref: "A"})
+	assert.EqualError(t, f.MergeCell("Sheet1", "E1", "F2"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test combine the overlapped merged cells in the existing worksheet
+	ws.(*xlsxWorksheet).MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: "A1:C2"}, {Ref: "B2:D3"}}}
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestMergeCellOverlap.xlsx")))
 
 	f, err := OpenFile(filepath.Join("test", "TestMergeCellOverlap.xlsx"))
@@ -108,6 +129,51 @@ func TestMergeCellOverlap(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestMergeCells(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.MergeCell("Sheet1", "A1", "C1"))
+	var ranges []string
+	for row := 2; row <= 2001; row++ {
+		ranges = append(ranges, fmt.Sprintf("C%d:A%d", row, row))
+	}
+	// Test merge cells with duplicate ranges and the existing merged cell
+	assert.NoError(t, f.MergeCells("Sheet1", append(ranges, "A1:C1", "A2:C2", "E5")))
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 2002)
+	assert.Equal(t, "A2:C2", mergeCells[1][0])
+	assert.Equal(t, "E5:E5", mergeCells[2001][0])
+	// Test merge cells with invalid and overlapped ranges
+	err = f.MergeCells("Sheet1", []string{"F1:G2", "A", "B2:D3", "G2:H3", "J1:J2", "D2000:D2001"})
+	assert.EqualError(t, err, newMergeCellsError([]string{
+		newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error(),
+		newMergeCellOverlapError("B2:D3", "A2:C2").Error(),
+		newMergeCellOverlapError("G2:H3", "F1:G2").Error(),
+	}).Error())
+	// Test no partial result written on merge cells failed
+	mergeCells, err = f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 2002)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestMergeCells.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	// Test merge cells on the worksheet without merged cells
+	assert.NoError(t, f.MergeCells("Sheet1", nil))
+	assert.NoError(t, f.MergeCells("Sheet1", []string{"B2:A1"}))
+	mergeCells, err = f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []MergeCell{{"A1:B2", ""}}, mergeCells)
+	// Test merge cells on not exists worksheet
+	assert.EqualError(t, f.MergeCells("SheetN", []string{"A1:B2"}), "sheet SheetN does not exist")
+	// Test merge cells with invalid existing merged cell reference
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.MergeCells.Cells = append(ws.MergeCells.Cells, nil, &xlsxMergeCell{Ref: "A"})
+	assert.EqualError(t, f.MergeCells("Sheet1", []string{"D1:E2"}), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.NoError(t, f.Close())
+}
+
 func TestGetMergeCells(t *testing.T) {
 	wants := []struct {
 		value string
@@ -157,6 +223,42 @@ func TestGetMergeCells(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestGetMergeCellsCache(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.MergeCell("Sheet1", "A1", "B2"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "A1"))
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []MergeCell{{"A1:B2", "A1"}}, mergeCells)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, ws.MergeCells.merged)
+	// Test merged cells cache invalidation on merge cells
+	assert.NoError(t, f.MergeCell("Sheet1", "D1", "E2"))
+	assert.False(t, ws.MergeCells.merged)
+	mergeCells, err = f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []MergeCell{{"A1:B2", "A1"}, {"D1:E2", ""}}, mergeCells)
+	assert.True(t, ws.MergeCells.merged)
+	// Test merged cells cache invalidation on insert rows
+	assert.NoError(t, f.InsertRows("Sheet1", 1, 1))
+	assert.False(t, ws.MergeCells.merged)
+	mergeCells, err = f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []MergeCell{{"A2:B3", "A1"}, {"D2:E3", ""}}, mergeCells)
+	// Test get merged cells after unmerge cells
+	assert.NoError(t, f.UnmergeCell("Sheet1", "A2", "D2"))
+	mergeCells, err = f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, mergeCells)
+	// Test get merged cells with unsupported charset shared strings table
+	assert.NoError(t, f.MergeCell("Sheet1", "A1", "B2"))
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	_, err = f.GetMergeCells("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestUnmergeCell(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "MergeCell.xlsx"))
 	if !assert.NoError(t, err) {
@@ -219,3 +321,22 @@ func TestMergeCellsParser(t *testing.T) {
 	_, err := ws.mergeCellsParser("A1")
 	assert.NoError(t, err)
 }
+
+func BenchmarkGetMergeCells(b *testing.B) {
+	f := NewFile()
+	for row := 1; row <= 10000; row++ {
+		hCell, vCell := fmt.Sprintf("A%d", row), fmt.Sprintf("C%d", row)
+		if err := f.SetCellValue("Sheet1", hCell, row); err != nil {
+			b.Error(err)
+		}
+		if err := f.MergeCell("Sheet1", hCell, vCell); err != nil {
+			b.Error(err)
+		}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := f.GetMergeCells("Sheet1"); err != nil {
+			b.Error(err)
+		}
+	}
+}
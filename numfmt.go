@@ -5061,6 +5061,12 @@ func (nf *numberFormat) positiveHandler() string {
 // currencyLanguageHandler will be handling currency and language types tokens
 // for a number format expression.
 func (nf *numberFormat) currencyLanguageHandler(token nfp.Token) (bool, error) {
+	var currency bool
+	for _, part := range token.Parts {
+		if part.Token.TType == nfp.TokenSubTypeCurrencyString {
+			currency = true
+		}
+	}
 	for _, part := range token.Parts {
 		if inStrSlice(supportedTokenTypes, part.Token.TType, true) == -1 {
 			return false, ErrUnsupportedNumberFormat
@@ -5081,6 +5087,11 @@ func (nf *numberFormat) currencyLanguageHandler(token nfp.Token) (bool, error) {
 				part.Token.TValue = "409"
 			}
 			if _, ok := supportedLanguageInfo[strings.ToUpper(part.Token.TValue)]; !ok {
+				// The locale ID of a currency token only affects the symbol,
+				// so the literal currency string can still be used
+				if currency && isCurrencyLocaleID(part.Token.TValue) {
+					continue
+				}
 				return false, ErrUnsupportedNumberFormat
 			}
 			nf.localCode = strings.ToUpper(part.Token.TValue)
@@ -5092,6 +5103,16 @@ func (nf *numberFormat) currencyLanguageHandler(token nfp.Token) (bool, error) {
 	return false, nil
 }
 
+// isCurrencyLocaleID returns whether the given language info of a currency
+// token is a hexadecimal locale ID or part of an "x-euro" tag.
+func isCurrencyLocaleID(ID string) bool {
+	if inStrSlice([]string{"x", "euro", "euro1", "euro2"}, ID, false) != -1 {
+		return true
+	}
+	_, err := strconv.ParseUint(ID, 16, 32)
+	return err == nil
+}
+
 // localAmPm return AM/PM name by supported language ID.
 func (nf *numberFormat) localAmPm(ap string) string {
 	if languageInfo, ok := supportedLanguageInfo[nf.localCode]; ok {
@@ -3556,6 +3556,16 @@ func TestNumFmt(t *testing.T) {
 		{"-123.4567", "#\\ ?/100", "-123 46/100"},
 		{"123.4567", "#\\ ?/1000", "123 457/1000"},
 		{"1234.5678", "[$$-409]#,##0.00", "$1,234.57"},
+		{"1234.5", "[$€-407]#,##0.00", "€1,234.50"},
+		{"-1234.5", "[$€-407]#,##0.00;-[$€-407]#,##0.00", "-€1,234.50"},
+		{"1234.5", "#,##0.00\\ [$€-407]", "1,234.50 €"},
+		{"-1234.5", "#,##0.00\\ [$€-407];\\-#,##0.00\\ [$€-407]", "-1,234.50 €"},
+		{"-1234.5", "[$$-409]#,##0.00_);\\([$$-409]#,##0.00\\)", "($1,234.50)"},
+		{"1234.5", "[$CHF-100C] #,##0.00", "CHF 1,234.50"},
+		{"1234.5", "[$€-x-euro2] #,##0.00", "€ 1,234.50"},
+		{"1234.5", "[$USD] #,##0.00", "USD 1,234.50"},
+		{"45000", "[$-409]mmmm d, yyyy", "March 15, 2023"},
+		{"45000", "[$-407]mmmm d, yyyy", "März 15, 2023"},
 		// Unsupported number format
 		{"37947.7500001", "0.00000000E+000", "37947.7500001"},
 		{"123", "[$x.-unknown]#,##0.00", "123"},
@@ -0,0 +1,401 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/richardlehane/mscfb"
+)
+
+// oleObjectPackageCLSID is the class identifier of the OLE package object
+// {0003000C-0000-0000-C000-000000000046}.
+var oleObjectPackageCLSID = []byte{0x0C, 0x00, 0x03, 0x00, 0x00, 0x00, 0x00, 0x00, 0xC0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x46}
+
+// vmlPictureFormulas defined the formulas of the VML picture shape type, which
+// used by the VML shape of the OLE object.
+var vmlPictureFormulas = []string{
+	"if lineDrawn pixelLineWidth 0", "sum @0 1 0", "sum 0 0 @1", "prod @2 1 2",
+	"prod @3 21600 pixelWidth", "prod @3 21600 pixelHeight", "sum @0 0 1",
+	"prod @6 1 2", "prod @7 21600 pixelWidth", "sum @8 21600 0",
+	"prod @7 21600 pixelHeight", "sum @10 21600 0",
+}
+
+// AddOLEObject provides the method to embed a file as an OLE object in a
+// worksheet by given worksheet name, cell reference, file path and OLE object
+// options. The file will be stored as an OLE package object, unless the file
+// is already an OLE compound file. The preview image of the object is
+// required, supported image types: EMF, EMZ, GIF, JPEG, JPG, PNG, SVG, TIF,
+// TIFF, WMF, and WMZ. The size of the preview image will be used if the width
+// or height of the object is not specified. The programmatic identifier and
+// the icon of the object will be kept, so that the spreadsheet application
+// could open the object by the right application. For example, embed the
+// file "report.pdf" as an icon in the cell B2 on Sheet1:
+//
+//	icon, err := os.ReadFile("pdf.emf")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.AddOLEObject("Sheet1", "B2", "report.pdf", excelize.OLEObjectOptions{
+//	    ProgID:        "Package",
+//	    DisplayAsIcon: true,
+//	    Icon:          icon,
+//	    IconExtension: ".emf",
+//	    Width:         64,
+//	    Height:        64,
+//	})
+func (f *File) AddOLEObject(sheet, cell, filePath string, opts OLEObjectOptions) error {
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		return err
+	}
+	if len(opts.Icon) == 0 {
+		return ErrParameterInvalid
+	}
+	ext, ok := supportedImageTypes[strings.ToLower(opts.IconExtension)]
+	if !ok {
+		return ErrImgExt
+	}
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	file, err := os.ReadFile(filepath.Clean(filePath))
+	if err != nil {
+		return err
+	}
+	if opts.ProgID == "" {
+		opts.ProgID = "Package"
+	}
+	if !bytes.HasPrefix(file, oleIdentifier) {
+		file = newOLEPackage(filepath.Base(filePath), file)
+	}
+	width, height := int(opts.Width), int(opts.Height)
+	if img, _, err := image.DecodeConfig(bytes.NewReader(opts.Icon)); err == nil {
+		if width == 0 {
+			width = img.Width
+		}
+		if height == 0 {
+			height = img.Height
+		}
+	}
+	if width == 0 || height == 0 {
+		return ErrParameterInvalid
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(sheet, col, row, opts.OffsetX, opts.OffsetY, width, height)
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	objects, err := ws.getOLEObjects()
+	if err != nil {
+		return err
+	}
+	vmlID, drawingVML := f.addSheetVMLDrawing(ws, sheet, f.countVMLDrawing()+1)
+	vml, err := f.getVMLDrawing(drawingVML, vmlID)
+	if err != nil {
+		return err
+	}
+	shapeID := vml.nextShapeID()
+	for _, obj := range objects {
+		if obj.ShapeID >= shapeID {
+			shapeID = obj.ShapeID + 1
+		}
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+	oleObjectID := f.countOLEObjects() + 1
+	f.Pkg.Store("xl/embeddings/oleObject"+strconv.Itoa(oleObjectID)+".bin", file)
+	oleObjectRID := f.addRels(sheetRels, SourceRelationshipOLEObject, "../embeddings/oleObject"+strconv.Itoa(oleObjectID)+".bin", "")
+	media := ".." + strings.TrimPrefix(f.addMedia(opts.Icon, ext), "xl")
+	iconRID := f.addRels(sheetRels, SourceRelationshipImage, media, "")
+	vmlIconRID := f.addRels("xl/drawings/_rels/"+filepath.Base(drawingVML)+".rels", SourceRelationshipImage, media, "")
+	f.addSheetNameSpace(sheet, SourceRelationship)
+	vml.addOLEObjectShape(shapeID, vmlIconRID, fmt.Sprintf("%d, %d, %d, %d, %d, %d, %d, %d",
+		colStart, opts.OffsetX, rowStart, opts.OffsetY, colEnd, x2, rowEnd, y2), width, height)
+	f.VMLDrawing[drawingVML] = vml
+	var dvAspect string
+	if opts.DisplayAsIcon {
+		dvAspect = ` dvAspect="DVASPECT_ICON"`
+	}
+	var progID bytes.Buffer
+	if err = xml.EscapeText(&progID, []byte(opts.ProgID)); err != nil {
+		return err
+	}
+	oleObject := fmt.Sprintf(`<oleObject progId="%s"%s shapeId="%d" r:id="rId%d"`, progID.String(), dvAspect, shapeID, oleObjectRID)
+	if ws.OleObjects == nil {
+		ws.OleObjects = &xlsxInnerXML{}
+	}
+	ws.OleObjects.Content += `<mc:AlternateContent xmlns:mc="` + SourceRelationshipCompatibility.Value + `">` +
+		`<mc:Choice xmlns:x14="` + NameSpaceSpreadSheetX14.Value + `" Requires="x14">` + oleObject + `>` +
+		fmt.Sprintf(`<objectPr defaultSize="0" autoPict="0" r:id="rId%d">`, iconRID) +
+		`<anchor moveWithCells="1">` +
+		fmt.Sprintf(`<from><xdr:col xmlns:xdr="%[1]s">%[2]d</xdr:col><xdr:colOff xmlns:xdr="%[1]s">%[3]d</xdr:colOff><xdr:row xmlns:xdr="%[1]s">%[4]d</xdr:row><xdr:rowOff xmlns:xdr="%[1]s">%[5]d</xdr:rowOff></from>`,
+			NameSpaceDrawingMLSpreadSheet.Value, colStart, opts.OffsetX*EMU, rowStart, opts.OffsetY*EMU) +
+		fmt.Sprintf(`<to><xdr:col xmlns:xdr="%[1]s">%[2]d</xdr:col><xdr:colOff xmlns:xdr="%[1]s">%[3]d</xdr:colOff><xdr:row xmlns:xdr="%[1]s">%[4]d</xdr:row><xdr:rowOff xmlns:xdr="%[1]s">%[5]d</xdr:rowOff></to>`,
+			NameSpaceDrawingMLSpreadSheet.Value, colEnd, x2*EMU, rowEnd, y2*EMU) +
+		`</anchor></objectPr></oleObject></mc:Choice>` +
+		`<mc:Fallback>` + oleObject + `/></mc:Fallback></mc:AlternateContent>`
+	if err = f.addContentTypePart(oleObjectID, "oleObject"); err != nil {
+		return err
+	}
+	if err = f.setContentTypePartVMLExtensions(); err != nil {
+		return err
+	}
+	return f.setContentTypePartImageExtensions()
+}
+
+// addOLEObjectShape provides a function to add the VML shape of the OLE object
+// to the VML drawing by given shape ID, the relationship ID of the preview
+// image, the anchor, width and height of the object.
+func (vml *vmlDrawing) addOLEObjectShape(shapeID, iconRID int, anchor string, width, height int) {
+	shapeType := &xlsxShapeType{
+		ID:             "_x0000_t75",
+		CoordSize:      "21600,21600",
+		Spt:            75,
+		PreferRelative: "t",
+		Path:           "m@4@5l@4@11@9@11@9@5xe",
+		Filled:         "f",
+		Stroked:        "f",
+		Stroke:         &xlsxStroke{JoinStyle: "miter"},
+		Formulas:       &vFormulas{},
+		VPath:          &vPath{ExtrusionOK: "f", GradientShapeOK: "t", ConnectType: "rect"},
+		Lock:           &oLock{Ext: "edit", AspectRatio: "t"},
+	}
+	for _, eqn := range vmlPictureFormulas {
+		shapeType.Formulas.F = append(shapeType.Formulas.F, vFormula{Eqn: eqn})
+	}
+	vml.addShapeType(shapeType)
+	vml.Shape = append(vml.Shape, xlsxShape{
+		ID:          fmt.Sprintf("_x0000_s%d", shapeID),
+		Type:        "#_x0000_t75",
+		Style:       fmt.Sprintf("position:absolute;margin-left:0;margin-top:0;width:%gpt;height:%gpt;z-index:%d", float64(width)*0.75, float64(height)*0.75, len(vml.Shape)+1),
+		Filled:      "t",
+		FillColor:   "window [65]",
+		InsetMode:   "auto",
+		Stroked:     "t",
+		StrokeColor: "windowText [64]",
+		Val: fmt.Sprintf(`<v:fill color2="window [65]"/><v:imagedata o:relid="rId%d" o:title=""/>`+
+			`<x:ClientData ObjectType="Pict"><x:SizeWithCells/><x:Anchor>%s</x:Anchor><x:CF>Pict</x:CF><x:AutoPict/></x:ClientData>`, iconRID, anchor),
+	})
+}
+
+// GetOLEObjects provides a function to get the OLE objects embedded in a
+// worksheet by given worksheet name. The content of the embedded file will be
+// extracted from the OLE package objects. For example, extract the embedded
+// files on Sheet1:
+//
+//	objects, err := f.GetOLEObjects("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, obj := range objects {
+//	    if err := os.WriteFile(obj.FileName, obj.Data, 0o644); err != nil {
+//	        fmt.Println(err)
+//	    }
+//	}
+func (f *File) GetOLEObjects(sheet string) ([]OLEObject, error) {
+	var oleObjects []OLEObject
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return oleObjects, err
+	}
+	ws.mu.Lock()
+	objects, err := ws.getOLEObjects()
+	ws.mu.Unlock()
+	if err != nil {
+		return oleObjects, err
+	}
+	for _, obj := range objects {
+		oleObject := OLEObject{ProgID: obj.ProgID, DisplayAsIcon: obj.DvAspect == "DVASPECT_ICON"}
+		if target := f.getSheetRelationshipsTargetByID(sheet, obj.RID); target != "" {
+			oleObject.Data = f.readBytes(strings.ReplaceAll(target, "..", "xl"))
+		}
+		if fileName, data, err := extractOLEPackage(oleObject.Data); err == nil {
+			oleObject.FileName, oleObject.Data = fileName, data
+		}
+		if obj.ObjectPr != nil {
+			if oleObject.Cell, err = CoordinatesToCellName(obj.ObjectPr.Anchor.From.Col+1, obj.ObjectPr.Anchor.From.Row+1); err != nil {
+				return oleObjects, err
+			}
+			if target := f.getSheetRelationshipsTargetByID(sheet, obj.ObjectPr.RID); target != "" {
+				oleObject.Icon = f.readBytes(strings.ReplaceAll(target, "..", "xl"))
+				oleObject.IconExtension = path.Ext(target)
+			}
+		}
+		oleObjects = append(oleObjects, oleObject)
+	}
+	return oleObjects, err
+}
+
+// getOLEObjects provides a function to parse the OLE objects of the
+// worksheet, the objects in the fallback content will be used only if the
+// choice content doesn't exist.
+func (ws *xlsxWorksheet) getOLEObjects() ([]decodeOleObject, error) {
+	var (
+		objects    []decodeOleObject
+		oleObjects decodeOleObjects
+	)
+	if ws.OleObjects == nil {
+		return objects, nil
+	}
+	if err := xml.Unmarshal([]byte("<oleObjects>"+ws.OleObjects.Content+"</oleObjects>"), &oleObjects); err != nil {
+		return objects, err
+	}
+	for _, content := range oleObjects.AlternateContent {
+		if content.Choice != nil {
+			objects = append(objects, content.Choice.OleObject...)
+			continue
+		}
+		if content.Fallback != nil {
+			objects = append(objects, content.Fallback.OleObject...)
+		}
+	}
+	return append(objects, oleObjects.OleObject...), nil
+}
+
+// countOLEObjects provides a function to get embedded OLE objects count
+// storage in the folder xl/embeddings.
+func (f *File) countOLEObjects() int {
+	count := 0
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.HasPrefix(k.(string), "xl/embeddings/oleObject") {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+// newOLEPackage provides a function to create an OLE compound file which
+// contains the OLE package object by given file name and file content.
+func newOLEPackage(fileName string, content []byte) []byte {
+	var native, compObj bytes.Buffer
+	writeUint32 := func(buf *bytes.Buffer, value int) {
+		_ = binary.Write(buf, binary.LittleEndian, uint32(value))
+	}
+	writeAnsiString := func(buf *bytes.Buffer, value string) {
+		writeUint32(buf, len(value)+1)
+		buf.WriteString(value + "\x00")
+	}
+	// The native data of the OLE package object: the label, the source path,
+	// the temporary path and the content of the file.
+	var data bytes.Buffer
+	data.Write([]byte{0x02, 0x00})
+	data.WriteString(fileName + "\x00")
+	data.WriteString(fileName + "\x00")
+	data.Write([]byte{0x00, 0x00, 0x03, 0x00})
+	writeAnsiString(&data, fileName)
+	writeUint32(&data, len(content))
+	data.Write(content)
+	writeUint32(&native, data.Len())
+	native.Write(data.Bytes())
+	// The CompObj stream specifies the clipboard format and user type.
+	writeUint32(&compObj, 0xFFFE0001)
+	writeUint32(&compObj, 0x00000A03)
+	writeUint32(&compObj, 0xFFFFFFFF)
+	compObj.Write(oleObjectPackageCLSID)
+	writeAnsiString(&compObj, "OLE Package")
+	writeUint32(&compObj, 0)
+	writeAnsiString(&compObj, "Package")
+	writeUint32(&compObj, 0x71B239F4)
+	for i := 0; i < 3; i++ {
+		writeUint32(&compObj, 0)
+	}
+	compoundFile := &cfb{
+		paths:   []string{"Root Entry/"},
+		sectors: []sector{{name: "Root Entry", typeID: 5, clsID: oleObjectPackageCLSID}},
+	}
+	compoundFile.put("\x01CompObj", compObj.Bytes())
+	compoundFile.put("\x01Ole10Native", native.Bytes())
+	return compoundFile.write()
+}
+
+// extractOLEPackage provides a function to extract the file name and content
+// of the file from the OLE package object by given OLE compound file.
+func extractOLEPackage(raw []byte) (string, []byte, error) {
+	doc, err := mscfb.New(bytes.NewReader(raw))
+	if err != nil {
+		return "", nil, err
+	}
+	for entry, err := doc.Next(); err == nil; entry, err = doc.Next() {
+		if strings.TrimPrefix(entry.Name, "\x01") != "Ole10Native" {
+			continue
+		}
+		native, err := io.ReadAll(entry)
+		if err != nil {
+			return "", nil, err
+		}
+		return parseOLENative(native)
+	}
+	return "", nil, ErrParameterInvalid
+}
+
+// parseOLENative provides a function to parse the native data stream of the
+// OLE package object, returns the label and the content of the file.
+func parseOLENative(native []byte) (string, []byte, error) {
+	pos := 6
+	readString := func() (string, bool) {
+		if pos > len(native) {
+			return "", false
+		}
+		end := bytes.IndexByte(native[pos:], 0)
+		if end == -1 {
+			return "", false
+		}
+		value := string(native[pos : pos+end])
+		pos += end + 1
+		return value, true
+	}
+	readUint32 := func() (int, bool) {
+		if pos+4 > len(native) {
+			return 0, false
+		}
+		value := int(binary.LittleEndian.Uint32(native[pos : pos+4]))
+		pos += 4
+		return value, true
+	}
+	label, ok := readString()
+	if !ok {
+		return "", nil, ErrParameterInvalid
+	}
+	if _, ok = readString(); !ok {
+		return "", nil, ErrParameterInvalid
+	}
+	pos += 4
+	size, ok := readUint32()
+	if !ok || pos+size > len(native) {
+		return "", nil, ErrParameterInvalid
+	}
+	pos += size
+	if size, ok = readUint32(); !ok || pos+size > len(native) {
+		return "", nil, ErrParameterInvalid
+	}
+	return label, native[pos : pos+size], nil
+}
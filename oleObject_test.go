@@ -0,0 +1,146 @@
+package excelize
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddOLEObject(t *testing.T) {
+	icon, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	data, err := os.ReadFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	f := NewFile()
+	opts := OLEObjectOptions{DisplayAsIcon: true, Icon: icon, IconExtension: ".png", Width: 64, Height: 64}
+	assert.NoError(t, f.AddOLEObject("Sheet1", "B2", filepath.Join("test", "Book1.xlsx"), opts))
+	assert.NoError(t, f.AddOLEObject("Sheet1", "E5", filepath.Join("test", "images", "excel.png"),
+		OLEObjectOptions{ProgID: "Paint.Picture", Icon: icon, IconExtension: ".png", OffsetX: 10, OffsetY: 10}))
+	objects, err := f.GetOLEObjects("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, objects, 2)
+	assert.Equal(t, "B2", objects[0].Cell)
+	assert.Equal(t, "Package", objects[0].ProgID)
+	assert.True(t, objects[0].DisplayAsIcon)
+	assert.Equal(t, "Book1.xlsx", objects[0].FileName)
+	assert.Equal(t, data, objects[0].Data)
+	assert.Equal(t, icon, objects[0].Icon)
+	assert.Equal(t, ".png", objects[0].IconExtension)
+	assert.Equal(t, "E5", objects[1].Cell)
+	assert.Equal(t, "Paint.Picture", objects[1].ProgID)
+	assert.False(t, objects[1].DisplayAsIcon)
+	assert.Equal(t, icon, objects[1].Data)
+	// Test the shape IDs of the OLE objects are allocated in the VML drawing
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.NotNil(t, ws.(*xlsxWorksheet).LegacyDrawing)
+	oleObjects, err := ws.(*xlsxWorksheet).getOLEObjects()
+	assert.NoError(t, err)
+	assert.Equal(t, 1025, oleObjects[0].ShapeID)
+	assert.Equal(t, 1026, oleObjects[1].ShapeID)
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Comment"}))
+	vml := f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	assert.Len(t, vml.ShapeType, 2)
+	assert.Equal(t, "_x0000_t75", vml.ShapeType[0].ID)
+	assert.Equal(t, "_x0000_t202", vml.ShapeType[1].ID)
+	assert.Len(t, vml.Shape, 3)
+	for i, shapeID := range []string{"_x0000_s1025", "_x0000_s1026", "_x0000_s1027"} {
+		assert.Equal(t, shapeID, vml.Shape[i].ID)
+	}
+	assert.Equal(t, "#_x0000_t75", vml.Shape[0].Type)
+	assert.Contains(t, vml.Shape[0].Val, `<v:imagedata o:relid="rId1" o:title=""/>`)
+	assert.Contains(t, vml.Shape[0].Val, `<x:Anchor>1, 0, 1, 0, 2, 0, 4, 10</x:Anchor>`)
+	rels, err := f.relsReader("xl/drawings/_rels/vmlDrawing1.vml.rels")
+	assert.NoError(t, err)
+	assert.Equal(t, "../media/image1.png", rels.Relationships[0].Target)
+	// Test delete form control will not delete the shape of the OLE object
+	assert.NoError(t, f.DeleteFormControl("Sheet1", "B2"))
+	assert.Len(t, f.VMLDrawing["xl/drawings/vmlDrawing1.vml"].Shape, 3)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddOLEObject.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test get OLE objects after reopen the workbook
+	f, err = OpenFile(filepath.Join("test", "TestAddOLEObject.xlsx"))
+	assert.NoError(t, err)
+	objects, err = f.GetOLEObjects("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, objects, 2)
+	assert.Equal(t, "B2", objects[0].Cell)
+	assert.Equal(t, data, objects[0].Data)
+	assert.Equal(t, icon, objects[1].Icon)
+	// Test add OLE object with the exists VML drawing
+	assert.NoError(t, f.AddOLEObject("Sheet1", "H8", filepath.Join("test", "Book1.xlsx"), opts))
+	vml, err = f.getVMLDrawing("xl/drawings/vmlDrawing1.vml", 1)
+	assert.NoError(t, err)
+	assert.Len(t, vml.ShapeType, 2)
+	assert.Len(t, vml.Shape, 4)
+	assert.Equal(t, "_x0000_s1028", vml.Shape[3].ID)
+	// Test get OLE objects on the worksheet without OLE objects
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	objects, err = f.GetOLEObjects("Sheet2")
+	assert.NoError(t, err)
+	assert.Empty(t, objects)
+	// Test get OLE objects on not exists worksheet
+	_, err = f.GetOLEObjects("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	// Test add OLE object with not exists file
+	assert.True(t, os.IsNotExist(f.AddOLEObject("Sheet1", "A1", filepath.Join("test", "NotExists.xlsx"), opts)))
+	// Test add OLE object without icon
+	assert.Equal(t, ErrParameterInvalid, f.AddOLEObject("Sheet1", "A1", filepath.Join("test", "Book1.xlsx"), OLEObjectOptions{}))
+	// Test add OLE object with unsupported icon extension
+	assert.Equal(t, ErrImgExt, f.AddOLEObject("Sheet1", "A1", filepath.Join("test", "Book1.xlsx"), OLEObjectOptions{Icon: icon, IconExtension: ".txt"}))
+	// Test add OLE object with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.AddOLEObject("Sheet1", "A", filepath.Join("test", "Book1.xlsx"), opts))
+	// Test add OLE object with unknown icon size
+	assert.Equal(t, ErrParameterInvalid, f.AddOLEObject("Sheet1", "A1", filepath.Join("test", "Book1.xlsx"), OLEObjectOptions{Icon: icon[:8], IconExtension: ".png"}))
+	// Test add OLE object on not exists worksheet
+	assert.EqualError(t, f.AddOLEObject("SheetN", "A1", filepath.Join("test", "Book1.xlsx"), opts), "sheet SheetN does not exist")
+	// Test add OLE object with unsupported charset VML drawing
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Comment"}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddOLEObject2.xlsx")))
+	f.VMLDrawing["xl/drawings/vmlDrawing1.vml"] = nil
+	f.Pkg.Store("xl/drawings/vmlDrawing1.vml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddOLEObject("Sheet1", "A1", filepath.Join("test", "Book1.xlsx"), opts), "XML syntax error on line 1: invalid UTF-8")
+	// Test add and get OLE objects with unsupported charset worksheet
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).OleObjects = &xlsxInnerXML{Content: "<oleObject"}
+	assert.Error(t, f.AddOLEObject("Sheet1", "A1", filepath.Join("test", "Book1.xlsx"), opts))
+	_, err = f.GetOLEObjects("Sheet1")
+	assert.Error(t, err)
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	f.checked = sync.Map{}
+	assert.EqualError(t, f.AddOLEObject("Sheet1", "A1", filepath.Join("test", "Book1.xlsx"), opts), "XML syntax error on line 1: invalid UTF-8")
+	_, err = f.GetOLEObjects("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestExtractOLEPackage(t *testing.T) {
+	fileName, data, err := extractOLEPackage(newOLEPackage("a.txt", []byte("text")))
+	assert.NoError(t, err)
+	assert.Equal(t, "a.txt", fileName)
+	assert.Equal(t, []byte("text"), data)
+	// Test extract OLE package with invalid compound file
+	_, _, err = extractOLEPackage([]byte("text"))
+	assert.Error(t, err)
+	// Test parse OLE native data with invalid content
+	for _, native := range [][]byte{
+		{0x00},
+		[]byte("\x00\x00\x00\x00\x02\x00a.txt"),
+		[]byte("\x00\x00\x00\x00\x02\x00a.txt\x00a.txt"),
+		[]byte("\x00\x00\x00\x00\x02\x00a.txt\x00a.txt\x00\x00\x00\x03\x00\xff\x00\x00\x00"),
+		[]byte("\x00\x00\x00\x00\x02\x00a.txt\x00a.txt\x00\x00\x00\x03\x00\x00\x00\x00\x00\xff\x00\x00\x00"),
+	} {
+		_, _, err = parseOLENative(native)
+		assert.Equal(t, ErrParameterInvalid, err)
+	}
+}
@@ -293,7 +293,9 @@ func (f *File) addSheetPicture(sheet string, rID int) error {
 }
 
 // countDrawings provides a function to get drawing files count storage in the
-// folder xl/drawings.
+// folder xl/drawings. The largest drawing file ID will be returned if the
+// drawing files are not numbered continuously, such as after a chart sheet
+// has been deleted.
 func (f *File) countDrawings() int {
 	drawings := map[string]struct{}{}
 	f.Pkg.Range(func(k, v interface{}) bool {
@@ -308,7 +310,13 @@ func (f *File) countDrawings() int {
 		}
 		return true
 	})
-	return len(drawings)
+	count := len(drawings)
+	for name := range drawings {
+		if id, err := strconv.Atoi(strings.TrimSuffix(name[strings.LastIndex(name, "drawing")+7:], ".xml")); err == nil && id > count {
+			count = id
+		}
+	}
+	return count
 }
 
 // addDrawingPicture provides a function to add picture by given sheet,
@@ -477,6 +485,43 @@ func (f *File) GetPictures(sheet, cell string) ([]Picture, error) {
 	return f.getPicture(row, col, drawingXML, drawingRelationships)
 }
 
+// GetSheetPictures provides a function to get all pictures meta info and raw
+// content embed in the worksheet by given worksheet name, include the pictures
+// placed with one cell anchor and two cell anchor. The Cell field of each
+// picture is the top-left anchor cell reference of the picture. For example,
+// extract all pictures in the worksheet named Sheet1:
+//
+//	pics, err := f.GetSheetPictures("Sheet1")
+//	if err != nil {
+//		fmt.Println(err)
+//	}
+//	for idx, pic := range pics {
+//	    name := fmt.Sprintf("%s_image%d%s", pic.Cell, idx+1, pic.Extension)
+//	    if err := os.WriteFile(name, pic.File, 0644); err != nil {
+//	        fmt.Println(err)
+//	    }
+//	}
+func (f *File) GetSheetPictures(sheet string) ([]Picture, error) {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	f.mu.Unlock()
+	if ws.Drawing == nil {
+		return nil, err
+	}
+	target := f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID)
+	drawingXML := strings.TrimPrefix(strings.ReplaceAll(target, "..", "xl"), "/")
+	drawingRelationships := strings.ReplaceAll(
+		strings.ReplaceAll(target, "../drawings", "xl/drawings/_rels"), ".xml", ".xml.rels")
+
+	return f.getPictures(drawingXML, drawingRelationships,
+		func(from *xlsxFrom) bool { return true },
+		func(from *decodeFrom) bool { return true })
+}
+
 // GetPictureCells returns all picture cell references in a worksheet by a
 // specific worksheet name.
 func (f *File) GetPictureCells(sheet string) ([]string, error) {
@@ -551,18 +596,28 @@ func (f *File) DeletePicture(sheet, cell string) error {
 
 // getPicture provides a function to get picture base name and raw content
 // embed in spreadsheet by given coordinates and drawing relationships.
-func (f *File) getPicture(row, col int, drawingXML, drawingRelationships string) (pics []Picture, err error) {
+func (f *File) getPicture(row, col int, drawingXML, drawingRelationships string) ([]Picture, error) {
+	return f.getPictures(drawingXML, drawingRelationships,
+		func(from *xlsxFrom) bool { return from.Col == col && from.Row == row },
+		func(from *decodeFrom) bool { return from.Col == col && from.Row == row })
+}
+
+// getPictures provides a function to get pictures base name, top-left anchor
+// cell and raw content embed in spreadsheet by given drawing part path,
+// drawing relationships path and conditional functions.
+func (f *File) getPictures(drawingXML, drawingRelationships string,
+	cond func(from *xlsxFrom) bool, cond2 func(from *decodeFrom) bool,
+) (pics []Picture, err error) {
 	var wsDr *xlsxWsDr
 	if wsDr, _, err = f.drawingParser(drawingXML); err != nil {
 		return
 	}
 	wsDr.mu.Lock()
 	defer wsDr.mu.Unlock()
-	cond := func(from *xlsxFrom) bool { return from.Col == col && from.Row == row }
-	cond2 := func(from *decodeFrom) bool { return from.Col == col && from.Row == row }
 	cb := func(a *xdrCellAnchor, r *xlsxRelationship) {
 		pic := Picture{Extension: filepath.Ext(r.Target), Format: &GraphicOptions{}}
 		if buffer, _ := f.Pkg.Load(strings.ReplaceAll(r.Target, "..", "xl")); buffer != nil {
+			pic.Cell, _ = CoordinatesToCellName(a.From.Col+1, a.From.Row+1)
 			pic.File = buffer.([]byte)
 			pic.Format.AltText = a.Pic.NvPicPr.CNvPr.Descr
 			pics = append(pics, pic)
@@ -571,6 +626,7 @@ func (f *File) getPicture(row, col int, drawingXML, drawingRelationships string)
 	cb2 := func(a *decodeCellAnchor, r *xlsxRelationship) {
 		pic := Picture{Extension: filepath.Ext(r.Target), Format: &GraphicOptions{}}
 		if buffer, _ := f.Pkg.Load(strings.ReplaceAll(r.Target, "..", "xl")); buffer != nil {
+			pic.Cell, _ = CoordinatesToCellName(a.From.Col+1, a.From.Row+1)
 			pic.File = buffer.([]byte)
 			pic.Format.AltText = a.Pic.NvPicPr.CNvPr.Descr
 			pics = append(pics, pic)
@@ -216,6 +216,11 @@ func TestGetPicture(t *testing.T) {
 	cells, err := f.GetPictureCells("Sheet2")
 	assert.NoError(t, err)
 	assert.Equal(t, []string{"K16"}, cells)
+	// Try to get all pictures in worksheet with one cell anchor
+	pics, err = f.GetSheetPictures("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	assert.Equal(t, "K16", pics[0].Cell)
 
 	// Test get picture from none drawing worksheet
 	f = NewFile()
@@ -236,6 +241,32 @@ func TestGetPicture(t *testing.T) {
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestGetSheetPictures(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), nil))
+	assert.NoError(t, f.AddPicture("Sheet1", "C3", filepath.Join("test", "images", "excel.jpg"), &GraphicOptions{AltText: "Excel Logo", Positioning: "oneCell"}))
+	pics, err := f.GetSheetPictures("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 2)
+	assert.Equal(t, "A1", pics[0].Cell)
+	assert.Equal(t, ".png", pics[0].Extension)
+	assert.Len(t, pics[0].File, 13233)
+	assert.Equal(t, "C3", pics[1].Cell)
+	assert.Equal(t, ".jpeg", pics[1].Extension)
+	assert.Equal(t, "Excel Logo", pics[1].Format.AltText)
+	// Test get pictures from none drawing worksheet
+	f = NewFile()
+	pics, err = f.GetSheetPictures("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 0)
+	// Test get pictures on not exists worksheet
+	_, err = f.GetSheetPictures("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get pictures with invalid sheet name
+	_, err = f.GetSheetPictures("Sheet:1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+}
+
 func TestAddDrawingPicture(t *testing.T) {
 	// Test addDrawingPicture with illegal cell reference
 	f := NewFile()
@@ -16,55 +16,71 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
+	"path"
 	"path/filepath"
 	"reflect"
 	"strconv"
 	"strings"
-
-	"golang.org/x/text/cases"
-	"golang.org/x/text/language"
 )
 
 // PivotTableOptions directly maps the format settings of the pivot table.
 //
+// DisableRefreshOnLoad specifies whether to disable refreshing the pivot table
+// when the workbook is opened. The pivot cache records will not be stored in
+// the workbook, so the pivot table will be shown empty until it is refreshed
+// manually if this option is enabled.
+//
 // PivotTableStyleName: The built-in pivot table style names
 //
 //	PivotStyleLight1 - PivotStyleLight28
 //	PivotStyleMedium1 - PivotStyleMedium28
 //	PivotStyleDark1 - PivotStyleDark28
 type PivotTableOptions struct {
-	pivotTableXML       string
-	pivotCacheXML       string
-	pivotSheetName      string
-	pivotDataRange      string
-	namedDataRange      bool
-	DataRange           string
-	PivotTableRange     string
-	Name                string
-	Rows                []PivotTableField
-	Columns             []PivotTableField
-	Data                []PivotTableField
-	Filter              []PivotTableField
-	RowGrandTotals      bool
-	ColGrandTotals      bool
-	ShowDrill           bool
-	UseAutoFormatting   bool
-	PageOverThenDown    bool
-	MergeItem           bool
-	CompactData         bool
-	ShowError           bool
-	ShowRowHeaders      bool
-	ShowColHeaders      bool
-	ShowRowStripes      bool
-	ShowColStripes      bool
-	ShowLastColumn      bool
-	PivotTableStyleName string
+	pivotTableXML        string
+	pivotCacheXML        string
+	pivotSheetName       string
+	pivotDataRange       string
+	namedDataRange       bool
+	DataRange            string
+	PivotTableRange      string
+	Name                 string
+	Rows                 []PivotTableField
+	Columns              []PivotTableField
+	Data                 []PivotTableField
+	Filter               []PivotTableField
+	RowGrandTotals       bool
+	ColGrandTotals       bool
+	ShowDrill            bool
+	UseAutoFormatting    bool
+	PageOverThenDown     bool
+	MergeItem            bool
+	CompactData          bool
+	ShowError            bool
+	ShowRowHeaders       bool
+	ShowColHeaders       bool
+	ShowRowStripes       bool
+	ShowColStripes       bool
+	ShowLastColumn       bool
+	DisableRefreshOnLoad bool
+	PivotTableStyleName  string
+}
+
+// PivotTableSourceOptions directly maps the data source settings of the pivot
+// table. DataRange specifies the new data range of the pivot table, the
+// current data range will be kept if it is empty. RefreshOnLoad specifies
+// whether the pivot table should be refreshed when the workbook is opened,
+// it will always be enabled when the data range has been changed.
+type PivotTableSourceOptions struct {
+	DataRange     string
+	RefreshOnLoad bool
 }
 
 // PivotTableField directly maps the field settings of the pivot table.
 // Subtotal specifies the aggregation function that applies to this data
-// field. The default value is sum. The possible values for this attribute
-// are:
+// field. The default value is sum. For the row and column fields, Subtotal
+// specifies the subtotal function of the field when DefaultSubtotal is
+// enabled, the automatic subtotal will be used if it is empty. The possible
+// values for this attribute are:
 //
 //	Average
 //	Count
@@ -79,7 +95,17 @@ type PivotTableOptions struct {
 //	Varp
 //
 // Name specifies the name of the data field. Maximum 255 characters
-// are allowed in data field name, excess characters will be truncated.
+// are allowed in data field name, excess characters will be truncated. If
+// the name of the data field is empty, the caption will be generated by the
+// subtotal function and the field name, such as "Sum of Sales".
+//
+// DefaultSubtotal specifies whether to show the subtotals of the row and
+// column fields.
+//
+// Sort specifies the sort order of the items in the row and column fields,
+// the possible values are Ascending and Descending.
+//
+// NumFmt specifies the built-in number format index of the field values.
 type PivotTableField struct {
 	Compact         bool
 	Data            string
@@ -87,6 +113,26 @@ type PivotTableField struct {
 	Outline         bool
 	Subtotal        string
 	DefaultSubtotal bool
+	Sort            string
+	NumFmt          int
+}
+
+// pivotTableSubtotals defined the list of the pivot table subtotal functions
+// and the subtotal item types of the pivot field.
+var pivotTableSubtotals = []struct {
+	name, itemType string
+}{
+	{"Average", "avg"},
+	{"Count", "countA"},
+	{"CountNums", "count"},
+	{"Max", "max"},
+	{"Min", "min"},
+	{"Product", "product"},
+	{"StdDev", "stdDev"},
+	{"StdDevp", "stdDevP"},
+	{"Sum", "sum"},
+	{"Var", "var"},
+	{"Varp", "varP"},
 }
 
 // AddPivotTable provides the method to add pivot table by given pivot table
@@ -278,7 +324,7 @@ func (f *File) addPivotCache(opts *PivotTableOptions) error {
 	vCell, _ := CoordinatesToCellName(coordinates[2], coordinates[3])
 	pc := xlsxPivotCacheDefinition{
 		SaveData:              false,
-		RefreshOnLoad:         true,
+		RefreshOnLoad:         !opts.DisableRefreshOnLoad,
 		CreatedVersion:        pivotTableVersion,
 		RefreshedVersion:      pivotTableRefreshedVersion,
 		MinRefreshableVersion: pivotTableVersion,
@@ -446,15 +492,20 @@ func (f *File) addPivotDataFields(pt *xlsxPivotTableDefinition, opts *PivotTable
 		return err
 	}
 	dataFieldsSubtotals := f.getPivotTableFieldsSubtotal(opts.Data)
-	dataFieldsName := f.getPivotTableFieldsName(opts.Data)
+	dataFieldsName := f.getPivotTableDataFieldsName(opts.Data)
 	for idx, dataField := range dataFieldsIndex {
 		if pt.DataFields == nil {
 			pt.DataFields = &xlsxDataFields{}
 		}
+		var numFmtID string
+		if numFmt := opts.Data[idx].NumFmt; numFmt > 0 {
+			numFmtID = strconv.Itoa(numFmt)
+		}
 		pt.DataFields.DataField = append(pt.DataFields.DataField, &xlsxDataField{
 			Name:     dataFieldsName[idx],
 			Fld:      dataField,
 			Subtotal: dataFieldsSubtotals[idx],
+			NumFmtID: numFmtID,
 		})
 	}
 
@@ -525,29 +576,9 @@ func (f *File) addPivotFields(pt *xlsxPivotTableDefinition, opts *PivotTableOpti
 	if err != nil {
 		return err
 	}
-	x := 0
 	for _, name := range order {
 		if inPivotTableField(opts.Rows, name) != -1 {
-			rowOptions, ok := f.getPivotTableFieldOptions(name, opts.Rows)
-			var items []*xlsxItem
-			if !ok || !rowOptions.DefaultSubtotal {
-				items = append(items, &xlsxItem{X: &x})
-			} else {
-				items = append(items, &xlsxItem{T: "default"})
-			}
-
-			pt.PivotFields.PivotField = append(pt.PivotFields.PivotField, &xlsxPivotField{
-				Name:            f.getPivotTableFieldName(name, opts.Rows),
-				Axis:            "axisRow",
-				DataField:       inPivotTableField(opts.Data, name) != -1,
-				Compact:         &rowOptions.Compact,
-				Outline:         &rowOptions.Outline,
-				DefaultSubtotal: &rowOptions.DefaultSubtotal,
-				Items: &xlsxItems{
-					Count: len(items),
-					Item:  items,
-				},
-			})
+			pt.PivotFields.PivotField = append(pt.PivotFields.PivotField, f.getPivotField(name, "axisRow", opts.Rows, opts))
 			continue
 		}
 		if inPivotTableField(opts.Filter, name) != -1 {
@@ -565,25 +596,7 @@ func (f *File) addPivotFields(pt *xlsxPivotTableDefinition, opts *PivotTableOpti
 			continue
 		}
 		if inPivotTableField(opts.Columns, name) != -1 {
-			columnOptions, ok := f.getPivotTableFieldOptions(name, opts.Columns)
-			var items []*xlsxItem
-			if !ok || !columnOptions.DefaultSubtotal {
-				items = append(items, &xlsxItem{X: &x})
-			} else {
-				items = append(items, &xlsxItem{T: "default"})
-			}
-			pt.PivotFields.PivotField = append(pt.PivotFields.PivotField, &xlsxPivotField{
-				Name:            f.getPivotTableFieldName(name, opts.Columns),
-				Axis:            "axisCol",
-				DataField:       inPivotTableField(opts.Data, name) != -1,
-				Compact:         &columnOptions.Compact,
-				Outline:         &columnOptions.Outline,
-				DefaultSubtotal: &columnOptions.DefaultSubtotal,
-				Items: &xlsxItems{
-					Count: len(items),
-					Item:  items,
-				},
-			})
+			pt.PivotFields.PivotField = append(pt.PivotFields.PivotField, f.getPivotField(name, "axisCol", opts.Columns, opts))
 			continue
 		}
 		if inPivotTableField(opts.Data, name) != -1 {
@@ -597,30 +610,75 @@ func (f *File) addPivotFields(pt *xlsxPivotTableDefinition, opts *PivotTableOpti
 	return err
 }
 
+// getPivotField create a pivot field on the row or column axis by given field
+// name, axis, fields and pivot table option.
+func (f *File) getPivotField(name, axis string, fields []PivotTableField, opts *PivotTableOptions) *xlsxPivotField {
+	x := 0
+	fieldOptions, ok := f.getPivotTableFieldOptions(name, fields)
+	defaultSubtotal := fieldOptions.DefaultSubtotal
+	fld := &xlsxPivotField{
+		Name:      f.getPivotTableFieldName(name, fields),
+		Axis:      axis,
+		DataField: inPivotTableField(opts.Data, name) != -1,
+		Compact:   &fieldOptions.Compact,
+		Outline:   &fieldOptions.Outline,
+		SortType:  getPivotFieldSortType(fieldOptions.Sort),
+	}
+	if fieldOptions.NumFmt > 0 {
+		fld.NumFmtID = strconv.Itoa(fieldOptions.NumFmt)
+	}
+	var items []*xlsxItem
+	if !ok || !fieldOptions.DefaultSubtotal {
+		items = append(items, &xlsxItem{X: &x})
+	} else if itemType := getPivotFieldSubtotalItemType(fieldOptions.Subtotal); itemType != "" {
+		defaultSubtotal = false
+		reflect.ValueOf(fld).Elem().FieldByName(strings.ToUpper(itemType[:1]) + itemType[1:] + "Subtotal").SetBool(true)
+		items = append(items, &xlsxItem{T: itemType})
+	} else {
+		items = append(items, &xlsxItem{T: "default"})
+	}
+	fld.DefaultSubtotal = &defaultSubtotal
+	fld.Items = &xlsxItems{Count: len(items), Item: items}
+	return fld
+}
+
+// getPivotFieldSortType returns the sort type of the pivot field by given
+// sort order, returns empty string if the sort order is invalid.
+func getPivotFieldSortType(sort string) string {
+	for _, sortType := range []string{"ascending", "descending"} {
+		if strings.EqualFold(sortType, sort) {
+			return sortType
+		}
+	}
+	return ""
+}
+
+// getPivotFieldSubtotalItemType returns the subtotal item type of the pivot
+// field by given subtotal function, returns empty string if the subtotal
+// function is invalid.
+func getPivotFieldSubtotalItemType(subtotal string) string {
+	for _, fn := range pivotTableSubtotals {
+		if strings.EqualFold(fn.name, subtotal) {
+			return fn.itemType
+		}
+	}
+	return ""
+}
+
 // countPivotTables provides a function to get pivot table files count storage
-// in the folder xl/pivotTables.
+// in the folder xl/pivotTables. The largest pivot table file ID will be
+// returned if the pivot table files are not numbered continuously, such as
+// after a pivot table has been deleted.
 func (f *File) countPivotTables() int {
-	count := 0
-	f.Pkg.Range(func(k, v interface{}) bool {
-		if strings.Contains(k.(string), "xl/pivotTables/pivotTable") {
-			count++
-		}
-		return true
-	})
-	return count
+	return f.countPartsWithPrefix("xl/pivotTables/pivotTable")
 }
 
 // countPivotCache provides a function to get pivot table cache definition files
-// count storage in the folder xl/pivotCache.
+// count storage in the folder xl/pivotCache. The largest pivot cache definition
+// file ID will be returned if the pivot cache definition files are not
+// numbered continuously, such as after a pivot table has been deleted.
 func (f *File) countPivotCache() int {
-	count := 0
-	f.Pkg.Range(func(k, v interface{}) bool {
-		if strings.Contains(k.(string), "xl/pivotCache/pivotCacheDefinition") {
-			count++
-		}
-		return true
-	})
-	return count
+	return f.countPartsWithPrefix("xl/pivotCache/pivotCacheDefinition")
 }
 
 // getPivotFieldsIndex convert the column of the first row in the data region
@@ -642,17 +700,46 @@ func (f *File) getPivotFieldsIndex(fields []PivotTableField, opts *PivotTableOpt
 // getPivotTableFieldsSubtotal prepare fields subtotal by given pivot table fields.
 func (f *File) getPivotTableFieldsSubtotal(fields []PivotTableField) []string {
 	field := make([]string, len(fields))
-	enums := []string{"average", "count", "countNums", "max", "min", "product", "stdDev", "stdDevp", "sum", "var", "varp"}
-	inEnums := func(enums []string, val string) string {
-		for _, enum := range enums {
-			if strings.EqualFold(enum, val) {
-				return enum
+	for idx, fld := range fields {
+		field[idx] = "sum"
+		for _, fn := range pivotTableSubtotals {
+			if strings.EqualFold(fn.name, fld.Subtotal) {
+				field[idx] = strings.ToLower(fn.name[:1]) + fn.name[1:]
+				break
 			}
 		}
-		return "sum"
+	}
+	return field
+}
+
+// getPivotTableDataFieldsName prepare data fields caption list by given pivot
+// table fields. The caption will be generated by the subtotal function and
+// the field name if the name of the data field is empty, and the duplicate
+// captions will be numbered.
+func (f *File) getPivotTableDataFieldsName(fields []PivotTableField) []string {
+	field, subtotals := f.getPivotTableFieldsName(fields), f.getPivotTableFieldsSubtotal(fields)
+	captions := map[string]bool{}
+	for idx := range fields {
+		if field[idx] != "" {
+			captions[field[idx]] = true
+		}
 	}
 	for idx, fld := range fields {
-		field[idx] = inEnums(enums, fld.Subtotal)
+		if field[idx] != "" {
+			continue
+		}
+		caption := fmt.Sprintf("%s of %s", strings.ToUpper(subtotals[idx][:1])+subtotals[idx][1:], fld.Data)
+		if subtotals[idx] == "countNums" {
+			caption = "Count of " + fld.Data
+		}
+		name := caption
+		for num := 2; captions[name]; num++ {
+			name = fmt.Sprintf("%s%d", caption, num)
+		}
+		if len(name) > MaxFieldLength {
+			name = name[:MaxFieldLength]
+		}
+		field[idx], captions[name] = name, true
 	}
 	return field
 }
@@ -802,11 +889,15 @@ func (f *File) getPivotTable(sheet, pivotTableXML, pivotCacheRels string) (Pivot
 	if err != nil {
 		return opts, err
 	}
+	dataSheet := pc.CacheSource.WorksheetSource.Sheet
+	if dataSheet == "" {
+		dataSheet = sheet
+	}
 	opts = PivotTableOptions{
 		pivotTableXML:   pivotTableXML,
 		pivotCacheXML:   pivotCacheXML,
 		pivotSheetName:  sheet,
-		DataRange:       fmt.Sprintf("%s!%s", sheet, pc.CacheSource.WorksheetSource.Ref),
+		DataRange:       fmt.Sprintf("%s!%s", dataSheet, pc.CacheSource.WorksheetSource.Ref),
 		PivotTableRange: fmt.Sprintf("%s!%s", sheet, pt.Location.Ref),
 		Name:            pt.Name,
 	}
@@ -830,6 +921,7 @@ func (f *File) getPivotTable(sheet, pivotTableXML, pivotCacheRels string) (Pivot
 		opts.ShowLastColumn = si.ShowLastColumn
 		opts.PivotTableStyleName = si.Name
 	}
+	opts.DisableRefreshOnLoad = !pc.RefreshOnLoad
 	order, err := f.getTableFieldsOrder(&opts)
 	if err != nil {
 		return opts, err
@@ -882,11 +974,19 @@ func (f *File) extractPivotTableFields(order []string, pt *xlsxPivotTableDefinit
 	}
 	if pt.DataFields != nil {
 		for _, field := range pt.DataFields.DataField {
-			opts.Data = append(opts.Data, PivotTableField{
+			dataField := PivotTableField{
 				Data:     order[field.Fld],
 				Name:     field.Name,
-				Subtotal: cases.Title(language.English).String(field.Subtotal),
-			})
+				Subtotal: "Sum",
+			}
+			for _, fn := range pivotTableSubtotals {
+				if strings.EqualFold(fn.name, field.Subtotal) {
+					dataField.Subtotal = fn.name
+					break
+				}
+			}
+			dataField.NumFmt, _ = strconv.Atoi(field.NumFmtID)
+			opts.Data = append(opts.Data, dataField)
 		}
 	}
 }
@@ -908,6 +1008,17 @@ func extractPivotTableField(data string, fld *xlsxPivotField) PivotTableField {
 			mutable.FieldByName(field).SetBool(immutableField.Elem().Bool())
 		}
 	}
+	for _, fn := range pivotTableSubtotals {
+		itemType := fn.itemType
+		if immutable.FieldByName(strings.ToUpper(itemType[:1]) + itemType[1:] + "Subtotal").Bool() {
+			pivotTableField.Subtotal, pivotTableField.DefaultSubtotal = fn.name, true
+			break
+		}
+	}
+	if fld.SortType == "ascending" || fld.SortType == "descending" {
+		pivotTableField.Sort = strings.ToUpper(fld.SortType[:1]) + fld.SortType[1:]
+	}
+	pivotTableField.NumFmt, _ = strconv.Atoi(fld.NumFmtID)
 	return pivotTableField
 }
 
@@ -965,9 +1076,36 @@ func (f *File) deleteWorkbookPivotCache(opt PivotTableOptions) error {
 	return err
 }
 
+// deletePivotCacheParts provides a function to remove the pivot cache
+// definition part, the pivot cache records parts and the relationships of them
+// by given pivot cache definition part path.
+func (f *File) deletePivotCacheParts(pivotCacheXML string) {
+	pivotCacheXML = strings.TrimPrefix(pivotCacheXML, "/")
+	pivotCacheRels := "xl/pivotCache/_rels/" + filepath.Base(pivotCacheXML) + ".rels"
+	if rels, _ := f.relsReader(pivotCacheRels); rels != nil {
+		for _, rel := range rels.Relationships {
+			if rel.Type != SourceRelationshipPivotCacheRecords {
+				continue
+			}
+			pivotCacheRecordsXML := strings.TrimPrefix(path.Join(path.Dir(pivotCacheXML), rel.Target), "/")
+			if strings.HasPrefix(rel.Target, "/") {
+				pivotCacheRecordsXML = strings.TrimPrefix(rel.Target, "/")
+			}
+			_ = f.removeContentTypesPart(ContentTypeSpreadSheetMLPivotCacheRecords, "/"+pivotCacheRecordsXML)
+			f.Pkg.Delete(pivotCacheRecordsXML)
+		}
+	}
+	_ = f.removeContentTypesPart(ContentTypeSpreadSheetMLPivotCacheDefinition, "/"+pivotCacheXML)
+	f.Pkg.Delete(pivotCacheXML)
+	f.Pkg.Delete(pivotCacheRels)
+	f.Relationships.Delete(pivotCacheRels)
+}
+
 // DeletePivotTable delete a pivot table by giving the worksheet name and pivot
-// table name. Note that this function does not clean cell values in the pivot
-// table range.
+// table name. The pivot table part and its relationships will be removed, and
+// the pivot cache parts will also be removed if the pivot cache isn't used by
+// other pivot tables. Note that this function does not clean cell values in
+// the pivot table range.
 func (f *File) DeletePivotTable(sheet, name string) error {
 	sheetXML, ok := f.getSheetXMLPath(sheet)
 	if !ok {
@@ -998,9 +1136,18 @@ func (f *File) DeletePivotTable(sheet, name string) error {
 				pivotTableXML := strings.ReplaceAll(v.Target, "..", "xl")
 				if opt.Name == name && opt.pivotTableXML == pivotTableXML {
 					if pivotTableCaches[opt.pivotCacheXML] == 1 {
-						err = f.deleteWorkbookPivotCache(opt)
+						if err = f.deleteWorkbookPivotCache(opt); err != nil {
+							return err
+						}
+						f.deletePivotCacheParts(opt.pivotCacheXML)
 					}
 					f.deleteSheetRelationships(sheet, v.ID)
+					pivotTableXML = strings.TrimPrefix(pivotTableXML, "/")
+					pivotTableRels := "xl/pivotTables/_rels/" + filepath.Base(pivotTableXML) + ".rels"
+					_ = f.removeContentTypesPart(ContentTypeSpreadSheetMLPivotTable, "/"+pivotTableXML)
+					f.Pkg.Delete(pivotTableXML)
+					f.Pkg.Delete(pivotTableRels)
+					f.Relationships.Delete(pivotTableRels)
 					return err
 				}
 			}
@@ -1008,3 +1155,103 @@ func (f *File) DeletePivotTable(sheet, name string) error {
 	}
 	return newNoExistTableError(name)
 }
+
+// SetPivotTableSource provides a function to update the data source of the
+// pivot table by given worksheet name, pivot table name and data source
+// settings. The pivot cache fields will be regenerated based on the first row
+// of the data range, and the pivot table will be regenerated with the settings
+// which could be got by the GetPivotTables function, so that the row, column,
+// filter and data fields of the pivot table must exist in the new data range.
+// The pivot cache records will not be stored in the workbook, so the pivot
+// table will be always set to refresh on load when the data range has been
+// changed, to let the spreadsheet application rebuild the pivot cache records
+// and show the fresh data of the data range when the workbook is opened. Set
+// RefreshOnLoad to true to refresh the pivot table on load with the current
+// data range. A new pivot cache will be created for the pivot table if its
+// pivot cache is shared with other pivot tables. For example, update the data
+// range of the pivot table named PivotTable1 on Sheet1 after appending rows
+// to the data:
+//
+//	err := f.SetPivotTableSource("Sheet1", "PivotTable1", &excelize.PivotTableSourceOptions{
+//	    DataRange:     "Sheet1!A1:E41",
+//	    RefreshOnLoad: true,
+//	})
+func (f *File) SetPivotTableSource(sheet, name string, opts *PivotTableSourceOptions) error {
+	if opts == nil {
+		return ErrParameterRequired
+	}
+	pivotTables, err := f.GetPivotTables(sheet)
+	if err != nil {
+		return err
+	}
+	for _, pivotTable := range pivotTables {
+		if pivotTable.Name != name {
+			continue
+		}
+		rangeChanged := opts.DataRange != "" && opts.DataRange != pivotTable.DataRange
+		if rangeChanged {
+			pivotTable.DataRange, pivotTable.pivotDataRange, pivotTable.namedDataRange = opts.DataRange, "", false
+		}
+		pivotTable.DisableRefreshOnLoad = !opts.RefreshOnLoad && !rangeChanged
+		if _, _, err = f.parseFormatPivotTableSet(&pivotTable); err != nil {
+			return err
+		}
+		order, err := f.getTableFieldsOrder(&pivotTable)
+		if err != nil {
+			return err
+		}
+		for _, fields := range [][]PivotTableField{pivotTable.Rows, pivotTable.Columns, pivotTable.Filter, pivotTable.Data} {
+			for _, field := range fields {
+				if inStrSlice(order, field.Data, true) == -1 {
+					return newPivotTableDataRangeError(ErrParameterInvalid.Error())
+				}
+			}
+		}
+		pt, err := f.pivotTableReader(pivotTable.pivotTableXML)
+		if err != nil {
+			return err
+		}
+		cacheID, err := f.detachPivotCache(&pivotTable, pt.CacheID)
+		if err != nil {
+			return err
+		}
+		if err = f.addPivotCache(&pivotTable); err != nil {
+			return err
+		}
+		return f.addPivotTable(cacheID, 0, &pivotTable)
+	}
+	return newNoExistTableError(name)
+}
+
+// detachPivotCache provides a function to create a new pivot cache for the
+// pivot table if its pivot cache is shared with other pivot tables, and
+// returns the pivot cache ID of the pivot table by given pivot table settings
+// and the current pivot cache ID.
+func (f *File) detachPivotCache(opts *PivotTableOptions, cacheID int) (int, error) {
+	var shared int
+	for _, sheetName := range f.GetSheetList() {
+		sheetPivotTables, _ := f.GetPivotTables(sheetName)
+		for _, sheetPivotTable := range sheetPivotTables {
+			if sheetPivotTable.pivotCacheXML == opts.pivotCacheXML {
+				shared++
+			}
+		}
+	}
+	if shared < 2 {
+		return cacheID, nil
+	}
+	pivotCacheID := f.countPivotCache() + 1
+	opts.pivotCacheXML = "xl/pivotCache/pivotCacheDefinition" + strconv.Itoa(pivotCacheID) + ".xml"
+	workBookPivotCacheRID := f.addRels(f.getWorkbookRelsPath(), SourceRelationshipPivotCache, strings.TrimPrefix(opts.pivotCacheXML, "xl/"), "")
+	pivotCacheRels := "xl/pivotTables/_rels/" + filepath.Base(opts.pivotTableXML) + ".rels"
+	rels, err := f.relsReader(pivotCacheRels)
+	if err != nil {
+		return cacheID, err
+	}
+	for idx, rel := range rels.Relationships {
+		if rel.Type == SourceRelationshipPivotCache {
+			rels.Relationships[idx].Target = fmt.Sprintf("../pivotCache/pivotCacheDefinition%d.xml", pivotCacheID)
+		}
+	}
+	return f.addWorkbookPivotCache(workBookPivotCacheRID), f.addContentTypePart(pivotCacheID, "pivotCache")
+}
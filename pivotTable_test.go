@@ -255,6 +255,27 @@ func TestPivotTable(t *testing.T) {
 	assert.Len(t, pivotTables, 6)
 	assert.NoError(t, err)
 
+	// Test get pivot table with data range on another worksheet
+	_, err = f.NewSheet("Sheet3")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddPivotTable(&PivotTableOptions{
+		DataRange:       "Sheet1!A1:E31",
+		PivotTableRange: "Sheet3!A1:G30",
+		Rows:            []PivotTableField{{Data: "Month"}},
+		Columns:         []PivotTableField{{Data: "Type"}},
+		Filter:          []PivotTableField{{Data: "Region"}},
+		Data:            []PivotTableField{{Data: "Sales", Name: "Sum of Sales"}},
+	}))
+	pivotTables, err = f.GetPivotTables("Sheet3")
+	assert.NoError(t, err)
+	assert.Len(t, pivotTables, 1)
+	assert.Equal(t, "Sheet1!A1:E31", pivotTables[0].DataRange)
+	assert.Equal(t, "Sheet3!A1:G30", pivotTables[0].PivotTableRange)
+	assert.Equal(t, []PivotTableField{{Data: "Month"}}, pivotTables[0].Rows)
+	assert.Equal(t, []PivotTableField{{Data: "Type"}}, pivotTables[0].Columns)
+	assert.Equal(t, []PivotTableField{{Data: "Region"}}, pivotTables[0].Filter)
+	assert.Equal(t, []PivotTableField{{Data: "Sales", Name: "Sum of Sales", Subtotal: "Sum"}}, pivotTables[0].Data)
+
 	// Test add pivot table with invalid sheet name
 	assert.EqualError(t, f.AddPivotTable(&PivotTableOptions{
 		DataRange:       "Sheet:1!A1:E31",
@@ -338,6 +359,75 @@ func TestPivotTable(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestPivotTableFieldSettings(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Month", "Year", "Type", "Sales", "Region"}))
+	for row, cells := range [][]interface{}{
+		{"Jan", 2017, "Meat", 100, "East"},
+		{"Feb", 2018, "Dairy", 200, "West"},
+		{"Mar", 2019, "Produce", 300, "North"},
+	} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row+2), &cells))
+	}
+	opts := &PivotTableOptions{
+		DataRange:       "Sheet1!A1:E4",
+		PivotTableRange: "Sheet1!G2:M20",
+		Rows:            []PivotTableField{{Data: "Month", DefaultSubtotal: true, Subtotal: "Average", Sort: "descending"}, {Data: "Year", Sort: "Ascending"}},
+		Columns:         []PivotTableField{{Data: "Type", DefaultSubtotal: true, Subtotal: "CountNums", NumFmt: 1}},
+		Data: []PivotTableField{
+			{Data: "Sales", Subtotal: "max", NumFmt: 4},
+			{Data: "Sales", Subtotal: "StdDevp"},
+			{Data: "Sales", Subtotal: "Count", Name: "Count of Sales"},
+			{Data: "Sales", Subtotal: "CountNums"},
+		},
+	}
+	assert.NoError(t, f.AddPivotTable(opts))
+	pc, err := f.pivotCacheReader("xl/pivotCache/pivotCacheDefinition1.xml")
+	assert.NoError(t, err)
+	assert.True(t, pc.RefreshOnLoad)
+	pt, err := f.pivotTableReader("xl/pivotTables/pivotTable1.xml")
+	assert.NoError(t, err)
+	assert.True(t, pt.PivotFields.PivotField[0].AvgSubtotal)
+	assert.False(t, *pt.PivotFields.PivotField[0].DefaultSubtotal)
+	assert.Equal(t, "avg", pt.PivotFields.PivotField[0].Items.Item[0].T)
+	assert.Equal(t, "descending", pt.PivotFields.PivotField[0].SortType)
+	assert.True(t, pt.PivotFields.PivotField[2].CountSubtotal)
+	assert.Equal(t, "1", pt.PivotFields.PivotField[2].NumFmtID)
+	// Test get pivot table with field settings
+	pivotTables, err := f.GetPivotTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, pivotTables, 1)
+	assert.False(t, pivotTables[0].DisableRefreshOnLoad)
+	assert.Equal(t, []PivotTableField{
+		{Data: "Month", DefaultSubtotal: true, Subtotal: "Average", Sort: "Descending"},
+		{Data: "Year", Sort: "Ascending"},
+	}, pivotTables[0].Rows)
+	assert.Equal(t, []PivotTableField{{Data: "Type", DefaultSubtotal: true, Subtotal: "CountNums", NumFmt: 1}}, pivotTables[0].Columns)
+	assert.Equal(t, []PivotTableField{
+		{Data: "Sales", Name: "Max of Sales", Subtotal: "Max", NumFmt: 4},
+		{Data: "Sales", Name: "StdDevp of Sales", Subtotal: "StdDevp"},
+		{Data: "Sales", Name: "Count of Sales", Subtotal: "Count"},
+		{Data: "Sales", Name: "Count of Sales2", Subtotal: "CountNums"},
+	}, pivotTables[0].Data)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestPivotTableFieldSettings.xlsx")))
+	// Test add pivot table with refresh on load disabled
+	assert.NoError(t, f.AddPivotTable(&PivotTableOptions{
+		DataRange:            "Sheet1!A1:E4",
+		PivotTableRange:      "Sheet1!G30:M40",
+		Rows:                 []PivotTableField{{Data: "Month"}},
+		Data:                 []PivotTableField{{Data: "Sales"}},
+		DisableRefreshOnLoad: true,
+	}))
+	pc, err = f.pivotCacheReader("xl/pivotCache/pivotCacheDefinition2.xml")
+	assert.NoError(t, err)
+	assert.False(t, pc.RefreshOnLoad)
+	pivotTables, err = f.GetPivotTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, pivotTables, 2)
+	assert.True(t, pivotTables[1].DisableRefreshOnLoad)
+	assert.NoError(t, f.Close())
+}
+
 func TestPivotTableDataRange(t *testing.T) {
 	f := NewFile()
 	// Create table in a worksheet
@@ -485,3 +575,159 @@ func TestDeleteWorkbookPivotCache(t *testing.T) {
 	f.Pkg.Store("xl/_rels/workbook.xml.rels", MacintoshCyrillicCharset)
 	assert.EqualError(t, f.deleteWorkbookPivotCache(PivotTableOptions{pivotCacheXML: "pivotCache/pivotCacheDefinition1.xml"}), "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestSetPivotTableSource(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Month", "Type", "Sales"}))
+	for row := 2; row < 12; row++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row), &[]interface{}{"Jan", "Meat", row * 10}))
+	}
+	opts := &PivotTableOptions{
+		DataRange:       "Sheet1!A1:C6",
+		PivotTableRange: "Sheet1!G2:M34",
+		Rows:            []PivotTableField{{Data: "Month", DefaultSubtotal: true}},
+		Columns:         []PivotTableField{{Data: "Type"}},
+		Data:            []PivotTableField{{Data: "Sales", Subtotal: "Sum", Name: "Summarize by Sum"}},
+		RowGrandTotals:  true,
+		ShowRowHeaders:  true,
+	}
+	assert.NoError(t, f.AddPivotTable(opts))
+	// Test update the data range and refresh on load setting of the pivot table
+	assert.NoError(t, f.SetPivotTableSource("Sheet1", "PivotTable1", &PivotTableSourceOptions{
+		DataRange: "Sheet1!A1:C11", RefreshOnLoad: true,
+	}))
+	pivotTables, err := f.GetPivotTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, pivotTables, 1)
+	assert.Equal(t, "Sheet1!A1:C11", pivotTables[0].DataRange)
+	assert.False(t, pivotTables[0].DisableRefreshOnLoad)
+	assert.Equal(t, opts.Rows, pivotTables[0].Rows)
+	assert.Equal(t, opts.Columns, pivotTables[0].Columns)
+	assert.Equal(t, opts.Data, pivotTables[0].Data)
+	assert.True(t, pivotTables[0].RowGrandTotals)
+	assert.True(t, pivotTables[0].ShowRowHeaders)
+	// Test toggle the refresh on load setting with the current data range
+	assert.NoError(t, f.SetPivotTableSource("Sheet1", "PivotTable1", &PivotTableSourceOptions{}))
+	pivotTables, err = f.GetPivotTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sheet1!A1:C11", pivotTables[0].DataRange)
+	assert.True(t, pivotTables[0].DisableRefreshOnLoad)
+	assert.NoError(t, f.SetPivotTableSource("Sheet1", "PivotTable1", &PivotTableSourceOptions{DataRange: "Sheet1!A1:C11"}))
+	pivotTables, err = f.GetPivotTables("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, pivotTables[0].DisableRefreshOnLoad)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetPivotTableSource.xlsx")))
+
+	// Test update the pivot table with the shared pivot cache
+	assert.NoError(t, f.AddPivotTable(&PivotTableOptions{
+		DataRange:       "Sheet1!A1:C6",
+		PivotTableRange: "Sheet1!O2:U34",
+		Name:            "PivotTable2",
+		Rows:            []PivotTableField{{Data: "Type"}},
+		Data:            []PivotTableField{{Data: "Sales"}},
+	}))
+	rels, err := f.relsReader("xl/pivotTables/_rels/pivotTable2.xml.rels")
+	assert.NoError(t, err)
+	rels.Relationships[0].Target = "../pivotCache/pivotCacheDefinition1.xml"
+	assert.NoError(t, f.SetPivotTableSource("Sheet1", "PivotTable2", &PivotTableSourceOptions{DataRange: "Sheet1!A1:C8"}))
+	pivotTables, err = f.GetPivotTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, pivotTables, 2)
+	assert.Equal(t, "Sheet1!A1:C11", pivotTables[0].DataRange)
+	assert.Equal(t, "Sheet1!A1:C8", pivotTables[1].DataRange)
+	assert.False(t, pivotTables[1].DisableRefreshOnLoad)
+	assert.Equal(t, "xl/pivotCache/pivotCacheDefinition3.xml", pivotTables[1].pivotCacheXML)
+	pt, err := f.pivotTableReader("xl/pivotTables/pivotTable2.xml")
+	assert.NoError(t, err)
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	assert.Len(t, wb.PivotCaches.PivotCache, 3)
+	assert.Equal(t, wb.PivotCaches.PivotCache[2].CacheID, pt.CacheID)
+
+	// Test update the pivot table with nil options
+	assert.Equal(t, ErrParameterRequired, f.SetPivotTableSource("Sheet1", "PivotTable1", nil))
+	// Test update the not exists pivot table
+	assert.EqualError(t, f.SetPivotTableSource("Sheet1", "PivotTable3", &PivotTableSourceOptions{}), "table PivotTable3 does not exist")
+	// Test update the pivot table on not exists worksheet
+	assert.EqualError(t, f.SetPivotTableSource("SheetN", "PivotTable1", &PivotTableSourceOptions{}), "sheet SheetN does not exist")
+	// Test update the pivot table with invalid data range
+	assert.EqualError(t, f.SetPivotTableSource("Sheet1", "PivotTable1", &PivotTableSourceOptions{DataRange: "Sheet1!A1:A1"}),
+		newPivotTableDataRangeError(ErrParameterInvalid.Error()).Error())
+	// Test update the pivot table with the data range without the pivot table fields
+	assert.EqualError(t, f.SetPivotTableSource("Sheet1", "PivotTable1", &PivotTableSourceOptions{DataRange: "Sheet1!B1:C11"}),
+		newPivotTableDataRangeError(ErrParameterInvalid.Error()).Error())
+	// Test update the pivot table with unsupported charset pivot table
+	f.Pkg.Store("xl/pivotTables/pivotTable1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetPivotTableSource("Sheet1", "PivotTable1", &PivotTableSourceOptions{}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestDeletePivotTableParts(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Month", "Type", "Sales"}))
+	for row := 2; row < 6; row++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row), &[]interface{}{"Jan", "Meat", row * 10}))
+	}
+	for idx, pivotTableRange := range []string{"Sheet1!G2:M34", "Sheet1!O2:U34"} {
+		assert.NoError(t, f.AddPivotTable(&PivotTableOptions{
+			DataRange:       "Sheet1!A1:C5",
+			PivotTableRange: pivotTableRange,
+			Name:            fmt.Sprintf("PivotTable%d", idx+1),
+			Rows:            []PivotTableField{{Data: "Month"}},
+			Data:            []PivotTableField{{Data: "Sales"}},
+		}))
+	}
+	// Prepare the pivot cache records part of the first pivot cache
+	f.Pkg.Store("xl/pivotCache/pivotCacheRecords1.xml", []byte(`<pivotCacheRecords count="0"/>`))
+	f.addRels("xl/pivotCache/_rels/pivotCacheDefinition1.xml.rels", SourceRelationshipPivotCacheRecords, "pivotCacheRecords1.xml", "")
+	content, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	content.Overrides = append(content.Overrides, xlsxOverride{
+		PartName: "/xl/pivotCache/pivotCacheRecords1.xml", ContentType: ContentTypeSpreadSheetMLPivotCacheRecords,
+	})
+	// Test delete pivot table with the pivot table and pivot cache parts
+	assert.NoError(t, f.DeletePivotTable("Sheet1", "PivotTable1"))
+	for _, part := range []string{
+		"xl/pivotTables/pivotTable1.xml", "xl/pivotTables/_rels/pivotTable1.xml.rels",
+		"xl/pivotCache/pivotCacheDefinition1.xml", "xl/pivotCache/_rels/pivotCacheDefinition1.xml.rels",
+		"xl/pivotCache/pivotCacheRecords1.xml",
+	} {
+		_, ok := f.Pkg.Load(part)
+		assert.False(t, ok, part)
+	}
+	for _, override := range content.Overrides {
+		assert.NotContains(t, []string{
+			"/xl/pivotTables/pivotTable1.xml", "/xl/pivotCache/pivotCacheDefinition1.xml", "/xl/pivotCache/pivotCacheRecords1.xml",
+		}, override.PartName)
+	}
+	// Test add pivot table after delete pivot table without overwriting the existing parts
+	assert.NoError(t, f.AddPivotTable(&PivotTableOptions{
+		DataRange:       "Sheet1!A1:C5",
+		PivotTableRange: "Sheet1!G2:M34",
+		Name:            "PivotTable3",
+		Rows:            []PivotTableField{{Data: "Type"}},
+		Data:            []PivotTableField{{Data: "Sales"}},
+	}))
+	pivotTables, err := f.GetPivotTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, pivotTables, 2)
+	assert.Equal(t, "xl/pivotTables/pivotTable2.xml", pivotTables[0].pivotTableXML)
+	assert.Equal(t, "xl/pivotCache/pivotCacheDefinition2.xml", pivotTables[0].pivotCacheXML)
+	assert.Equal(t, "xl/pivotTables/pivotTable3.xml", pivotTables[1].pivotTableXML)
+	assert.Equal(t, "xl/pivotCache/pivotCacheDefinition3.xml", pivotTables[1].pivotCacheXML)
+	// Test delete pivot table with the pivot cache shared with other pivot tables
+	rels, err := f.relsReader("xl/pivotTables/_rels/pivotTable3.xml.rels")
+	assert.NoError(t, err)
+	rels.Relationships[0].Target = "../pivotCache/pivotCacheDefinition2.xml"
+	assert.NoError(t, f.DeletePivotTable("Sheet1", "PivotTable2"))
+	_, ok := f.Pkg.Load("xl/pivotTables/pivotTable2.xml")
+	assert.False(t, ok)
+	_, ok = f.Pkg.Load("xl/pivotCache/pivotCacheDefinition2.xml")
+	assert.True(t, ok)
+	pivotTables, err = f.GetPivotTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, pivotTables, 1)
+	assert.Equal(t, "PivotTable3", pivotTables[0].Name)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeletePivotTableParts.xlsx")))
+	assert.NoError(t, f.Close())
+}
@@ -85,11 +85,12 @@ type Rows struct {
 	needClose, rawCellValue bool
 	sheet                   string
 	f                       *File
-	tempFile                *os.File
+	tempFile                io.ReadCloser
 	sst                     *xlsxSST
 	decoder                 *xml.Decoder
 	token                   xml.Token
 	curRowOpts, seekRowOpts RowOpts
+	sharedFormulas          map[int]SharedFormula
 }
 
 // Next will return true if it finds the next row element.
@@ -100,7 +101,10 @@ func (rows *Rows) Next() bool {
 		return true
 	}
 	for {
-		token, _ := rows.decoder.Token()
+		token, err := rows.decoder.Token()
+		if err != nil && err != io.EOF {
+			rows.err = err
+		}
 		if token == nil {
 			return false
 		}
@@ -128,7 +132,8 @@ func (rows *Rows) GetRowOpts() RowOpts {
 	return rows.curRowOpts
 }
 
-// Error will return the error when the error occurs.
+// Error will return the error when the error occurs, such as the worksheet
+// XML is malformed during iteration.
 func (rows *Rows) Error() error {
 	return rows.err
 }
@@ -146,19 +151,61 @@ func (rows *Rows) Close() error {
 // data as a stream, returns each cell in a row as is, and will not skip empty
 // rows in the tail of the worksheet.
 func (rows *Rows) Columns(opts ...Options) ([]string, error) {
+	var rowIterator rowXMLIterator
+	rows.rawCellValue = getOptions(opts...).RawCellValue
+	rows.readRow(&rowIterator)
+	return rowIterator.cells, rowIterator.err
+}
+
+// Cells return the cells of the current row, including the cell reference,
+// data type, style index, formula and the value of each cell. This fetches the
+// worksheet data as a stream like the Columns does, the cells which not exist
+// in the worksheet will be skipped, and the formula of the cells which share
+// the base formula will be resolved during the iteration. For example, get the
+// cell styles and types on Sheet1 with bounded memory:
+//
+//	rows, err := f.Rows("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for rows.Next() {
+//	    cells, err := rows.Cells()
+//	    if err != nil {
+//	        fmt.Println(err)
+//	        break
+//	    }
+//	    for _, cell := range cells {
+//	        fmt.Println(cell.Ref, cell.Type, cell.StyleID, cell.Formula, cell.Value)
+//	    }
+//	}
+//	if err = rows.Close(); err != nil {
+//	    fmt.Println(err)
+//	}
+func (rows *Rows) Cells(opts ...Options) ([]RowCell, error) {
+	rowIterator := rowXMLIterator{withRowCells: true}
+	rows.rawCellValue = getOptions(opts...).RawCellValue
+	rows.readRow(&rowIterator)
+	return rowIterator.rowCells, rowIterator.err
+}
+
+// readRow read the cells of the current row by given row iterator.
+func (rows *Rows) readRow(rowIterator *rowXMLIterator) {
 	if rows.curRow > rows.seekRow {
-		return nil, nil
+		return
 	}
-	var rowIterator rowXMLIterator
 	var token xml.Token
-	rows.rawCellValue = getOptions(opts...).RawCellValue
 	if rows.sst, rowIterator.err = rows.f.sharedStringsReader(); rowIterator.err != nil {
-		return rowIterator.cells, rowIterator.err
+		return
 	}
 	for {
 		if rows.token != nil {
 			token = rows.token
-		} else if token, _ = rows.decoder.Token(); token == nil {
+		} else if token, rowIterator.err = rows.decoder.Token(); token == nil {
+			if rowIterator.err == io.EOF {
+				rowIterator.err = nil
+			}
+			rows.err = rowIterator.err
 			break
 		}
 		switch xmlElement := token.(type) {
@@ -175,21 +222,20 @@ func (rows *Rows) Columns(opts ...Options) ([]string, error) {
 				rows.seekRowOpts = extractRowOpts(xmlElement.Attr)
 				if rows.curRow > rows.seekRow {
 					rows.token = nil
-					return rowIterator.cells, rowIterator.err
+					return
 				}
 			}
-			if rows.rowXMLHandler(&rowIterator, &xmlElement, rows.rawCellValue); rowIterator.err != nil {
+			if rows.rowXMLHandler(rowIterator, &xmlElement, rows.rawCellValue); rowIterator.err != nil {
 				rows.token = nil
-				return rowIterator.cells, rowIterator.err
+				return
 			}
 			rows.token = nil
 		case xml.EndElement:
 			if xmlElement.Name.Local == "sheetData" {
-				return rowIterator.cells, rowIterator.err
+				return
 			}
 		}
 	}
-	return rowIterator.cells, rowIterator.err
 }
 
 // extractRowOpts extract row element attributes.
@@ -221,6 +267,8 @@ type rowXMLIterator struct {
 	inElement        string
 	cellCol, cellRow int
 	cells            []string
+	withRowCells     bool
+	rowCells         []RowCell
 }
 
 // rowXMLHandler parse the row XML element of the worksheet.
@@ -228,12 +276,18 @@ func (rows *Rows) rowXMLHandler(rowIterator *rowXMLIterator, xmlElement *xml.Sta
 	if rowIterator.inElement == "c" {
 		rowIterator.cellCol++
 		colCell := xlsxC{}
-		_ = rows.decoder.DecodeElement(&colCell, xmlElement)
+		if rowIterator.err = rows.decoder.DecodeElement(&colCell, xmlElement); rowIterator.err != nil {
+			return
+		}
 		if colCell.R != "" {
 			if rowIterator.cellCol, _, rowIterator.err = CellNameToCoordinates(colCell.R); rowIterator.err != nil {
 				return
 			}
 		}
+		if rowIterator.withRowCells {
+			rowIterator.rowCells = append(rowIterator.rowCells, rows.newRowCell(&colCell, rowIterator.cellCol, raw))
+			return
+		}
 		blank := rowIterator.cellCol - len(rowIterator.cells)
 		if val, _ := colCell.getValueFrom(rows.f, rows.sst, raw); val != "" || colCell.F != nil {
 			rowIterator.cells = append(appendSpace(blank, rowIterator.cells), val)
@@ -241,9 +295,42 @@ func (rows *Rows) rowXMLHandler(rowIterator *rowXMLIterator, xmlElement *xml.Sta
 	}
 }
 
+// newRowCell create the cell data of the rows iterator by given decoded cell,
+// column number and if get the raw cell value. The shared formula of the
+// master cell will be kept for resolving the formula of the following cells
+// which share it.
+func (rows *Rows) newRowCell(c *xlsxC, col int, raw bool) RowCell {
+	cell := RowCell{Ref: c.R, Type: cellTypes[c.T], StyleID: c.S}
+	if cell.Ref == "" {
+		cell.Ref, _ = CoordinatesToCellName(col, rows.curRow)
+	}
+	cell.Value, _ = c.getValueFrom(rows.f, rows.sst, raw)
+	if c.F == nil {
+		return cell
+	}
+	cell.Formula = c.F.Content
+	if c.F.T != STCellFormulaTypeShared || c.F.Si == nil {
+		return cell
+	}
+	if c.F.Ref != "" {
+		if rows.sharedFormulas == nil {
+			rows.sharedFormulas = make(map[int]SharedFormula)
+		}
+		rows.sharedFormulas[*c.F.Si] = SharedFormula{Master: cell.Ref, Ref: c.F.Ref, Formula: c.F.Content}
+		return cell
+	}
+	if formula, ok := rows.sharedFormulas[*c.F.Si]; ok {
+		cell.Formula = shiftSharedFormula(formula.Master, formula.Formula, cell.Ref)
+	}
+	return cell
+}
+
 // Rows returns a rows iterator, used for streaming reading data for a
-// worksheet with a large data. This function is concurrency safe. For
-// example:
+// worksheet with a large data. This function is concurrency safe. The
+// worksheet which exceeds the UnzipXMLSizeLimit will be decoded row by row
+// from the archive entry directly with bounded memory if it has not been
+// loaded, and the Cells function of the iterator could be used to get the data
+// type, style index and formula of each cell during iteration. For example:
 //
 //	rows, err := f.Rows("Sheet1")
 //	if err != nil {
@@ -335,18 +422,20 @@ func (f *File) getFromStringItem(index int) string {
 	return f.getFromStringItem(index)
 }
 
-// xmlDecoder creates XML decoder by given path in the zip from memory data
-// or system temporary file.
-func (f *File) xmlDecoder(name string) (bool, *xml.Decoder, *os.File, error) {
+// xmlDecoder creates XML decoder by given path in the zip from memory data,
+// system temporary file or the archive entry which has not been extracted.
+func (f *File) xmlDecoder(name string) (bool, *xml.Decoder, io.ReadCloser, error) {
 	var (
 		content  []byte
 		err      error
-		tempFile *os.File
+		tempFile io.ReadCloser
 	)
 	if content = f.readXML(name); len(content) > 0 {
 		return false, f.xmlNewDecoder(bytes.NewReader(content)), tempFile, err
 	}
-	tempFile, err = f.readTemp(name)
+	if tempFile, err = f.readTemp(name); err != nil || tempFile == nil {
+		return false, f.xmlNewDecoder(bytes.NewReader(content)), nil, err
+	}
 	return true, f.xmlNewDecoder(tempFile), tempFile, err
 }
 
@@ -544,6 +633,82 @@ func (f *File) GetRowOutlineLevel(sheet string, row int) (uint8, error) {
 	return ws.SheetData.Row[row-1].OutlineLevel, nil
 }
 
+// SetRowGroup provides a function to group rows by given worksheet name, the
+// range of Excel row numbers, outline level and collapsed state. The value of
+// parameter 'level' is 1-7. The rows in the group will be hidden if the group
+// is collapsed, and the collapsed state will be stored on the summary row of
+// the group, which is the next row below the group by default, or the
+// previous row above the group if the summary rows are not below detail. The
+// direction of the summary rows and columns can be set by the
+// 'OutlineSummaryBelow' and 'OutlineSummaryRight' of the 'SetSheetProps'
+// function, and the columns can be grouped by the 'SetColGroup' function.
+// This function is concurrency safe. For example, group the rows 2 to 5 in
+// Sheet1 to level 1 and collapse the group:
+//
+//	err := f.SetRowGroup("Sheet1", 2, 5, 1, true)
+func (f *File) SetRowGroup(sheet string, start, end, level int, collapsed bool) error {
+	if end < start {
+		start, end = end, start
+	}
+	if start < 1 {
+		return newInvalidRowNumberError(start)
+	}
+	if end > TotalRows {
+		return ErrMaxRows
+	}
+	if level > 7 || level < 1 {
+		return ErrOutlineLevel
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	summary := end + 1
+	if ws.SheetPr != nil && ws.SheetPr.OutlinePr != nil &&
+		ws.SheetPr.OutlinePr.SummaryBelow != nil && !*ws.SheetPr.OutlinePr.SummaryBelow {
+		summary = start - 1
+	}
+	if summary > TotalRows {
+		summary = 0
+	}
+	if ws.prepareSheetXML(0, end); summary > end {
+		ws.prepareSheetXML(0, summary)
+	}
+	for row := start; row <= end; row++ {
+		ws.SheetData.Row[row-1].OutlineLevel = uint8(level)
+		ws.SheetData.Row[row-1].Hidden = collapsed
+	}
+	if summary > 0 {
+		ws.SheetData.Row[summary-1].Collapsed = collapsed
+	}
+	return nil
+}
+
+// GetRowCollapsed provides a function to get the collapsed state of the
+// group which summary row is the given Excel row number on the worksheet.
+// For example, get collapsed state of the group on the summary row 6 in
+// Sheet1:
+//
+//	collapsed, err := f.GetRowCollapsed("Sheet1", 6)
+func (f *File) GetRowCollapsed(sheet string, row int) (bool, error) {
+	if row < 1 {
+		return false, newInvalidRowNumberError(row)
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return false, err
+	}
+	if row > len(ws.SheetData.Row) {
+		return false, nil
+	}
+	return ws.SheetData.Row[row-1].Collapsed, nil
+}
+
 // RemoveRow provides a function to remove single row by given worksheet name
 // and Excel row number. For example, remove row 3 in Sheet1:
 //
@@ -860,8 +1025,10 @@ func (r *xlsxRow) hasAttr() bool {
 }
 
 // SetRowStyle provides a function to set the style of rows by given worksheet
-// name, row range, and style ID. Note that this will overwrite the existing
-// styles for the rows, it won't append or merge style with existing styles.
+// name, row range, and style ID. This function is concurrency safe. Note that
+// this will overwrite the existing styles for the rows, it won't append or
+// merge style with existing styles. The cells written in these rows later
+// will inherit the row style, unless a style has been specified for the cell.
 //
 // For example set style of row 1 on Sheet1:
 //
@@ -880,19 +1047,26 @@ func (f *File) SetRowStyle(sheet string, start, end, styleID int) error {
 	if end > TotalRows {
 		return ErrMaxRows
 	}
+	f.mu.Lock()
 	s, err := f.stylesReader()
 	if err != nil {
+		f.mu.Unlock()
 		return err
 	}
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	if styleID < 0 || s.CellXfs == nil || len(s.CellXfs.Xf) <= styleID {
-		return newInvalidStyleID(styleID)
-	}
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
+		f.mu.Unlock()
 		return err
 	}
+	f.mu.Unlock()
+	s.mu.Lock()
+	if styleID < 0 || s.CellXfs == nil || len(s.CellXfs.Xf) <= styleID {
+		s.mu.Unlock()
+		return newInvalidStyleID(styleID)
+	}
+	s.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
 	ws.prepareSheetXML(0, end)
 	for row := start - 1; row < end; row++ {
 		ws.SheetData.Row[row].S = styleID
@@ -138,6 +138,85 @@ func TestRowsGetRowOpts(t *testing.T) {
 	assert.Equal(t, expectedRowStyleID3, rowOpts)
 }
 
+func TestRowsCells(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{NumFmt: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"text", 1.5, true}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"text", 2.5, false}))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B2", style))
+	formulaType, ref := STCellFormulaTypeShared, "D1:D2"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "B1*2", FormulaOpts{Type: &formulaType, Ref: &ref}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A4", "end"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRowsCells.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test get cells of the rows with the worksheet decoded from the archive
+	f, err = OpenFile(filepath.Join("test", "TestRowsCells.xlsx"), Options{UnzipXMLSizeLimit: 128})
+	assert.NoError(t, err)
+	_, ok := f.zipFiles.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	rows, err := f.Rows("Sheet1")
+	assert.NoError(t, err)
+	var collectedCells [][]RowCell
+	for rows.Next() {
+		cells, err := rows.Cells()
+		assert.NoError(t, err)
+		collectedCells = append(collectedCells, cells)
+	}
+	assert.NoError(t, rows.Error())
+	assert.NoError(t, rows.Close())
+	assert.Len(t, collectedCells, 4)
+	assert.Equal(t, []RowCell{
+		{Ref: "A1", Type: CellTypeSharedString, Value: "text"},
+		{Ref: "B1", StyleID: style, Value: "1.50"},
+		{Ref: "C1", Type: CellTypeBool, Value: "TRUE"},
+		{Ref: "D1", Type: CellTypeFormula, Formula: "B1*2"},
+	}, collectedCells[0])
+	assert.Equal(t, RowCell{Ref: "D2", Formula: "B2*2"}, collectedCells[1][3])
+	assert.Empty(t, collectedCells[2])
+	assert.Equal(t, []RowCell{{Ref: "A4", Type: CellTypeSharedString, Value: "end"}}, collectedCells[3])
+	// Test the worksheet has not been extracted after iterating the rows
+	_, ok = f.Pkg.Load("xl/worksheets/sheet1.xml")
+	assert.False(t, ok)
+	// Test get cells with raw cell value
+	rows, err = f.Rows("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, rows.Next())
+	cells, err := rows.Cells(Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5", cells[1].Value)
+	assert.NoError(t, rows.Close())
+	assert.NoError(t, f.Close())
+
+	// Test get cells without cell reference
+	f = NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row><c t="str"><f t="shared" si="0">1+1</f><v>2</v></c></row></sheetData></worksheet>`))
+	rows, err = f.Rows("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, rows.Next())
+	cells, err = rows.Cells()
+	assert.NoError(t, err)
+	assert.Equal(t, []RowCell{{Ref: "A1", Type: CellTypeFormula, Formula: "1+1", Value: "2"}}, cells)
+	// Test get cells with invalid cell reference
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet><sheetData><row r="1"><c r="A"/></row></sheetData></worksheet>`))
+	rows, err = f.Rows("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, rows.Next())
+	_, err = rows.Cells()
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test get cells with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	rows, err = f.Rows("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, rows.Next())
+	_, err = rows.Cells()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestRowsError(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	if !assert.NoError(t, err) {
@@ -245,6 +324,24 @@ func TestColumns(t *testing.T) {
 	rows.decoder = f.xmlNewDecoder(bytes.NewReader(nil))
 	_, err = rows.Columns()
 	assert.NoError(t, err)
+
+	// Test get columns with malformed cell element
+	rows.decoder = f.xmlNewDecoder(bytes.NewReader([]byte(`<worksheet><sheetData><row r="1"><c r="A1"><v>1</c></row></sheetData></worksheet>`)))
+	assert.True(t, rows.Next())
+	_, err = rows.Columns()
+	assert.EqualError(t, err, "XML syntax error on line 1: element <v> closed by </c>")
+
+	// Test get columns with malformed row element
+	rows = &Rows{f: f, decoder: f.xmlNewDecoder(bytes.NewReader([]byte(`<worksheet><sheetData><row r="1"></c>`)))}
+	assert.True(t, rows.Next())
+	_, err = rows.Columns()
+	assert.EqualError(t, err, "XML syntax error on line 1: element <row> closed by </c>")
+	assert.EqualError(t, rows.Error(), "XML syntax error on line 1: element <row> closed by </c>")
+
+	// Test iterate rows with malformed worksheet
+	rows = &Rows{f: f, decoder: f.xmlNewDecoder(bytes.NewReader([]byte(`<worksheet><sheetData></row>`)))}
+	assert.False(t, rows.Next())
+	assert.EqualError(t, rows.Error(), "XML syntax error on line 1: element <sheetData> closed by </row>")
 }
 
 func TestSharedStringsReader(t *testing.T) {
@@ -906,6 +1003,7 @@ func TestDuplicateRow(t *testing.T) {
 	cfs, err := f.GetConditionalFormats("Sheet1")
 	assert.NoError(t, err)
 	assert.Len(t, cfs, 2)
+	expected[0].Priority = 1
 	assert.Equal(t, expected, cfs["A10:A10"])
 
 	dvs, err := f.GetDataValidations("Sheet1")
@@ -1027,6 +1125,12 @@ func TestSetRowStyle(t *testing.T) {
 	cellStyleID, err = f.GetCellStyle("Sheet1", "C1")
 	assert.NoError(t, err)
 	assert.Equal(t, style2, cellStyleID)
+	// Test cell style takes precedence over rows style
+	assert.NoError(t, f.SetCellStyle("Sheet1", "D1", "D1", style1))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D1", 1))
+	cellStyleID, err = f.GetCellStyle("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, style1, cellStyleID)
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetRowStyle.xlsx")))
 	// Test set row style with unsupported charset style sheet
 	f.Styles = nil
@@ -1164,3 +1268,61 @@ func trimSliceSpace(s []string) []string {
 	}
 	return s
 }
+
+func TestSetRowGroup(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetRowGroup("Sheet1", 3, 4, 2, false))
+	assert.NoError(t, f.SetRowGroup("Sheet1", 5, 2, 1, true))
+	assert.NoError(t, f.SetRowGroup("Sheet1", 3, 4, 2, true))
+	for row, expected := range map[int][]interface{}{
+		2: {uint8(1), false, false}, 3: {uint8(2), false, false},
+		4: {uint8(2), false, false}, 5: {uint8(1), true, false},
+		6: {uint8(0), true, true},
+	} {
+		level, err := f.GetRowOutlineLevel("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[0], level, row)
+		collapsed, err := f.GetRowCollapsed("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[1], collapsed, row)
+		visible, err := f.GetRowVisible("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[2], visible, row)
+	}
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Len(t, ws.(*xlsxWorksheet).SheetData.Row, 6)
+	// Test set row group with summary rows above detail
+	assert.NoError(t, f.SetSheetProps("Sheet1", &SheetPropsOptions{OutlineSummaryBelow: boolPtr(false)}))
+	assert.NoError(t, f.SetRowGroup("Sheet1", 9, 10, 1, true))
+	collapsed, err := f.GetRowCollapsed("Sheet1", 8)
+	assert.NoError(t, err)
+	assert.True(t, collapsed)
+	assert.NoError(t, f.SetRowGroup("Sheet1", 1, 1, 1, true))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetRowGroup.xlsx")))
+	// Test set row group at the last row of the worksheet
+	f = NewFile()
+	assert.NoError(t, f.SetRowGroup("Sheet1", TotalRows, TotalRows, 1, true))
+	collapsed, err = f.GetRowCollapsed("Sheet1", TotalRows)
+	assert.NoError(t, err)
+	assert.False(t, collapsed)
+	// Test get row collapsed state of the row not exists
+	collapsed, err = f.GetRowCollapsed("Sheet1", TotalRows+1)
+	assert.NoError(t, err)
+	assert.False(t, collapsed)
+	// Test set row group with invalid row number and outline level
+	assert.EqualError(t, f.SetRowGroup("Sheet1", 0, 1, 1, false), newInvalidRowNumberError(0).Error())
+	assert.EqualError(t, f.SetRowGroup("Sheet1", 1, TotalRows+1, 1, false), ErrMaxRows.Error())
+	assert.EqualError(t, f.SetRowGroup("Sheet1", 1, 2, 0, false), ErrOutlineLevel.Error())
+	assert.EqualError(t, f.SetRowGroup("Sheet1", 1, 2, 8, false), ErrOutlineLevel.Error())
+	_, err = f.GetRowCollapsed("Sheet1", 0)
+	assert.EqualError(t, err, newInvalidRowNumberError(0).Error())
+	// Test set and get row group on not exists worksheet
+	assert.EqualError(t, f.SetRowGroup("SheetN", 1, 2, 1, false), "sheet SheetN does not exist")
+	_, err = f.GetRowCollapsed("SheetN", 1)
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test set and get row group with invalid sheet name
+	assert.EqualError(t, f.SetRowGroup("Sheet:1", 1, 2, 1, false), ErrSheetNameInvalid.Error())
+	_, err = f.GetRowCollapsed("Sheet:1", 1)
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+}
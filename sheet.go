@@ -24,6 +24,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"unicode"
 	"unicode/utf16"
 	"unicode/utf8"
 
@@ -150,7 +151,11 @@ func (f *File) workSheetWriter() {
 			if sheet.Cols != nil && len(sheet.Cols.Col) > 0 {
 				f.mergeExpandedCols(sheet)
 			}
+			f.squashIgnoredErrors(sheet)
 			sheet.SheetData.Row = trimRow(&sheet.SheetData)
+			if f.options != nil && f.options.UpdateSheetDimension {
+				sheet.updateDimension()
+			}
 			if sheet.SheetPr != nil || sheet.Drawing != nil || sheet.Hyperlinks != nil || sheet.Picture != nil || sheet.TableParts != nil {
 				f.addNameSpaces(p.(string), SourceRelationship)
 			}
@@ -175,6 +180,45 @@ func (f *File) workSheetWriter() {
 	})
 }
 
+// updateDimension provides a function to recalculate the used range of the
+// worksheet by the minimum and maximum row and column of the non-blank cells.
+func (ws *xlsxWorksheet) updateDimension() {
+	coordinates := make([]int, 4)
+	for _, row := range ws.SheetData.Row {
+		for _, c := range row.C {
+			if !c.hasValue() {
+				continue
+			}
+			col, rowNum, err := CellNameToCoordinates(c.R)
+			if err != nil {
+				continue
+			}
+			if coordinates[0] == 0 || col < coordinates[0] {
+				coordinates[0] = col
+			}
+			if coordinates[1] == 0 || rowNum < coordinates[1] {
+				coordinates[1] = rowNum
+			}
+			if col > coordinates[2] {
+				coordinates[2] = col
+			}
+			if rowNum > coordinates[3] {
+				coordinates[3] = rowNum
+			}
+		}
+	}
+	if coordinates[0] == 0 {
+		ws.Dimension = &xlsxDimension{Ref: "A1"}
+		return
+	}
+	ref, _ := CoordinatesToCellName(coordinates[0], coordinates[1])
+	if coordinates[0] != coordinates[2] || coordinates[1] != coordinates[3] {
+		lastCell, _ := CoordinatesToCellName(coordinates[2], coordinates[3])
+		ref += ":" + lastCell
+	}
+	ws.Dimension = &xlsxDimension{Ref: ref}
+}
+
 // trimRow provides a function to trim empty rows.
 func trimRow(sheetData *xlsxSheetData) []xlsxRow {
 	var (
@@ -294,7 +338,7 @@ func (f *File) SetActiveSheet(index int) {
 		ws, err := f.workSheetReader(name)
 		if err != nil {
 			// Chartsheet, macrosheet or dialogsheet
-			return
+			continue
 		}
 		if ws.SheetViews == nil {
 			ws.SheetViews = &xlsxSheetViews{
@@ -480,6 +524,9 @@ func (f *File) getSheetMap() (map[string]string, error) {
 				if _, ok := f.tempFiles.Load(sheetXMLPath); ok {
 					maps[v.Name] = sheetXMLPath
 				}
+				if _, ok := f.zipFiles.Load(sheetXMLPath); ok {
+					maps[v.Name] = sheetXMLPath
+				}
 			}
 		}
 	}
@@ -547,7 +594,9 @@ func (f *File) setSheetBackground(sheet, extension string, file []byte) error {
 // worksheet name. Use this method with caution, which will affect changes in
 // references such as formulas, charts, and so on. If there is any referenced
 // value of the deleted worksheet, it will cause a file error when you open
-// it. This function will be invalid when only one worksheet is left.
+// it. This function will be invalid when only one worksheet is left. The
+// drawing and chart parts of the chart sheet will be deleted together when
+// deleting a chart sheet.
 func (f *File) DeleteSheet(sheet string) error {
 	if err := checkSheetName(sheet); err != nil {
 		return err
@@ -575,14 +624,20 @@ func (f *File) DeleteSheet(sheet string) error {
 					sheetXML = f.getWorksheetPath(rel.Target)
 					sheetXMLPath, _ := f.getSheetXMLPath(sheet)
 					rels = "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+					if rel.Type == SourceRelationshipChartsheet {
+						rels = "xl/chartsheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/chartsheets/") + ".rels"
+						f.deleteChartSheetDrawing(rels)
+					}
 				}
 			}
 		}
 		target := f.deleteSheetFromWorkbookRels(v.ID)
 		_ = f.removeContentTypesPart(ContentTypeSpreadSheetMLWorksheet, target)
+		_ = f.removeContentTypesPart(ContentTypeSpreadSheetMLChartsheet, target)
 		_ = f.deleteCalcChain(f.getSheetID(sheet), "")
 		delete(f.sheetMap, v.Name)
 		f.Pkg.Delete(sheetXML)
+		f.zipFiles.Delete(sheetXML)
 		f.Pkg.Delete(rels)
 		f.Relationships.Delete(rels)
 		f.Sheet.Delete(sheetXML)
@@ -594,6 +649,41 @@ func (f *File) DeleteSheet(sheet string) error {
 	return err
 }
 
+// deleteChartSheetDrawing provides a function to delete the drawing part and
+// the chart parts in the drawing of the chart sheet by given chart sheet
+// relationships path.
+func (f *File) deleteChartSheetDrawing(rels string) {
+	sheetRels, _ := f.relsReader(rels)
+	if sheetRels == nil {
+		return
+	}
+	for _, rel := range sheetRels.Relationships {
+		if rel.Type != SourceRelationshipDrawingML {
+			continue
+		}
+		drawingXML := strings.TrimPrefix(strings.ReplaceAll(rel.Target, "..", "xl"), "/")
+		drawingRels := "xl/drawings/_rels/" + strings.TrimPrefix(drawingXML, "xl/drawings/") + ".rels"
+		if drawingRel, _ := f.relsReader(drawingRels); drawingRel != nil {
+			for _, v := range drawingRel.Relationships {
+				if v.Type != SourceRelationshipChart {
+					continue
+				}
+				chartXML := strings.TrimPrefix(strings.ReplaceAll(v.Target, "..", "xl"), "/")
+				chartRels := "xl/charts/_rels/" + strings.TrimPrefix(chartXML, "xl/charts/") + ".rels"
+				_ = f.removeContentTypesPart(ContentTypeDrawingML, "/"+chartXML)
+				f.Pkg.Delete(chartXML)
+				f.Pkg.Delete(chartRels)
+				f.Relationships.Delete(chartRels)
+			}
+		}
+		_ = f.removeContentTypesPart(ContentTypeDrawing, "/"+drawingXML)
+		f.Pkg.Delete(drawingXML)
+		f.Drawings.Delete(drawingXML)
+		f.Pkg.Delete(drawingRels)
+		f.Relationships.Delete(drawingRels)
+	}
+}
+
 // deleteAndAdjustDefinedNames delete and adjust defined name in the workbook
 // by given worksheet ID.
 func deleteAndAdjustDefinedNames(wb *xlsxWorkbook, deleteLocalSheetID int) {
@@ -701,6 +791,7 @@ func (f *File) copySheet(from, to int) error {
 	if err != nil {
 		return err
 	}
+	f.squashIgnoredErrors(sheet)
 	worksheet := deepcopy.Copy(sheet).(*xlsxWorksheet)
 	toSheetID := strconv.Itoa(f.getSheetID(f.GetSheetName(to)))
 	sheetXMLPath := "xl/worksheets/sheet" + toSheetID + ".xml"
@@ -722,6 +813,338 @@ func (f *File) copySheet(from, to int) error {
 	return err
 }
 
+// DuplicateSheet provides a function to create a new worksheet as a deep copy
+// of the source worksheet by given source and target worksheet name. Besides
+// the cell values and formatting, the merged cells, data validations,
+// conditional formats, comments, pictures, charts and the worksheet scoped
+// defined names will be copied, and the drawing parts will be duplicated, so
+// the two worksheets are independent. The references to the source worksheet
+// in the copied charts and defined names will refer to the target worksheet.
+// Note that the tables, pivot tables and slicers will not be copied, because
+// their names must be unique in the workbook. The target worksheet will be
+// appended at the end of the workbook, and the name of it should not be the
+// same as any existing worksheet. For example, duplicate the worksheet named
+// Template as a new worksheet named Jan:
+//
+//	err := f.DuplicateSheet("Template", "Jan")
+func (f *File) DuplicateSheet(fromSheet, toSheet string) error {
+	if err := checkSheetName(toSheet); err != nil {
+		return err
+	}
+	fromIndex, err := f.GetSheetIndex(fromSheet)
+	if err != nil {
+		return err
+	}
+	if fromIndex == -1 {
+		return ErrSheetNotExist{fromSheet}
+	}
+	if index, _ := f.GetSheetIndex(toSheet); index != -1 {
+		return ErrExistsSheet
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(fromSheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	f.squashIgnoredErrors(ws)
+	worksheet := deepcopy.Copy(ws).(*xlsxWorksheet)
+	ws.mu.Unlock()
+	if worksheet.SheetViews != nil {
+		for idx := range worksheet.SheetViews.SheetView {
+			worksheet.SheetViews.SheetView[idx].TabSelected = false
+		}
+	}
+	worksheet.TableParts = nil
+	toIndex, err := f.NewSheet(toSheet)
+	if err != nil {
+		return err
+	}
+	fromSheetXMLPath, _ := f.getSheetXMLPath(fromSheet)
+	toSheetXMLPath, _ := f.getSheetXMLPath(toSheet)
+	fromRels := "xl/worksheets/_rels/" + strings.TrimPrefix(fromSheetXMLPath, "xl/worksheets/") + ".rels"
+	toRels := "xl/worksheets/_rels/" + strings.TrimPrefix(toSheetXMLPath, "xl/worksheets/") + ".rels"
+	if err = f.duplicateSheetRels(worksheet, fromSheet, toSheet, fromRels, toRels); err != nil {
+		return err
+	}
+	f.Sheet.Store(toSheetXMLPath, worksheet)
+	if fromSheetAttr, ok := f.xmlAttr.Load(fromSheetXMLPath); ok {
+		f.xmlAttr.Store(toSheetXMLPath, fromSheetAttr)
+	}
+	return f.duplicateSheetDefinedNames(fromIndex, toIndex, fromSheet, toSheet)
+}
+
+// duplicateSheetRels provides a function to duplicate the worksheet
+// relationships and the drawing, VML drawing, comments and threaded comments
+// parts of the worksheet by given worksheet, source and target worksheet name
+// and relationships path.
+func (f *File) duplicateSheetRels(ws *xlsxWorksheet, fromSheet, toSheet, fromRels, toRels string) error {
+	sheetRels, err := f.relsReader(fromRels)
+	if err != nil || sheetRels == nil {
+		return err
+	}
+	var legacyDrawingRID string
+	if ws.LegacyDrawing != nil {
+		legacyDrawingRID = ws.LegacyDrawing.RID
+	}
+	commentsID := f.countComments()
+	if vmlID := f.countVMLDrawing(); vmlID > commentsID {
+		commentsID = vmlID
+	}
+	commentsID++
+	rels, slicer, threadIDs := &xlsxRelationships{}, false, map[string]string{}
+	for _, rel := range sheetRels.Relationships {
+		switch rel.Type {
+		case SourceRelationshipTable, SourceRelationshipPivotTable:
+			continue
+		case SourceRelationshipSlicer:
+			slicer = true
+			continue
+		case SourceRelationshipDrawingML:
+			if rel.Target, err = f.duplicateDrawing(rel.Target, fromSheet, toSheet); err != nil {
+				return err
+			}
+		case SourceRelationshipDrawingVML:
+			vmlID := commentsID
+			if rel.ID != legacyDrawingRID {
+				if vmlID = f.countVMLDrawing() + 1; vmlID <= commentsID {
+					vmlID = commentsID + 1
+				}
+			}
+			rel.Target = f.duplicateVMLDrawing(rel.Target, vmlID)
+		case SourceRelationshipComments:
+			if rel.Target, err = f.duplicateComments(rel.Target, commentsID); err != nil {
+				return err
+			}
+		case SourceRelationshipThreadedComment:
+			if rel.Target, threadIDs, err = f.duplicateThreadedComments(rel.Target, toSheet); err != nil {
+				return err
+			}
+		}
+		rels.Relationships = append(rels.Relationships, rel)
+	}
+	if cmts, ok := f.Comments["xl/comments"+strconv.Itoa(commentsID)+".xml"]; ok {
+		for idx, author := range cmts.Authors.Author {
+			if threadID, ok := threadIDs[strings.TrimPrefix(author, "tc=")]; ok {
+				cmts.Authors.Author[idx] = "tc=" + threadID
+			}
+		}
+	}
+	if slicer {
+		if err = ws.deleteSlicerList(); err != nil {
+			return err
+		}
+	}
+	if len(rels.Relationships) > 0 {
+		f.Relationships.Store(toRels, rels)
+	}
+	return err
+}
+
+// getRelsTargetPath returns the part path of the relationship target which is
+// relative to the given folder in the package.
+func getRelsTargetPath(folder, target string) string {
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	return path.Clean(path.Join(folder, target))
+}
+
+// duplicateDrawing provides a function to duplicate the drawing part and the
+// charts in it by given relationship target of the drawing part and source
+// and target worksheet name, and returns the relationship target of the new
+// drawing part.
+func (f *File) duplicateDrawing(target, fromSheet, toSheet string) (string, error) {
+	fromPath := getRelsTargetPath("xl/worksheets", target)
+	wsDr, _, err := f.drawingParser(fromPath)
+	if err != nil {
+		return target, err
+	}
+	drawingID := f.countDrawings() + 1
+	toPath := "xl/drawings/drawing" + strconv.Itoa(drawingID) + ".xml"
+	// the slicers will not be copied, remove the slicer anchors
+	content := deepcopy.Copy(wsDr).(*xlsxWsDr)
+	alternateContent, twoCellAnchor := content.AlternateContent, content.TwoCellAnchor
+	content.AlternateContent, content.TwoCellAnchor = nil, nil
+	for _, ac := range alternateContent {
+		if !strings.Contains(ac.Content, NameSpaceDrawingMLSlicer.Value) {
+			content.AlternateContent = append(content.AlternateContent, ac)
+		}
+	}
+	for _, anchor := range twoCellAnchor {
+		if !isSlicerAnchor(anchor) {
+			content.TwoCellAnchor = append(content.TwoCellAnchor, anchor)
+		}
+	}
+	f.Drawings.Store(toPath, content)
+	drawingRels, err := f.relsReader("xl/drawings/_rels/" + path.Base(fromPath) + ".rels")
+	if err != nil {
+		return target, err
+	}
+	if drawingRels != nil {
+		rels := &xlsxRelationships{}
+		for _, rel := range drawingRels.Relationships {
+			if rel.Type == SourceRelationshipChart {
+				if rel.Target, err = f.duplicateChart(rel.Target, fromSheet, toSheet); err != nil {
+					return target, err
+				}
+			}
+			rels.Relationships = append(rels.Relationships, rel)
+		}
+		f.Relationships.Store("xl/drawings/_rels/drawing"+strconv.Itoa(drawingID)+".xml.rels", rels)
+	}
+	return "../drawings/drawing" + strconv.Itoa(drawingID) + ".xml", f.addContentTypePart(drawingID, "drawings")
+}
+
+// isSlicerAnchor returns whether the cell anchor in the drawing part is a
+// slicer.
+func isSlicerAnchor(anchor *xdrCellAnchor) bool {
+	if strings.Contains(anchor.GraphicFrame, NameSpaceDrawingMLSlicer.Value) {
+		return true
+	}
+	for _, ac := range anchor.AlternateContent {
+		if strings.Contains(ac.Content, NameSpaceDrawingMLSlicer.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// duplicateChart provides a function to duplicate the chart part by given
+// relationship target of the chart part and source and target worksheet
+// name, and returns the relationship target of the new chart part.
+func (f *File) duplicateChart(target, fromSheet, toSheet string) (string, error) {
+	fromPath := getRelsTargetPath("xl/drawings", target)
+	chartID := f.countCharts() + 1
+	toPath := "xl/charts/chart" + strconv.Itoa(chartID) + ".xml"
+	content := regexp.MustCompile(`<(c:)?f>[^<]*</(c:)?f>`).ReplaceAllStringFunc(string(f.readXML(fromPath)), func(formula string) string {
+		return replaceSheetNameRefs(formula, fromSheet, toSheet)
+	})
+	f.saveFileList(toPath, []byte(content))
+	chartRels, err := f.relsReader("xl/charts/_rels/" + path.Base(fromPath) + ".rels")
+	if err != nil {
+		return target, err
+	}
+	if chartRels != nil {
+		f.Relationships.Store("xl/charts/_rels/chart"+strconv.Itoa(chartID)+".xml.rels", &xlsxRelationships{
+			Relationships: append([]xlsxRelationship{}, chartRels.Relationships...),
+		})
+	}
+	return "../charts/chart" + strconv.Itoa(chartID) + ".xml", f.addContentTypePart(chartID, "chart")
+}
+
+// duplicateVMLDrawing provides a function to duplicate the VML drawing part
+// by given relationship target of the VML drawing part and the new VML
+// drawing ID, and returns the relationship target of the new VML drawing
+// part.
+func (f *File) duplicateVMLDrawing(target string, vmlID int) string {
+	fromPath := getRelsTargetPath("xl/worksheets", target)
+	toPath := "xl/drawings/vmlDrawing" + strconv.Itoa(vmlID) + ".vml"
+	content := f.readXML(fromPath)
+	if vml := f.VMLDrawing[fromPath]; vml != nil {
+		content, _ = xml.Marshal(vml)
+	}
+	f.Pkg.Store(toPath, append([]byte{}, content...))
+	if vmlRels, _ := f.relsReader("xl/drawings/_rels/" + path.Base(fromPath) + ".rels"); vmlRels != nil {
+		f.Relationships.Store("xl/drawings/_rels/vmlDrawing"+strconv.Itoa(vmlID)+".vml.rels", &xlsxRelationships{
+			Relationships: append([]xlsxRelationship{}, vmlRels.Relationships...),
+		})
+	}
+	return "../drawings/vmlDrawing" + strconv.Itoa(vmlID) + ".vml"
+}
+
+// duplicateComments provides a function to duplicate the comments part by
+// given relationship target of the comments part and the new comments ID,
+// and returns the relationship target of the new comments part.
+func (f *File) duplicateComments(target string, commentsID int) (string, error) {
+	cmts, err := f.commentsReader(getRelsTargetPath("xl/worksheets", target))
+	if err != nil {
+		return target, err
+	}
+	if cmts != nil {
+		f.Comments["xl/comments"+strconv.Itoa(commentsID)+".xml"] = deepcopy.Copy(cmts).(*xlsxComments)
+	}
+	return "../comments" + strconv.Itoa(commentsID) + ".xml", f.addContentTypePart(commentsID, "comments")
+}
+
+// duplicateThreadedComments provides a function to duplicate the threaded
+// comments part with new thread IDs by given relationship target of the
+// threaded comments part and target worksheet name, and returns the
+// relationship target of the new part and the new thread IDs by the source
+// thread IDs.
+func (f *File) duplicateThreadedComments(target, toSheet string) (string, map[string]string, error) {
+	cmts, err := f.threadedCommentsReader(getRelsTargetPath("xl/worksheets", target))
+	if err != nil {
+		return target, nil, err
+	}
+	threadIDs, sheetID := make(map[string]string, len(cmts.ThreadedComment)), f.getSheetID(toSheet)
+	for idx, cmt := range cmts.ThreadedComment {
+		threadIDs[cmt.ID] = newThreadedCommentID(sheetID, idx+1)
+	}
+	for idx, cmt := range cmts.ThreadedComment {
+		cmts.ThreadedComment[idx].ID = threadIDs[cmt.ID]
+		if parentID, ok := threadIDs[cmt.ParentID]; ok {
+			cmts.ThreadedComment[idx].ParentID = parentID
+		}
+	}
+	output, err := xml.Marshal(cmts)
+	if err != nil {
+		return target, nil, err
+	}
+	threadedCommentID := f.countPartsWithPrefix("xl/threadedComments/threadedComment") + 1
+	f.saveFileList("xl/threadedComments/threadedComment"+strconv.Itoa(threadedCommentID)+".xml", output)
+	return "../threadedComments/threadedComment" + strconv.Itoa(threadedCommentID) + ".xml", threadIDs,
+		f.addContentTypePart(threadedCommentID, "threadedComment")
+}
+
+// duplicateSheetDefinedNames provides a function to duplicate the worksheet
+// scoped defined names by given source and target worksheet index and name.
+func (f *File) duplicateSheetDefinedNames(fromIndex, toIndex int, fromSheet, toSheet string) error {
+	wb, err := f.workbookReader()
+	if err != nil || wb.DefinedNames == nil {
+		return err
+	}
+	for _, dn := range wb.DefinedNames.DefinedName {
+		if dn.LocalSheetID == nil || *dn.LocalSheetID != fromIndex {
+			continue
+		}
+		definedName, localSheetID := dn, toIndex
+		definedName.LocalSheetID = &localSheetID
+		definedName.Data = replaceSheetNameRefs(dn.Data, fromSheet, toSheet)
+		wb.DefinedNames.DefinedName = append(wb.DefinedNames.DefinedName, definedName)
+	}
+	return err
+}
+
+// replaceSheetNameRefs returns the formula which references to the source
+// worksheet have been replaced with the target worksheet by given formula,
+// source and target worksheet name.
+func replaceSheetNameRefs(formula, fromSheet, toSheet string) string {
+	quoted := "'" + strings.ReplaceAll(fromSheet, "'", "''") + "'!"
+	formula = strings.ReplaceAll(formula, quoted, escapeSheetName(toSheet)+"!")
+	if escapeSheetName(fromSheet) != fromSheet {
+		return formula
+	}
+	var b strings.Builder
+	for {
+		idx := strings.Index(formula, fromSheet+"!")
+		if idx == -1 {
+			break
+		}
+		b.WriteString(formula[:idx])
+		if r, _ := utf8.DecodeLastRuneInString(formula[:idx]); idx > 0 && (unicode.IsLetter(r) || unicode.IsNumber(r) || r == '_' || r == '.') {
+			b.WriteString(fromSheet + "!")
+		} else {
+			b.WriteString(escapeSheetName(toSheet) + "!")
+		}
+		formula = formula[idx+len(fromSheet)+1:]
+	}
+	b.WriteString(formula)
+	return b.String()
+}
+
 // getSheetState returns sheet visible enumeration by given hidden status.
 func getSheetState(visible bool, veryHidden []bool) string {
 	state := "hidden"
@@ -777,31 +1200,79 @@ func (f *File) SetSheetVisible(sheet string, visible bool, veryHidden ...bool) e
 	return err
 }
 
+// defaultActivePane returns the default active pane by given horizontal and
+// vertical split positions of the panes.
+func (panes *Panes) defaultActivePane() string {
+	switch {
+	case panes.XSplit > 0 && panes.YSplit > 0:
+		return "bottomRight"
+	case panes.YSplit > 0:
+		return "bottomLeft"
+	case panes.XSplit > 0:
+		return "topRight"
+	}
+	return ""
+}
+
+// defaultSelection returns the default selection of each pane by given split
+// positions, the top left pane doesn't need the selection, and the active
+// cell will be selected in the bottom right pane.
+func (panes *Panes) defaultSelection(activePane string) []Selection {
+	if activePane == "" {
+		return nil
+	}
+	var selection []Selection
+	if panes.XSplit > 0 && panes.YSplit > 0 {
+		selection = append(selection, Selection{Pane: "topRight"}, Selection{Pane: "bottomLeft"})
+	}
+	return append(selection, Selection{SQRef: panes.TopLeftCell, ActiveCell: panes.TopLeftCell, Pane: activePane})
+}
+
 // setPanes set create freeze panes and split panes by given options.
 func (ws *xlsxWorksheet) setPanes(panes *Panes) error {
-	if panes == nil {
+	if panes == nil || panes.XSplit < 0 || panes.YSplit < 0 {
 		return ErrParameterInvalid
 	}
-	p := &xlsxPane{
-		ActivePane:  panes.ActivePane,
-		TopLeftCell: panes.TopLeftCell,
-		XSplit:      float64(panes.XSplit),
-		YSplit:      float64(panes.YSplit),
+	opts := *panes
+	if opts.Freeze && opts.TopLeftCell == "" {
+		cell, err := CoordinatesToCellName(opts.XSplit+1, opts.YSplit+1)
+		if err != nil {
+			return err
+		}
+		opts.TopLeftCell = cell
 	}
-	if panes.Freeze {
+	if opts.ActivePane == "" {
+		opts.ActivePane = opts.defaultActivePane()
+	}
+	if len(opts.Selection) == 0 {
+		opts.Selection = opts.defaultSelection(opts.ActivePane)
+	}
+	p := &xlsxPane{
+		ActivePane:  opts.ActivePane,
+		TopLeftCell: opts.TopLeftCell,
+		XSplit:      float64(opts.XSplit),
+		YSplit:      float64(opts.YSplit),
+	}
+	switch {
+	case opts.Freeze && opts.Split:
+		p.State = "frozenSplit"
+	case opts.Freeze:
 		p.State = "frozen"
+	case opts.Split:
+		p.State = "split"
 	}
 	if ws.SheetViews == nil {
 		ws.SheetViews = &xlsxSheetViews{SheetView: []xlsxSheetView{{}}}
 	}
 	ws.SheetViews.SheetView[len(ws.SheetViews.SheetView)-1].Pane = p
-	if !(panes.Freeze) && !(panes.Split) {
+	if !(opts.Freeze) && !(opts.Split) {
 		if len(ws.SheetViews.SheetView) > 0 {
 			ws.SheetViews.SheetView[len(ws.SheetViews.SheetView)-1].Pane = nil
 		}
+		opts.Selection = panes.Selection
 	}
 	var s []*xlsxSelection
-	for _, p := range panes.Selection {
+	for _, p := range opts.Selection {
 		s = append(s, &xlsxSelection{
 			ActiveCell: p.ActiveCell,
 			Pane:       p.Pane,
@@ -872,8 +1343,20 @@ func (ws *xlsxWorksheet) setPanes(panes *Panes) error {
 // number of rows visible in the left pane. The possible values for this
 // attribute are defined by the W3C XML Schema double datatype.
 //
+// The split positions of split panes are not related to the number of rows
+// and columns, set the 'Split' as true and 'Freeze' as false to create split
+// panes, set both of them as true to create frozen panes which were split
+// before being frozen.
+//
 // TopLeftCell: Location of the top left visible cell in the bottom right pane
-// (when in Left-To-Right mode).
+// (when in Left-To-Right mode). If the panes are frozen and this is not
+// specified, the cell next to the frozen rows and columns will be used.
+//
+// The active pane and selection will be created by the split positions if the
+// 'ActivePane' or 'Selection' is not specified. When both rows and columns are
+// frozen or split, the selection of the top right, bottom left and bottom
+// right panes will be created, and the top left pane uses the selection
+// without the pane.
 //
 // SQRef (Sequence of References): Range of the selection. Can be non-contiguous
 // set of ranges.
@@ -926,6 +1409,14 @@ func (ws *xlsxWorksheet) setPanes(panes *Panes) error {
 //	    },
 //	})
 //
+// An example of how to freeze the top row and first column in the Sheet1:
+//
+//	err := f.SetPanes("Sheet1", &excelize.Panes{
+//	    Freeze: true,
+//	    XSplit: 1,
+//	    YSplit: 1,
+//	})
+//
 // An example of how to unfreeze and remove all panes on Sheet1:
 //
 //	err := f.SetPanes("Sheet1", &excelize.Panes{Freeze: false, Split: false})
@@ -961,8 +1452,13 @@ func (ws *xlsxWorksheet) getPanes() Panes {
 		return panes
 	}
 	panes.ActivePane = sw.Pane.ActivePane
-	if sw.Pane.State == "frozen" {
+	switch sw.Pane.State {
+	case "frozen":
 		panes.Freeze = true
+	case "frozenSplit":
+		panes.Freeze, panes.Split = true, true
+	default:
+		panes.Split = true
 	}
 	panes.TopLeftCell = sw.Pane.TopLeftCell
 	panes.XSplit = int(sw.Pane.XSplit)
@@ -971,7 +1467,9 @@ func (ws *xlsxWorksheet) getPanes() Panes {
 }
 
 // GetPanes provides a function to get freeze panes, split panes, and worksheet
-// views by given worksheet name.
+// views by given worksheet name. For example, get the panes of the Sheet1:
+//
+//	panes, err := f.GetPanes("Sheet1")
 func (f *File) GetPanes(sheet string) (Panes, error) {
 	var panes Panes
 	ws, err := f.workSheetReader(sheet)
@@ -1256,6 +1754,10 @@ func attrValToBool(name string, attrs []xml.Attr) (val bool, err error) {
 // that same page
 //
 // - No footer on the first page
+//
+// The DifferentFirst and DifferentOddEven will be enabled automatically if
+// the first page or even page header or footer has been set, since those
+// variants only take effect with the corresponding option enabled.
 func (f *File) SetHeaderFooter(sheet string, opts *HeaderFooterOptions) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -1269,15 +1771,15 @@ func (f *File) SetHeaderFooter(sheet string, opts *HeaderFooterOptions) error {
 	v := reflect.ValueOf(*opts)
 	// Check 6 string type fields: OddHeader, OddFooter, EvenHeader, EvenFooter,
 	// FirstFooter, FirstHeader
-	for i := 4; i < v.NumField()-1; i++ {
+	for i := 4; i < v.NumField(); i++ {
 		if len(utf16.Encode([]rune(v.Field(i).String()))) > MaxFieldLength {
 			return newFieldLengthError(v.Type().Field(i).Name)
 		}
 	}
 	ws.HeaderFooter = &xlsxHeaderFooter{
 		AlignWithMargins: opts.AlignWithMargins,
-		DifferentFirst:   opts.DifferentFirst,
-		DifferentOddEven: opts.DifferentOddEven,
+		DifferentFirst:   opts.DifferentFirst || opts.FirstHeader != "" || opts.FirstFooter != "",
+		DifferentOddEven: opts.DifferentOddEven || opts.EvenHeader != "" || opts.EvenFooter != "",
 		ScaleWithDoc:     opts.ScaleWithDoc,
 		OddHeader:        opts.OddHeader,
 		OddFooter:        opts.OddFooter,
@@ -1404,6 +1906,123 @@ func (f *File) UnprotectSheet(sheet string, password ...string) error {
 	return err
 }
 
+// AddProtectedRange provides a function to add a protected range by given
+// worksheet name and protected range options. The cells in the protected
+// range could be edited by the users which know the password or have been
+// granted by the security descriptor after the worksheet was protected by the
+// ProtectSheet function. The protected range will be replaced if a protected
+// range with the same name exists. For example, allow editing range B2:C3
+// of Sheet1 with password, and protect the rest of the worksheet:
+//
+//	err := f.AddProtectedRange("Sheet1", &excelize.ProtectedRangeOptions{
+//	    Name:          "Range1",
+//	    Range:         "B2:C3",
+//	    Password:      "password",
+//	    AlgorithmName: "SHA-512",
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.ProtectSheet("Sheet1", &excelize.SheetProtectionOptions{
+//	    Password: "password",
+//	})
+func (f *File) AddProtectedRange(sheet string, opts *ProtectedRangeOptions) error {
+	if opts == nil {
+		return ErrParameterInvalid
+	}
+	if opts.Name == "" || opts.Range == "" {
+		return ErrParameterRequired
+	}
+	if utf8.RuneCountInString(opts.Name) > MaxFieldLength {
+		return ErrNameLength
+	}
+	if _, err := f.flatSqref(opts.Range); err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	protectedRange := &xlsxProtectedRange{Name: opts.Name, Sqref: opts.Range}
+	if opts.SecurityDescriptor != "" {
+		protectedRange.SecurityDescriptorElement = []string{opts.SecurityDescriptor}
+	}
+	if opts.Password != "" {
+		if opts.AlgorithmName == "" {
+			protectedRange.Password = genSheetPasswd(opts.Password)
+		} else {
+			hashValue, saltValue, err := genISOPasswdHash(opts.Password, opts.AlgorithmName, "", int(sheetProtectionSpinCount))
+			if err != nil {
+				return err
+			}
+			protectedRange.AlgorithmName = opts.AlgorithmName
+			protectedRange.SaltValue = saltValue
+			protectedRange.HashValue = hashValue
+			protectedRange.SpinCount = int(sheetProtectionSpinCount)
+		}
+	}
+	if ws.ProtectedRanges == nil {
+		ws.ProtectedRanges = new(xlsxProtectedRanges)
+	}
+	for i, r := range ws.ProtectedRanges.ProtectedRange {
+		if strings.EqualFold(r.Name, opts.Name) {
+			ws.ProtectedRanges.ProtectedRange[i] = protectedRange
+			return err
+		}
+	}
+	ws.ProtectedRanges.ProtectedRange = append(ws.ProtectedRanges.ProtectedRange, protectedRange)
+	return err
+}
+
+// GetProtectedRanges provides a function to get the protected ranges by given
+// worksheet name. Note that the password of the protected range couldn't be
+// recovered, so the Password field of the returned options will be empty.
+func (f *File) GetProtectedRanges(sheet string) ([]ProtectedRangeOptions, error) {
+	var protectedRanges []ProtectedRangeOptions
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.ProtectedRanges == nil {
+		return protectedRanges, err
+	}
+	for _, r := range ws.ProtectedRanges.ProtectedRange {
+		opts := ProtectedRangeOptions{
+			Name:               r.Name,
+			Range:              r.Sqref,
+			AlgorithmName:      r.AlgorithmName,
+			SecurityDescriptor: r.SecurityDescriptorAttr,
+		}
+		if len(r.SecurityDescriptorElement) > 0 {
+			opts.SecurityDescriptor = r.SecurityDescriptorElement[0]
+		}
+		protectedRanges = append(protectedRanges, opts)
+	}
+	return protectedRanges, err
+}
+
+// DeleteProtectedRange provides a function to delete the protected range by
+// given worksheet name and protected range name. All protected ranges in the
+// worksheet will be deleted if not specify the protected range name.
+func (f *File) DeleteProtectedRange(sheet string, name ...string) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.ProtectedRanges == nil {
+		return err
+	}
+	if len(name) == 0 {
+		ws.ProtectedRanges = nil
+		return err
+	}
+	for i, r := range ws.ProtectedRanges.ProtectedRange {
+		if strings.EqualFold(r.Name, name[0]) {
+			ws.ProtectedRanges.ProtectedRange = append(ws.ProtectedRanges.ProtectedRange[:i], ws.ProtectedRanges.ProtectedRange[i+1:]...)
+			break
+		}
+	}
+	if len(ws.ProtectedRanges.ProtectedRange) == 0 {
+		ws.ProtectedRanges = nil
+	}
+	return err
+}
+
 // checkSheetName check whether there are illegal characters in the sheet name.
 // 1. Confirm that the sheet name is not empty
 // 2. Make sure to enter a name with no more than 31 characters
@@ -1598,6 +2217,14 @@ func (ws *xlsxWorksheet) setPageSetUp(opts *PageLayoutOptions) {
 		ws.newPageSetUp()
 		ws.PageSetUp.BlackAndWhite = *opts.BlackAndWhite
 	}
+	// The fit to pages scaling requires the fit to page print option enabled.
+	if opts.FitToHeight != nil || opts.FitToWidth != nil {
+		ws.prepareSheetPr()
+		if ws.SheetPr.PageSetUpPr == nil {
+			ws.SheetPr.PageSetUpPr = new(xlsxPageSetUpPr)
+		}
+		ws.SheetPr.PageSetUpPr.FitToPage = true
+	}
 }
 
 // GetPageLayout provides a function to gets worksheet page layout.
@@ -1636,9 +2263,173 @@ func (f *File) GetPageLayout(sheet string) (PageLayoutOptions, error) {
 	return opts, err
 }
 
+// SetPrintArea provides a function to set the print area of the worksheet by
+// given worksheet name and range reference, multiple ranges could be
+// separated by commas. Set the range reference as empty string to remove the
+// print area of the worksheet. For example, set the print area of Sheet1 as
+// A1:F20:
+//
+//	err := f.SetPrintArea("Sheet1", "A1:F20")
+func (f *File) SetPrintArea(sheet, rangeRef string) error {
+	var refs []string
+	if rangeRef != "" {
+		for _, ref := range strings.Split(rangeRef, ",") {
+			if !strings.Contains(ref, ":") {
+				ref += ":" + ref
+			}
+			coordinates, err := rangeRefToCoordinates(ref)
+			if err != nil {
+				return err
+			}
+			_ = sortCoordinates(coordinates)
+			if ref, err = f.coordinatesToRangeRef(coordinates, true); err != nil {
+				return err
+			}
+			refs = append(refs, escapeSheetName(sheet)+"!"+ref)
+		}
+	}
+	return f.setSheetBuiltInDefinedName(sheet, builtInDefinedNames[0], strings.Join(refs, ","))
+}
+
+// GetPrintArea provides a function to get the print area of the worksheet by
+// given worksheet name, multiple ranges will be separated by commas. An empty
+// string will be returned if the print area of the worksheet doesn't exist.
+func (f *File) GetPrintArea(sheet string) (string, error) {
+	refersTo, err := f.getSheetBuiltInDefinedName(sheet, builtInDefinedNames[0])
+	if err != nil || refersTo == "" {
+		return "", err
+	}
+	var refs []string
+	for _, ref := range strings.Split(refersTo, ",") {
+		refs = append(refs, strings.ReplaceAll(ref[strings.LastIndex(ref, "!")+1:], "$", ""))
+	}
+	return strings.Join(refs, ","), err
+}
+
+// SetPrintTitles provides a function to set the rows and columns to repeat
+// at top and left of each printed page by given worksheet name, rows range
+// (such as "1:2") and columns range (such as "A:B"). Set both the rows and
+// columns as empty string to remove the print titles of the worksheet. For
+// example, repeat the first row at top and column A at left of each printed
+// page of Sheet1:
+//
+//	err := f.SetPrintTitles("Sheet1", "1", "A")
+func (f *File) SetPrintTitles(sheet, rows, cols string) error {
+	var refs []string
+	if cols != "" {
+		minVal, maxVal, err := f.parseColRange(cols)
+		if err != nil {
+			return err
+		}
+		minCol, _ := ColumnNumberToName(minVal)
+		maxCol, _ := ColumnNumberToName(maxVal)
+		refs = append(refs, escapeSheetName(sheet)+"!$"+minCol+":$"+maxCol)
+	}
+	if rows != "" {
+		rowsTab, rowNums := strings.Split(rows, ":"), []int{}
+		if len(rowsTab) > 2 {
+			return ErrParameterInvalid
+		}
+		for _, row := range rowsTab {
+			rowNum, err := strconv.Atoi(row)
+			if err != nil {
+				return ErrParameterInvalid
+			}
+			if rowNum < 1 {
+				return newInvalidRowNumberError(rowNum)
+			}
+			if rowNum > TotalRows {
+				return ErrMaxRows
+			}
+			rowNums = append(rowNums, rowNum)
+		}
+		sort.Ints(rowNums)
+		refs = append(refs, fmt.Sprintf("%s!$%d:$%d", escapeSheetName(sheet), rowNums[0], rowNums[len(rowNums)-1]))
+	}
+	return f.setSheetBuiltInDefinedName(sheet, builtInDefinedNames[1], strings.Join(refs, ","))
+}
+
+// GetPrintTitles provides a function to get the rows and columns to repeat
+// at top and left of each printed page by given worksheet name. Empty strings
+// will be returned if the print titles of the worksheet doesn't exist.
+func (f *File) GetPrintTitles(sheet string) (rows, cols string, err error) {
+	refersTo, err := f.getSheetBuiltInDefinedName(sheet, builtInDefinedNames[1])
+	if err != nil || refersTo == "" {
+		return
+	}
+	for _, ref := range strings.Split(refersTo, ",") {
+		ref = strings.ReplaceAll(ref[strings.LastIndex(ref, "!")+1:], "$", "")
+		if strings.IndexFunc(ref, unicode.IsLetter) == -1 {
+			rows = ref
+			continue
+		}
+		cols = ref
+	}
+	return
+}
+
+// setSheetBuiltInDefinedName provides a function to set or remove the
+// built-in defined name in the worksheet scope by given worksheet name,
+// defined name and the formula it refers to.
+func (f *File) setSheetBuiltInDefinedName(sheet, name, refersTo string) error {
+	localSheetID, err := f.GetSheetIndex(sheet)
+	if err != nil {
+		return err
+	}
+	if localSheetID == -1 {
+		return ErrSheetNotExist{sheet}
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if wb.DefinedNames == nil {
+		wb.DefinedNames = &xlsxDefinedNames{}
+	}
+	definedNames := wb.DefinedNames.DefinedName[:0]
+	for _, dn := range wb.DefinedNames.DefinedName {
+		if dn.LocalSheetID != nil && *dn.LocalSheetID == localSheetID && strings.EqualFold(dn.Name, name) {
+			continue
+		}
+		definedNames = append(definedNames, dn)
+	}
+	if refersTo != "" {
+		definedNames = append(definedNames, xlsxDefinedName{Name: name, LocalSheetID: &localSheetID, Data: refersTo})
+	}
+	wb.DefinedNames.DefinedName = definedNames
+	if len(wb.DefinedNames.DefinedName) == 0 {
+		wb.DefinedNames = nil
+	}
+	return err
+}
+
+// getSheetBuiltInDefinedName provides a function to get the formula of the
+// built-in defined name in the worksheet scope by given worksheet name and
+// defined name.
+func (f *File) getSheetBuiltInDefinedName(sheet, name string) (string, error) {
+	localSheetID, err := f.GetSheetIndex(sheet)
+	if err != nil {
+		return "", err
+	}
+	if localSheetID == -1 {
+		return "", ErrSheetNotExist{sheet}
+	}
+	wb, err := f.workbookReader()
+	if err != nil || wb.DefinedNames == nil {
+		return "", err
+	}
+	for _, dn := range wb.DefinedNames.DefinedName {
+		if dn.LocalSheetID != nil && *dn.LocalSheetID == localSheetID && strings.EqualFold(dn.Name, name) {
+			return dn.Data, err
+		}
+	}
+	return "", err
+}
+
 // SetDefinedName provides a function to set the defined names of the workbook
-// or worksheet. If not specified scope, the default scope is workbook.
-// For example:
+// or worksheet. If not specified scope, the default scope is workbook. The
+// worksheet scope will be stored as the local sheet ID of the worksheet by its
+// order in the workbook. For example:
 //
 //	err := f.SetDefinedName(&excelize.DefinedName{
 //	    Name:     "Amount",
@@ -1646,8 +2437,22 @@ func (f *File) GetPageLayout(sheet string) (PageLayoutOptions, error) {
 //	    Comment:  "defined name comment",
 //	    Scope:    "Sheet2",
 //	})
+//
+// The defined name could also refer to a formula or constant, the leading
+// equal sign of the formula is optional. For example, set the defined name
+// "TaxRate" to a constant and "Total" to a formula based on it:
+//
+//	err := f.SetDefinedName(&excelize.DefinedName{
+//	    Name:     "TaxRate",
+//	    RefersTo: "0.2",
+//	})
+//	err = f.SetDefinedName(&excelize.DefinedName{
+//	    Name:     "Total",
+//	    RefersTo: "=SUM(Sheet1!$A$2:$A$5)*(1+TaxRate)",
+//	})
 func (f *File) SetDefinedName(definedName *DefinedName) error {
-	if definedName.Name == "" || definedName.RefersTo == "" {
+	refersTo := strings.TrimPrefix(definedName.RefersTo, "=")
+	if definedName.Name == "" || refersTo == "" {
 		return ErrParameterInvalid
 	}
 	if err := checkDefinedName(definedName.Name); err != nil && inStrSlice(builtInDefinedNames[:2], definedName.Name, false) == -1 {
@@ -1660,20 +2465,15 @@ func (f *File) SetDefinedName(definedName *DefinedName) error {
 	d := xlsxDefinedName{
 		Name:    definedName.Name,
 		Comment: definedName.Comment,
-		Data:    definedName.RefersTo,
+		Data:    refersTo,
 	}
-	if definedName.Scope != "" {
-		if sheetIndex, _ := f.GetSheetIndex(definedName.Scope); sheetIndex >= 0 {
-			d.LocalSheetID = &sheetIndex
-		}
+	if d.LocalSheetID, err = f.getDefinedNameLocalSheetID(definedName.Scope); err != nil {
+		return err
 	}
 	if wb.DefinedNames != nil {
+		scope := f.getDefinedNameScope(d)
 		for _, dn := range wb.DefinedNames.DefinedName {
-			var scope string
-			if dn.LocalSheetID != nil {
-				scope = f.GetSheetName(*dn.LocalSheetID)
-			}
-			if scope == definedName.Scope && dn.Name == definedName.Name {
+			if strings.EqualFold(f.getDefinedNameScope(dn), scope) && strings.EqualFold(dn.Name, definedName.Name) {
 				return ErrDefinedNameDuplicate
 			}
 		}
@@ -1688,7 +2488,8 @@ func (f *File) SetDefinedName(definedName *DefinedName) error {
 
 // DeleteDefinedName provides a function to delete the defined names of the
 // workbook or worksheet. If not specified scope, the default scope is
-// workbook. For example:
+// workbook. The defined name in the worksheet scope will not affect the
+// defined name with the same name in the workbook scope. For example:
 //
 //	err := f.DeleteDefinedName(&excelize.DefinedName{
 //	    Name:     "Amount",
@@ -1700,16 +2501,12 @@ func (f *File) DeleteDefinedName(definedName *DefinedName) error {
 		return err
 	}
 	if wb.DefinedNames != nil {
+		deleteScope := definedName.Scope
+		if deleteScope == "" {
+			deleteScope = "Workbook"
+		}
 		for idx, dn := range wb.DefinedNames.DefinedName {
-			scope := "Workbook"
-			deleteScope := definedName.Scope
-			if deleteScope == "" {
-				deleteScope = "Workbook"
-			}
-			if dn.LocalSheetID != nil {
-				scope = f.GetSheetName(*dn.LocalSheetID)
-			}
-			if scope == deleteScope && dn.Name == definedName.Name {
+			if strings.EqualFold(f.getDefinedNameScope(dn), deleteScope) && strings.EqualFold(dn.Name, definedName.Name) {
 				wb.DefinedNames.DefinedName = append(wb.DefinedNames.DefinedName[:idx], wb.DefinedNames.DefinedName[idx+1:]...)
 				return err
 			}
@@ -1719,27 +2516,69 @@ func (f *File) DeleteDefinedName(definedName *DefinedName) error {
 }
 
 // GetDefinedName provides a function to get the defined names of the workbook
-// or worksheet.
-func (f *File) GetDefinedName() []DefinedName {
+// or worksheet. Specify the optional name and scope to look up the defined
+// names, if not specified scope, the default scope is workbook. If the name is
+// empty, all defined names on the scope will be returned. For example, get the
+// defined name "Amount" in the scope of the worksheet "Sheet2":
+//
+//	definedNames := f.GetDefinedName(&excelize.DefinedName{
+//	    Name:  "Amount",
+//	    Scope: "Sheet2",
+//	})
+func (f *File) GetDefinedName(definedName ...*DefinedName) []DefinedName {
 	var definedNames []DefinedName
 	wb, _ := f.workbookReader()
 	if wb.DefinedNames != nil {
 		for _, dn := range wb.DefinedNames.DefinedName {
-			definedName := DefinedName{
+			scope := f.getDefinedNameScope(dn)
+			if len(definedName) > 0 && definedName[0] != nil {
+				lookupScope := definedName[0].Scope
+				if lookupScope == "" {
+					lookupScope = "Workbook"
+				}
+				if !strings.EqualFold(scope, lookupScope) ||
+					(definedName[0].Name != "" && !strings.EqualFold(dn.Name, definedName[0].Name)) {
+					continue
+				}
+			}
+			definedNames = append(definedNames, DefinedName{
 				Name:     dn.Name,
 				Comment:  dn.Comment,
 				RefersTo: dn.Data,
-				Scope:    "Workbook",
-			}
-			if dn.LocalSheetID != nil && *dn.LocalSheetID >= 0 {
-				definedName.Scope = f.GetSheetName(*dn.LocalSheetID)
-			}
-			definedNames = append(definedNames, definedName)
+				Scope:    scope,
+			})
 		}
 	}
 	return definedNames
 }
 
+// getDefinedNameLocalSheetID provides a function to get the local sheet ID of
+// the defined name by given scope. It returns nil for the workbook scope.
+func (f *File) getDefinedNameLocalSheetID(scope string) (*int, error) {
+	if scope == "" || strings.EqualFold(scope, "Workbook") {
+		return nil, nil
+	}
+	sheetIndex, err := f.GetSheetIndex(scope)
+	if err != nil {
+		return nil, err
+	}
+	if sheetIndex == -1 {
+		return nil, ErrSheetNotExist{scope}
+	}
+	return &sheetIndex, err
+}
+
+// getDefinedNameScope provides a function to get the scope of the defined
+// name by its local sheet ID. It returns "Workbook" for the workbook scope.
+func (f *File) getDefinedNameScope(dn xlsxDefinedName) string {
+	if dn.LocalSheetID != nil && *dn.LocalSheetID >= 0 {
+		if name := f.GetSheetName(*dn.LocalSheetID); name != "" {
+			return name
+		}
+	}
+	return "Workbook"
+}
+
 // GroupSheets provides a function to group worksheets by given worksheets
 // name. Group worksheets must contain an active worksheet.
 func (f *File) GroupSheets(sheets []string) error {
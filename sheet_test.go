@@ -94,6 +94,55 @@ func TestPanes(t *testing.T) {
 			},
 		},
 	))
+	// Test freeze the top row and first column
+	assert.NoError(t, f.SetPanes("Panes 4", &Panes{Freeze: true, XSplit: 1, YSplit: 1}))
+	panes, err = f.GetPanes("Panes 4")
+	assert.NoError(t, err)
+	assert.Equal(t, Panes{
+		Freeze:      true,
+		XSplit:      1,
+		YSplit:      1,
+		TopLeftCell: "B2",
+		ActivePane:  "bottomRight",
+		Selection: []Selection{
+			{Pane: "topRight"},
+			{Pane: "bottomLeft"},
+			{SQRef: "B2", ActiveCell: "B2", Pane: "bottomRight"},
+		},
+	}, panes)
+	// Test freeze the top row only
+	assert.NoError(t, f.SetPanes("Panes 4", &Panes{Freeze: true, YSplit: 1}))
+	panes, err = f.GetPanes("Panes 4")
+	assert.NoError(t, err)
+	assert.Equal(t, Panes{
+		Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft",
+		Selection: []Selection{{SQRef: "A2", ActiveCell: "A2", Pane: "bottomLeft"}},
+	}, panes)
+	// Test set and get split panes
+	expected = Panes{Split: true, XSplit: 3270, YSplit: 1800, TopLeftCell: "N57", ActivePane: "bottomRight",
+		Selection: []Selection{{Pane: "topRight"}, {Pane: "bottomLeft"}, {SQRef: "N57", ActiveCell: "N57", Pane: "bottomRight"}},
+	}
+	assert.NoError(t, f.SetPanes("Panes 4", &Panes{Split: true, XSplit: 3270, YSplit: 1800, TopLeftCell: "N57"}))
+	panes, err = f.GetPanes("Panes 4")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, panes)
+	// Test set and get frozen panes which were split before being frozen
+	expected.Freeze, expected.XSplit, expected.YSplit = true, 13, 56
+	assert.NoError(t, f.SetPanes("Panes 4", &expected))
+	panes, err = f.GetPanes("Panes 4")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, panes)
+	// Test get split panes without pane state
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet4.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetViews.SheetView[0].Pane.State = ""
+	panes, err = f.GetPanes("Panes 4")
+	assert.NoError(t, err)
+	assert.True(t, panes.Split)
+	assert.False(t, panes.Freeze)
+	// Test set panes with invalid split positions
+	assert.EqualError(t, f.SetPanes("Panes 4", &Panes{Freeze: true, XSplit: -1}), ErrParameterInvalid.Error())
+	assert.EqualError(t, f.SetPanes("Panes 4", &Panes{Freeze: true, XSplit: MaxColumns}), ErrColumnNumber.Error())
 	assert.EqualError(t, f.SetPanes("Panes 4", nil), ErrParameterInvalid.Error())
 	assert.EqualError(t, f.SetPanes("SheetN", nil), "sheet SheetN does not exist")
 	// Test set panes with invalid sheet name
@@ -102,7 +151,7 @@ func TestPanes(t *testing.T) {
 
 	// Test get panes with empty sheet views
 	f = NewFile()
-	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
 	assert.True(t, ok)
 	ws.(*xlsxWorksheet).SheetViews = &xlsxSheetViews{}
 	_, err = f.GetPanes("Sheet1")
@@ -215,6 +264,8 @@ func TestSetPageLayout(t *testing.T) {
 	opts, err := f.GetPageLayout("Sheet1")
 	assert.NoError(t, err)
 	assert.Equal(t, expected, opts)
+	// Test fit to page print option enabled with fit to pages scaling
+	assert.True(t, ws.(*xlsxWorksheet).SheetPr.PageSetUpPr.FitToPage)
 	// Test set page layout on not exists worksheet
 	assert.EqualError(t, f.SetPageLayout("SheetN", nil), "sheet SheetN does not exist")
 	// Test set page layout with invalid sheet name
@@ -231,6 +282,88 @@ func TestGetPageLayout(t *testing.T) {
 	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
 }
 
+func TestPrintAreaAndTitles(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet 2")
+	assert.NoError(t, err)
+	// Test get print area and titles with default settings
+	ref, err := f.GetPrintArea("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, ref)
+	rows, cols, err := f.GetPrintTitles("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, rows)
+	assert.Empty(t, cols)
+
+	assert.NoError(t, f.SetPrintArea("Sheet1", "D10:A1,F1"))
+	assert.NoError(t, f.SetPrintArea("Sheet 2", "B2:C3"))
+	assert.NoError(t, f.SetPrintTitles("Sheet1", "2:1", "A:B"))
+	assert.NoError(t, f.SetPrintTitles("Sheet 2", "3", ""))
+	// Test replace the existing print area
+	assert.NoError(t, f.SetPrintArea("Sheet1", "A1:D10,F1"))
+	assert.NoError(t, f.SetHeaderFooter("Sheet1", &HeaderFooterOptions{OddFooter: "&L&P&C&N&R&D"}))
+	assert.NoError(t, f.SetPageLayout("Sheet1", &PageLayoutOptions{FitToWidth: intPtr(1), FitToHeight: intPtr(0)}))
+	assert.NoError(t, f.SetPageMargins("Sheet1", &PageLayoutMarginsOptions{Top: float64Ptr(1.5)}))
+	definedNames := f.GetDefinedName()
+	assert.Len(t, definedNames, 4)
+	assert.Equal(t, DefinedName{Name: "_xlnm.Print_Area", RefersTo: "Sheet1!$A$1:$D$10,Sheet1!$F$1:$F$1", Scope: "Sheet1"}, definedNames[3])
+	assert.Equal(t, DefinedName{Name: "_xlnm.Print_Titles", RefersTo: "Sheet1!$A:$B,Sheet1!$1:$2", Scope: "Sheet1"}, definedNames[1])
+	assert.Equal(t, DefinedName{Name: "_xlnm.Print_Titles", RefersTo: "'Sheet 2'!$3:$3", Scope: "Sheet 2"}, definedNames[2])
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestPrintAreaAndTitles.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestPrintAreaAndTitles.xlsx"))
+	assert.NoError(t, err)
+	ref, err = f.GetPrintArea("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:D10,F1:F1", ref)
+	ref, err = f.GetPrintArea("Sheet 2")
+	assert.NoError(t, err)
+	assert.Equal(t, "B2:C3", ref)
+	rows, cols, err = f.GetPrintTitles("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1:2", rows)
+	assert.Equal(t, "A:B", cols)
+	rows, cols, err = f.GetPrintTitles("Sheet 2")
+	assert.NoError(t, err)
+	assert.Equal(t, "3:3", rows)
+	assert.Empty(t, cols)
+	headerFooter, err := f.GetHeaderFooter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "&L&P&C&N&R&D", headerFooter.OddFooter)
+	layout, err := f.GetPageLayout("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *layout.FitToWidth)
+	assert.Equal(t, 0, *layout.FitToHeight)
+	margins, err := f.GetPageMargins("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, *margins.Top)
+	// Test remove print area and titles
+	assert.NoError(t, f.SetPrintArea("Sheet1", ""))
+	assert.NoError(t, f.SetPrintTitles("Sheet1", "", ""))
+	assert.NoError(t, f.SetPrintArea("Sheet 2", ""))
+	assert.NoError(t, f.SetPrintTitles("Sheet 2", "", ""))
+	assert.Empty(t, f.GetDefinedName())
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	// Test set and get print area and titles on not exists worksheet
+	assert.EqualError(t, f.SetPrintArea("SheetN", "A1"), "sheet SheetN does not exist")
+	assert.EqualError(t, f.SetPrintTitles("SheetN", "1", ""), "sheet SheetN does not exist")
+	_, err = f.GetPrintArea("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	_, _, err = f.GetPrintTitles("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test set print area and titles with invalid arguments
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetPrintArea("Sheet1", "A:B"))
+	assert.Equal(t, ErrParameterInvalid, f.SetPrintTitles("Sheet1", "1:2:3", ""))
+	assert.Equal(t, ErrParameterInvalid, f.SetPrintTitles("Sheet1", "A", ""))
+	assert.Equal(t, newInvalidRowNumberError(0), f.SetPrintTitles("Sheet1", "0", ""))
+	assert.Equal(t, ErrMaxRows, f.SetPrintTitles("Sheet1", strconv.Itoa(TotalRows+1), ""))
+	assert.Equal(t, newInvalidColumnNameError("1"), f.SetPrintTitles("Sheet1", "", "1"))
+	assert.NoError(t, f.Close())
+}
+
 func TestHeaderFooter(t *testing.T) {
 	f := NewFile()
 	// Test get header and footer with default header and footer settings
@@ -250,6 +383,9 @@ func TestHeaderFooter(t *testing.T) {
 	assert.EqualError(t, f.SetHeaderFooter("Sheet1", &HeaderFooterOptions{
 		OddHeader: strings.Repeat("c", MaxFieldLength+1),
 	}), newFieldLengthError("OddHeader").Error())
+	assert.EqualError(t, f.SetHeaderFooter("Sheet1", &HeaderFooterOptions{
+		FirstFooter: strings.Repeat("c", MaxFieldLength+1),
+	}), newFieldLengthError("FirstFooter").Error())
 
 	assert.NoError(t, f.SetHeaderFooter("Sheet1", nil))
 	text := strings.Repeat("一", MaxFieldLength)
@@ -274,6 +410,47 @@ func TestHeaderFooter(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expected, opts)
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetHeaderFooter.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test the first page and odd and even pages variants enabled automatically
+	f = NewFile()
+	assert.NoError(t, f.SetHeaderFooter("Sheet1", &HeaderFooterOptions{
+		OddHeader:   "&COdd",
+		EvenHeader:  "&CEven",
+		FirstFooter: "&CFirst",
+	}))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	opts, err = f.GetHeaderFooter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, &HeaderFooterOptions{
+		DifferentFirst:   true,
+		DifferentOddEven: true,
+		OddHeader:        "&COdd",
+		EvenHeader:       "&CEven",
+		FirstFooter:      "&CFirst",
+	}, opts)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	output, err := xml.Marshal(ws.(*xlsxWorksheet).HeaderFooter)
+	assert.NoError(t, err)
+	assert.Equal(t, `<headerFooter differentOddEven="true" differentFirst="true"><oddHeader>&amp;COdd</oddHeader><evenHeader>&amp;CEven</evenHeader><firstFooter>&amp;CFirst</firstFooter></headerFooter>`, string(output))
+	// Test the first page variant enabled automatically by the first page
+	// header or footer only
+	for _, opts := range []*HeaderFooterOptions{
+		{FirstHeader: "&CFirst"}, {FirstFooter: "&CFirst"},
+	} {
+		assert.NoError(t, f.SetHeaderFooter("Sheet1", opts))
+		result, err := f.GetHeaderFooter("Sheet1")
+		assert.NoError(t, err)
+		assert.True(t, result.DifferentFirst)
+		assert.False(t, result.DifferentOddEven)
+		assert.False(t, opts.DifferentFirst)
+	}
+	assert.NoError(t, f.Close())
 }
 
 func TestDefinedName(t *testing.T) {
@@ -322,6 +499,59 @@ func TestDefinedName(t *testing.T) {
 	assert.Exactly(t, "Sheet1!$A$2:$D$5", f.GetDefinedName()[0].RefersTo)
 	assert.Len(t, f.GetDefinedName(), 3)
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDefinedName.xlsx")))
+	// Test set defined name with not exist worksheet scope
+	assert.EqualError(t, f.SetDefinedName(&DefinedName{
+		Name: "Amount", RefersTo: "Sheet1!$A$2:$D$5", Scope: "SheetN",
+	}), "sheet SheetN does not exist")
+	// Test set defined name with invalid worksheet scope
+	assert.EqualError(t, f.SetDefinedName(&DefinedName{
+		Name: "Amount", RefersTo: "Sheet1!$A$2:$D$5", Scope: "Sheet:1",
+	}), ErrSheetNameInvalid.Error())
+	// Test set defined name with the same name in different scopes
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	for _, scope := range []string{"", "Sheet2"} {
+		assert.NoError(t, f.SetDefinedName(&DefinedName{
+			Name: "Total", RefersTo: "Sheet2!$A$1", Scope: scope,
+		}))
+	}
+	assert.EqualError(t, f.SetDefinedName(&DefinedName{
+		Name: "total", RefersTo: "Sheet2!$A$1", Scope: "Workbook",
+	}), ErrDefinedNameDuplicate.Error())
+	assert.EqualError(t, f.SetDefinedName(&DefinedName{
+		Name: "Total", RefersTo: "Sheet2!$A$1", Scope: "sheet2",
+	}), ErrDefinedNameDuplicate.Error())
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *wb.DefinedNames.DefinedName[4].LocalSheetID)
+	definedNames := f.GetDefinedName()
+	assert.Equal(t, "Workbook", definedNames[3].Scope)
+	assert.Equal(t, "Sheet2", definedNames[4].Scope)
+	// Test get defined name by given name and scope
+	definedNames = f.GetDefinedName(&DefinedName{Name: "total", Scope: "Sheet2"})
+	assert.Len(t, definedNames, 1)
+	assert.Equal(t, "Sheet2", definedNames[0].Scope)
+	definedNames = f.GetDefinedName(&DefinedName{Name: "Total"})
+	assert.Len(t, definedNames, 1)
+	assert.Equal(t, "Workbook", definedNames[0].Scope)
+	assert.Len(t, f.GetDefinedName(&DefinedName{Scope: "Sheet1"}), 3)
+	assert.Empty(t, f.GetDefinedName(&DefinedName{Name: "Total", Scope: "Sheet1"}))
+	assert.Len(t, f.GetDefinedName(nil), 5)
+	// Test set defined name refers to a formula with leading equal sign
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Rate", RefersTo: "=0.2*2"}))
+	assert.Equal(t, "0.2*2", f.GetDefinedName(&DefinedName{Name: "Rate"})[0].RefersTo)
+	assert.EqualError(t, f.SetDefinedName(&DefinedName{Name: "Rate", RefersTo: "="}), ErrParameterInvalid.Error())
+	assert.NoError(t, f.DeleteDefinedName(&DefinedName{Name: "Rate"}))
+	// Test delete defined name in worksheet scope
+	assert.NoError(t, f.DeleteDefinedName(&DefinedName{Name: "Total", Scope: "Sheet2"}))
+	definedNames = f.GetDefinedName()
+	assert.Len(t, definedNames, 4)
+	assert.Equal(t, "Total", definedNames[3].Name)
+	assert.Equal(t, "Workbook", definedNames[3].Scope)
+	assert.EqualError(t, f.DeleteDefinedName(&DefinedName{Name: "Total", Scope: "Sheet2"}), ErrDefinedNameScope.Error())
+	// Test get defined name with invalid local sheet ID
+	wb.DefinedNames.DefinedName[3].LocalSheetID = intPtr(10)
+	assert.Equal(t, "Workbook", f.GetDefinedName()[3].Scope)
 	// Test set defined name with unsupported charset workbook
 	f.WorkBook = nil
 	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
@@ -538,6 +768,182 @@ func TestGetSheetID(t *testing.T) {
 	assert.NotEqual(t, -1, id)
 }
 
+func TestDuplicateSheet(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{nil, "Apple", "Orange", "Pear"}, {"Small", 2, 3, 3},
+		{"Normal", 5, 2, 4}, {"Large", 6, 7, 8},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	assert.NoError(t, f.MergeCell("Sheet1", "A6", "B7"))
+	dv := NewDataValidation(true)
+	dv.Sqref = "B2:D4"
+	assert.NoError(t, dv.SetRange(0, 10, DataValidationTypeWhole, DataValidationOperatorBetween))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	format, err := f.NewConditionalStyle(&Style{Font: &Font{Color: "9A0511"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "B2:D4", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: format, Value: "5"},
+	}))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "This is a comment."}))
+	assert.NoError(t, f.AddPicture("Sheet1", "F1", filepath.Join("test", "images", "excel.png"), nil))
+	assert.NoError(t, f.AddChart("Sheet1", "F10", &Chart{
+		Type: Col,
+		Series: []ChartSeries{
+			{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"},
+		},
+	}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet1!$B$2:$D$4", Scope: "Sheet1"}))
+
+	assert.NoError(t, f.DuplicateSheet("Sheet1", "Sheet2"))
+	val, err := f.GetCellValue("Sheet2", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", val)
+	mergeCells, err := f.GetMergeCells("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	dvs, err := f.GetDataValidations("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 1)
+	conditionalFormats, err := f.GetConditionalFormats("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, conditionalFormats, 1)
+	comments, err := f.GetComments("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	pics, err := f.GetPictures("Sheet2", "F1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	charts, err := f.GetCharts("Sheet2")
+	assert.NoError(t, err)
+	if assert.Len(t, charts, 1) {
+		assert.Equal(t, "Sheet2!$B$1:$D$1", charts[0].Series[0].Categories)
+		assert.Equal(t, "Sheet2!$B$2:$D$2", charts[0].Series[0].Values)
+	}
+	assert.Contains(t, f.GetDefinedName(), DefinedName{Name: "Amount", RefersTo: "Sheet2!$B$2:$D$4", Scope: "Sheet2"})
+	// Test the duplicated worksheet is independent from the source worksheet
+	assert.NoError(t, f.AddComment("Sheet2", Comment{Cell: "B1", Author: "Excelize", Text: "This is a comment."}))
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.NoError(t, f.SetCellValue("Sheet2", "B2", 10))
+	val, err = f.GetCellValue("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", val)
+	charts, err = f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	if assert.Len(t, charts, 1) {
+		assert.Equal(t, "Sheet1!$B$2:$D$2", charts[0].Series[0].Values)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDuplicateSheet.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test duplicate worksheet after reopen the workbook
+	f, err = OpenFile(filepath.Join("test", "TestDuplicateSheet.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.DuplicateSheet("Sheet2", "Sheet3"))
+	comments, err = f.GetComments("Sheet3")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 2)
+	pics, err = f.GetPictures("Sheet3", "F1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	charts, err = f.GetCharts("Sheet3")
+	assert.NoError(t, err)
+	if assert.Len(t, charts, 1) {
+		assert.Equal(t, "Sheet3!$B$2:$D$2", charts[0].Series[0].Values)
+	}
+	// Test duplicate worksheet with exists target worksheet name
+	assert.Equal(t, ErrExistsSheet, f.DuplicateSheet("Sheet1", "sheet2"))
+	// Test duplicate worksheet on not exists worksheet
+	assert.Equal(t, ErrSheetNotExist{"SheetN"}, f.DuplicateSheet("SheetN", "Sheet4"))
+	// Test duplicate worksheet with invalid sheet name
+	assert.EqualError(t, f.DuplicateSheet("Sheet:1", "Sheet4"), ErrSheetNameInvalid.Error())
+	assert.EqualError(t, f.DuplicateSheet("Sheet1", "Sheet:4"), ErrSheetNameInvalid.Error())
+	assert.NoError(t, f.Close())
+
+	// Test duplicate worksheet with table and slicer
+	f = NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Name", "Amount"}))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Name: "Table1", Range: "A1:B3"}))
+	assert.NoError(t, f.AddSlicer("Sheet1", &SlicerOptions{
+		Name: "Name", Cell: "D1", TableSheet: "Sheet1", TableName: "Table1", Caption: "Name",
+	}))
+	assert.NoError(t, f.AddPicture("Sheet1", "H1", filepath.Join("test", "images", "excel.png"), nil))
+	assert.NoError(t, f.DuplicateSheet("Sheet1", "Sheet2"))
+	tables, err := f.GetTables("Sheet2")
+	assert.NoError(t, err)
+	assert.Empty(t, tables)
+	ws, err := f.workSheetReader("Sheet2")
+	assert.NoError(t, err)
+	assert.Nil(t, ws.ExtLst)
+	pics, err = f.GetPictures("Sheet2", "H1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Contains(t, ws.ExtLst.Ext, ExtURISlicerListX15)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDuplicateSheetSlicer.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test duplicate worksheet with threaded comments
+	f = NewFile()
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{
+		Cell: "A1", Author: "Excelize", Text: "Thread",
+		Replies: []ThreadedCommentReply{{Author: "Reviewer", Text: "Reply"}},
+	}))
+	assert.NoError(t, f.DuplicateSheet("Sheet1", "Sheet2"))
+	source, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	comments, err = f.GetComments("Sheet2")
+	assert.NoError(t, err)
+	if assert.Len(t, comments, 1) && assert.NotNil(t, comments[0].ThreadedComment) {
+		assert.Equal(t, source[0].ThreadedComment, comments[0].ThreadedComment)
+		assert.NotEqual(t, source[0].Author, comments[0].Author)
+	}
+	assert.Equal(t, "xl/threadedComments/threadedComment2.xml", f.getSheetThreadedComments("Sheet2"))
+	// Test the duplicated threaded comments are independent from the source
+	assert.NoError(t, f.DeleteComment("Sheet2", "A1"))
+	assert.NoError(t, f.AddThreadedComment("Sheet2", ThreadedComment{Cell: "B2", Text: "Copy"}))
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	if assert.Len(t, comments, 1) && assert.NotNil(t, comments[0].ThreadedComment) {
+		assert.Equal(t, "Thread", comments[0].ThreadedComment.Text)
+		assert.Len(t, comments[0].ThreadedComment.Replies, 1)
+	}
+	comments, err = f.GetComments("Sheet2")
+	assert.NoError(t, err)
+	if assert.Len(t, comments, 1) && assert.NotNil(t, comments[0].ThreadedComment) {
+		assert.Equal(t, "B2", comments[0].Cell)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDuplicateSheetThreadedComment.xlsx")))
+	// Test duplicate worksheet with unsupported charset threaded comments
+	f.Pkg.Store("xl/threadedComments/threadedComment1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.DuplicateSheet("Sheet1", "Sheet3"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test duplicate chart sheet
+	f = NewFile()
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"}},
+	}))
+	assert.EqualError(t, f.DuplicateSheet("Chart1", "Chart2"), newNotWorksheetError("Chart1").Error())
+	// Test duplicate worksheet with unsupported charset worksheet relationships
+	f.Relationships.Delete("xl/worksheets/_rels/sheet1.xml.rels")
+	f.Pkg.Store("xl/worksheets/_rels/sheet1.xml.rels", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.DuplicateSheet("Sheet1", "Sheet3"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestReplaceSheetNameRefs(t *testing.T) {
+	assert.Equal(t, "Sheet2!A1+Sheet2!B1+MySheet1!C1", replaceSheetNameRefs("Sheet1!A1+'Sheet1'!B1+MySheet1!C1", "Sheet1", "Sheet2"))
+	assert.Equal(t, "'Jan 1'!A1+Sheet10!A1", replaceSheetNameRefs("'Sheet 1'!A1+Sheet10!A1", "Sheet 1", "Jan 1"))
+}
+
 func TestSetSheetVisible(t *testing.T) {
 	f := NewFile()
 	// Test set sheet visible with invalid sheet name
@@ -739,3 +1145,36 @@ func TestSheetDimension(t *testing.T) {
 	assert.Empty(t, dimension)
 	assert.EqualError(t, err, "sheet SheetN does not exist")
 }
+
+func TestUpdateSheetDimension(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "C2", "A"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "E8", 1))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B5", true))
+	assert.NoError(t, f.SetCellValue("Sheet2", "D4", 1))
+	// Test save the workbook without recalculate the worksheet dimension
+	assert.NoError(t, f.SetSheetDimension("Sheet1", "A1"))
+	assert.NoError(t, f.SetSheetDimension("Sheet2", "A1"))
+	file := filepath.Join("test", "TestUpdateSheetDimension.xlsx")
+	assert.NoError(t, f.SaveAs(file))
+	dimension, err := f.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1", dimension)
+	// Test save the workbook with recalculate the worksheet dimension
+	assert.NoError(t, f.SaveAs(file, Options{UpdateSheetDimension: true}))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(file)
+	assert.NoError(t, err)
+	for sheet, expected := range map[string]string{"Sheet1": "B2:E8", "Sheet2": "D4"} {
+		dimension, err := f.GetSheetDimension(sheet)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, dimension, sheet)
+	}
+	assert.NoError(t, f.Close())
+	// Test recalculate the dimension of the worksheet without non-blank cells
+	ws := &xlsxWorksheet{SheetData: xlsxSheetData{Row: []xlsxRow{{C: []xlsxC{{R: "A1"}, {R: "-", V: "1"}}}}}}
+	ws.updateDimension()
+	assert.Equal(t, "A1", ws.Dimension.Ref)
+}
@@ -11,6 +11,11 @@
 
 package excelize
 
+import (
+	"strconv"
+	"strings"
+)
+
 // getSheetView returns the SheetView object
 func (f *File) getSheetView(sheet string, viewIndex int) (*xlsxSheetView, error) {
 	ws, err := f.workSheetReader(sheet)
@@ -71,7 +76,13 @@ func (view *xlsxSheetView) setSheetView(opts *ViewOptions) {
 }
 
 // SetSheetView sets sheet view options. The viewIndex may be negative and if
-// so is counted backward (-1 is the last view).
+// so is counted backward (-1 is the last view). For example, set the grid
+// lines color of the first view on Sheet1:
+//
+//	color := "FF0000"
+//	err := f.SetSheetView("Sheet1", 0, &excelize.ViewOptions{
+//	    GridLineColor: &color,
+//	})
 func (f *File) SetSheetView(sheet string, viewIndex int, opts *ViewOptions) error {
 	view, err := f.getSheetView(sheet, viewIndex)
 	if err != nil {
@@ -80,10 +91,152 @@ func (f *File) SetSheetView(sheet string, viewIndex int, opts *ViewOptions) erro
 	if opts == nil {
 		return err
 	}
+	if opts.GridLineColor != nil {
+		colorID, err := f.getGridLineColorID(*opts.GridLineColor)
+		if err != nil {
+			return err
+		}
+		view.ColorID = &colorID
+		if opts.DefaultGridColor == nil {
+			view.DefaultGridColor = boolPtr(false)
+		}
+	}
 	view.setSheetView(opts)
 	return nil
 }
 
+// getIndexedColors provides a function to get the indexed color palette of
+// the workbook, the default indexed color palette will be returned if the
+// palette in the styles part has not been modified.
+func (f *File) getIndexedColors() ([]string, error) {
+	s, err := f.stylesReader()
+	if err != nil {
+		return nil, err
+	}
+	if s.Colors == nil || s.Colors.IndexedColors == nil || len(s.Colors.IndexedColors.RgbColor) == 0 {
+		return IndexedColorMapping, err
+	}
+	colors := make([]string, 0, len(s.Colors.IndexedColors.RgbColor))
+	for _, clr := range s.Colors.IndexedColors.RgbColor {
+		rgb := strings.ToUpper(clr.RGB)
+		if len(rgb) == 8 {
+			rgb = rgb[2:]
+		}
+		colors = append(colors, rgb)
+	}
+	return colors, err
+}
+
+// getGridLineColorID provides a function to get the index in the indexed
+// color palette by given grid lines color in hex RGB format.
+func (f *File) getGridLineColorID(color string) (int, error) {
+	color = strings.ToUpper(strings.TrimPrefix(color, "#"))
+	if _, err := strconv.ParseUint(color, 16, 32); err != nil || len(color) != 6 {
+		return -1, ErrParameterInvalid
+	}
+	colors, err := f.getIndexedColors()
+	if err != nil {
+		return -1, err
+	}
+	if idx := inStrSlice(colors, color, true); idx != -1 {
+		return idx, err
+	}
+	return -1, newUnsupportedGridLineColorError(color)
+}
+
+// SetOpenView provides a function to set the workbook to open on the given
+// worksheet with specified cell selected and scrolled into view at the given
+// zoom scale in one call. The zoom scale must be between 10 and 400. If panes
+// exist in the first view of the worksheet, the cell will be selected in the
+// active pane and the panes will be kept. For example, make the workbook open
+// on Sheet2 with cell C5 selected at 120% zoom:
+//
+//	err := f.SetOpenView("Sheet2", "C5", 120)
+func (f *File) SetOpenView(sheet, cell string, zoom uint) error {
+	index, err := f.GetSheetIndex(sheet)
+	if err != nil {
+		return err
+	}
+	if index == -1 {
+		return ErrSheetNotExist{sheet}
+	}
+	if zoom < 10 || zoom > 400 {
+		return ErrZoomScale
+	}
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	if cell, err = CoordinatesToCellName(col, row); err != nil {
+		return err
+	}
+	view, err := f.getSheetView(sheet, 0)
+	if err != nil {
+		return err
+	}
+	f.SetActiveSheet(index)
+	view.ZoomScale = float64(zoom)
+	if view.Pane == nil {
+		view.TopLeftCell = cell
+	}
+	view.setActiveCell(cell)
+	return err
+}
+
+// SetActiveCell provides a function to set the active cell of the worksheet
+// by given worksheet name and cell reference, and make the worksheet be the
+// active sheet of the workbook, so that the cursor will be placed on the cell
+// when the workbook opens. If panes exist in the first view of the
+// worksheet, the cell will be selected in the active pane and the panes will
+// be kept. For example, set cell B3 as the active cell of Sheet2:
+//
+//	err := f.SetActiveCell("Sheet2", "B3")
+func (f *File) SetActiveCell(sheet, cell string) error {
+	index, err := f.GetSheetIndex(sheet)
+	if err != nil {
+		return err
+	}
+	if index == -1 {
+		return ErrSheetNotExist{sheet}
+	}
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	if cell, err = CoordinatesToCellName(col, row); err != nil {
+		return err
+	}
+	view, err := f.getSheetView(sheet, 0)
+	if err != nil {
+		return err
+	}
+	f.SetActiveSheet(index)
+	view.setActiveCell(cell)
+	return err
+}
+
+// setActiveCell provides a function to select the cell in the active pane of
+// the sheet view, the selection of the other panes will be kept.
+func (view *xlsxSheetView) setActiveCell(cell string) {
+	if view.Pane == nil {
+		view.Selection = []*xlsxSelection{{ActiveCell: cell, SQRef: cell}}
+		return
+	}
+	paneName := func(pane string) string {
+		if pane == "" {
+			return "topLeft"
+		}
+		return pane
+	}
+	for _, selection := range view.Selection {
+		if paneName(selection.Pane) == paneName(view.Pane.ActivePane) {
+			selection.ActiveCell, selection.ActiveCellID, selection.SQRef = cell, nil, cell
+			return
+		}
+	}
+	view.Selection = append(view.Selection, &xlsxSelection{Pane: view.Pane.ActivePane, ActiveCell: cell, SQRef: cell})
+}
+
 // GetSheetView gets the value of sheet view options. The viewIndex may be
 // negative and if so is counted backward (-1 is the last view).
 func (f *File) GetSheetView(sheet string, viewIndex int) (ViewOptions, error) {
@@ -104,6 +257,15 @@ func (f *File) GetSheetView(sheet string, viewIndex int) (ViewOptions, error) {
 	if view.DefaultGridColor != nil {
 		opts.DefaultGridColor = view.DefaultGridColor
 	}
+	if view.ColorID != nil {
+		colors, err := f.getIndexedColors()
+		if err != nil {
+			return opts, err
+		}
+		if *view.ColorID >= 0 && *view.ColorID < len(colors) {
+			opts.GridLineColor = stringPtr(colors[*view.ColorID])
+		}
+	}
 	opts.RightToLeft = boolPtr(view.RightToLeft)
 	opts.ShowFormulas = boolPtr(view.ShowFormulas)
 	if view.ShowGridLines != nil {
@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -35,6 +36,58 @@ func TestSetView(t *testing.T) {
 	assert.EqualError(t, f.SetSheetView("SheetN", 0, nil), "sheet SheetN does not exist")
 }
 
+func TestSetSheetViewGridLineColor(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetView("Sheet1", 0, &ViewOptions{GridLineColor: stringPtr("#ff0000")}))
+	opts, err := f.GetSheetView("Sheet1", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "FF0000", *opts.GridLineColor)
+	assert.False(t, *opts.DefaultGridColor)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, 2, *ws.(*xlsxWorksheet).SheetViews.SheetView[0].ColorID)
+	// Test set grid lines color with default grid color
+	assert.NoError(t, f.SetSheetView("Sheet1", 0, &ViewOptions{GridLineColor: stringPtr("000000"), DefaultGridColor: boolPtr(true)}))
+	opts, err = f.GetSheetView("Sheet1", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "000000", *opts.GridLineColor)
+	assert.True(t, *opts.DefaultGridColor)
+	// Test set grid lines color with invalid color
+	for _, color := range []string{"", "FF00", "GG0000", "FF000000"} {
+		assert.Equal(t, ErrParameterInvalid, f.SetSheetView("Sheet1", 0, &ViewOptions{GridLineColor: stringPtr(color)}))
+	}
+	// Test set grid lines color which not in the indexed color palette
+	assert.EqualError(t, f.SetSheetView("Sheet1", 0, &ViewOptions{GridLineColor: stringPtr("123456")}), newUnsupportedGridLineColorError("123456").Error())
+	// Test get grid lines color with custom indexed color palette
+	f.Styles.Colors = &xlsxStyleColors{IndexedColors: &xlsxIndexedColors{RgbColor: []xlsxColor{{RGB: "FF123456"}}}}
+	assert.NoError(t, f.SetSheetView("Sheet1", 0, &ViewOptions{GridLineColor: stringPtr("123456")}))
+	opts, err = f.GetSheetView("Sheet1", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "123456", *opts.GridLineColor)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetSheetViewGridLineColor.xlsx")))
+	f, err = OpenFile(filepath.Join("test", "TestSetSheetViewGridLineColor.xlsx"))
+	assert.NoError(t, err)
+	opts, err = f.GetSheetView("Sheet1", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "123456", *opts.GridLineColor)
+	// Test get grid lines color with invalid color index
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetViews.SheetView[0].ColorID = intPtr(100)
+	opts, err = f.GetSheetView("Sheet1", 0)
+	assert.NoError(t, err)
+	assert.Nil(t, opts.GridLineColor)
+	// Test set and get grid lines color with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetSheetView("Sheet1", 0, &ViewOptions{GridLineColor: stringPtr("FF0000")}), "XML syntax error on line 1: invalid UTF-8")
+	f.Styles = nil
+	ws.(*xlsxWorksheet).SheetViews.SheetView[0].ColorID = intPtr(0)
+	_, err = f.GetSheetView("Sheet1", 0)
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestGetView(t *testing.T) {
 	f := NewFile()
 	_, err := f.getSheetView("SheetN", 0)
@@ -48,3 +101,106 @@ func TestGetView(t *testing.T) {
 	_, err = f.GetSheetView("SheetN", 0)
 	assert.EqualError(t, err, "sheet SheetN does not exist")
 }
+
+func TestSetOpenView(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetOpenView("Sheet2", "c5", 120))
+	assert.Equal(t, 1, f.GetActiveSheetIndex())
+	opts, err := f.GetSheetView("Sheet2", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 120.0, *opts.ZoomScale)
+	assert.Equal(t, "C5", *opts.TopLeftCell)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet2.xml")
+	assert.True(t, ok)
+	assert.Equal(t, []*xlsxSelection{{ActiveCell: "C5", SQRef: "C5"}}, ws.(*xlsxWorksheet).SheetViews.SheetView[0].Selection)
+	assert.True(t, ws.(*xlsxWorksheet).SheetViews.SheetView[0].TabSelected)
+	// Test set open view on the worksheet with panes
+	assert.NoError(t, f.SetPanes("Sheet1", &Panes{Freeze: true, XSplit: 1, YSplit: 1, TopLeftCell: "B2", ActivePane: "bottomRight"}))
+	assert.NoError(t, f.SetOpenView("Sheet1", "D10", 80))
+	assert.Equal(t, 0, f.GetActiveSheetIndex())
+	panes, err := f.GetPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "B2", panes.TopLeftCell)
+	assert.Len(t, panes.Selection, 3)
+	assert.Equal(t, Selection{SQRef: "D10", ActiveCell: "D10", Pane: "bottomRight"}, panes.Selection[2])
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetViews.SheetView[0].Selection = nil
+	assert.NoError(t, f.SetOpenView("Sheet1", "E10", 80))
+	panes, err = f.GetPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []Selection{{SQRef: "E10", ActiveCell: "E10", Pane: "bottomRight"}}, panes.Selection)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetOpenView.xlsx")))
+	// Test set open view with invalid zoom scale
+	assert.Equal(t, ErrZoomScale, f.SetOpenView("Sheet1", "A1", 9))
+	assert.Equal(t, ErrZoomScale, f.SetOpenView("Sheet1", "A1", 401))
+	// Test set open view with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetOpenView("Sheet1", "A", 100))
+	// Test set open view on not exists worksheet
+	assert.EqualError(t, f.SetOpenView("SheetN", "A1", 100), "sheet SheetN does not exist")
+	// Test set open view with invalid sheet name
+	assert.EqualError(t, f.SetOpenView("Sheet:1", "A1", 100), ErrSheetNameInvalid.Error())
+	// Test set open view on chart sheet
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"}},
+	}))
+	assert.EqualError(t, f.SetOpenView("Chart1", "A1", 100), newNotWorksheetError("Chart1").Error())
+	assert.Equal(t, 0, f.GetActiveSheetIndex())
+}
+
+func TestSetActiveCell(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetActiveCell("Sheet2", "b3"))
+	assert.Equal(t, 1, f.GetActiveSheetIndex())
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet2.xml")
+	assert.True(t, ok)
+	assert.Equal(t, []*xlsxSelection{{ActiveCell: "B3", SQRef: "B3"}}, ws.(*xlsxWorksheet).SheetViews.SheetView[0].Selection)
+	assert.Empty(t, ws.(*xlsxWorksheet).SheetViews.SheetView[0].TopLeftCell)
+	assert.True(t, ws.(*xlsxWorksheet).SheetViews.SheetView[0].TabSelected)
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.False(t, ws.(*xlsxWorksheet).SheetViews.SheetView[0].TabSelected)
+	// Test set active cell on the worksheet with frozen panes
+	assert.NoError(t, f.SetPanes("Sheet1", &Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"}))
+	assert.NoError(t, f.SetActiveCell("Sheet1", "C4"))
+	assert.Equal(t, 0, f.GetActiveSheetIndex())
+	panes, err := f.GetPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, panes.Freeze)
+	assert.Equal(t, []Selection{{SQRef: "C4", ActiveCell: "C4", Pane: "bottomLeft"}}, panes.Selection)
+	// Test set active cell on the top left pane without the pane name
+	ws.(*xlsxWorksheet).SheetViews.SheetView[0].Pane.ActivePane = ""
+	assert.NoError(t, f.SetActiveCell("Sheet1", "A1"))
+	assert.Len(t, ws.(*xlsxWorksheet).SheetViews.SheetView[0].Selection, 2)
+	assert.Equal(t, &xlsxSelection{ActiveCell: "A1", SQRef: "A1"}, ws.(*xlsxWorksheet).SheetViews.SheetView[0].Selection[1])
+	assert.NoError(t, f.SetActiveCell("Sheet1", "A2"))
+	assert.Len(t, ws.(*xlsxWorksheet).SheetViews.SheetView[0].Selection, 2)
+	assert.Equal(t, &xlsxSelection{ActiveCell: "A2", SQRef: "A2"}, ws.(*xlsxWorksheet).SheetViews.SheetView[0].Selection[1])
+	// Test set active cell on the worksheet after the chart sheet
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"}},
+	}))
+	_, err = f.NewSheet("Sheet3")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetActiveCell("Sheet3", "D1"))
+	assert.Equal(t, 3, f.GetActiveSheetIndex())
+	sheet3, err := f.workSheetReader("Sheet3")
+	assert.NoError(t, err)
+	assert.True(t, sheet3.SheetViews.SheetView[0].TabSelected)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetActiveCell.xlsx")))
+	// Test set active cell on the chart sheet
+	assert.EqualError(t, f.SetActiveCell("Chart1", "A1"), newNotWorksheetError("Chart1").Error())
+	// Test set active cell with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetActiveCell("Sheet1", "A"))
+	// Test set active cell on not exists worksheet
+	assert.EqualError(t, f.SetActiveCell("SheetN", "A1"), "sheet SheetN does not exist")
+	// Test set active cell with invalid sheet name
+	assert.EqualError(t, f.SetActiveCell("Sheet:1", "A1"), ErrSheetNameInvalid.Error())
+	assert.NoError(t, f.Close())
+}
@@ -16,7 +16,6 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
-	"sort"
 	"strconv"
 	"strings"
 	"unicode"
@@ -280,15 +279,37 @@ func (f *File) addSheetTableSlicer(ws *xlsxWorksheet, rID int, extURI string) er
 		ext.xmlns = []xml.Attr{{Name: xml.Name{Local: "xmlns:" + NameSpaceSpreadSheetX15.Name.Local}, Value: NameSpaceSpreadSheetX15.Value}}
 	}
 	decodeExtLst.Ext = append(decodeExtLst.Ext, ext)
-	sort.Slice(decodeExtLst.Ext, func(i, j int) bool {
-		return inStrSlice(worksheetExtURIPriority, decodeExtLst.Ext[i].URI, false) <
-			inStrSlice(worksheetExtURIPriority, decodeExtLst.Ext[j].URI, false)
-	})
+	sortExtLst(decodeExtLst.Ext, worksheetExtURIPriority)
 	extLstBytes, err = xml.Marshal(decodeExtLst)
 	ws.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
 	return err
 }
 
+// deleteSlicerList provides a function to remove the slicer list extensions
+// of the worksheet.
+func (ws *xlsxWorksheet) deleteSlicerList() error {
+	if ws.ExtLst == nil {
+		return nil
+	}
+	decodeExtLst := new(decodeExtLst)
+	if err := xml.Unmarshal([]byte("<extLst>"+ws.ExtLst.Ext+"</extLst>"), decodeExtLst); err != nil && err != io.EOF {
+		return err
+	}
+	var exts []*xlsxExt
+	for _, ext := range decodeExtLst.Ext {
+		if ext.URI != ExtURISlicerListX14 && ext.URI != ExtURISlicerListX15 {
+			exts = append(exts, ext)
+		}
+	}
+	if decodeExtLst.Ext = exts; len(exts) == 0 {
+		ws.ExtLst = nil
+		return nil
+	}
+	extLstBytes, err := xml.Marshal(decodeExtLst)
+	ws.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
+	return err
+}
+
 // addSlicer adds a new slicer to the workbook by giving the slicer ID and
 // settings.
 func (f *File) addSlicer(slicerID int, slicer xlsxSlicer) error {
@@ -717,10 +738,7 @@ func (f *File) addWorkbookSlicerCache(slicerCacheID int, URI string) error {
 			})
 		}
 	}
-	sort.Slice(decodeExtLst.Ext, func(i, j int) bool {
-		return inStrSlice(workbookExtURIPriority, decodeExtLst.Ext[i].URI, false) <
-			inStrSlice(workbookExtURIPriority, decodeExtLst.Ext[j].URI, false)
-	})
+	sortExtLst(decodeExtLst.Ext, workbookExtURIPriority)
 	extLstBytes, err = xml.Marshal(decodeExtLst)
 	wb.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
 	return err
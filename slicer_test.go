@@ -265,6 +265,9 @@ func TestAddSlicer(t *testing.T) {
 		Name:  "Table1",
 		Range: "A1:D5",
 	}))
+	worksheet, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	f.prepareDrawing(worksheet, 2, "Sheet1", "xl/drawings/drawing2.xml")
 	f.Pkg.Store("xl/drawings/drawing2.xml", MacintoshCyrillicCharset)
 	assert.EqualError(t, f.AddSlicer("Sheet1", &SlicerOptions{
 		Name:       "Column1",
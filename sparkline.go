@@ -14,7 +14,7 @@ package excelize
 import (
 	"encoding/xml"
 	"io"
-	"sort"
+	"reflect"
 	"strings"
 )
 
@@ -374,21 +374,32 @@ func (f *File) addSparklineGroupByStyle(ID int) *xlsxX14SparklineGroup {
 //
 // The following shows the formatting options of sparkline supported by excelize:
 //
-//	 Parameter   | Description
-//	-------------+--------------------------------------------
-//	 Location    | Required, must have the same number with 'Range' parameter
-//	 Range       | Required, must have the same number with 'Location' parameter
-//	 Type        | Enumeration value: line, column, win_loss
-//	 Style       | Value range: 0 - 35
-//	 Hight       | Toggle sparkline high points
-//	 Low         | Toggle sparkline low points
-//	 First       | Toggle sparkline first points
-//	 Last        | Toggle sparkline last points
-//	 Negative    | Toggle sparkline negative points
-//	 Markers     | Toggle sparkline markers
-//	 Axis        | Used to specify if show horizontal axis
-//	 Reverse     | Used to specify if enable plot data right-to-left
-//	 SeriesColor | An RGB Color is specified as RRGGBB
+//	 Parameter     | Description
+//	---------------+--------------------------------------------
+//	 Location      | Required, must have the same number with 'Range' parameter
+//	 Range         | Required, must have the same number with 'Location' parameter
+//	 Type          | Enumeration value: line, column, win_loss
+//	 Style         | Value range: 0 - 35
+//	 Hight         | Toggle sparkline high points
+//	 Low           | Toggle sparkline low points
+//	 First         | Toggle sparkline first points
+//	 Last          | Toggle sparkline last points
+//	 Negative      | Toggle sparkline negative points
+//	 Markers       | Toggle sparkline markers
+//	 Axis          | Used to specify if show horizontal axis
+//	 Reverse       | Used to specify if enable plot data right-to-left
+//	 DateAxis      | Used to specify if use the date axis
+//	 Hidden        | Used to specify if plot the data in hidden rows and columns
+//	 Weight        | The line weight of the line sparkline in points
+//	 EmptyCells    | Enumeration value: gap, span, zero, the default is gap
+//	 SeriesColor   | An RGB Color is specified as RRGGBB
+//	 NegativeColor | An RGB Color of the negative points
+//	 MarkersColor  | An RGB Color of the markers
+//	 FirstColor    | An RGB Color of the first points
+//	 LastColor     | An RGB Color of the last points
+//	 HightColor    | An RGB Color of the high points
+//	 LowColor      | An RGB Color of the low points
+//	 AxisColor     | An RGB Color of the horizontal axis, the default is black
 func (f *File) AddSparkline(sheet string, opts *SparklineOptions) error {
 	var (
 		err                 error
@@ -418,7 +429,16 @@ func (f *File) AddSparkline(sheet string, opts *SparklineOptions) error {
 	group = f.addSparklineGroupByStyle(opts.Style)
 	group.Type = sparkType
 	group.ColorAxis = &xlsxColor{RGB: "FF000000"}
+	if opts.AxisColor != "" {
+		group.ColorAxis = &xlsxColor{RGB: getPaletteColor(opts.AxisColor)}
+	}
 	group.DisplayEmptyCellsAs = "gap"
+	if opts.EmptyCells != "" {
+		group.DisplayEmptyCellsAs = opts.EmptyCells
+	}
+	group.LineWeight = opts.Weight
+	group.DateAxis = opts.DateAxis
+	group.DisplayHidden = opts.Hidden
 	group.High = opts.High
 	group.Low = opts.Low
 	group.First = opts.First
@@ -426,9 +446,20 @@ func (f *File) AddSparkline(sheet string, opts *SparklineOptions) error {
 	group.Negative = opts.Negative
 	group.DisplayXAxis = opts.Axis
 	group.Markers = opts.Markers
-	if opts.SeriesColor != "" {
-		group.ColorSeries = &xlsxColor{
-			RGB: getPaletteColor(opts.SeriesColor),
+	for _, c := range []struct {
+		color string
+		elem  **xlsxColor
+	}{
+		{opts.SeriesColor, &group.ColorSeries},
+		{opts.NegativeColor, &group.ColorNegative},
+		{opts.MarkersColor, &group.ColorMarkers},
+		{opts.FirstColor, &group.ColorFirst},
+		{opts.LastColor, &group.ColorLast},
+		{opts.HightColor, &group.ColorHigh},
+		{opts.LowColor, &group.ColorLow},
+	} {
+		if c.color != "" {
+			*c.elem = &xlsxColor{RGB: getPaletteColor(c.color)}
 		}
 	}
 	if opts.Reverse {
@@ -465,6 +496,9 @@ func (f *File) parseFormatAddSparklineSet(sheet string, opts *SparklineOptions)
 	if opts.Style < 0 || opts.Style > 35 {
 		return ws, ErrSparklineStyle
 	}
+	if inStrSlice([]string{"", "gap", "span", "zero"}, opts.EmptyCells, true) == -1 {
+		return ws, ErrParameterInvalid
+	}
 	if ws.ExtLst == nil {
 		ws.ExtLst = &xlsxExtLst{}
 	}
@@ -527,11 +561,192 @@ func (f *File) appendSparkline(ws *xlsxWorksheet, group *xlsxX14SparklineGroup,
 			URI: ExtURISparklineGroups, Content: string(sparklineGroupsBytes),
 		})
 	}
-	sort.Slice(decodeExtLst.Ext, func(i, j int) bool {
-		return inStrSlice(worksheetExtURIPriority, decodeExtLst.Ext[i].URI, false) <
-			inStrSlice(worksheetExtURIPriority, decodeExtLst.Ext[j].URI, false)
-	})
+	sortExtLst(decodeExtLst.Ext, worksheetExtURIPriority)
 	extLstBytes, err = xml.Marshal(decodeExtLst)
 	ws.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
 	return err
 }
+
+// GetSparklines provides a function to get sparkline groups of the worksheet
+// by given worksheet name, each sparkline group will be returned as a
+// sparkline options. The style of the sparkline group will be detected by the
+// colors of the group, and the colors which are different with the style will
+// be returned as RGB colors. For example, get sparkline groups on Sheet1:
+//
+//	sparklines, err := f.GetSparklines("Sheet1")
+func (f *File) GetSparklines(sheet string) ([]SparklineOptions, error) {
+	var sparklines []SparklineOptions
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.ExtLst == nil {
+		return sparklines, err
+	}
+	decodeExtLst := new(decodeExtLst)
+	if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+		Decode(decodeExtLst); err != nil && err != io.EOF {
+		return sparklines, err
+	}
+	for _, ext := range decodeExtLst.Ext {
+		if ext.URI != ExtURISparklineGroups {
+			continue
+		}
+		decodeSparklineGroups := new(decodeX14SparklineGroups)
+		if err = f.xmlNewDecoder(strings.NewReader(ext.Content)).
+			Decode(decodeSparklineGroups); err != nil && err != io.EOF {
+			return sparklines, err
+		}
+		for _, group := range decodeSparklineGroups.SparklineGroups {
+			sparklines = append(sparklines, f.getSparklineOptions(group))
+		}
+	}
+	return sparklines, nil
+}
+
+// getSparklineOptions provides a function to convert the sparkline group to
+// the sparkline options.
+func (f *File) getSparklineOptions(group *decodeX14SparklineGroup) SparklineOptions {
+	opts := SparklineOptions{
+		Type:       map[string]string{"": "line", "line": "line", "column": "column", "stacked": "win_loss"}[group.Type],
+		Weight:     group.LineWeight,
+		DateAxis:   group.DateAxis,
+		Markers:    group.Markers,
+		High:       group.High,
+		Low:        group.Low,
+		First:      group.First,
+		Last:       group.Last,
+		Negative:   group.Negative,
+		Axis:       group.DisplayXAxis,
+		Hidden:     group.DisplayHidden,
+		Reverse:    group.RightToLeft,
+		EmptyCells: group.DisplayEmptyCellsAs,
+	}
+	for _, sparkline := range group.Sparklines.Sparkline {
+		opts.Location = append(opts.Location, sparkline.Sqref)
+		opts.Range = append(opts.Range, sparkline.F)
+	}
+	colors := []*xlsxColor{group.ColorSeries, group.ColorNegative, group.ColorMarkers,
+		group.ColorFirst, group.ColorLast, group.ColorHigh, group.ColorLow}
+	minDiff := len(colors) + 1
+	var styleColors []*xlsxColor
+	for ID := 0; ID <= 35 && minDiff > 0; ID++ {
+		style := f.addSparklineGroupByStyle(ID)
+		presetColors := []*xlsxColor{style.ColorSeries, style.ColorNegative, style.ColorMarkers,
+			style.ColorFirst, style.ColorLast, style.ColorHigh, style.ColorLow}
+		var diff int
+		for idx, color := range presetColors {
+			if !reflect.DeepEqual(color, colors[idx]) {
+				diff++
+			}
+		}
+		if diff < minDiff {
+			minDiff, opts.Style, styleColors = diff, ID, presetColors
+		}
+	}
+	for idx, color := range []*string{&opts.SeriesColor, &opts.NegativeColor, &opts.MarkersColor,
+		&opts.FirstColor, &opts.LastColor, &opts.HightColor, &opts.LowColor} {
+		if colors[idx] != nil && colors[idx].RGB != "" && !reflect.DeepEqual(colors[idx], styleColors[idx]) {
+			*color = strings.TrimPrefix(colors[idx].RGB, "FF")
+		}
+	}
+	if group.ColorAxis != nil && group.ColorAxis.RGB != "" && group.ColorAxis.RGB != "FF000000" {
+		opts.AxisColor = strings.TrimPrefix(group.ColorAxis.RGB, "FF")
+	}
+	return opts
+}
+
+// DeleteSparkline provides a function to delete sparklines by given worksheet
+// name and the cell references of the sparklines. The sparkline group will be
+// deleted if all sparklines in it have been deleted, and all sparklines in the
+// worksheet will be deleted if not specify the cell references. For example,
+// delete the sparklines in cell A1 and A2 on Sheet1:
+//
+//	err := f.DeleteSparkline("Sheet1", "A1", "A2")
+func (f *File) DeleteSparkline(sheet string, location ...string) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.ExtLst == nil {
+		return err
+	}
+	for _, cell := range location {
+		if _, _, err = CellNameToCoordinates(cell); err != nil {
+			return err
+		}
+	}
+	decodeExtLst := new(decodeExtLst)
+	if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+		Decode(decodeExtLst); err != nil && err != io.EOF {
+		return err
+	}
+	for idx := 0; idx < len(decodeExtLst.Ext); idx++ {
+		if decodeExtLst.Ext[idx].URI != ExtURISparklineGroups {
+			continue
+		}
+		decodeSparklineGroups := new(decodeX14SparklineGroups)
+		if err = f.xmlNewDecoder(strings.NewReader(decodeExtLst.Ext[idx].Content)).
+			Decode(decodeSparklineGroups); err != nil && err != io.EOF {
+			return err
+		}
+		groups := &xlsxX14SparklineGroups{XMLNSXM: NameSpaceSpreadSheetExcel2006Main.Value}
+		for _, group := range decodeSparklineGroups.SparklineGroups {
+			if g := deleteSparklineGroupLocations(group, location); len(location) > 0 && g != nil {
+				groups.SparklineGroups = append(groups.SparklineGroups, g)
+			}
+		}
+		if len(groups.SparklineGroups) == 0 {
+			decodeExtLst.Ext = append(decodeExtLst.Ext[:idx], decodeExtLst.Ext[idx+1:]...)
+			idx--
+			continue
+		}
+		sparklineGroupsBytes, _ := xml.Marshal(groups)
+		decodeExtLst.Ext[idx].Content = string(sparklineGroupsBytes)
+	}
+	if len(decodeExtLst.Ext) == 0 {
+		ws.ExtLst = nil
+		return err
+	}
+	extLstBytes, err := xml.Marshal(decodeExtLst)
+	ws.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
+	return err
+}
+
+// deleteSparklineGroupLocations provides a function to convert the sparkline
+// group to the sparkline group for serialization without the sparklines in
+// the given cell references. The nil will be returned if no sparkline left in
+// the group.
+func deleteSparklineGroupLocations(group *decodeX14SparklineGroup, location []string) *xlsxX14SparklineGroup {
+	sparklineGroup := &xlsxX14SparklineGroup{
+		ManualMax:           group.ManualMax,
+		ManualMin:           group.ManualMin,
+		LineWeight:          group.LineWeight,
+		Type:                group.Type,
+		DateAxis:            group.DateAxis,
+		DisplayEmptyCellsAs: group.DisplayEmptyCellsAs,
+		Markers:             group.Markers,
+		High:                group.High,
+		Low:                 group.Low,
+		First:               group.First,
+		Last:                group.Last,
+		Negative:            group.Negative,
+		DisplayXAxis:        group.DisplayXAxis,
+		DisplayHidden:       group.DisplayHidden,
+		MinAxisType:         group.MinAxisType,
+		MaxAxisType:         group.MaxAxisType,
+		RightToLeft:         group.RightToLeft,
+		ColorSeries:         group.ColorSeries,
+		ColorNegative:       group.ColorNegative,
+		ColorAxis:           group.ColorAxis,
+		ColorMarkers:        group.ColorMarkers,
+		ColorFirst:          group.ColorFirst,
+		ColorLast:           group.ColorLast,
+		ColorHigh:           group.ColorHigh,
+		ColorLow:            group.ColorLow,
+	}
+	for _, sparkline := range group.Sparklines.Sparkline {
+		if inStrSlice(location, sparkline.Sqref, false) == -1 {
+			sparklineGroup.Sparklines.Sparkline = append(sparklineGroup.Sparklines.Sparkline,
+				&xlsxX14Sparkline{F: sparkline.F, Sqref: sparkline.Sqref})
+		}
+	}
+	if len(sparklineGroup.Sparklines.Sparkline) == 0 {
+		return nil
+	}
+	return sparklineGroup
+}
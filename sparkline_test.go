@@ -3,6 +3,7 @@ package excelize
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -290,6 +291,156 @@ func TestAppendSparkline(t *testing.T) {
 	assert.EqualError(t, f.appendSparkline(ws, &xlsxX14SparklineGroup{}, &xlsxX14SparklineGroups{}), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestGetSparklines(t *testing.T) {
+	f, err := prepareSparklineDataset()
+	assert.NoError(t, err)
+	expected := []SparklineOptions{
+		{
+			Location: []string{"A1", "A2"},
+			Range:    []string{"Sheet2!A1:E1", "Sheet2!A2:E2"},
+			Type:     "line", Style: 2, Markers: true, High: true, Low: true,
+			Weight: 1.25, EmptyCells: "zero", HightColor: "00B050", LowColor: "FF0000",
+		},
+		{
+			Location: []string{"A3"},
+			Range:    []string{"Sheet2!A3:E3"},
+			Type:     "win_loss", Negative: true, Axis: true, Reverse: true, DateAxis: true, Hidden: true,
+			EmptyCells: "gap", SeriesColor: "4472C4", AxisColor: "C00000",
+		},
+	}
+	for _, opts := range expected {
+		opts := opts
+		assert.NoError(t, f.AddSparkline("Sheet1", &opts))
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetSparklines.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestGetSparklines.xlsx"))
+	assert.NoError(t, err)
+	sparklines, err := f.GetSparklines("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, sparklines)
+	// Test get sparklines on worksheet without sparklines
+	sparklines, err = f.GetSparklines("Sheet2")
+	assert.NoError(t, err)
+	assert.Empty(t, sparklines)
+	// Test get sparklines on not exists worksheet
+	_, err = f.GetSparklines("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test add sparkline with invalid empty cells option
+	assert.Equal(t, ErrParameterInvalid, f.AddSparkline("Sheet1", &SparklineOptions{
+		Location: []string{"A4"}, Range: []string{"Sheet2!A3:E3"}, EmptyCells: "none",
+	}))
+	// Test get sparklines with unsupported charset
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.ExtLst.Ext = string(MacintoshCyrillicCharset)
+	_, err = f.GetSparklines("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestDeleteSparkline(t *testing.T) {
+	f, err := prepareSparklineDataset()
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "C1:C3", []ConditionalFormatOptions{
+		{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarColor: "#638EC6", BarSolid: true},
+	}))
+	for _, opts := range []*SparklineOptions{
+		{Location: []string{"A1", "A2"}, Range: []string{"Sheet2!A1:E1", "Sheet2!A2:E2"}, Markers: true, AxisColor: "FF0000"},
+		{Location: []string{"A3"}, Range: []string{"Sheet2!A3:E3"}, Type: "win_loss"},
+	} {
+		assert.NoError(t, f.AddSparkline("Sheet1", opts))
+	}
+	// Test delete sparkline in the sparkline group
+	assert.NoError(t, f.DeleteSparkline("Sheet1", "a1"))
+	sparklines, err := f.GetSparklines("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, sparklines, 2)
+	assert.Equal(t, []string{"A2"}, sparklines[0].Location)
+	assert.Equal(t, []string{"Sheet2!A2:E2"}, sparklines[0].Range)
+	assert.True(t, sparklines[0].Markers)
+	assert.Equal(t, "FF0000", sparklines[0].AxisColor)
+	// Test delete the last sparkline in the sparkline group
+	assert.NoError(t, f.DeleteSparkline("Sheet1", "A3"))
+	sparklines, err = f.GetSparklines("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, sparklines, 1)
+	assert.Equal(t, "line", sparklines[0].Type)
+	// Test delete all sparklines in the worksheet
+	assert.NoError(t, f.DeleteSparkline("Sheet1"))
+	sparklines, err = f.GetSparklines("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, sparklines)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.NotContains(t, ws.ExtLst.Ext, ExtURISparklineGroups)
+	assert.Contains(t, ws.ExtLst.Ext, ExtURIConditionalFormattings)
+	// Test delete sparklines on the worksheet without extension list
+	assert.NoError(t, f.DeleteSparkline("Sheet2", "A1"))
+	assert.NoError(t, f.AddSparkline("Sheet2", &SparklineOptions{Location: []string{"F1"}, Range: []string{"Sheet2!A1:E1"}}))
+	assert.NoError(t, f.DeleteSparkline("Sheet2", "F1"))
+	ws, err = f.workSheetReader("Sheet2")
+	assert.NoError(t, err)
+	assert.Nil(t, ws.ExtLst)
+	// Test delete sparkline with invalid cell reference
+	assert.NoError(t, f.AddSparkline("Sheet2", &SparklineOptions{Location: []string{"F1"}, Range: []string{"Sheet2!A1:E1"}}))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.DeleteSparkline("Sheet2", "A"))
+	// Test delete sparkline on not exists worksheet
+	assert.EqualError(t, f.DeleteSparkline("SheetN", "A1"), "sheet SheetN does not exist")
+	// Test delete sparkline in the sparkline group with decimal manual
+	// maximum and minimum values
+	ws.ExtLst = &xlsxExtLst{Ext: `<ext uri="` + ExtURISparklineGroups + `" xmlns:x14="http://schemas.microsoft.com/office/spreadsheetml/2009/9/main"><x14:sparklineGroups xmlns:xm="http://schemas.microsoft.com/office/excel/2006/main"><x14:sparklineGroup manualMax="1.5" manualMin="-0.25" minAxisType="custom" maxAxisType="custom"><x14:sparklines><x14:sparkline><xm:f>Sheet2!A1:E1</xm:f><xm:sqref>F1</xm:sqref></x14:sparkline><x14:sparkline><xm:f>Sheet2!A2:E2</xm:f><xm:sqref>F2</xm:sqref></x14:sparkline></x14:sparklines></x14:sparklineGroup></x14:sparklineGroups></ext>`}
+	assert.NoError(t, f.DeleteSparkline("Sheet2", "F1"))
+	assert.Contains(t, ws.ExtLst.Ext, `manualMax="1.5" manualMin="-0.25"`)
+	// Test delete sparkline with unsupported charset
+	ws.ExtLst = &xlsxExtLst{Ext: string(MacintoshCyrillicCharset)}
+	assert.EqualError(t, f.DeleteSparkline("Sheet2", "F1"), "XML syntax error on line 1: invalid UTF-8")
+	ws.ExtLst = &xlsxExtLst{Ext: `<ext uri="` + ExtURISparklineGroups + `">` + string(MacintoshCyrillicCharset) + `</ext>`}
+	assert.EqualError(t, f.DeleteSparkline("Sheet2", "F1"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestSparklineExtLstRoundTrip(t *testing.T) {
+	f, err := prepareSparklineDataset()
+	assert.NoError(t, err)
+	unknownExt := `<ext xmlns:xyz="urn:excelize:test" uri="{F7C9EE02-42E1-4005-9D12-6889AFFD525C}"><xyz:custom val="1"><xyz:child>text</xyz:child></xyz:custom></ext>`
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.ExtLst = &xlsxExtLst{Ext: unknownExt}
+	assert.NoError(t, f.AddSparkline("Sheet1", &SparklineOptions{
+		Location: []string{"A1"}, Range: []string{"Sheet2!A1:E1"},
+	}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSparklineExtLstRoundTrip.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test modify and save the workbook again after reopen it
+	f, err = OpenFile(filepath.Join("test", "TestSparklineExtLstRoundTrip.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", "text"))
+	assert.NoError(t, f.AddSparkline("Sheet1", &SparklineOptions{
+		Location: []string{"A2"}, Range: []string{"Sheet2!A2:E2"},
+	}))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "C1:C3", []ConditionalFormatOptions{
+		{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarColor: "#638EC6", BarSolid: true},
+	}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSparklineExtLstRoundTrip.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestSparklineExtLstRoundTrip.xlsx"))
+	assert.NoError(t, err)
+	sparklines, err := f.GetSparklines("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, sparklines, 2)
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	// Test the unrecognized extension was preserved and placed after the
+	// recognized extensions
+	assert.True(t, strings.HasSuffix(ws.ExtLst.Ext, unknownExt))
+	assert.Less(t, strings.Index(ws.ExtLst.Ext, ExtURIConditionalFormattings), strings.Index(ws.ExtLst.Ext, ExtURISparklineGroups))
+	assert.NoError(t, f.Close())
+}
+
 func prepareSparklineDataset() (*File, error) {
 	f := NewFile()
 	sheet2 := [][]int{
@@ -35,6 +35,7 @@ type StreamWriter struct {
 	rows            int
 	mergeCellsCount int
 	mergeCells      strings.Builder
+	hyperlinks      []xlsxHyperlink
 	tableParts      string
 }
 
@@ -385,6 +386,12 @@ func parseRowOpts(opts ...RowOpts) *RowOpts {
 //
 // As a special case, if Cell is used as a value, then the Cell.StyleID will be
 // applied to that cell.
+//
+// The numeric zero value will be written as the number 0 in the cell, the nil
+// value will be skipped, and the Cell with nil value will be written as a
+// blank cell with the style. The rich text runs given by []RichTextRun will
+// be written as the inline string, and the run properties could be read back
+// by the GetCellRichText function after flushing the stream.
 func (sw *StreamWriter) SetRow(cell string, values []interface{}, opts ...RowOpts) error {
 	col, row, err := CellNameToCoordinates(cell)
 	if err != nil {
@@ -498,6 +505,77 @@ func (sw *StreamWriter) MergeCell(hCell, vCell string) error {
 	return nil
 }
 
+// SetHyperlink provides a function to set cell hyperlink by given cell
+// reference and link URL address for the StreamWriter. LinkType defines two
+// types of hyperlink "External" for website or "Location" for moving to one
+// of cell in this workbook. This function is only used to set the hyperlink
+// of the cell and doesn't affect the value of the cell, and don't set
+// hyperlink for the same cell more than once. The hyperlinks will be merged
+// with the existing hyperlinks of the worksheet, and the existing hyperlink
+// of the same cell will be replaced. For example, add an external link and a
+// location link:
+//
+//	display, tooltip := "https://github.com/xuri/excelize", "Excelize on GitHub"
+//	err := sw.SetHyperlink("A3", "https://github.com/xuri/excelize",
+//	    "External", excelize.HyperlinkOpts{Display: &display, Tooltip: &tooltip})
+//	err = sw.SetHyperlink("A4", "Sheet1!A40", "Location")
+func (sw *StreamWriter) SetHyperlink(cell, link, linkType string, opts ...HyperlinkOpts) error {
+	if _, _, err := SplitCellName(cell); err != nil {
+		return err
+	}
+	count := len(sw.hyperlinks)
+	if sw.worksheet.Hyperlinks != nil {
+		count += len(sw.worksheet.Hyperlinks.Hyperlink)
+	}
+	if count >= TotalSheetHyperlinks {
+		return ErrTotalSheetHyperlinks
+	}
+	linkData := xlsxHyperlink{Ref: cell}
+	switch linkType {
+	case "External":
+		sheetPath := sw.file.sheetMap[sw.Sheet]
+		sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetPath, "xl/worksheets/") + ".rels"
+		rID := sw.file.addRels(sheetRels, SourceRelationshipHyperLink, link, linkType)
+		linkData.RID = "rId" + strconv.Itoa(rID)
+	case "Location":
+		linkData.Location = link
+	default:
+		return newInvalidLinkTypeError(linkType)
+	}
+	for _, o := range opts {
+		if o.Display != nil {
+			linkData.Display = *o.Display
+		}
+		if o.Tooltip != nil {
+			linkData.Tooltip = *o.Tooltip
+		}
+	}
+	sw.hyperlinks = append(sw.hyperlinks, linkData)
+	return nil
+}
+
+// mergeHyperlinks provides a function to merge the hyperlinks set by the
+// StreamWriter with the existing hyperlinks of the worksheet, and returns
+// the serialized hyperlinks. The existing hyperlink will be replaced if the
+// hyperlink of the same cell has been set by the StreamWriter.
+func (sw *StreamWriter) mergeHyperlinks() []byte {
+	var hyperlinks xlsxHyperlinks
+	if sw.worksheet.Hyperlinks != nil {
+		refs := make(map[string]struct{}, len(sw.hyperlinks))
+		for _, link := range sw.hyperlinks {
+			refs[link.Ref] = struct{}{}
+		}
+		for _, link := range sw.worksheet.Hyperlinks.Hyperlink {
+			if _, ok := refs[link.Ref]; !ok {
+				hyperlinks.Hyperlink = append(hyperlinks.Hyperlink, link)
+			}
+		}
+	}
+	hyperlinks.Hyperlink = append(hyperlinks.Hyperlink, sw.hyperlinks...)
+	output, _ := xml.Marshal(hyperlinks)
+	return output
+}
+
 // setCellFormula provides a function to set formula of a cell.
 func setCellFormula(c *xlsxC, formula string) {
 	if formula != "" {
@@ -507,14 +585,11 @@ func setCellFormula(c *xlsxC, formula string) {
 
 // setCellTime provides a function to set number of a cell with a time.
 func (sw *StreamWriter) setCellTime(c *xlsxC, val time.Time) error {
-	var date1904, isNum bool
-	wb, err := sw.file.workbookReader()
+	date1904, err := sw.file.getDate1904()
 	if err != nil {
 		return err
 	}
-	if wb != nil && wb.WorkbookPr != nil {
-		date1904 = wb.WorkbookPr.Date1904
-	}
+	var isNum bool
 	if isNum, err = c.setCellTime(val, date1904); err == nil && isNum && c.S == 0 {
 		style, _ := sw.file.NewStyle(&Style{NumFmt: 22})
 		c.S = style
@@ -668,7 +743,13 @@ func (sw *StreamWriter) Flush() error {
 		_, _ = mergeCells.WriteString(`</mergeCells>`)
 	}
 	_, _ = sw.rawData.WriteString(mergeCells.String())
-	bulkAppendFields(&sw.rawData, sw.worksheet, 17, 38)
+	bulkAppendFields(&sw.rawData, sw.worksheet, 17, 19)
+	if len(sw.hyperlinks) > 0 {
+		_, _ = sw.rawData.Write(replaceRelationshipsBytes(sw.mergeHyperlinks()))
+	} else {
+		bulkAppendFields(&sw.rawData, sw.worksheet, 20, 20)
+	}
+	bulkAppendFields(&sw.rawData, sw.worksheet, 21, 38)
 	_, _ = sw.rawData.WriteString(sw.tableParts)
 	bulkAppendFields(&sw.rawData, sw.worksheet, 40, 40)
 	_, _ = sw.rawData.WriteString(`</worksheet>`)
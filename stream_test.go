@@ -188,6 +188,30 @@ func TestStreamSetPanes(t *testing.T) {
 	assert.Equal(t, ErrStreamSetPanes, streamWriter.SetPanes(paneOpts))
 }
 
+func TestStreamSheetProps(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetProps("Sheet1", &SheetPropsOptions{
+		CodeName: stringPtr("Sheet1"), DefaultRowHeight: float64Ptr(20),
+	}))
+	assert.NoError(t, f.SetSheetDimension("Sheet1", "A1:C3"))
+	streamWriter, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, streamWriter.SetRow("A1", []interface{}{"A", "B", "C"}))
+	assert.NoError(t, streamWriter.Flush())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamSheetProps.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestStreamSheetProps.xlsx"))
+	assert.NoError(t, err)
+	opts, err := f.GetSheetProps("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sheet1", *opts.CodeName)
+	assert.Equal(t, 20.0, *opts.DefaultRowHeight)
+	dimension, err := f.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:C3", dimension)
+	assert.NoError(t, f.Close())
+}
+
 func TestStreamTable(t *testing.T) {
 	file := NewFile()
 	defer func() {
@@ -245,6 +269,80 @@ func TestStreamMergeCells(t *testing.T) {
 	assert.NoError(t, file.SaveAs(filepath.Join("test", "TestStreamMergeCells.xlsx")))
 }
 
+func TestStreamSetHyperlink(t *testing.T) {
+	file := NewFile()
+	streamWriter, err := file.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, streamWriter.SetRow("A1", []interface{}{"Report"}))
+	assert.NoError(t, streamWriter.SetRow("A2", []interface{}{"GitHub", "Detail"}))
+	assert.NoError(t, streamWriter.MergeCell("A1", "D1"))
+	display, tooltip := "https://github.com/xuri/excelize", "Excelize on GitHub"
+	assert.NoError(t, streamWriter.SetHyperlink("A2", "https://github.com/xuri/excelize", "External", HyperlinkOpts{Display: &display, Tooltip: &tooltip}))
+	assert.NoError(t, streamWriter.SetHyperlink("B2", "Sheet1!A40", "Location"))
+	// Test set hyperlink with illegal cell reference
+	assert.Equal(t, newInvalidCellNameError("A"), streamWriter.SetHyperlink("A", "Sheet1!A40", "Location"))
+	// Test set hyperlink with invalid link type
+	assert.Equal(t, newInvalidLinkTypeError(""), streamWriter.SetHyperlink("C2", "Sheet1!A40", ""))
+	assert.NoError(t, streamWriter.Flush())
+	assert.NoError(t, file.SaveAs(filepath.Join("test", "TestStreamSetHyperlink.xlsx")))
+	assert.NoError(t, file.Close())
+
+	file, err = OpenFile(filepath.Join("test", "TestStreamSetHyperlink.xlsx"))
+	assert.NoError(t, err)
+	ok, link, err := file.GetCellHyperLink("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "https://github.com/xuri/excelize", link)
+	ok, link, err = file.GetCellHyperLink("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "Sheet1!A40", link)
+	mergeCells, err := file.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "A1:D1", mergeCells[0].GetStartAxis()+":"+mergeCells[0].GetEndAxis())
+	assert.Equal(t, "Report", mergeCells[0].GetCellValue())
+	assert.NoError(t, file.Close())
+
+	// Test set hyperlink on the worksheet which contains hyperlinks
+	file = NewFile()
+	assert.NoError(t, file.SetCellHyperLink("Sheet1", "A1", "Sheet1!A20", "Location"))
+	assert.NoError(t, file.SetCellHyperLink("Sheet1", "C3", "https://github.com", "External"))
+	streamWriter, err = file.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, streamWriter.SetHyperlink("A1", "Sheet1!A40", "Location"))
+	assert.NoError(t, streamWriter.SetHyperlink("B2", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, streamWriter.Flush())
+	assert.NoError(t, file.SaveAs(filepath.Join("test", "TestStreamSetHyperlink.xlsx")))
+	assert.NoError(t, file.Close())
+
+	file, err = OpenFile(filepath.Join("test", "TestStreamSetHyperlink.xlsx"))
+	assert.NoError(t, err)
+	for cell, expected := range map[string]string{
+		"A1": "Sheet1!A40", "B2": "https://github.com/xuri/excelize", "C3": "https://github.com",
+	} {
+		ok, link, err := file.GetCellHyperLink("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.True(t, ok, cell)
+		assert.Equal(t, expected, link, cell)
+	}
+	ws, err := file.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, ws.Hyperlinks.Hyperlink, 3)
+	assert.NoError(t, file.Close())
+
+	// Test set hyperlink exceeds the maximum limit of hyperlinks in a worksheet
+	file = NewFile()
+	streamWriter, err = file.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	streamWriter.hyperlinks = make([]xlsxHyperlink, TotalSheetHyperlinks)
+	assert.Equal(t, ErrTotalSheetHyperlinks, streamWriter.SetHyperlink("A1", "Sheet1!A40", "Location"))
+	streamWriter.hyperlinks = make([]xlsxHyperlink, TotalSheetHyperlinks-1)
+	streamWriter.worksheet.Hyperlinks = &xlsxHyperlinks{Hyperlink: []xlsxHyperlink{{Ref: "A1"}}}
+	assert.Equal(t, ErrTotalSheetHyperlinks, streamWriter.SetHyperlink("A2", "Sheet1!A40", "Location"))
+	assert.NoError(t, file.Close())
+}
+
 func TestStreamInsertPageBreak(t *testing.T) {
 	file := NewFile()
 	defer func() {
@@ -342,6 +440,48 @@ func TestStreamSetRowWithStyle(t *testing.T) {
 	}
 }
 
+func TestStreamSetRowWithRichText(t *testing.T) {
+	f := NewFile()
+	defer func() {
+		assert.NoError(t, f.Close())
+	}()
+	theme := 1
+	runs := []RichTextRun{
+		{Text: "Rich ", Font: &Font{Bold: true, Italic: true, Underline: "double", Family: "Arial", Size: 12, Color: "2354E8", VertAlign: "superscript"}},
+		{Text: " text "},
+		{Text: "theme", Font: &Font{Strike: true, Underline: "none", ColorTheme: &theme, ColorTint: 0.5}},
+	}
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	styleID, err := f.NewStyle(&Style{Font: &Font{Color: "777777"}})
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{runs, Cell{StyleID: styleID, Value: runs}}))
+	// Test set rich text exceeds the maximum characters limit in the cell
+	assert.ErrorIs(t, sw.SetRow("A2", []interface{}{[]RichTextRun{{Text: strings.Repeat("s", TotalCellChars+1)}}}), ErrCellCharsLength)
+	assert.NoError(t, sw.Flush())
+	// Test get the rich text written by the stream writer
+	for _, cell := range []string{"A1", "B1"} {
+		result, err := f.GetCellRichText("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, runs, result)
+	}
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Rich  text theme", val)
+	styleID, err = f.GetCellStyle("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, styleID)
+	// Test get the rich text after saving and reopening the workbook
+	path := filepath.Join("test", "TestStreamSetRowWithRichText.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	f2, err := OpenFile(path)
+	assert.NoError(t, err)
+	result, err := f2.GetCellRichText("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, runs, result)
+	assert.NoError(t, f2.Close())
+}
+
 func TestStreamSetCellValFunc(t *testing.T) {
 	f := NewFile()
 	defer func() {
@@ -18,9 +18,10 @@ import (
 	"io"
 	"math"
 	"reflect"
-	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/mohae/deepcopy"
 )
 
 // validType defined the list of valid validation types.
@@ -225,7 +226,21 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 // options, and returns style index. The same style index can not be used
 // across different workbook. This function is concurrency safe. Note that
 // the 'Font.Color' field uses an RGB color represented in 'RRGGBB' hexadecimal
-// notation.
+// notation. The theme color could be referenced by the 'ColorTheme' and
+// 'ColorTint' fields of the 'Font', 'Border' and 'Fill', which specifies the
+// zero-based index of the theme color and the tint value applied to the
+// color. For example, create a style with the pattern fill using the theme
+// color 'Accent 1' with 40% lighter:
+//
+//	theme := 4
+//	style, err := f.NewStyle(&excelize.Style{
+//	    Fill: excelize.Fill{
+//	        Type:       "pattern",
+//	        Pattern:    1,
+//	        ColorTheme: []*int{&theme},
+//	        ColorTint:  []float64{0.4},
+//	    },
+//	})
 //
 // The following table shows the border types used in 'Border.Type' supported by
 // excelize:
@@ -1309,11 +1324,15 @@ func (f *File) extractBorders(bdr *xlsxBorder, s *xlsxStyleSheet, style *Style)
 		var borders []Border
 		extractBorder := func(lineType string, line xlsxLine) {
 			if line.Style != "" {
-				borders = append(borders, Border{
+				border := Border{
 					Type:  lineType,
 					Color: f.getThemeColor(line.Color),
 					Style: inStrSlice(styleBorders, line.Style, false),
-				})
+				}
+				if line.Color != nil {
+					border.ColorTheme, border.ColorTint = line.Color.Theme, line.Color.Tint
+				}
+				borders = append(borders, border)
 			}
 		}
 		for i, line := range []xlsxLine{
@@ -1337,7 +1356,10 @@ func (f *File) extractBorders(bdr *xlsxBorder, s *xlsxStyleSheet, style *Style)
 // given fill styles definition.
 func (f *File) extractFills(fl *xlsxFill, s *xlsxStyleSheet, style *Style) {
 	if fl != nil {
-		var fill Fill
+		var (
+			fill   Fill
+			colors []*xlsxColor
+		)
 		if fl.GradientFill != nil {
 			fill.Type = "gradient"
 			for shading, variants := range styleFillVariants {
@@ -1351,18 +1373,29 @@ func (f *File) extractFills(fl *xlsxFill, s *xlsxStyleSheet, style *Style) {
 					break
 				}
 			}
-			for _, stop := range fl.GradientFill.Stop {
-				fill.Color = append(fill.Color, f.getThemeColor(&stop.Color))
+			for i := range fl.GradientFill.Stop {
+				colors = append(colors, &fl.GradientFill.Stop[i].Color)
 			}
 		}
 		if fl.PatternFill != nil {
 			fill.Type = "pattern"
 			fill.Pattern = inStrSlice(styleFillPatterns, fl.PatternFill.PatternType, false)
 			if fl.PatternFill.BgColor != nil {
-				fill.Color = []string{f.getThemeColor(fl.PatternFill.BgColor)}
+				colors = []*xlsxColor{fl.PatternFill.BgColor}
 			}
 			if fl.PatternFill.FgColor != nil {
-				fill.Color = []string{f.getThemeColor(fl.PatternFill.FgColor)}
+				colors = []*xlsxColor{fl.PatternFill.FgColor}
+			}
+		}
+		var themed bool
+		for _, clr := range colors {
+			fill.Color = append(fill.Color, f.getThemeColor(clr))
+			themed = themed || clr.Theme != nil
+		}
+		if themed {
+			for _, clr := range colors {
+				fill.ColorTheme = append(fill.ColorTheme, clr.Theme)
+				fill.ColorTint = append(fill.ColorTint, clr.Tint)
 			}
 		}
 		style.Fill = fill
@@ -1407,27 +1440,32 @@ func (f *File) extractFont(fnt *xlsxFont, s *xlsxStyleSheet, style *Style) {
 // extractNumFmt provides a function to extract number format by given styles
 // definition.
 func (f *File) extractNumFmt(n *int, s *xlsxStyleSheet, style *Style) {
-	if n != nil {
-		numFmtID := *n
+	if n == nil {
+		return
+	}
+	numFmtID := *n
+	// The number format code defined in the style sheet takes precedence over
+	// the built-in number format with the same ID, unless they are identical.
+	fmtCode, ok := s.getCustomNumFmtCode(numFmtID)
+	if builtInFmtCode, builtIn := f.getBuiltInNumFmtCode(numFmtID); ok && builtIn && strings.EqualFold(fmtCode, builtInFmtCode) {
+		ok = false
+	}
+	if !ok {
 		if _, ok := builtInNumFmt[numFmtID]; ok || isLangNumFmt(numFmtID) {
 			style.NumFmt = numFmtID
-			return
 		}
-		if s.NumFmts != nil {
-			for _, numFmt := range s.NumFmts.NumFmt {
-				style.CustomNumFmt = &numFmt.FormatCode
-				if strings.Contains(numFmt.FormatCode, ";[Red]") {
-					style.NegRed = true
-				}
-				for numFmtID, fmtCode := range currencyNumFmt {
-					if style.NegRed {
-						fmtCode += ";[Red]" + fmtCode
-					}
-					if numFmt.FormatCode == fmtCode {
-						style.NumFmt = numFmtID
-					}
-				}
-			}
+		return
+	}
+	style.CustomNumFmt = &fmtCode
+	if strings.Contains(fmtCode, ";[Red]") {
+		style.NegRed = true
+	}
+	for numFmtID, currencyFmtCode := range currencyNumFmt {
+		if style.NegRed {
+			currencyFmtCode += ";[Red]" + currencyFmtCode
+		}
+		if fmtCode == currencyFmtCode {
+			style.NumFmt = numFmtID
 		}
 	}
 }
@@ -1465,14 +1503,39 @@ func (f *File) extractProtection(p *xlsxProtection, s *xlsxStyleSheet, style *St
 }
 
 // GetStyle provides a function to get style definition by given style index.
+// The built-in number format will be returned by the NumFmt field, and the
+// custom number format will be returned by the CustomNumFmt field. The
+// returned style could be modified and used with NewStyle to create a new
+// style. For example, make the font of cell A1 on Sheet1 bold and keep the
+// other formatting:
+//
+//	styleID, err := f.GetCellStyle("Sheet1", "A1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	style, err := f.GetStyle(styleID)
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if style.Font == nil {
+//	    style.Font = &excelize.Font{}
+//	}
+//	style.Font.Bold = true
+//	if styleID, err = f.NewStyle(style); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.SetCellStyle("Sheet1", "A1", "A1", styleID)
 func (f *File) GetStyle(idx int) (*Style, error) {
 	var style *Style
 	f.mu.Lock()
 	s, err := f.stylesReader()
+	f.mu.Unlock()
 	if err != nil {
 		return style, err
 	}
-	f.mu.Unlock()
 	if idx < 0 || s.CellXfs == nil || len(s.CellXfs.Xf) <= idx {
 		return style, newInvalidStyleID(idx)
 	}
@@ -1497,6 +1560,62 @@ func (f *File) GetStyle(idx int) (*Style, error) {
 	return style, nil
 }
 
+// GetAllStyles provides a function to get all cell style definitions in the
+// workbook, the returned styles could be used with NewStyle in another
+// workbook. The identical styles will be returned once, and the theme and
+// indexed colors will be resolved as RGB colors. For example, copy all cell
+// styles from the workbook Book1.xlsx to the workbook Book2.xlsx:
+//
+//	styles, err := src.GetAllStyles()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, style := range styles {
+//	    if _, err := dst.NewStyle(style); err != nil {
+//	        fmt.Println(err)
+//	        return
+//	    }
+//	}
+func (f *File) GetAllStyles() ([]*Style, error) {
+	var styles []*Style
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	f.mu.Unlock()
+	if err != nil || s.CellXfs == nil {
+		return styles, err
+	}
+	for idx, xf := range s.CellXfs.Xf {
+		style, err := f.GetStyle(idx)
+		if err != nil {
+			return styles, err
+		}
+		if style.Fill.Type == "pattern" && style.Fill.Pattern == 0 && len(style.Fill.Color) == 0 {
+			style.Fill = Fill{}
+		}
+		style.Fill.ColorTheme, style.Fill.ColorTint = nil, nil
+		for i := range style.Border {
+			style.Border[i].ColorTheme, style.Border[i].ColorTint = nil, 0
+		}
+		if style.Font != nil {
+			if fnt := s.Fonts.Font[*xf.FontID]; fnt.Color != nil {
+				style.Font.Color = f.getThemeColor(fnt.Color)
+				style.Font.ColorIndexed, style.Font.ColorTheme, style.Font.ColorTint = 0, nil, 0
+			}
+		}
+		var existing bool
+		for _, s := range styles {
+			if existing = reflect.DeepEqual(s, style); existing {
+				break
+			}
+		}
+		if !existing {
+			styles = append(styles, style)
+		}
+	}
+	return styles, err
+}
+
 // getStyleID provides a function to get styleID by given style. If given
 // style does not exist, will return -1.
 func (f *File) getStyleID(ss *xlsxStyleSheet, style *Style) (int, error) {
@@ -1894,37 +2013,40 @@ func getFillID(styleSheet *xlsxStyleSheet, style *Style) (fillID int) {
 // given cell format settings.
 func newFills(style *Style, fg bool) *xlsxFill {
 	var fill xlsxFill
+	colors := len(style.Fill.Color)
+	if len(style.Fill.ColorTheme) > colors {
+		colors = len(style.Fill.ColorTheme)
+	}
 	switch style.Fill.Type {
 	case "gradient":
-		if len(style.Fill.Color) != 2 || style.Fill.Shading < 0 || style.Fill.Shading > 16 {
+		if colors != 2 || style.Fill.Shading < 0 || style.Fill.Shading > 16 {
 			break
 		}
 		gradient := styleFillVariants[style.Fill.Shading]
-		gradient.Stop[0].Color.RGB = getPaletteColor(style.Fill.Color[0])
-		gradient.Stop[1].Color.RGB = getPaletteColor(style.Fill.Color[1])
+		gradient.Stop = make([]*xlsxGradientFillStop, len(styleFillVariants[style.Fill.Shading].Stop))
+		for i, stop := range styleFillVariants[style.Fill.Shading].Stop {
+			gradient.Stop[i] = &xlsxGradientFillStop{Position: stop.Position}
+		}
+		gradient.Stop[0].Color = newFillColor(&style.Fill, 0)
+		gradient.Stop[1].Color = newFillColor(&style.Fill, 1)
 		if len(gradient.Stop) == 3 {
-			gradient.Stop[2].Color.RGB = getPaletteColor(style.Fill.Color[0])
+			gradient.Stop[2].Color = newFillColor(&style.Fill, 0)
 		}
 		fill.GradientFill = &gradient
 	case "pattern":
 		if style.Fill.Pattern > 18 || style.Fill.Pattern < 0 {
 			break
 		}
-		if len(style.Fill.Color) < 1 {
+		if colors < 1 {
 			break
 		}
 		var pattern xlsxPatternFill
 		pattern.PatternType = styleFillPatterns[style.Fill.Pattern]
+		color := newFillColor(&style.Fill, 0)
 		if fg {
-			if pattern.FgColor == nil {
-				pattern.FgColor = new(xlsxColor)
-			}
-			pattern.FgColor.RGB = getPaletteColor(style.Fill.Color[0])
+			pattern.FgColor = &color
 		} else {
-			if pattern.BgColor == nil {
-				pattern.BgColor = new(xlsxColor)
-			}
-			pattern.BgColor.RGB = getPaletteColor(style.Fill.Color[0])
+			pattern.BgColor = &color
 		}
 		fill.PatternFill = &pattern
 	default:
@@ -1933,6 +2055,37 @@ func newFills(style *Style, fg bool) *xlsxFill {
 	return &fill
 }
 
+// newFillColor provides a function to get the color of the fill by given fill
+// settings and the index of the color. The theme color will be used if the
+// theme color index has been specified.
+func newFillColor(fill *Fill, idx int) xlsxColor {
+	var (
+		color string
+		theme *int
+		tint  float64
+	)
+	if idx < len(fill.Color) {
+		color = fill.Color[idx]
+	}
+	if idx < len(fill.ColorTheme) {
+		theme = fill.ColorTheme[idx]
+	}
+	if idx < len(fill.ColorTint) {
+		tint = fill.ColorTint[idx]
+	}
+	return newThemeColor(color, theme, tint)
+}
+
+// newThemeColor provides a function to create color by given RGB color, the
+// index of the theme color and the tint value. The RGB color will be omitted
+// if the theme color index has been specified without the RGB color.
+func newThemeColor(color string, theme *int, tint float64) xlsxColor {
+	if theme != nil && color == "" {
+		return xlsxColor{Theme: theme, Tint: tint}
+	}
+	return xlsxColor{RGB: getPaletteColor(color), Theme: theme, Tint: tint}
+}
+
 // newAlignment provides a function to formatting information pertaining to
 // text alignment in cells. There are a variety of choices for how text is
 // aligned both horizontally and vertically, as well as indentation settings,
@@ -1986,8 +2139,7 @@ func newBorders(style *Style) *xlsxBorder {
 	var border xlsxBorder
 	for _, v := range style.Border {
 		if 0 <= v.Style && v.Style < 14 {
-			var color xlsxColor
-			color.RGB = getPaletteColor(v.Color)
+			color := newThemeColor(v.Color, v.ColorTheme, v.ColorTint)
 			switch v.Type {
 			case "left":
 				border.Left.Style = styleBorders[v.Style]
@@ -2053,6 +2205,74 @@ func setCellXfs(style *xlsxStyleSheet, fontID, numFmtID, fillID, borderID int, a
 	return style.CellXfs.Count - 1, nil
 }
 
+// getQuotePrefixStyleID provides a function to get the cell style index
+// which has the same formatting with the given cell style and the quote
+// prefix flag. A new cell style will be created if it doesn't exist.
+func (f *File) getQuotePrefixStyleID(styleID int) (int, error) {
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	if err != nil {
+		f.mu.Unlock()
+		return 0, err
+	}
+	f.mu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.CellXfs == nil || styleID < 0 || styleID >= len(s.CellXfs.Xf) {
+		return 0, newInvalidStyleID(styleID)
+	}
+	xf := s.CellXfs.Xf[styleID]
+	if xf.QuotePrefix != nil && *xf.QuotePrefix {
+		return styleID, err
+	}
+	xf.QuotePrefix = boolPtr(true)
+	for xfID, cellXf := range s.CellXfs.Xf {
+		if reflect.DeepEqual(cellXf, xf) {
+			return xfID, err
+		}
+	}
+	if len(s.CellXfs.Xf) == MaxCellStyles {
+		return 0, ErrCellStyles
+	}
+	s.CellXfs.Xf = append(s.CellXfs.Xf, xf)
+	s.CellXfs.Count = len(s.CellXfs.Xf)
+	return s.CellXfs.Count - 1, err
+}
+
+// getTextNumFmtStyleID provides a function to get the cell style index which
+// has the same formatting with the given cell style and the text number
+// format "@". A new cell style will be created if it doesn't exist.
+func (f *File) getTextNumFmtStyleID(styleID int) (int, error) {
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	if err != nil {
+		f.mu.Unlock()
+		return 0, err
+	}
+	f.mu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.CellXfs == nil || styleID < 0 || styleID >= len(s.CellXfs.Xf) {
+		return 0, newInvalidStyleID(styleID)
+	}
+	xf := s.CellXfs.Xf[styleID]
+	if xf.NumFmtID != nil && *xf.NumFmtID == 49 {
+		return styleID, err
+	}
+	xf.NumFmtID, xf.ApplyNumberFormat = intPtr(49), boolPtr(true)
+	for xfID, cellXf := range s.CellXfs.Xf {
+		if reflect.DeepEqual(cellXf, xf) {
+			return xfID, err
+		}
+	}
+	if len(s.CellXfs.Xf) == MaxCellStyles {
+		return 0, ErrCellStyles
+	}
+	s.CellXfs.Xf = append(s.CellXfs.Xf, xf)
+	s.CellXfs.Count = len(s.CellXfs.Xf)
+	return s.CellXfs.Count - 1, err
+}
+
 // GetCellStyle provides a function to get cell style index by given worksheet
 // name and cell reference.
 func (f *File) GetCellStyle(sheet, cell string) (int, error) {
@@ -2070,6 +2290,159 @@ func (f *File) GetCellStyle(sheet, cell string) (int, error) {
 	return ws.prepareCellStyle(col, row, ws.SheetData.Row[row-1].C[col-1].S), err
 }
 
+// SetCellProtection provides a function to set the locked and hidden
+// protection properties of the cell by given worksheet name and cell
+// reference. The other formatting of the cell will be kept, and the
+// protection only takes effect when the worksheet is protected. For example,
+// unlock the input cell B2 on Sheet1, and then protect the worksheet:
+//
+//	err := f.SetCellProtection("Sheet1", "B2", false, false)
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.ProtectSheet("Sheet1", &excelize.SheetProtectionOptions{
+//	    SelectLockedCells:   true,
+//	    SelectUnlockedCells: true,
+//	})
+func (f *File) SetCellProtection(sheet, cell string, locked, hidden bool) error {
+	styleID, err := f.GetCellStyle(sheet, cell)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	styleID, err = s.setProtection(styleID, locked, hidden)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return f.SetCellStyle(sheet, cell, cell, styleID)
+}
+
+// setProtection provides a function to get the index of the cell style
+// which cloned from the given cell style with the given protection
+// properties, the identical cell style will be reused if it exists.
+func (s *xlsxStyleSheet) setProtection(styleID int, locked, hidden bool) (int, error) {
+	if s.CellXfs == nil || len(s.CellXfs.Xf) <= styleID {
+		return styleID, newInvalidStyleID(styleID)
+	}
+	xf := deepcopy.Copy(s.CellXfs.Xf[styleID]).(xlsxXf)
+	xf.ApplyProtection = boolPtr(true)
+	xf.Protection = &xlsxProtection{Hidden: boolPtr(hidden), Locked: boolPtr(locked)}
+	for idx, cellXf := range s.CellXfs.Xf {
+		if reflect.DeepEqual(cellXf, xf) {
+			return idx, nil
+		}
+	}
+	if len(s.CellXfs.Xf) == MaxCellStyles {
+		return styleID, ErrCellStyles
+	}
+	s.CellXfs.Xf = append(s.CellXfs.Xf, xf)
+	s.CellXfs.Count = len(s.CellXfs.Xf)
+	return s.CellXfs.Count - 1, nil
+}
+
+// GetCellProtection provides a function to get the locked and hidden
+// protection properties of the cell by given worksheet name and cell
+// reference. The cell is locked and not hidden by default.
+func (f *File) GetCellProtection(sheet, cell string) (locked, hidden bool, err error) {
+	locked = true
+	styleID, err := f.GetCellStyle(sheet, cell)
+	if err != nil {
+		return
+	}
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	f.mu.Unlock()
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.CellXfs == nil || len(s.CellXfs.Xf) <= styleID {
+		return
+	}
+	if p := s.CellXfs.Xf[styleID].Protection; p != nil {
+		if p.Locked != nil {
+			locked = *p.Locked
+		}
+		if p.Hidden != nil {
+			hidden = *p.Hidden
+		}
+	}
+	return
+}
+
+// GetRangeNumberFormats provides a function to get the number format codes
+// of the cells in a range by given worksheet name and range reference. The
+// result is a two-dimensional array with the outer slice indexed by rows and
+// the inner slice indexed by columns of the range. The built-in number format
+// will be resolved to its standard number format code, and the cell without
+// number format will return "General". For example, get the number format
+// codes of the cells in the range A1:C3 on Sheet1:
+//
+//	numFmts, err := f.GetRangeNumberFormats("Sheet1", "A1:C3")
+func (f *File) GetRangeNumberFormats(sheet, rangeRef string) ([][]string, error) {
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return nil, err
+	}
+	_ = sortCoordinates(coordinates)
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return nil, err
+	}
+	s, err := f.stylesReader()
+	if err != nil {
+		return nil, err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	numFmts, fmtCodes := make([][]string, 0, coordinates[3]-coordinates[1]+1), map[int]string{}
+	for row := coordinates[1]; row <= coordinates[3]; row++ {
+		rowFmts := make([]string, 0, coordinates[2]-coordinates[0]+1)
+		for col := coordinates[0]; col <= coordinates[2]; col++ {
+			var styleID int
+			if row <= len(ws.SheetData.Row) && col <= len(ws.SheetData.Row[row-1].C) {
+				styleID = ws.SheetData.Row[row-1].C[col-1].S
+			}
+			styleID = ws.prepareCellStyle(col, row, styleID)
+			fmtCode, ok := fmtCodes[styleID]
+			if !ok {
+				fmtCode = f.getStyleNumFmtCode(s, styleID)
+				fmtCodes[styleID] = fmtCode
+			}
+			rowFmts = append(rowFmts, fmtCode)
+		}
+		numFmts = append(numFmts, rowFmts)
+	}
+	return numFmts, err
+}
+
+// getStyleNumFmtCode provides a function to get the number format code by
+// given style sheet and style index, the built-in number format will be
+// resolved to the standard number format code, and returns "General" if the
+// number format not found.
+func (f *File) getStyleNumFmtCode(s *xlsxStyleSheet, styleID int) string {
+	if s.CellXfs == nil || styleID < 0 || styleID >= len(s.CellXfs.Xf) || s.CellXfs.Xf[styleID].NumFmtID == nil {
+		return "General"
+	}
+	numFmtID := *s.CellXfs.Xf[styleID].NumFmtID
+	if fmtCode, ok := s.getCustomNumFmtCode(numFmtID); ok {
+		return fmtCode
+	}
+	if fmtCode, ok := f.getBuiltInNumFmtCode(numFmtID); ok && numFmtID != 0 {
+		return fmtCode
+	}
+	return "General"
+}
+
 // SetCellStyle provides a function to add style attribute for cells by given
 // worksheet name, range reference and style ID. This function is concurrency
 // safe. Note that diagonalDown and diagonalUp type border should be use same
@@ -2229,6 +2602,39 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 	return err
 }
 
+// SetCellStyleMultiSheet provides a function to add the same style attribute
+// for cells on multiple worksheets by given worksheet names, range reference
+// and style ID, like editing the grouped sheets in the spreadsheet
+// application. All worksheets, the range reference and the style ID will be
+// validated before setting the style, so that no worksheet will be changed if
+// any of them is invalid. For example, set the style for cells A1:D1 on
+// Sheet1 and Sheet2:
+//
+//	err := f.SetCellStyleMultiSheet([]string{"Sheet1", "Sheet2"}, "A1", "D1", style)
+func (f *File) SetCellStyleMultiSheet(sheets []string, hCell, vCell string, styleID int) error {
+	if err := f.checkMultiSheet(sheets, hCell, vCell); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	if styleID < 0 || s.CellXfs == nil || len(s.CellXfs.Xf) <= styleID {
+		s.mu.Unlock()
+		return newInvalidStyleID(styleID)
+	}
+	s.mu.Unlock()
+	for _, sheet := range sheets {
+		if err = f.SetCellStyle(sheet, hCell, vCell, styleID); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
 // SetConditionalFormat provides a function to create conditional formatting
 // rule for cell value. Conditional formatting is a feature of Excel which
 // allows you to apply a format to a cell or a range of cells based on certain
@@ -2282,6 +2688,7 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 //	               | BarOnly
 //	               | BarSolid
 //	 icon_set      | IconStyle
+//	               | IconThresholds
 //	               | ReverseIcons
 //	               | IconsOnly
 //	 formula       | Criteria
@@ -2555,7 +2962,7 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 // The available min/mid/max types are:
 //
 //	min        (for MinType only)
-//	num
+//	num        (or number)
 //	percent
 //	percentile
 //	formula
@@ -2569,12 +2976,32 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 // conditional formatting type is 2_color_scale, 3_color_scale or data_bar.
 //
 // MidValue - The MidValue is available for 3_color_scale. Same as MinValue,
-// see above.
+// see above. The MidValue defaults to 50 if it is empty. For the color scales,
+// when the minimum and maximum values have the same num, percent or
+// percentile type, the minimum value must not be greater than the maximum
+// value. For example, set a heatmap of variance with the midpoint at 0:
+//
+//	err := f.SetConditionalFormat("Sheet1", "D1:D10",
+//	    []excelize.ConditionalFormatOptions{
+//	        {
+//	            Type:     "3_color_scale",
+//	            Criteria: "=",
+//	            MinType:  "min",
+//	            MidType:  "num",
+//	            MaxType:  "max",
+//	            MidValue: "0",
+//	            MinColor: "#F8696B",
+//	            MidColor: "#FFFFFF",
+//	            MaxColor: "#63BE7B",
+//	        },
+//	    },
+//	)
 //
 // MaxValue - Same as MinValue, see above.
 //
 // MinColor - The MinColor and MaxColor properties are available when the
 // conditional formatting type is 2_color_scale, 3_color_scale or data_bar.
+// The colors of the color scales must be valid hex color codes.
 //
 // MidColor - The MidColor is available for 3_color_scale. The properties
 // are used as follows:
@@ -2633,6 +3060,29 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 //	5Quarters
 //	5Rating
 //
+// IconThresholds - Used for set the threshold of each icon in the icon set,
+// the number of thresholds must be equal to the number of icons of the icon
+// style, and the first threshold is the minimum value of the first icon. The
+// 'Type' of the threshold can be 'percent', 'num', 'percentile' or 'formula',
+// and the 'GreaterThan' specifies whether the value must be greater than the
+// threshold instead of greater than or equal to the threshold. The default
+// thresholds of the icon style will be used if it is not specified. For
+// example, highlight cells with traffic lights by number thresholds:
+//
+//	err := f.SetConditionalFormat("Sheet1", "A1:A10",
+//	    []excelize.ConditionalFormatOptions{
+//	        {
+//	            Type:      "icon_set",
+//	            IconStyle: "3TrafficLights1",
+//	            IconThresholds: []excelize.ConditionalFormatIconThreshold{
+//	                {Type: "num", Value: "0"},
+//	                {Type: "num", Value: "50"},
+//	                {Type: "num", Value: "80", GreaterThan: true},
+//	            },
+//	        },
+//	    },
+//	)
+//
 // ReverseIcons - Used for set reversed icons sets.
 //
 // IconsOnly - Used for set displayed without the cell value.
@@ -2641,6 +3091,21 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 // formatting rule when more than one rule is applied to a cell or a range of
 // cells. When this parameter is set then subsequent rules are not evaluated
 // if the current rule is true.
+//
+// Priority - Used to set the priority of a conditional formatting rule, the
+// rule with the lower value will be evaluated first. All the rules in the
+// same worksheet share the priority, if it is not specified, an incrementing
+// priority after the highest priority of the existing rules in the worksheet
+// will be assigned. For example, highlight the cells greater than 100 and
+// stop evaluating the rule which highlight the cells greater than 50 for
+// these cells:
+//
+//	err := f.SetConditionalFormat("Sheet1", "A1:A10",
+//	    []excelize.ConditionalFormatOptions{
+//	        {Type: "cell", Criteria: ">", Format: format1, Value: "100", StopIfTrue: true, Priority: 1},
+//	        {Type: "cell", Criteria: ">", Format: format2, Value: "50", Priority: 2},
+//	    },
+//	)
 func (f *File) SetConditionalFormat(sheet, rangeRef string, opts []ConditionalFormatOptions) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -2655,9 +3120,14 @@ func (f *File) SetConditionalFormat(sheet, rangeRef string, opts []ConditionalFo
 		rangeRef, _ = f.coordinatesToRangeRef(rect, strings.Contains(rangeRef, "$"))
 	}
 	// Create a pseudo GUID for each unique rule.
-	var rules int
+	var rules, priority int
 	for _, cf := range ws.ConditionalFormatting {
 		rules += len(cf.CfRule)
+		for _, rule := range cf.CfRule {
+			if rule.Priority > priority {
+				priority = rule.Priority
+			}
+		}
 	}
 	var (
 		GUID            = fmt.Sprintf("{00000000-0000-0000-%04X-%012X}", f.getSheetID(sheet), rules)
@@ -2674,6 +3144,9 @@ func (f *File) SetConditionalFormat(sheet, rangeRef string, opts []ConditionalFo
 	for p, v := range opts {
 		var vt, ct string
 		var ok bool
+		if v.Priority < 0 {
+			return ErrParameterInvalid
+		}
 		// "type" is a required parameter, check for valid validation types.
 		vt, ok = validType[v.Type]
 		if ok {
@@ -2686,6 +3159,12 @@ func (f *File) SetConditionalFormat(sheet, rangeRef string, opts []ConditionalFo
 					if rule == nil {
 						return ErrParameterInvalid
 					}
+					if rule.Priority = v.Priority; rule.Priority == 0 {
+						rule.Priority = priority + 1
+					}
+					if rule.Priority > priority {
+						priority = rule.Priority
+					}
 					if x14rule != nil {
 						if err = f.appendCfRule(ws, x14rule); err != nil {
 							return err
@@ -2748,10 +3227,7 @@ func (f *File) appendCfRule(ws *xlsxWorksheet, rule *xlsxX14CfRule) error {
 			URI: ExtURIConditionalFormattings, Content: string(condFmtsBytes),
 		})
 	}
-	sort.Slice(decodeExtLst.Ext, func(i, j int) bool {
-		return inStrSlice(worksheetExtURIPriority, decodeExtLst.Ext[i].URI, false) <
-			inStrSlice(worksheetExtURIPriority, decodeExtLst.Ext[j].URI, false)
-	})
+	sortExtLst(decodeExtLst.Ext, worksheetExtURIPriority)
 	extLstBytes, err = xml.Marshal(decodeExtLst)
 	ws.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
 	return err
@@ -2761,25 +3237,27 @@ func (f *File) appendCfRule(ws *xlsxWorksheet, rule *xlsxX14CfRule) error {
 // settings for cell value (include between, not between, equal, not equal,
 // greater than and less than) by given conditional formatting rule.
 func extractCondFmtCellIs(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
-	format := ConditionalFormatOptions{StopIfTrue: c.StopIfTrue, Type: "cell", Criteria: operatorType[c.Operator], Format: *c.DxfID}
+	format := ConditionalFormatOptions{StopIfTrue: c.StopIfTrue, Type: "cell", Criteria: operatorType[c.Operator], Format: intValue(c.DxfID)}
 	if len(c.Formula) == 2 {
 		format.MinValue, format.MaxValue = c.Formula[0], c.Formula[1]
 		return format
 	}
-	format.Value = c.Formula[0]
+	if len(c.Formula) > 0 {
+		format.Value = c.Formula[0]
+	}
 	return format
 }
 
 // extractCondFmtTimePeriod provides a function to extract conditional format
 // settings for time period by given conditional formatting rule.
 func extractCondFmtTimePeriod(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
-	return ConditionalFormatOptions{StopIfTrue: c.StopIfTrue, Type: "time_period", Criteria: operatorType[c.Operator], Format: *c.DxfID}
+	return ConditionalFormatOptions{StopIfTrue: c.StopIfTrue, Type: "time_period", Criteria: operatorType[c.Operator], Format: intValue(c.DxfID)}
 }
 
 // extractCondFmtText provides a function to extract conditional format
 // settings for text cell values by given conditional formatting rule.
 func extractCondFmtText(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
-	return ConditionalFormatOptions{StopIfTrue: c.StopIfTrue, Type: "text", Criteria: operatorType[c.Operator], Format: *c.DxfID, Value: c.Text}
+	return ConditionalFormatOptions{StopIfTrue: c.StopIfTrue, Type: "text", Criteria: operatorType[c.Operator], Format: intValue(c.DxfID), Value: c.Text}
 }
 
 // extractCondFmtTop10 provides a function to extract conditional format
@@ -2790,7 +3268,7 @@ func extractCondFmtTop10(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOpt
 		StopIfTrue: c.StopIfTrue,
 		Type:       "top",
 		Criteria:   "=",
-		Format:     *c.DxfID,
+		Format:     intValue(c.DxfID),
 		Percent:    c.Percent,
 		Value:      strconv.Itoa(c.Rank),
 	}
@@ -2808,8 +3286,8 @@ func extractCondFmtAboveAverage(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFo
 		StopIfTrue:   c.StopIfTrue,
 		Type:         "average",
 		Criteria:     "=",
-		Format:       *c.DxfID,
-		AboveAverage: *c.AboveAverage,
+		Format:       intValue(c.DxfID),
+		AboveAverage: c.AboveAverage == nil || *c.AboveAverage,
 	}
 }
 
@@ -2824,7 +3302,7 @@ func extractCondFmtDuplicateUniqueValues(c *xlsxCfRule, extLst *xlsxExtLst) Cond
 			"uniqueValues":    "unique",
 		}[c.Type],
 		Criteria: "=",
-		Format:   *c.DxfID,
+		Format:   intValue(c.DxfID),
 	}
 }
 
@@ -2834,7 +3312,7 @@ func extractCondFmtBlanks(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOp
 	return ConditionalFormatOptions{
 		StopIfTrue: c.StopIfTrue,
 		Type:       "blanks",
-		Format:     *c.DxfID,
+		Format:     intValue(c.DxfID),
 	}
 }
 
@@ -2844,7 +3322,7 @@ func extractCondFmtNoBlanks(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormat
 	return ConditionalFormatOptions{
 		StopIfTrue: c.StopIfTrue,
 		Type:       "no_blanks",
-		Format:     *c.DxfID,
+		Format:     intValue(c.DxfID),
 	}
 }
 
@@ -2854,7 +3332,7 @@ func extractCondFmtErrors(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOp
 	return ConditionalFormatOptions{
 		StopIfTrue: c.StopIfTrue,
 		Type:       "errors",
-		Format:     *c.DxfID,
+		Format:     intValue(c.DxfID),
 	}
 }
 
@@ -2864,7 +3342,7 @@ func extractCondFmtNoErrors(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormat
 	return ConditionalFormatOptions{
 		StopIfTrue: c.StopIfTrue,
 		Type:       "no_errors",
-		Format:     *c.DxfID,
+		Format:     intValue(c.DxfID),
 	}
 }
 
@@ -2874,6 +3352,9 @@ func extractCondFmtNoErrors(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormat
 func extractCondFmtColorScale(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
 	format := ConditionalFormatOptions{StopIfTrue: c.StopIfTrue}
 	format.Type, format.Criteria = "2_color_scale", "="
+	if c.ColorScale == nil {
+		return format
+	}
 	values := len(c.ColorScale.Cfvo)
 	colors := len(c.ColorScale.Color)
 	if colors > 1 && values > 1 {
@@ -2891,9 +3372,7 @@ func extractCondFmtColorScale(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalForm
 	if colors == 3 {
 		format.Type = "3_color_scale"
 		format.MidType = c.ColorScale.Cfvo[1].Type
-		if c.ColorScale.Cfvo[1].Val != "0" {
-			format.MidValue = c.ColorScale.Cfvo[1].Val
-		}
+		format.MidValue = c.ColorScale.Cfvo[1].Val
 		format.MidColor = "#" + strings.TrimPrefix(strings.ToUpper(c.ColorScale.Color[1].RGB), "FF")
 		format.MaxType = c.ColorScale.Cfvo[2].Type
 		if c.ColorScale.Cfvo[2].Val != "0" {
@@ -2960,7 +3439,7 @@ func extractCondFmtDataBar(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatO
 // extractCondFmtExp provides a function to extract conditional format settings
 // for expression by given conditional formatting rule.
 func extractCondFmtExp(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
-	format := ConditionalFormatOptions{StopIfTrue: c.StopIfTrue, Type: "formula", Format: *c.DxfID}
+	format := ConditionalFormatOptions{StopIfTrue: c.StopIfTrue, Type: "formula", Format: intValue(c.DxfID)}
 	if len(c.Formula) > 0 {
 		format.Criteria = c.Formula[0]
 	}
@@ -2977,12 +3456,35 @@ func extractCondFmtIconSet(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatO
 		}
 		format.IconStyle = c.IconSet.IconSet
 		format.ReverseIcons = c.IconSet.Reverse
+		if preset, _ := drawCondFmtIconSet(0, "", "", "", &format); preset == nil ||
+			!reflect.DeepEqual(preset.IconSet.Cfvo, c.IconSet.Cfvo) {
+			for _, cfvo := range c.IconSet.Cfvo {
+				format.IconThresholds = append(format.IconThresholds, ConditionalFormatIconThreshold{
+					Type: cfvo.Type, Value: cfvo.Val, GreaterThan: cfvo.Gte != nil && !*cfvo.Gte,
+				})
+			}
+		}
 	}
 	return format
 }
 
 // GetConditionalFormats returns conditional format settings by given worksheet
-// name.
+// name. The returned map is keyed by the range reference of each conditional
+// formatting, and the rules could be modified and re-applied on the worksheet
+// by the SetConditionalFormat function. For example, get all conditional
+// formats on Sheet1 and apply them on Sheet2:
+//
+//	condFmts, err := f.GetConditionalFormats("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for rangeRef, opts := range condFmts {
+//	    if err := f.SetConditionalFormat("Sheet2", rangeRef, opts); err != nil {
+//	        fmt.Println(err)
+//	        return
+//	    }
+//	}
 func (f *File) GetConditionalFormats(sheet string) (map[string][]ConditionalFormatOptions, error) {
 	conditionalFormats := make(map[string][]ConditionalFormatOptions)
 	ws, err := f.workSheetReader(sheet)
@@ -2993,7 +3495,9 @@ func (f *File) GetConditionalFormats(sheet string) (map[string][]ConditionalForm
 		var opts []ConditionalFormatOptions
 		for _, cr := range cf.CfRule {
 			if extractFunc, ok := extractContFmtFunc[cr.Type]; ok {
-				opts = append(opts, extractFunc(cr, ws.ExtLst))
+				opt := extractFunc(cr, ws.ExtLst)
+				opt.Priority = cr.Priority
+				opts = append(opts, opt)
 			}
 		}
 		conditionalFormats[cf.SQRef] = opts
@@ -3142,6 +3646,11 @@ func drawCondFmtDuplicateUniqueValues(p int, ct, ref, GUID string, format *Condi
 // rule for color scale (include 2 color scale and 3 color scale) by given
 // priority, criteria type and format settings.
 func drawCondFmtColorScale(p int, ct, ref, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
+	opts, ok := normalizeCondFmtColorScale(*format)
+	if !ok {
+		return nil, nil
+	}
+	format = &opts
 	minValue := format.MinValue
 	if minValue == "" {
 		minValue = "0"
@@ -3177,6 +3686,46 @@ func drawCondFmtColorScale(p int, ct, ref, GUID string, format *ConditionalForma
 	return c, nil
 }
 
+// normalizeCondFmtColorScale provides a function to validate the value object
+// types, values and colors of the color scale conditional formatting, and
+// returns a copy of the format settings which the type "number" normalized as
+// "num". The minimum value must not be greater than the maximum value when
+// they have the same numeric type.
+func normalizeCondFmtColorScale(format ConditionalFormatOptions) (ConditionalFormatOptions, bool) {
+	type valueObject struct {
+		typ          *string
+		value, color string
+		types        []string
+	}
+	cfvo := []valueObject{
+		{&format.MinType, format.MinValue, format.MinColor, []string{"min", "num", "percent", "percentile", "formula"}},
+		{&format.MaxType, format.MaxValue, format.MaxColor, []string{"max", "num", "percent", "percentile", "formula"}},
+	}
+	if validType[format.Type] == "3_color_scale" {
+		cfvo = append(cfvo, valueObject{&format.MidType, format.MidValue, format.MidColor, []string{"num", "percent", "percentile", "formula"}})
+	}
+	for _, v := range cfvo {
+		if *v.typ == "number" {
+			*v.typ = "num"
+		}
+		if inStrSlice(v.types, *v.typ, true) == -1 {
+			return format, false
+		}
+		hex := strings.TrimPrefix(v.color, "#")
+		if _, err := strconv.ParseUint(hex, 16, 32); err != nil || len(hex) != 6 {
+			return format, false
+		}
+	}
+	if format.MinType == format.MaxType && inStrSlice([]string{"num", "percent", "percentile"}, format.MinType, true) != -1 {
+		minValue, err1 := strconv.ParseFloat(format.MinValue, 64)
+		maxValue, err2 := strconv.ParseFloat(format.MaxValue, 64)
+		if err1 == nil && err2 == nil && minValue > maxValue {
+			return format, false
+		}
+	}
+	return format, true
+}
+
 // drawCondFmtDataBar provides a function to create conditional formatting
 // rule for data bar by given priority, criteria type and format settings.
 func drawCondFmtDataBar(p int, ct, ref, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
@@ -3318,6 +3867,22 @@ func drawCondFmtIconSet(p int, ct, ref, GUID string, format *ConditionalFormatOp
 	if !ok {
 		return nil, nil
 	}
+	if len(format.IconThresholds) > 0 {
+		if len(format.IconThresholds) != len(cfRule.IconSet.Cfvo) {
+			return nil, nil
+		}
+		cfRule.IconSet.Cfvo = nil
+		for _, threshold := range format.IconThresholds {
+			if inStrSlice([]string{"formula", "num", "percent", "percentile"}, threshold.Type, true) == -1 {
+				return nil, nil
+			}
+			cfvo := &xlsxCfvo{Type: threshold.Type, Val: threshold.Value}
+			if threshold.GreaterThan {
+				cfvo.Gte = boolPtr(false)
+			}
+			cfRule.IconSet.Cfvo = append(cfRule.IconSet.Cfvo, cfvo)
+		}
+	}
 	cfRule.Priority = p + 1
 	cfRule.IconSet.IconSet = format.IconStyle
 	cfRule.IconSet.Reverse = format.ReverseIcons
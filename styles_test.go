@@ -191,8 +191,70 @@ func TestSetConditionalFormat(t *testing.T) {
 	assert.EqualError(t, f.SetConditionalFormat("Sheet1", "A1:A2", condFmts), "XML syntax error on line 1: element <conditionalFormattings> closed by </conditionalFormatting>")
 	// Test creating a conditional format with invalid icon set style
 	assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormat("Sheet1", "A1:A2", []ConditionalFormatOptions{{Type: "icon_set", IconStyle: "unknown"}}))
+	// Test creating a conditional format with invalid icon set thresholds
+	assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormat("Sheet1", "A1:A2", []ConditionalFormatOptions{{Type: "icon_set", IconStyle: "4Rating", IconThresholds: []ConditionalFormatIconThreshold{
+		{Type: "percent", Value: "0"}, {Type: "percent", Value: "50"}, {Type: "percent", Value: "75"},
+	}}}))
+	assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormat("Sheet1", "A1:A2", []ConditionalFormatOptions{{Type: "icon_set", IconStyle: "3Flags", IconThresholds: []ConditionalFormatIconThreshold{
+		{Type: "percent", Value: "0"}, {Type: "max", Value: "50"}, {Type: "percent", Value: "75"},
+	}}}))
 	// Test unsupported conditional formatting rule types
 	assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormat("Sheet1", "A1", []ConditionalFormatOptions{{Type: "unsupported"}}))
+	// Test creating a color scale with custom midpoint
+	f = NewFile()
+	colorScale := []ConditionalFormatOptions{{Type: "3_color_scale", Criteria: "=", MinType: "min", MidType: "number", MaxType: "max", MidValue: "0", MinColor: "#F8696B", MidColor: "#FFFFFF", MaxColor: "#63BE7B"}}
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", colorScale))
+	assert.Equal(t, "number", colorScale[0].MidType)
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "B1:B10", []ConditionalFormatOptions{{Type: "3_color_scale", Criteria: "=", MinType: "percentile", MidType: "percentile", MaxType: "percentile", MinValue: "10", MidValue: "30", MaxValue: "90", MinColor: "#F8696B", MidColor: "#FFFFFF", MaxColor: "#63BE7B"}}))
+	opts, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []ConditionalFormatOptions{{Type: "3_color_scale", Criteria: "=", MinType: "min", MidType: "num", MaxType: "max", MidValue: "0", MinColor: "#F8696B", MidColor: "#FFFFFF", MaxColor: "#63BE7B", Priority: 1}}, opts["A1:A10"])
+	assert.Equal(t, "30", opts["B1:B10"][0].MidValue)
+	// Test set conditional formats with priority across ranges
+	f = NewFile()
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: 0, Value: "100", StopIfTrue: true},
+		{Type: "cell", Criteria: ">", Format: 0, Value: "50"},
+	}))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "B1:B10", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: 0, Value: "10", Priority: 5},
+		{Type: "cell", Criteria: "<", Format: 0, Value: "0"},
+	}))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "C1:C10", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: 0, Value: "10"},
+	}))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	var priorities []int
+	for _, cf := range ws.(*xlsxWorksheet).ConditionalFormatting {
+		for _, rule := range cf.CfRule {
+			priorities = append(priorities, rule.Priority)
+		}
+	}
+	assert.Equal(t, []int{1, 2, 5, 6, 7}, priorities)
+	assert.True(t, ws.(*xlsxWorksheet).ConditionalFormatting[0].CfRule[0].StopIfTrue)
+	opts, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []ConditionalFormatOptions{
+		{Type: "cell", Criteria: "greater than", Format: 0, Value: "100", StopIfTrue: true, Priority: 1},
+		{Type: "cell", Criteria: "greater than", Format: 0, Value: "50", Priority: 2},
+	}, opts["A1:A10"])
+	// Test set conditional formats with invalid priority
+	assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormat("Sheet1", "D1:D10", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: 0, Value: "10", Priority: -1},
+	}))
+	// Test creating color scales with invalid value object types, colors and values
+	for _, opts := range []ConditionalFormatOptions{
+		{Type: "2_color_scale", Criteria: "=", MinType: "max", MaxType: "max", MinColor: "#F8696B", MaxColor: "#63BE7B"},
+		{Type: "2_color_scale", Criteria: "=", MinType: "min", MaxType: "unknown", MinColor: "#F8696B", MaxColor: "#63BE7B"},
+		{Type: "3_color_scale", Criteria: "=", MinType: "min", MidType: "min", MaxType: "max", MinColor: "#F8696B", MidColor: "#FFFFFF", MaxColor: "#63BE7B"},
+		{Type: "2_color_scale", Criteria: "=", MinType: "min", MaxType: "max", MinColor: "#F8696", MaxColor: "#63BE7B"},
+		{Type: "3_color_scale", Criteria: "=", MinType: "min", MidType: "num", MaxType: "max", MinColor: "#F8696B", MidColor: "#GGGGGG", MaxColor: "#63BE7B"},
+		{Type: "2_color_scale", Criteria: "=", MinType: "num", MaxType: "num", MinValue: "10", MaxValue: "-10", MinColor: "#F8696B", MaxColor: "#63BE7B"},
+		{Type: "3_color_scale", Criteria: "=", MinType: "percent", MidType: "percent", MaxType: "percent", MinValue: "60", MidValue: "50", MaxValue: "40", MinColor: "#F8696B", MidColor: "#FFFFFF", MaxColor: "#63BE7B"},
+	} {
+		assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormat("Sheet1", "C1:C10", []ConditionalFormatOptions{opts}))
+	}
 }
 
 func TestGetConditionalFormats(t *testing.T) {
@@ -228,17 +290,52 @@ func TestGetConditionalFormats(t *testing.T) {
 		{{Type: "errors", Format: 1}},
 		{{Type: "no_errors", Format: 1}},
 		{{Type: "icon_set", IconStyle: "3Arrows", ReverseIcons: true, IconsOnly: true}},
+		{{Type: "icon_set", IconStyle: "3TrafficLights1", IconThresholds: []ConditionalFormatIconThreshold{
+			{Type: "num", Value: "0"}, {Type: "percentile", Value: "50"}, {Type: "formula", Value: "$B$1", GreaterThan: true},
+		}}},
+		{{Type: "icon_set", IconStyle: "5Arrows", IconThresholds: []ConditionalFormatIconThreshold{
+			{Type: "percent", Value: "0"}, {Type: "percent", Value: "10"}, {Type: "percent", Value: "20"},
+			{Type: "percent", Value: "30"}, {Type: "num", Value: "100"},
+		}}},
 	} {
 		f := NewFile()
 		err := f.SetConditionalFormat("Sheet1", "A2:A1", format)
 		assert.NoError(t, err)
 		opts, err := f.GetConditionalFormats("Sheet1")
 		assert.NoError(t, err)
+		for i := range format {
+			format[i].Priority = i + 1
+		}
 		assert.Equal(t, format, opts["A1:A2"])
 	}
-	// Test get conditional formats on no exists worksheet
+	// Test get conditional formats without differential formatting and with
+	// default above average attribute
 	f := NewFile()
-	_, err := f.GetConditionalFormats("SheetN")
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).ConditionalFormatting = []*xlsxConditionalFormatting{
+		{SQRef: "A1:A2", CfRule: []*xlsxCfRule{
+			{Type: "cellIs", Operator: "equal", Priority: 1},
+			{Type: "aboveAverage", Priority: 2},
+			{Type: "colorScale", Priority: 3},
+		}},
+	}
+	opts, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []ConditionalFormatOptions{
+		{Type: "cell", Criteria: "equal to", Priority: 1},
+		{Type: "average", Criteria: "=", AboveAverage: true, Priority: 2},
+		{Type: "2_color_scale", Criteria: "=", Priority: 3},
+	}, opts["A1:A2"])
+	// Test re-apply the conditional formats on another worksheet
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetConditionalFormat("Sheet2", "A1:A2", opts["A1:A2"][:2]))
+	opts, err = f.GetConditionalFormats("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, opts["A1:A2"], 2)
+	// Test get conditional formats on no exists worksheet
+	_, err = f.GetConditionalFormats("SheetN")
 	assert.EqualError(t, err, "sheet SheetN does not exist")
 	// Test get conditional formats with invalid sheet name
 	_, err = f.GetConditionalFormats("Sheet:1")
@@ -485,6 +582,142 @@ func TestSetCellStyle(t *testing.T) {
 	assert.EqualError(t, f.SetCellStyle("Sheet1", "A1", "A2", 1), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestCellProtection(t *testing.T) {
+	f := NewFile()
+	// Test get cell protection with default cell style
+	locked, hidden, err := f.GetCellProtection("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.True(t, locked)
+	assert.False(t, hidden)
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}, Border: []Border{{Type: "left", Color: "FF0000", Style: 1}}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "B2", style))
+	assert.NoError(t, f.SetCellProtection("Sheet1", "A1", false, true))
+	assert.NoError(t, f.SetCellProtection("Sheet1", "B2", false, true))
+	assert.NoError(t, f.SetCellProtection("Sheet1", "C3", false, false))
+	// Test the identical cell style will be reused
+	styleA1, err := f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	styleB2, err := f.GetCellStyle("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, styleA1, styleB2)
+	assert.NotEqual(t, style, styleA1)
+	// Test the other formatting of the cell will be kept
+	cellStyle, err := f.GetStyle(styleA1)
+	assert.NoError(t, err)
+	assert.True(t, cellStyle.Font.Bold)
+	assert.Equal(t, []Border{{Type: "left", Color: "FF0000", Style: 1}}, cellStyle.Border)
+	assert.Equal(t, &Protection{Hidden: true}, cellStyle.Protection)
+	// Test the original cell style is unchanged
+	locked, hidden, err = f.GetCellProtection("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.True(t, locked)
+	assert.False(t, hidden)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCellProtection.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestCellProtection.xlsx"))
+	assert.NoError(t, err)
+	for cell, expected := range map[string][]bool{"A1": {false, true}, "B2": {false, true}, "C3": {false, false}, "D4": {true, false}} {
+		locked, hidden, err = f.GetCellProtection("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, []bool{locked, hidden}, cell)
+	}
+	// Test set and get cell protection on not exists worksheet
+	assert.EqualError(t, f.SetCellProtection("SheetN", "A1", false, false), "sheet SheetN does not exist")
+	_, _, err = f.GetCellProtection("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test set and get cell protection with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetCellProtection("Sheet1", "A", false, false))
+	_, _, err = f.GetCellProtection("Sheet1", "A")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test set cell protection with exceeds cell styles limit
+	styles, err := f.stylesReader()
+	assert.NoError(t, err)
+	styles.CellXfs.Xf = make([]xlsxXf, MaxCellStyles)
+	assert.Equal(t, ErrCellStyles, f.SetCellProtection("Sheet1", "A1", true, true))
+	// Test set cell protection with not exists style ID
+	styles.CellXfs.Xf = nil
+	assert.Equal(t, newInvalidStyleID(0), f.SetCellProtection("Sheet1", "D4", true, true))
+	assert.NoError(t, f.Close())
+
+	// Test set and get cell protection with unsupported charset style sheet
+	f = NewFile()
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellProtection("Sheet1", "A1", false, false), "XML syntax error on line 1: invalid UTF-8")
+	f.Styles = nil
+	_, _, err = f.GetCellProtection("Sheet1", "A1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestSetCellStyleMultiSheet(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyleMultiSheet([]string{"Sheet1", "Sheet2"}, "A1", "D1", style))
+	for _, sheet := range []string{"Sheet1", "Sheet2"} {
+		styleID, err := f.GetCellStyle(sheet, "D1")
+		assert.NoError(t, err)
+		assert.Equal(t, style, styleID)
+	}
+	// Test set cell style on multiple worksheets with not exists worksheet
+	assert.EqualError(t, f.SetCellStyleMultiSheet([]string{"Sheet1", "SheetN"}, "A2", "D2", style), "sheet SheetN does not exist")
+	styleID, err := f.GetCellStyle("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Zero(t, styleID)
+	// Test set cell style on multiple worksheets with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetCellStyleMultiSheet([]string{"Sheet1"}, "A", "D2", style))
+	// Test set cell style on multiple worksheets with not exists style ID
+	assert.Equal(t, newInvalidStyleID(10), f.SetCellStyleMultiSheet([]string{"Sheet1", "Sheet2"}, "A3", "D3", 10))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Len(t, ws.(*xlsxWorksheet).SheetData.Row, 2)
+	// Test set cell style on multiple worksheets with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellStyleMultiSheet([]string{"Sheet1"}, "A1", "D1", style), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestGetRangeNumberFormats(t *testing.T) {
+	f := NewFile()
+	percent, err := f.NewStyle(&Style{NumFmt: 10})
+	assert.NoError(t, err)
+	customNumFmt := "0.00\"kg\""
+	custom, err := f.NewStyle(&Style{CustomNumFmt: &customNumFmt})
+	assert.NoError(t, err)
+	date, err := f.NewStyle(&Style{NumFmt: 14})
+	assert.NoError(t, err)
+	bold, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A2", percent))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B1", custom))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B2", "B2", bold))
+	assert.NoError(t, f.SetColStyle("Sheet1", "C", date))
+	numFmts, err := f.GetRangeNumberFormats("Sheet1", "C3:A1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"0.00%", customNumFmt, "mm-dd-yy"},
+		{"0.00%", "General", "mm-dd-yy"},
+		{"General", "General", "mm-dd-yy"},
+	}, numFmts)
+	// Test get range number formats with invalid range reference
+	_, err = f.GetRangeNumberFormats("Sheet1", "A1")
+	assert.Equal(t, ErrParameterInvalid, err)
+	_, err = f.GetRangeNumberFormats("Sheet1", "A:B1")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test get range number formats on not exists worksheet
+	_, err = f.GetRangeNumberFormats("SheetN", "A1:B2")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get range number formats with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.GetRangeNumberFormats("Sheet1", "A1:B2")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestGetStyleID(t *testing.T) {
 	f := NewFile()
 	styleID, err := f.getStyleID(&xlsxStyleSheet{}, nil)
@@ -547,6 +780,54 @@ func TestGetThemeColor(t *testing.T) {
 	assert.Empty(t, f.getThemeColor(&xlsxColor{Indexed: len(IndexedColorMapping), Tint: 0.5}))
 }
 
+func TestThemeColorStyle(t *testing.T) {
+	f := NewFile()
+	theme := 4
+	styleID, err := f.NewStyle(&Style{
+		Border: []Border{{Type: "left", ColorTheme: &theme, ColorTint: -0.25, Style: 1}},
+		Fill:   Fill{Type: "pattern", Pattern: 1, ColorTheme: []*int{&theme}, ColorTint: []float64{0.4}},
+	})
+	assert.NoError(t, err)
+	s, err := f.stylesReader()
+	assert.NoError(t, err)
+	xf := s.CellXfs.Xf[styleID]
+	assert.Equal(t, xlsxColor{Theme: &theme, Tint: 0.4}, *s.Fills.Fill[*xf.FillID].PatternFill.FgColor)
+	assert.Equal(t, xlsxColor{Theme: &theme, Tint: -0.25}, *s.Borders.Border[*xf.BorderID].Left.Color)
+	// Test get style with theme colors
+	style, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, []*int{&theme}, style.Fill.ColorTheme)
+	assert.Equal(t, []float64{0.4}, style.Fill.ColorTint)
+	assert.Equal(t, "9DC3E6", style.Fill.Color[0])
+	assert.Equal(t, &theme, style.Border[0].ColorTheme)
+	assert.Equal(t, -0.25, style.Border[0].ColorTint)
+	// Test the theme colors will be resolved as RGB colors on getting all styles
+	styles, err := f.GetAllStyles()
+	assert.NoError(t, err)
+	assert.Nil(t, styles[len(styles)-1].Fill.ColorTheme)
+	assert.Nil(t, styles[len(styles)-1].Border[0].ColorTheme)
+	// Test create gradient fill with theme color and RGB color
+	styleID, err = f.NewStyle(&Style{
+		Fill: Fill{Type: "gradient", Color: []string{"", "FF0000"}, ColorTheme: []*int{&theme}, Shading: 2},
+	})
+	assert.NoError(t, err)
+	gradient := s.Fills.Fill[*s.CellXfs.Xf[styleID].FillID].GradientFill
+	assert.Equal(t, xlsxColor{Theme: &theme}, gradient.Stop[0].Color)
+	assert.Equal(t, xlsxColor{RGB: "FFFF0000"}, gradient.Stop[1].Color)
+	assert.Equal(t, xlsxColor{Theme: &theme}, gradient.Stop[2].Color)
+	assert.Equal(t, xlsxColor{}, styleFillVariants[2].Stop[0].Color)
+	style, err = f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, []*int{&theme, nil, &theme}, style.Fill.ColorTheme)
+	assert.Equal(t, []float64{0, 0, 0}, style.Fill.ColorTint)
+	// Test create conditional format with theme color
+	format, err := f.NewConditionalStyle(&Style{
+		Fill: Fill{Type: "pattern", Pattern: 1, ColorTheme: []*int{&theme}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, xlsxColor{Theme: &theme}, *s.Dxfs.Dxfs[format].Fill.PatternFill.BgColor)
+}
+
 func TestGetStyle(t *testing.T) {
 	f := NewFile()
 	expected := &Style{
@@ -618,6 +899,38 @@ func TestGetStyle(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expected.NumFmt, style.NumFmt)
 
+	// Test get style with the built-in number format
+	numFmtStyleID, err := f.NewStyle(&Style{NumFmt: 14, Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	style, err = f.GetStyle(numFmtStyleID)
+	assert.NoError(t, err)
+	assert.Equal(t, 14, style.NumFmt)
+	assert.Nil(t, style.CustomNumFmt)
+	// Test modify one attribute of an existing style
+	style.Font.Italic = true
+	newStyleID, err := f.NewStyle(style)
+	assert.NoError(t, err)
+	assert.NotEqual(t, numFmtStyleID, newStyleID)
+	style, err = f.GetStyle(newStyleID)
+	assert.NoError(t, err)
+	assert.Equal(t, 14, style.NumFmt)
+	assert.Equal(t, &Font{Bold: true, Italic: true, Family: "Calibri", Size: 11}, style.Font)
+	// Test get style with the built-in number format defined in the style sheet
+	f.Styles.NumFmts = &xlsxNumFmts{NumFmt: []*xlsxNumFmt{
+		{NumFmtID: 14, FormatCode: "MM-DD-YY"},
+		{NumFmtID: 15, FormatCode: "yyyy/m/d"},
+	}}
+	style, err = f.GetStyle(numFmtStyleID)
+	assert.NoError(t, err)
+	assert.Equal(t, 14, style.NumFmt)
+	assert.Nil(t, style.CustomNumFmt)
+	f.Styles.CellXfs.Xf[numFmtStyleID].NumFmtID = intPtr(15)
+	style, err = f.GetStyle(numFmtStyleID)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, style.NumFmt)
+	assert.Equal(t, "yyyy/m/d", *style.CustomNumFmt)
+	f.Styles.NumFmts = nil
+
 	// Test get style with custom color index
 	f.Styles.Colors = &xlsxStyleColors{
 		IndexedColors: &xlsxIndexedColors{
@@ -643,3 +956,52 @@ func TestGetStyle(t *testing.T) {
 	assert.Nil(t, style)
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestGetAllStyles(t *testing.T) {
+	f := NewFile()
+	style := &Style{
+		Font: &Font{Bold: true, Family: "Arial", Size: 12, ColorTheme: intPtr(4)},
+		Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"FF0000"}},
+	}
+	_, err := f.NewStyle(style)
+	assert.NoError(t, err)
+	// Test get all styles with the identical cell formats
+	customNumFmt := "0.00%"
+	for i := 0; i < 2; i++ {
+		_, err = f.NewStyle(&Style{CustomNumFmt: &customNumFmt})
+		assert.NoError(t, err)
+		f.Styles.CellXfs.Xf = append(f.Styles.CellXfs.Xf, f.Styles.CellXfs.Xf[len(f.Styles.CellXfs.Xf)-1])
+	}
+	styles, err := f.GetAllStyles()
+	assert.NoError(t, err)
+	assert.Len(t, styles, 3)
+	assert.Equal(t, &Font{Family: "Calibri", Size: 11, Color: "000000"}, styles[0].Font)
+	assert.Equal(t, &Font{Bold: true, Family: "Arial", Size: 12, Color: "5B9BD5"}, styles[1].Font)
+	assert.Equal(t, style.Fill, styles[1].Fill)
+	assert.Equal(t, customNumFmt, *styles[2].CustomNumFmt)
+
+	// Test copy all styles into another workbook
+	f2 := NewFile()
+	for _, style := range styles {
+		_, err = f2.NewStyle(style)
+		assert.NoError(t, err)
+	}
+	copied, err := f2.GetAllStyles()
+	assert.NoError(t, err)
+	assert.Equal(t, styles, copied)
+	assert.NoError(t, f.Close())
+	assert.NoError(t, f2.Close())
+
+	// Test get all styles without cell formats
+	f = NewFile()
+	f.Styles.CellXfs = nil
+	styles, err = f.GetAllStyles()
+	assert.NoError(t, err)
+	assert.Empty(t, styles)
+	// Test get all styles with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	styles, err = f.GetAllStyles()
+	assert.Nil(t, styles)
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
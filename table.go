@@ -16,9 +16,12 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
+	"math"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 	"unicode/utf8"
 )
@@ -28,6 +31,16 @@ var (
 	conditionFormat  = regexp.MustCompile(`(or|\|\|)`)
 	blankFormat      = regexp.MustCompile("blanks|nonblanks")
 	matchFormat      = regexp.MustCompile("[*?]")
+	// autoFilterDateGroupings defined the supported date time grouping of the
+	// date group filter criteria.
+	autoFilterDateGroupings = []string{"year", "month", "day", "hour", "minute", "second"}
+	// tableTotalsRowFunctions defined the supported totals row functions of
+	// the table column, and the function number of the SUBTOTAL function for
+	// each of them.
+	tableTotalsRowFunctions = map[string]int{
+		"none": 0, "average": 101, "countNums": 102, "count": 103, "max": 104,
+		"min": 105, "stdDev": 107, "sum": 109, "var": 110,
+	}
 )
 
 // parseTableOptions provides a function to parse the format settings of the
@@ -43,6 +56,11 @@ func parseTableOptions(opts *Table) (*Table, error) {
 	if err = checkDefinedName(opts.Name); err != nil {
 		return opts, err
 	}
+	for _, column := range opts.Columns {
+		if _, ok := tableTotalsRowFunctions[column.TotalsRowFunction]; !ok && column.TotalsRowFunction != "" {
+			return opts, newUnsupportedTotalsRowFunctionError(column.TotalsRowFunction)
+		}
+	}
 	return opts, err
 }
 
@@ -65,6 +83,20 @@ func parseTableOptions(opts *Table) (*Table, error) {
 //	    ShowColumnStripes: true,
 //	})
 //
+// Create a table of A1:C5 on Sheet1 with a totals row, which sums the values
+// of the "Sales" column and shows the label "Total" in the "Region" column,
+// the totals row will be added in row 6:
+//
+//	err := f.AddTable("Sheet1", &excelize.Table{
+//	    Range:         "A1:C5",
+//	    Name:          "Report",
+//	    ShowTotalsRow: true,
+//	    Columns: []excelize.TableColumn{
+//	        {Name: "Region", TotalsRowLabel: "Total"},
+//	        {Name: "Sales", TotalsRowFunction: "sum"},
+//	    },
+//	})
+//
 // Note that the table must be at least two lines including the header. The
 // header cells must contain strings and must be unique, and must set the
 // header row data of the table before calling the AddTable function. Multiple
@@ -79,26 +111,31 @@ func parseTableOptions(opts *Table) (*Table, error) {
 //	TableStyleLight1 - TableStyleLight21
 //	TableStyleMedium1 - TableStyleMedium28
 //	TableStyleDark1 - TableStyleDark11
+//
+// ShowTotalsRow: Specifies if add a totals row below the range of the table,
+// the range of the table will be extended by one row. An error will be
+// returned if the row below the range of the table is not empty.
+//
+// Columns: The totals row settings of the table columns by the header name of
+// the column, the TotalsRowLabel specifies the text in the totals row of the
+// column, and the TotalsRowFunction specifies the aggregation function in the
+// totals row of the column:
+//
+//	none
+//	average
+//	count
+//	countNums
+//	max
+//	min
+//	stdDev
+//	sum
+//	var
 func (f *File) AddTable(sheet string, table *Table) error {
 	options, err := parseTableOptions(table)
 	if err != nil {
 		return err
 	}
-	var exist bool
-	f.Pkg.Range(func(k, v interface{}) bool {
-		if strings.Contains(k.(string), "xl/tables/table") {
-			var t xlsxTable
-			if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(v.([]byte)))).
-				Decode(&t); err != nil && err != io.EOF {
-				return true
-			}
-			if exist = t.Name == options.Name; exist {
-				return false
-			}
-		}
-		return true
-	})
-	if exist {
+	if f.existsTableName(options.Name, "") {
 		return ErrExistsTableName
 	}
 	// Coordinate conversion, convert C1:B3 to 2,0,1,2.
@@ -108,6 +145,11 @@ func (f *File) AddTable(sheet string, table *Table) error {
 	}
 	// Correct table reference range, such correct C1:B3 to B1:C3.
 	_ = sortCoordinates(coordinates)
+	if options.ShowTotalsRow {
+		if err = f.checkTableTotalsRow(sheet, coordinates); err != nil {
+			return err
+		}
+	}
 	tableID := f.countTables() + 1
 	sheetRelationshipsTableXML := "../tables/table" + strconv.Itoa(tableID) + ".xml"
 	tableXML := strings.ReplaceAll(sheetRelationshipsTableXML, "..", "xl")
@@ -126,7 +168,8 @@ func (f *File) AddTable(sheet string, table *Table) error {
 }
 
 // GetTables provides the method to get all tables in a worksheet by given
-// worksheet name.
+// worksheet name. The range of the table includes the totals row if it
+// exists.
 func (f *File) GetTables(sheet string) ([]Table, error) {
 	var tables []Table
 	ws, err := f.workSheetReader(sheet)
@@ -163,12 +206,139 @@ func (f *File) GetTables(sheet string) ([]Table, error) {
 				table.ShowLastColumn = t.TableStyleInfo.ShowLastColumn
 				table.ShowRowStripes = &t.TableStyleInfo.ShowRowStripes
 			}
+			if t.HeaderRowCount != nil && *t.HeaderRowCount == 0 {
+				table.ShowHeaderRow = boolPtr(false)
+			}
+			table.ShowTotalsRow = t.TotalsRowCount > 0
+			if t.TableColumns != nil {
+				for _, column := range t.TableColumns.TableColumn {
+					table.Columns = append(table.Columns, TableColumn{
+						Name:              column.Name,
+						TotalsRowFunction: column.TotalsRowFunction,
+						TotalsRowLabel:    column.TotalsRowLabel,
+					})
+				}
+			}
 			tables = append(tables, table)
 		}
 	}
 	return tables, err
 }
 
+// SetTable provides the method to update the table by given worksheet name,
+// table name and format set, which could be used to resize, rename, change
+// style of the table, or toggle the totals row of the table. The table will
+// be regenerated with the given settings as the AddTable function, and the
+// settings of the existing table columns will be kept. The current range,
+// name, style name and header row visibility of the table will be kept if the
+// Range, Name, StyleName or ShowHeaderRow is empty. The Range
+// specifies the new range of the table, which includes the header row and
+// excludes the totals row as the AddTable function, note that the range of
+// the table returned by the GetTables function includes the totals row if it
+// exists.
+// The Columns specifies all the totals row settings of the table columns when
+// ShowTotalsRow is enabled. For example, extend the table named "Report" on
+// Sheet1 to A1:C10 after appending the rows, and sum the values of the
+// "Sales" column in the totals row:
+//
+//	err := f.SetTable("Sheet1", "Report", &excelize.Table{
+//	    Range:         "A1:C10",
+//	    StyleName:     "TableStyleMedium2",
+//	    ShowTotalsRow: true,
+//	    Columns: []excelize.TableColumn{
+//	        {Name: "Region", TotalsRowLabel: "Total"},
+//	        {Name: "Sales", TotalsRowFunction: "sum"},
+//	    },
+//	})
+//
+// Note that the formulas which reference the table by the old name will not
+// be updated when renaming the table.
+func (f *File) SetTable(sheet, name string, table *Table) error {
+	if table == nil {
+		return ErrParameterRequired
+	}
+	options, err := parseTableOptions(table)
+	if err != nil {
+		return err
+	}
+	tables, err := f.GetTables(sheet)
+	if err != nil {
+		return err
+	}
+	for _, tbl := range tables {
+		if tbl.Name != name {
+			continue
+		}
+		opts := *options
+		if opts.Name == "" {
+			opts.Name = tbl.Name
+		}
+		if opts.StyleName == "" {
+			opts.StyleName = tbl.StyleName
+		}
+		if opts.ShowHeaderRow == nil {
+			opts.ShowHeaderRow = tbl.ShowHeaderRow
+		}
+		if opts.Name != tbl.Name && f.existsTableName(opts.Name, tbl.tableXML) {
+			return ErrExistsTableName
+		}
+		current, err := rangeRefToCoordinates(tbl.Range)
+		if err != nil {
+			return err
+		}
+		_ = sortCoordinates(current)
+		if tbl.ShowTotalsRow {
+			current[3]--
+		}
+		if tbl.ShowHeaderRow != nil && !*tbl.ShowHeaderRow {
+			current[1]--
+		}
+		coordinates := current
+		if opts.Range != "" {
+			if coordinates, err = rangeRefToCoordinates(opts.Range); err != nil {
+				return err
+			}
+			_ = sortCoordinates(coordinates)
+		}
+		if opts.ShowTotalsRow && (!tbl.ShowTotalsRow || current[3] != coordinates[3]) {
+			if err = f.checkTableTotalsRow(sheet, coordinates); err != nil {
+				return err
+			}
+		}
+		if tbl.ShowTotalsRow && (current[3]+1 < coordinates[1] || current[3]+1 > coordinates[3]) {
+			for col := current[0]; col <= current[2]; col++ {
+				cell, _ := CoordinatesToCellName(col, current[3]+1)
+				if err = f.SetCellValue(sheet, cell, nil); err != nil {
+					return err
+				}
+			}
+		}
+		return f.addTable(sheet, tbl.tableXML, coordinates[0], coordinates[1], coordinates[2], coordinates[3], tbl.tID, &opts)
+	}
+	return newNoExistTableError(name)
+}
+
+// existsTableName provides a function to check if the table name has been
+// used by the tables in the workbook, except the table by given table part
+// path.
+func (f *File) existsTableName(name, tableXML string) bool {
+	var exist bool
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.Contains(k.(string), "xl/tables/table") && k.(string) != tableXML {
+			var t xlsxTable
+			if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(v.([]byte)))).
+				Decode(&t); err != nil && err != io.EOF {
+				return true
+			}
+			if exist = t.Name == name; exist {
+				return false
+			}
+		}
+		return true
+	})
+	return exist
+}
+
 // DeleteTable provides the method to delete table by given table name.
 func (f *File) DeleteTable(name string) error {
 	if err := checkDefinedName(name); err != nil {
@@ -308,6 +478,76 @@ func (f *File) setTableColumns(sheet string, showHeaderRow bool, x1, y1, x2 int,
 	return nil
 }
 
+// setTableTotalsRow provides a function to set the totals row function and
+// label of the table columns, and set the cells value in totals row for the
+// table by given worksheet name, the column and row number of the first cell
+// in the totals row.
+func (f *File) setTableTotalsRow(sheet string, col, row int, tbl *xlsxTable, columns []TableColumn) error {
+	escape := strings.NewReplacer("'", "''", "[", "'[", "]", "']", "#", "'#")
+	for _, column := range columns {
+		idx := -1
+		for i, tableColumn := range tbl.TableColumns.TableColumn {
+			if tableColumn.Name == column.Name {
+				idx = i
+				break
+			}
+		}
+		if idx == -1 {
+			return newNoExistTableColumnError(column.Name)
+		}
+		tableColumn := tbl.TableColumns.TableColumn[idx]
+		tableColumn.TotalsRowLabel = column.TotalsRowLabel
+		if column.TotalsRowFunction != "none" {
+			tableColumn.TotalsRowFunction = column.TotalsRowFunction
+		}
+		cell, err := CoordinatesToCellName(col+idx, row)
+		if err != nil {
+			return err
+		}
+		if tableColumn.TotalsRowFunction != "" {
+			formula := fmt.Sprintf("SUBTOTAL(%d,%s[%s])", tableTotalsRowFunctions[tableColumn.TotalsRowFunction],
+				tbl.Name, escape.Replace(column.Name))
+			if err = f.SetCellFormula(sheet, cell, formula); err != nil {
+				return err
+			}
+			continue
+		}
+		if tableColumn.TotalsRowLabel != "" {
+			if err = f.SetCellStr(sheet, cell, tableColumn.TotalsRowLabel); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkTableTotalsRow provides a function to check if the row below the
+// table by given worksheet name and range coordinates of the table is empty,
+// which will be used as the totals row of the table.
+func (f *File) checkTableTotalsRow(sheet string, coordinates []int) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	row := coordinates[3] + 1
+	if coordinates[1] == coordinates[3] {
+		row++
+	}
+	if row > len(ws.SheetData.Row) {
+		return err
+	}
+	for _, c := range ws.SheetData.Row[row-1].C {
+		col, _, err := CellNameToCoordinates(c.R)
+		if err != nil {
+			return err
+		}
+		if col >= coordinates[0] && col <= coordinates[2] && (c.V != "" || c.F != nil || c.IS != nil) {
+			return newNotEmptyTotalsRowError(c.R)
+		}
+	}
+	return err
+}
+
 // checkDefinedName check whether there are illegal characters in the defined
 // name or table name. Verify that the name:
 // 1. Starts with a letter or underscore (_)
@@ -368,11 +608,32 @@ func (f *File) addTable(sheet, tableXML string, x1, y1, x2, y2, i int, opts *Tab
 			ShowColumnStripes: opts.ShowColumnStripes,
 		},
 	}
+	// Keep the settings of the table columns when updating the existing table
+	if content, ok := f.Pkg.Load(tableXML); ok {
+		var tbl xlsxTable
+		if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+			Decode(&tbl); err != nil && err != io.EOF {
+			return err
+		}
+		t.TableColumns = tbl.TableColumns
+	}
 	_ = f.setTableColumns(sheet, !hideHeaderRow, x1, y1, x2, &t)
+	for _, column := range t.TableColumns.TableColumn {
+		column.TotalsRowFunction, column.TotalsRowLabel = "", ""
+	}
 	if hideHeaderRow {
 		t.AutoFilter = nil
 		t.HeaderRowCount = intPtr(0)
 	}
+	if opts.ShowTotalsRow {
+		if t.Ref, err = f.coordinatesToRangeRef([]int{x1, y1, x2, y2 + 1}); err != nil {
+			return err
+		}
+		t.TotalsRowCount = 1
+		if err = f.setTableTotalsRow(sheet, x1, y2+1, &t, opts.Columns); err != nil {
+			return err
+		}
+	}
 	table, err := xml.Marshal(t)
 	f.saveFileList(tableXML, table)
 	return err
@@ -394,10 +655,11 @@ func (f *File) addTable(sheet, tableXML string, x1, y1, x2, y2, i int, opts *Tab
 // Column defines the filter columns in an auto filter range based on simple
 // criteria
 //
-// It isn't sufficient to just specify the filter condition. You must also
-// hide any rows that don't match the filter condition. Rows are hidden using
-// the SetRowVisible function. Excelize can't filter rows automatically since
-// this isn't part of the file format.
+// This function only stores the filter criteria, the rows in the auto filter
+// range will not be hidden. Use the ApplyAutoFilter function to hide the rows
+// which don't match the filter criteria after setting the cell values. If
+// there are more than one filter criteria settings for the same column, the
+// last one will be used.
 //
 // Setting a filter criteria for a column:
 //
@@ -449,6 +711,45 @@ func (f *File) addTable(sheet, tableXML string, x1, y1, x2, y2, i int, opts *Tab
 //	x     < 2000
 //	col   < 2000
 //	Price < 2000
+//
+// Besides the expression, the following types of filter criteria could be
+// set for the column, only one type of them could be set for each column:
+//
+// Values and Blanks specifies the cell values to be shown in the filter
+// column, and whether to show the blank cells. DateGroups specifies the
+// dates or times to be shown by the date time grouping, the optional values
+// of the Grouping are: year, month, day, hour, minute and second. For
+// example, show the cells with value "East" or "West" in column B, and show
+// the dates in March 2023 in column C:
+//
+//	err := f.AutoFilter("Sheet1", "A1:D10", []excelize.AutoFilterOptions{
+//	    {Column: "B", Values: []string{"East", "West"}},
+//	    {Column: "C", DateGroups: []excelize.AutoFilterDateGroup{
+//	        {Grouping: "month", Year: 2023, Month: 3},
+//	    }},
+//	})
+//
+// Top10 specifies to show the top or bottom N items (percent or number of
+// items) of the column. For example, show the bottom 10 percent of values in
+// column D:
+//
+//	err := f.AutoFilter("Sheet1", "A1:D10", []excelize.AutoFilterOptions{
+//	    {Column: "D", Top10: &excelize.AutoFilterTop10{Bottom: true, Percent: true, Value: 10}},
+//	})
+//
+// Color specifies to show the cells by fill color or font color, the color
+// is specified by the conditional format style. For example, show the cells
+// with red fill color in column A:
+//
+//	style, err := f.NewConditionalStyle(&excelize.Style{
+//	    Fill: excelize.Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 1},
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	err = f.AutoFilter("Sheet1", "A1:D10", []excelize.AutoFilterOptions{
+//	    {Column: "A", Color: &excelize.AutoFilterColor{Style: style}},
+//	})
 func (f *File) AutoFilter(sheet, rangeRef string, opts []AutoFilterOptions) error {
 	coordinates, err := rangeRefToCoordinates(rangeRef)
 	if err != nil {
@@ -496,6 +797,35 @@ func (f *File) AutoFilter(sheet, rangeRef string, opts []AutoFilterOptions) erro
 	return f.autoFilter(sheet, ref, columns, coordinates[0], opts)
 }
 
+// SetFilteredHeader provides a function to freeze the header row and set the
+// auto filter for the data range in one operation by given worksheet name and
+// range reference. The first row of the range will be used as the header row,
+// all rows above and including the header row will be frozen, and the range
+// must contain at least one data row below the header row. For example, freeze
+// the header row 2 and set auto filter for the range B2:E10 on Sheet1:
+//
+//	err := f.SetFilteredHeader("Sheet1", "B2:E10")
+func (f *File) SetFilteredHeader(sheet, rangeRef string) error {
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	if coordinates[1] == coordinates[3] {
+		return ErrFilteredHeaderRange
+	}
+	topLeftCell, _ := CoordinatesToCellName(1, coordinates[1]+1)
+	if err = f.SetPanes(sheet, &Panes{
+		Freeze:      true,
+		YSplit:      coordinates[1],
+		TopLeftCell: topLeftCell,
+		ActivePane:  "bottomLeft",
+	}); err != nil {
+		return err
+	}
+	return f.AutoFilter(sheet, rangeRef, nil)
+}
+
 // autoFilter provides a function to extract the tokens from the filter
 // expression. The tokens are mainly non-whitespace groups.
 func (f *File) autoFilter(sheet, ref string, columns, col int, opts []AutoFilterOptions) error {
@@ -503,16 +833,17 @@ func (f *File) autoFilter(sheet, ref string, columns, col int, opts []AutoFilter
 	if err != nil {
 		return err
 	}
-	if ws.SheetPr != nil {
-		ws.SheetPr.FilterMode = true
+	if ws.SheetPr == nil {
+		ws.SheetPr = new(xlsxSheetPr)
 	}
-	ws.SheetPr = &xlsxSheetPr{FilterMode: true}
+	ws.SheetPr.FilterMode = true
 	filter := &xlsxAutoFilter{
 		Ref: ref,
 	}
 	ws.AutoFilter = filter
+	filterColumns := map[int]*xlsxFilterColumn{}
 	for _, opt := range opts {
-		if opt.Column == "" || opt.Expression == "" {
+		if opt.Column == "" || countAutoFilterCriteria(opt) == 0 {
 			continue
 		}
 		fsCol, err := ColumnNameToNumber(opt.Column)
@@ -524,6 +855,69 @@ func (f *File) autoFilter(sheet, ref string, columns, col int, opts []AutoFilter
 			return newInvalidAutoFilterColumnError(opt.Column)
 		}
 		fc := &xlsxFilterColumn{ColID: offset}
+		if err = f.writeAutoFilterCriteria(fc, opt); err != nil {
+			return err
+		}
+		filterColumns[offset] = fc
+	}
+	for _, fc := range filterColumns {
+		filter.FilterColumn = append(filter.FilterColumn, fc)
+	}
+	sort.Slice(filter.FilterColumn, func(i, j int) bool {
+		return filter.FilterColumn[i].ColID < filter.FilterColumn[j].ColID
+	})
+	return err
+}
+
+// ApplyAutoFilter provides a function to evaluate the auto filter criteria of
+// the worksheet with the current cell values by given worksheet name. The rows
+// in the auto filter range which don't match the filter criteria of all filter
+// columns will be hidden, and the matched rows will be shown, so that the
+// workbook will be opened as filtered. Note that the rows in the auto filter
+// range which were hidden manually will be shown if they match the filter
+// criteria. For example, hide the rows which don't match the filter criteria
+// after setting the cell values and the auto filter on Sheet1:
+//
+//	err := f.AutoFilter("Sheet1", "A1:D4", []excelize.AutoFilterOptions{
+//	    {Column: "A", Expression: "x == East"},
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	err = f.ApplyAutoFilter("Sheet1")
+func (f *File) ApplyAutoFilter(sheet string) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.AutoFilter == nil {
+		return err
+	}
+	return f.applyAutoFilter(sheet, ws, ws.AutoFilter)
+}
+
+// countAutoFilterCriteria returns the number of filter criteria types in the
+// given auto filter options.
+func countAutoFilterCriteria(opt AutoFilterOptions) int {
+	var count int
+	for _, exist := range []bool{
+		opt.Expression != "",
+		len(opt.Values) > 0 || opt.Blanks || len(opt.DateGroups) > 0,
+		opt.Top10 != nil,
+		opt.Color != nil,
+	} {
+		if exist {
+			count++
+		}
+	}
+	return count
+}
+
+// writeAutoFilterCriteria provides a function to write the filter criteria of
+// the auto filter column by given auto filter options.
+func (f *File) writeAutoFilterCriteria(fc *xlsxFilterColumn, opt AutoFilterOptions) error {
+	if countAutoFilterCriteria(opt) > 1 {
+		return ErrAutoFilterCriteria
+	}
+	switch {
+	case opt.Expression != "":
 		token := expressionFormat.FindAllString(opt.Expression, -1)
 		if len(token) != 3 && len(token) != 7 {
 			return newInvalidAutoFilterExpError(opt.Expression)
@@ -533,15 +927,265 @@ func (f *File) autoFilter(sheet, ref string, columns, col int, opts []AutoFilter
 			return err
 		}
 		f.writeAutoFilter(fc, expressions, tokens)
-		filter.FilterColumn = append(filter.FilterColumn, fc)
+	case opt.Top10 != nil:
+		if opt.Top10.Value <= 0 || (opt.Top10.Percent && opt.Top10.Value > 100) {
+			return ErrParameterInvalid
+		}
+		fc.Top10 = &xlsxTop10{Top: !opt.Top10.Bottom, Percent: opt.Top10.Percent, Val: opt.Top10.Value}
+	case opt.Color != nil:
+		if _, err := f.GetConditionalStyle(opt.Color.Style); err != nil {
+			return err
+		}
+		fc.ColorFilter = &xlsxColorFilter{CellColor: !opt.Color.FontColor, DxfID: opt.Color.Style}
+	default:
+		fc.Filters = &xlsxFilters{Blank: opt.Blanks}
+		for _, val := range opt.Values {
+			fc.Filters.Filter = append(fc.Filters.Filter, &xlsxFilter{Val: val})
+		}
+		for _, group := range opt.DateGroups {
+			grouping := strings.ToLower(group.Grouping)
+			if inStrSlice(autoFilterDateGroupings, grouping, true) == -1 {
+				return ErrParameterInvalid
+			}
+			fc.Filters.DateGroupItem = append(fc.Filters.DateGroupItem, &xlsxDateGroupItem{
+				DateTimeGrouping: grouping, Year: group.Year, Month: group.Month, Day: group.Day,
+				Hour: group.Hour, Minute: group.Minute, Second: group.Second,
+			})
+		}
 	}
-	ws.AutoFilter = filter
 	return nil
 }
 
+// autoFilterCell directly maps the cell value of the data row in the auto
+// filter range.
+type autoFilterCell struct {
+	cell, raw, val string
+	num            float64
+	isNum          bool
+}
+
+// applyAutoFilter provides a function to hide the rows in the auto filter
+// range which don't match the filter criteria of all filter columns, and show
+// the matched rows, so that the workbook will be opened as filtered.
+func (f *File) applyAutoFilter(sheet string, ws *xlsxWorksheet, filter *xlsxAutoFilter) error {
+	coordinates, err := rangeRefToCoordinates(filter.Ref)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	if coordinates[1] == coordinates[3] {
+		return err
+	}
+	hidden := make([]bool, coordinates[3]-coordinates[1])
+	for _, fc := range filter.FilterColumn {
+		cells := make([]autoFilterCell, len(hidden))
+		for i := range cells {
+			if cells[i].cell, err = CoordinatesToCellName(coordinates[0]+fc.ColID, coordinates[1]+i+1); err != nil {
+				return err
+			}
+			if cells[i].raw, err = f.GetCellValue(sheet, cells[i].cell, Options{RawCellValue: true}); err != nil {
+				return err
+			}
+			if cells[i].val, err = f.GetCellValue(sheet, cells[i].cell); err != nil {
+				return err
+			}
+			num, err := strconv.ParseFloat(cells[i].raw, 64)
+			cells[i].num, cells[i].isNum = num, err == nil
+		}
+		match, err := f.autoFilterMatcher(sheet, fc, cells)
+		if err != nil {
+			return err
+		}
+		for i := range cells {
+			hidden[i] = hidden[i] || !match(cells[i])
+		}
+	}
+	for i, hide := range hidden {
+		row := coordinates[1] + i + 1
+		if hide {
+			ws.prepareSheetXML(0, row)
+		}
+		if row <= len(ws.SheetData.Row) {
+			ws.SheetData.Row[row-1].Hidden = hide
+		}
+	}
+	return err
+}
+
+// autoFilterMatcher returns a function which reports whether the cell value
+// matches the filter criteria of the given filter column.
+func (f *File) autoFilterMatcher(sheet string, fc *xlsxFilterColumn, cells []autoFilterCell) (func(autoFilterCell) bool, error) {
+	switch {
+	case fc.CustomFilters != nil:
+		return func(c autoFilterCell) bool {
+			for _, cf := range fc.CustomFilters.CustomFilter {
+				if matched := matchCustomFilter(cf, c); matched != fc.CustomFilters.And {
+					return matched
+				}
+			}
+			return fc.CustomFilters.And
+		}, nil
+	case fc.Top10 != nil:
+		return matchTop10Filter(fc.Top10, cells), nil
+	case fc.ColorFilter != nil:
+		return f.matchColorFilter(sheet, fc.ColorFilter)
+	case fc.Filters != nil:
+		date1904, err := f.getDate1904()
+		return func(c autoFilterCell) bool {
+			if strings.TrimSpace(c.val) == "" {
+				return fc.Filters.Blank
+			}
+			for _, filter := range fc.Filters.Filter {
+				if strings.EqualFold(filter.Val, c.val) {
+					return true
+				}
+			}
+			if !c.isNum {
+				return false
+			}
+			for _, group := range fc.Filters.DateGroupItem {
+				if matchDateGroupItem(group, timeFromExcelTime(c.num, date1904)) {
+					return true
+				}
+			}
+			return false
+		}, err
+	}
+	return func(autoFilterCell) bool { return true }, nil
+}
+
+// matchCustomFilter reports whether the cell value matches the given custom
+// filter criteria.
+func matchCustomFilter(cf *xlsxCustomFilter, c autoFilterCell) bool {
+	if cf.Val == " " { // Blanks or non-blanks
+		return (strings.TrimSpace(c.val) == "") == (cf.Operator == "equal")
+	}
+	num, err := strconv.ParseFloat(cf.Val, 64)
+	isNum := c.isNum && err == nil
+	if !isNum && (cf.Operator == "equal" || cf.Operator == "notEqual") {
+		return matchWildcardToRegExp(cf.Val).MatchString(c.val) == (cf.Operator == "equal")
+	}
+	cmp := strings.Compare(strings.ToLower(c.val), strings.ToLower(cf.Val))
+	if isNum {
+		switch {
+		case c.num < num:
+			cmp = -1
+		case c.num > num:
+			cmp = 1
+		default:
+			cmp = 0
+		}
+	}
+	switch cf.Operator {
+	case "lessThan":
+		return cmp < 0
+	case "lessThanOrEqual":
+		return cmp <= 0
+	case "greaterThan":
+		return cmp > 0
+	case "greaterThanOrEqual":
+		return cmp >= 0
+	case "notEqual":
+		return cmp != 0
+	}
+	return cmp == 0
+}
+
+// matchTop10Filter returns a function which reports whether the cell value is
+// in the top or bottom N items of the column, the filter value of the top 10
+// filter criteria will be updated with the boundary value.
+func matchTop10Filter(top10 *xlsxTop10, cells []autoFilterCell) func(autoFilterCell) bool {
+	var nums []float64
+	for _, c := range cells {
+		if c.isNum {
+			nums = append(nums, c.num)
+		}
+	}
+	if len(nums) == 0 {
+		return func(autoFilterCell) bool { return false }
+	}
+	sort.Float64s(nums)
+	if top10.Top {
+		sort.Sort(sort.Reverse(sort.Float64Slice(nums)))
+	}
+	n := int(top10.Val)
+	if top10.Percent {
+		n = int(math.Ceil(float64(len(nums)) * top10.Val / 100))
+	}
+	if n < 1 {
+		n = 1
+	}
+	if n > len(nums) {
+		n = len(nums)
+	}
+	top10.FilterVal = nums[n-1]
+	return func(c autoFilterCell) bool {
+		if !c.isNum {
+			return false
+		}
+		if top10.Top {
+			return c.num >= top10.FilterVal
+		}
+		return c.num <= top10.FilterVal
+	}
+}
+
+// matchColorFilter returns a function which reports whether the fill color or
+// font color of the cell matches the given color filter criteria.
+func (f *File) matchColorFilter(sheet string, cf *xlsxColorFilter) (func(autoFilterCell) bool, error) {
+	getColor := func(style *Style) string {
+		if !cf.CellColor {
+			if style.Font == nil {
+				return ""
+			}
+			return strings.ToUpper(style.Font.Color)
+		}
+		if len(style.Fill.Color) == 0 {
+			return ""
+		}
+		return strings.ToUpper(style.Fill.Color[0])
+	}
+	dxf, err := f.GetConditionalStyle(cf.DxfID)
+	if err != nil {
+		return nil, err
+	}
+	color, colors := getColor(dxf), map[int]string{}
+	return func(c autoFilterCell) bool {
+		styleID, _ := f.GetCellStyle(sheet, c.cell)
+		if _, ok := colors[styleID]; !ok {
+			if style, err := f.GetStyle(styleID); err == nil {
+				colors[styleID] = getColor(style)
+			}
+		}
+		return colors[styleID] == color
+	}, err
+}
+
+// matchDateGroupItem reports whether the date time matches the given date
+// group filter criteria.
+func matchDateGroupItem(group *xlsxDateGroupItem, t time.Time) bool {
+	for i, val := range [][]int{
+		{group.Year, t.Year()}, {group.Month, int(t.Month())}, {group.Day, t.Day()},
+		{group.Hour, t.Hour()}, {group.Minute, t.Minute()}, {group.Second, t.Second()},
+	} {
+		if val[0] != val[1] {
+			return false
+		}
+		if autoFilterDateGroupings[i] == group.DateTimeGrouping {
+			break
+		}
+	}
+	return true
+}
+
 // writeAutoFilter provides a function to check for single or double custom
 // filters as default filters and handle them accordingly.
 func (f *File) writeAutoFilter(fc *xlsxFilterColumn, exp []int, tokens []string) {
+	if len(exp) == 1 && exp[0] == 2 && tokens[0] == "blanks" {
+		// Single equality with blanks.
+		fc.Filters = &xlsxFilters{Blank: true}
+		return
+	}
 	if len(exp) == 1 && exp[0] == 2 {
 		// Single equality.
 		var filters []*xlsxFilter
@@ -681,3 +1325,267 @@ func (f *File) parseFilterTokens(expression string, tokens []string) ([]int, str
 	}
 	return []int{operator}, token, nil
 }
+
+// Sort value ranks of the cell values, the values will be sorted by the rank
+// first, and the blank cells will always be sorted at the end.
+const (
+	sortRankCustom = iota
+	sortRankNumber
+	sortRankText
+	sortRankBool
+	sortRankError
+	sortRankBlank
+)
+
+// sortValue directly maps the sort key value of the cell.
+type sortValue struct {
+	rank, custom int
+	num          float64
+	str          string
+}
+
+// sortRow directly maps the row to be sorted in the sort range.
+type sortRow struct {
+	row   int
+	cells []xlsxC
+	keys  []sortValue
+}
+
+// SortRange provides a function to sort the rows in place in a range of the
+// worksheet by given worksheet name, range reference or table name and sort
+// keys. The rows will be sorted by the sort keys in order, the numbers
+// (including dates) will be sorted before the texts, logical values and
+// errors, and the blank cells will always be sorted at the end. The sorting
+// is stable and the case of texts will be ignored. The cell values, styles
+// and formulas will be moved with the rows, and the relative references in
+// the formulas will be adjusted as the row moved. The header row and totals
+// row will be kept if the table name was given, the range shouldn't contain
+// the header row and merged cells. For example, sort the rows in the range
+// A2:D10 on Sheet1 by column B in descending order and then by column C in
+// the custom order:
+//
+//	err := f.SortRange("Sheet1", "A2:D10", []excelize.SortKey{
+//	    {Column: "B", Descending: true},
+//	    {Column: "C", CustomList: []string{"Low", "Medium", "High"}},
+//	})
+//
+// Sort the data rows of the table named "Table1" on Sheet1 by column A:
+//
+//	err := f.SortRange("Sheet1", "Table1", []excelize.SortKey{{Column: "A"}})
+func (f *File) SortRange(sheet, rangeRef string, keys []SortKey) error {
+	if len(keys) == 0 {
+		return ErrParameterRequired
+	}
+	coordinates, err := f.getSortRangeCoordinates(sheet, rangeRef)
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if ws.MergeCells != nil {
+		for _, mergeCell := range ws.MergeCells.Cells {
+			rect, err := rangeRefToCoordinates(mergeCell.Ref)
+			if err != nil {
+				return err
+			}
+			_ = sortCoordinates(rect)
+			if isOverlap(rect, coordinates) {
+				return ErrSortRangeMergeCell
+			}
+		}
+	}
+	x1, y1, x2, y2 := coordinates[0], coordinates[1], coordinates[2], coordinates[3]
+	keyCols := make([]int, len(keys))
+	for i, key := range keys {
+		if keyCols[i], err = ColumnNameToNumber(key.Column); err != nil {
+			return err
+		}
+		if keyCols[i] < x1 || keyCols[i] > x2 {
+			return newInvalidSortKeyColumnError(key.Column)
+		}
+	}
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return err
+	}
+	ws.checkSheet()
+	if err = ws.checkRow(); err != nil {
+		return err
+	}
+	f.detachSharedFormulas(ws, coordinates)
+	sheetID, rows := f.getSheetID(sheet), make([]sortRow, y2-y1+1)
+	for i := range rows {
+		row := y1 + i
+		ws.prepareSheetXML(x2, row)
+		rows[i] = sortRow{row: row, cells: make([]xlsxC, x2-x1+1), keys: make([]sortValue, len(keys))}
+		copy(rows[i].cells, ws.SheetData.Row[row-1].C[x1-1:x2])
+		for _, c := range rows[i].cells {
+			if c.F != nil {
+				if err = f.deleteCalcChain(sheetID, c.R); err != nil {
+					return err
+				}
+			}
+		}
+		for k, col := range keyCols {
+			if rows[i].keys[k], err = newSortValue(f, sst, rows[i].cells[col-x1], keys[k]); err != nil {
+				return err
+			}
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		for k, key := range keys {
+			if cmp := compareSortKeyValue(rows[i].keys[k], rows[j].keys[k], key.Descending); cmp != 0 {
+				return cmp < 0
+			}
+		}
+		return false
+	})
+	for i, r := range rows {
+		row := y1 + i
+		for j, c := range r.cells {
+			cell, _ := CoordinatesToCellName(x1+j, row)
+			if c.F != nil && r.row != row {
+				formula := *c.F
+				formula.Content = shiftSharedFormula(c.R, c.F.Content, cell)
+				if formula.Ref, err = offsetSqref(formula.Ref, 0, row-r.row); err != nil {
+					return err
+				}
+				c.F = &formula
+			}
+			c.R = cell
+			ws.SheetData.Row[row-1].C[x1+j-1] = c
+		}
+	}
+	return err
+}
+
+// getSortRangeCoordinates returns the coordinates of the sort range by given
+// worksheet name and range reference or table name. The header row and
+// totals row will be excluded from the range if the table name was given.
+func (f *File) getSortRangeCoordinates(sheet, rangeRef string) ([]int, error) {
+	if strings.Contains(rangeRef, ":") {
+		coordinates, err := rangeRefToCoordinates(rangeRef)
+		if err != nil {
+			return coordinates, err
+		}
+		_ = sortCoordinates(coordinates)
+		return coordinates, err
+	}
+	tables, err := f.GetTables(sheet)
+	if err != nil {
+		return nil, err
+	}
+	for _, table := range tables {
+		if !strings.EqualFold(table.Name, rangeRef) {
+			continue
+		}
+		coordinates, err := rangeRefToCoordinates(table.Range)
+		if err != nil {
+			return coordinates, err
+		}
+		_ = sortCoordinates(coordinates)
+		if table.ShowHeaderRow == nil || *table.ShowHeaderRow {
+			coordinates[1]++
+		}
+		if table.ShowTotalsRow {
+			coordinates[3]--
+		}
+		if coordinates[1] > coordinates[3] {
+			coordinates[3] = coordinates[1] - 1
+		}
+		return coordinates, err
+	}
+	return nil, newNoExistTableError(rangeRef)
+}
+
+// detachSharedFormulas converts the shared formulas which used by the cells
+// in the given range to the normal formulas, so that the cells could be moved
+// without breaking the shared formulas.
+func (f *File) detachSharedFormulas(ws *xlsxWorksheet, coordinates []int) {
+	sis := map[int]bool{}
+	for row := coordinates[1]; row <= coordinates[3] && row <= len(ws.SheetData.Row); row++ {
+		for _, c := range ws.SheetData.Row[row-1].C {
+			col, _, err := CellNameToCoordinates(c.R)
+			if err == nil && col >= coordinates[0] && col <= coordinates[2] &&
+				c.F != nil && c.F.T == STCellFormulaTypeShared && c.F.Si != nil {
+				sis[*c.F.Si] = true
+			}
+		}
+	}
+	if len(sis) == 0 {
+		return
+	}
+	formulas := map[string]string{}
+	for _, r := range ws.SheetData.Row {
+		for _, c := range r.C {
+			if c.F != nil && c.F.T == STCellFormulaTypeShared && c.F.Si != nil && sis[*c.F.Si] {
+				formulas[c.R] = getSharedFormula(ws, *c.F.Si, c.R)
+			}
+		}
+	}
+	for i, r := range ws.SheetData.Row {
+		for j, c := range r.C {
+			if formula, ok := formulas[c.R]; ok {
+				ws.SheetData.Row[i].C[j].F = &xlsxF{Content: formula}
+			}
+		}
+	}
+}
+
+// newSortValue returns the sort key value of the cell by given shared strings
+// table, cell and sort key.
+func newSortValue(f *File, sst *xlsxSST, c xlsxC, key SortKey) (sortValue, error) {
+	val, err := c.getValueFrom(f, sst, true)
+	if err != nil || (val == "" && c.T != "e") {
+		return sortValue{rank: sortRankBlank}, err
+	}
+	value := sortValue{rank: sortRankText, str: strings.ToLower(val)}
+	switch c.T {
+	case "b":
+		value.rank, value.num = sortRankBool, 0
+		if val == "1" || strings.EqualFold(val, "TRUE") {
+			value.num = 1
+		}
+	case "e":
+		value.rank = sortRankError
+	case "", "n":
+		if num, err := strconv.ParseFloat(val, 64); err == nil {
+			value.rank, value.num = sortRankNumber, num
+		}
+	}
+	if idx := inStrSlice(key.CustomList, val, false); idx != -1 {
+		value.rank, value.custom = sortRankCustom, idx
+	}
+	return value, err
+}
+
+// compareSortKeyValue compares two sort key values, returns a negative number if
+// the first value should be sorted before the second value, returns a positive
+// number if the first value should be sorted after the second value, and
+// returns zero if they are equal.
+func compareSortKeyValue(a, b sortValue, descending bool) int {
+	if a.rank == sortRankBlank || b.rank == sortRankBlank {
+		return a.rank - b.rank
+	}
+	var cmp int
+	switch {
+	case a.rank != b.rank:
+		cmp = a.rank - b.rank
+	case a.rank == sortRankCustom:
+		cmp = a.custom - b.custom
+	case a.rank == sortRankNumber || a.rank == sortRankBool:
+		if a.num < b.num {
+			cmp = -1
+		} else if a.num > b.num {
+			cmp = 1
+		}
+	default:
+		cmp = strings.Compare(a.str, b.str)
+	}
+	if descending {
+		return -cmp
+	}
+	return cmp
+}
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -87,6 +88,104 @@ func TestAddTable(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestAddTableTotalsRow(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{"Region", "Sales", "Units [pcs]", "Note"}, {"East", 10, 1}, {"West", 20, 3}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", idx+1), &row))
+	}
+	columns := []TableColumn{
+		{Name: "Region", TotalsRowLabel: "Total"},
+		{Name: "Sales", TotalsRowFunction: "sum"},
+		{Name: "Units [pcs]", TotalsRowFunction: "average"},
+		{Name: "Note", TotalsRowFunction: "none"},
+	}
+	assert.NoError(t, f.AddTable("Sheet1", &Table{
+		Range:         "A1:D3",
+		Name:          "Report",
+		StyleName:     "TableStyleMedium2",
+		ShowTotalsRow: true,
+		Columns:       columns,
+	}))
+	for cell, expected := range map[string]string{
+		"B4": "SUBTOTAL(109,Report[Sales])",
+		"C4": "SUBTOTAL(101,Report[Units '[pcs']])",
+		"D4": "",
+	} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	label, err := f.GetCellValue("Sheet1", "A4")
+	assert.NoError(t, err)
+	assert.Equal(t, "Total", label)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddTableTotalsRow.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestAddTableTotalsRow.xlsx"))
+	assert.NoError(t, err)
+	tables, err := f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, tables, 1)
+	assert.Equal(t, "A1:D4", tables[0].Range)
+	assert.True(t, tables[0].ShowTotalsRow)
+	columns[3].TotalsRowFunction = ""
+	assert.Equal(t, columns, tables[0].Columns)
+	// Test insert rows keeps the totals row of the table
+	assert.NoError(t, f.InsertRows("Sheet1", 3, 1))
+	tables, err = f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:D5", tables[0].Range)
+	assert.True(t, tables[0].ShowTotalsRow)
+	assert.NoError(t, f.Close())
+
+	// Test add table with unsupported totals row function
+	f = NewFile()
+	assert.Equal(t, newUnsupportedTotalsRowFunctionError("median"), f.AddTable("Sheet1", &Table{
+		Range: "A1:B3", ShowTotalsRow: true, Columns: []TableColumn{{Name: "Column1", TotalsRowFunction: "median"}},
+	}))
+	// Test add table with not exist table column name
+	assert.Equal(t, newNoExistTableColumnError("Sales"), f.AddTable("Sheet1", &Table{
+		Range: "A1:B3", ShowTotalsRow: true, Columns: []TableColumn{{Name: "Sales", TotalsRowFunction: "sum"}},
+	}))
+	// Test add table with the totals row exceeds the maximum row
+	assert.Equal(t, ErrMaxRows, f.AddTable("Sheet1", &Table{
+		Range: fmt.Sprintf("D%d:E%d", TotalRows-1, TotalRows), ShowTotalsRow: true,
+	}))
+	// Test add table with the non-empty totals row
+	assert.NoError(t, f.SetCellValue("Sheet1", "B4", 1))
+	assert.Equal(t, newNotEmptyTotalsRowError("B4"), f.AddTable("Sheet1", &Table{
+		Range: "A1:B3", ShowTotalsRow: true,
+	}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A3", "1+1"))
+	assert.Equal(t, newNotEmptyTotalsRowError("A3"), f.AddTable("Sheet1", &Table{
+		Range: "A1:B1", ShowTotalsRow: true,
+	}))
+	val, err := f.GetCellValue("Sheet1", "B4")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", val)
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "D1:E3", ShowTotalsRow: true}))
+	// Test add table with the totals row on not exists worksheet
+	assert.EqualError(t, f.AddTable("SheetN", &Table{Range: "A1:B3", ShowTotalsRow: true}), "sheet SheetN does not exist")
+	// Test add table with invalid cell reference in the totals row
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[3].C[0].R = "*"
+	assert.Equal(t, newCellNameToCoordinatesError("*", newInvalidCellNameError("*")), f.AddTable("Sheet1", &Table{
+		Range: "A1:B3", ShowTotalsRow: true,
+	}))
+	// Test add table with invalid sheet name in the totals row
+	assert.Equal(t, ErrSheetNameInvalid, f.setTableTotalsRow("Sheet:1", 1, 4, &xlsxTable{
+		TableColumns: &xlsxTableColumns{TableColumn: []*xlsxTableColumn{{Name: "A"}, {Name: "B"}}},
+	}, []TableColumn{{Name: "A", TotalsRowLabel: "Total"}}))
+	assert.Equal(t, ErrSheetNameInvalid, f.setTableTotalsRow("Sheet:1", 1, 4, &xlsxTable{
+		TableColumns: &xlsxTableColumns{TableColumn: []*xlsxTableColumn{{Name: "A"}, {Name: "B"}}},
+	}, []TableColumn{{Name: "B", TotalsRowFunction: "sum"}}))
+	assert.Equal(t, ErrMaxRows, f.setTableTotalsRow("Sheet1", 1, TotalRows+1, &xlsxTable{
+		TableColumns: &xlsxTableColumns{TableColumn: []*xlsxTableColumn{{Name: "A"}}},
+	}, []TableColumn{{Name: "A"}}))
+	assert.NoError(t, f.Close())
+}
+
 func TestGetTables(t *testing.T) {
 	f := NewFile()
 	// Test get tables in none table worksheet
@@ -137,6 +236,104 @@ func TestDeleteTable(t *testing.T) {
 	assert.Equal(t, "Values", val)
 }
 
+func TestSetTable(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{"Region", "Sales"}, {"East", 10}, {"West", 20}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", idx+1), &row))
+	}
+	assert.NoError(t, f.AddTable("Sheet1", &Table{
+		Range: "A1:B3", Name: "Report", ShowTotalsRow: true,
+		Columns: []TableColumn{{Name: "Sales", TotalsRowFunction: "sum"}},
+	}))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "D1:E3", Name: "Table2"}))
+	// Test resize, rename and change style of the table after append rows
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A4", &[]interface{}{"North", 30}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A5", &[]interface{}{"South", 40}))
+	columns := []TableColumn{{Name: "Region", TotalsRowLabel: "Total"}, {Name: "Sales", TotalsRowFunction: "max"}}
+	assert.NoError(t, f.SetTable("Sheet1", "Report", &Table{
+		Range: "A1:B5", Name: "Sales", StyleName: "TableStyleMedium2", ShowTotalsRow: true, Columns: columns,
+	}))
+	tables, err := f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, tables, 2)
+	assert.Equal(t, "A1:B6", tables[0].Range)
+	assert.Equal(t, "Sales", tables[0].Name)
+	assert.Equal(t, "TableStyleMedium2", tables[0].StyleName)
+	assert.True(t, tables[0].ShowTotalsRow)
+	assert.Equal(t, columns, tables[0].Columns)
+	formula, err := f.GetCellFormula("Sheet1", "B6")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUBTOTAL(104,Sales[Sales])", formula)
+	for cell, expected := range map[string]string{"A4": "North", "B4": "30", "A6": "Total"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	// Test toggle off the totals row of the table with the current range
+	assert.NoError(t, f.SetTable("Sheet1", "Sales", &Table{}))
+	tables, err = f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:B5", tables[0].Range)
+	assert.Equal(t, "Sales", tables[0].Name)
+	assert.False(t, tables[0].ShowTotalsRow)
+	assert.Equal(t, "TableStyleMedium2", tables[0].StyleName)
+	assert.Equal(t, []TableColumn{{Name: "Region"}, {Name: "Sales"}}, tables[0].Columns)
+	for _, cell := range []string{"A6", "B6"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Empty(t, val, cell)
+		formula, err = f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Empty(t, formula, cell)
+	}
+	// Test update table with hidden header row with the current range
+	assert.NoError(t, f.SetTable("Sheet1", "Table2", &Table{ShowHeaderRow: boolPtr(false)}))
+	assert.NoError(t, f.SetTable("Sheet1", "Table2", &Table{ShowHeaderRow: boolPtr(false), ShowTotalsRow: true}))
+	tables, err = f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "D2:E4", tables[1].Range)
+	assert.False(t, *tables[1].ShowHeaderRow)
+	// Test update table with hidden header row without the header row setting
+	assert.NoError(t, f.SetCellValue("Sheet1", "D1", "Keep"))
+	assert.NoError(t, f.SetTable("Sheet1", "Table2", &Table{}))
+	tables, err = f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "D2:E3", tables[1].Range)
+	assert.False(t, *tables[1].ShowHeaderRow)
+	val, err := f.GetCellValue("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Keep", val)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetTable.xlsx")))
+
+	// Test update table with nil options
+	assert.Equal(t, ErrParameterRequired, f.SetTable("Sheet1", "Sales", nil))
+	// Test update table with invalid table name
+	assert.Equal(t, newInvalidNameError("Table 1"), f.SetTable("Sheet1", "Sales", &Table{Name: "Table 1"}))
+	// Test update table with exists table name
+	assert.Equal(t, ErrExistsTableName, f.SetTable("Sheet1", "Sales", &Table{Name: "Table2"}))
+	// Test update table with not exists table name
+	assert.Equal(t, newNoExistTableError("Report"), f.SetTable("Sheet1", "Report", &Table{}))
+	// Test update table on not exists worksheet
+	assert.EqualError(t, f.SetTable("SheetN", "Sales", &Table{}), "sheet SheetN does not exist")
+	// Test update table with invalid range reference
+	assert.Equal(t, ErrParameterInvalid, f.SetTable("Sheet1", "Sales", &Table{Range: "A1"}))
+	// Test update table with unsupported totals row function
+	assert.Equal(t, newUnsupportedTotalsRowFunctionError("median"), f.SetTable("Sheet1", "Sales", &Table{
+		ShowTotalsRow: true, Columns: []TableColumn{{Name: "Sales", TotalsRowFunction: "median"}},
+	}))
+	// Test update table with the non-empty totals row
+	assert.NoError(t, f.SetCellValue("Sheet1", "E4", "Data"))
+	assert.Equal(t, newNotEmptyTotalsRowError("E4"), f.SetTable("Sheet1", "Table2", &Table{ShowTotalsRow: true}))
+	tables, err = f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.False(t, tables[1].ShowTotalsRow)
+	// Test update table with unsupported charset table part
+	f.Pkg.Store("xl/tables/table1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetTable("Sheet1", "Table2", &Table{}), "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.addTable("Sheet1", "xl/tables/table1.xml", 1, 1, 2, 2, 1, &Table{ShowRowStripes: boolPtr(true)}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestSetTableColumns(t *testing.T) {
 	f := NewFile()
 	assert.Equal(t, newCoordinatesToCellNameError(1, 0), f.setTableColumns("Sheet1", true, 1, 0, 1, nil))
@@ -215,6 +412,291 @@ func TestAutoFilterError(t *testing.T) {
 	}}))
 }
 
+func TestAutoFilterCriteria(t *testing.T) {
+	f := NewFile()
+	for i, row := range [][]interface{}{
+		{"Region", "Date", "Amount", "Name"},
+		{"East", time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), 10, "apple"},
+		{"West", time.Date(2023, 3, 15, 0, 0, 0, 0, time.UTC), 20, "banana"},
+		{"North", time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC), 30, "cherry"},
+		{"east", time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), 40, ""},
+		{nil, time.Date(2023, 3, 20, 0, 0, 0, 0, time.UTC), 50, "apricot"},
+	} {
+		cell, err := CoordinatesToCellName(1, i+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	red, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "D3", "D4", red))
+	blue, err := f.NewStyle(&Style{Font: &Font{Color: "0000FF"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "D2", "D2", blue))
+	blueFilter, err := f.NewConditionalStyle(&Style{Font: &Font{Color: "0000FF"}})
+	assert.NoError(t, err)
+	redFilter, err := f.NewConditionalStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetProps("Sheet1", &SheetPropsOptions{TabColorRGB: stringPtr("00FF00")}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+
+	getVisibleRows := func() []int {
+		var rows []int
+		for row := 2; row <= 6; row++ {
+			if visible, err := f.GetRowVisible("Sheet1", row); assert.NoError(t, err) && visible {
+				rows = append(rows, row)
+			}
+		}
+		return rows
+	}
+	for _, c := range []struct {
+		opts     []AutoFilterOptions
+		expected []int
+	}{
+		{opts: nil, expected: []int{2, 3, 4, 5, 6}},
+		{opts: []AutoFilterOptions{{Column: "A", Values: []string{"East", "West"}}}, expected: []int{2, 3, 5}},
+		{opts: []AutoFilterOptions{{Column: "A", Values: []string{"North"}, Blanks: true}}, expected: []int{4, 6}},
+		{opts: []AutoFilterOptions{{Column: "A", Expression: "x == blanks"}}, expected: []int{6}},
+		{opts: []AutoFilterOptions{{Column: "A", Expression: "x == nonblanks"}}, expected: []int{2, 3, 4, 5}},
+		{opts: []AutoFilterOptions{{Column: "D", Expression: "x == a*"}}, expected: []int{2, 6}},
+		{opts: []AutoFilterOptions{{Column: "D", Expression: "x != *an*"}}, expected: []int{2, 4, 5, 6}},
+		{opts: []AutoFilterOptions{{Column: "C", Expression: "x > 10 and x <= 40"}}, expected: []int{3, 4, 5}},
+		{opts: []AutoFilterOptions{{Column: "C", Expression: "x < 20 or x >= 50"}}, expected: []int{2, 6}},
+		{opts: []AutoFilterOptions{{Column: "D", Expression: "x >= b"}}, expected: []int{3, 4}},
+		{opts: []AutoFilterOptions{{Column: "B", DateGroups: []AutoFilterDateGroup{{Grouping: "month", Year: 2023, Month: 3}}}}, expected: []int{2, 3, 6}},
+		{opts: []AutoFilterOptions{{Column: "B", DateGroups: []AutoFilterDateGroup{{Grouping: "Year", Year: 2022}}}}, expected: []int{5}},
+		{opts: []AutoFilterOptions{{Column: "B", DateGroups: []AutoFilterDateGroup{{Grouping: "day", Year: 2023, Month: 4, Day: 1}}}}, expected: []int{4}},
+		{opts: []AutoFilterOptions{{Column: "C", Top10: &AutoFilterTop10{Value: 2}}}, expected: []int{5, 6}},
+		{opts: []AutoFilterOptions{{Column: "C", Top10: &AutoFilterTop10{Bottom: true, Percent: true, Value: 50}}}, expected: []int{2, 3, 4}},
+		{opts: []AutoFilterOptions{{Column: "A", Top10: &AutoFilterTop10{Value: 2}}}, expected: nil},
+		{opts: []AutoFilterOptions{{Column: "D", Color: &AutoFilterColor{Style: redFilter}}}, expected: []int{3, 4}},
+		{opts: []AutoFilterOptions{{Column: "D", Color: &AutoFilterColor{FontColor: true, Style: blueFilter}}}, expected: []int{2}},
+		{opts: []AutoFilterOptions{{Column: "D", Color: &AutoFilterColor{FontColor: true, Style: redFilter}}}, expected: []int{3, 4, 5, 6}},
+		{opts: []AutoFilterOptions{
+			{Column: "C", Expression: "x > 10"},
+			{Column: "A", Values: []string{"East", "West"}},
+		}, expected: []int{3, 5}},
+		{opts: []AutoFilterOptions{
+			{Column: "A", Values: []string{"North"}},
+			{Column: "A", Values: []string{"West"}},
+		}, expected: []int{3}},
+	} {
+		assert.NoError(t, f.AutoFilter("Sheet1", "A1:D6", c.opts))
+		assert.NoError(t, f.ApplyAutoFilter("Sheet1"))
+		assert.Equal(t, c.expected, getVisibleRows(), c.opts)
+	}
+	// Test filter columns are sorted by column and the existing sheet
+	// properties are kept
+	assert.NoError(t, f.AutoFilter("Sheet1", "A1:D6", []AutoFilterOptions{
+		{Column: "D", Expression: "x != blanks"},
+		{Column: "B", DateGroups: []AutoFilterDateGroup{{Grouping: "month", Year: 2023, Month: 3}}},
+		{Column: "C", Top10: &AutoFilterTop10{Value: 3}},
+	}))
+	assert.NoError(t, f.ApplyAutoFilter("Sheet1"))
+	autoFilter := ws.(*xlsxWorksheet).AutoFilter
+	assert.Len(t, autoFilter.FilterColumn, 3)
+	assert.Equal(t, 1, autoFilter.FilterColumn[0].ColID)
+	assert.Equal(t, &xlsxDateGroupItem{DateTimeGrouping: "month", Year: 2023, Month: 3}, autoFilter.FilterColumn[0].Filters.DateGroupItem[0])
+	assert.Equal(t, &xlsxTop10{Top: true, Val: 3, FilterVal: 30}, autoFilter.FilterColumn[1].Top10)
+	assert.Equal(t, "notEqual", autoFilter.FilterColumn[2].CustomFilters.CustomFilter[0].Operator)
+	assert.Equal(t, []int{6}, getVisibleRows())
+	assert.True(t, ws.(*xlsxWorksheet).SheetPr.FilterMode)
+	assert.Equal(t, "00FF00", ws.(*xlsxWorksheet).SheetPr.TabColor.RGB)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAutoFilterCriteria.xlsx")))
+
+	// Test auto filter with invalid criteria
+	for _, opts := range []AutoFilterOptions{
+		{Column: "A", Expression: "x == East", Values: []string{"West"}},
+		{Column: "A", Top10: &AutoFilterTop10{}, Color: &AutoFilterColor{}},
+	} {
+		assert.Equal(t, ErrAutoFilterCriteria, f.AutoFilter("Sheet1", "A1:D6", []AutoFilterOptions{opts}))
+	}
+	for _, opts := range []AutoFilterOptions{
+		{Column: "C", Top10: &AutoFilterTop10{}},
+		{Column: "C", Top10: &AutoFilterTop10{Percent: true, Value: 101}},
+		{Column: "B", DateGroups: []AutoFilterDateGroup{{Grouping: "week"}}},
+	} {
+		assert.Equal(t, ErrParameterInvalid, f.AutoFilter("Sheet1", "A1:D6", []AutoFilterOptions{opts}))
+	}
+	assert.Equal(t, newInvalidStyleID(100), f.AutoFilter("Sheet1", "A1:D6", []AutoFilterOptions{{Column: "A", Color: &AutoFilterColor{Style: 100}}}))
+	// Test auto filter with only the header row
+	assert.NoError(t, f.AutoFilter("Sheet1", "A1:D1", []AutoFilterOptions{{Column: "A", Values: []string{"North"}}}))
+	// Test apply auto filter with invalid range reference
+	assert.Equal(t, ErrParameterInvalid, f.applyAutoFilter("Sheet1", ws.(*xlsxWorksheet), &xlsxAutoFilter{Ref: "A1", FilterColumn: []*xlsxFilterColumn{{}}}))
+	assert.Equal(t, ErrColumnNumber, f.applyAutoFilter("Sheet1", ws.(*xlsxWorksheet), &xlsxAutoFilter{Ref: "A1:A2", FilterColumn: []*xlsxFilterColumn{{ColID: MaxColumns}}}))
+	// Test apply auto filter on not exists worksheet
+	assert.EqualError(t, f.ApplyAutoFilter("SheetN"), "sheet SheetN does not exist")
+	// Test apply auto filter with unsupported charset styles
+	assert.NoError(t, f.AutoFilter("Sheet1", "A1:D6", []AutoFilterOptions{{Column: "D", Color: &AutoFilterColor{Style: redFilter}}}))
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.ApplyAutoFilter("Sheet1"), "XML syntax error on line 1: invalid UTF-8")
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AutoFilter("Sheet1", "A1:D6", []AutoFilterOptions{{Column: "A", Color: &AutoFilterColor{}}}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestApplyAutoFilter(t *testing.T) {
+	f := NewFile()
+	// Test apply auto filter without auto filter
+	assert.NoError(t, f.ApplyAutoFilter("Sheet1"))
+	// Test the rows will not be hidden by setting the auto filter before the
+	// cell values
+	assert.NoError(t, f.AutoFilter("Sheet1", "A1:D4", []AutoFilterOptions{{Column: "A", Expression: "x == East"}}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", "East"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A3", "West"))
+	for row := 2; row <= 3; row++ {
+		visible, err := f.GetRowVisible("Sheet1", row)
+		assert.NoError(t, err)
+		assert.True(t, visible, row)
+	}
+	assert.NoError(t, f.ApplyAutoFilter("Sheet1"))
+	for row, expected := range map[int]bool{2: true, 3: false, 4: false} {
+		visible, err := f.GetRowVisible("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, visible, row)
+	}
+	assert.NoError(t, f.Close())
+}
+
+func TestSortRange(t *testing.T) {
+	f := NewFile()
+	for i, row := range [][]interface{}{
+		{"Name", "Priority", "Amount", "Date", "Total"},
+		{"banana", "Low", 30, time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"Apple", "High", 10, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{nil, "Medium", 20, nil},
+		{"cherry", "High", true, time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"apple", "Medium", 30, time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)},
+	} {
+		cell, err := CoordinatesToCellName(1, i+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+		if i > 0 {
+			assert.NoError(t, f.SetCellFormula("Sheet1", fmt.Sprintf("E%d", i+1), fmt.Sprintf("C%d*$C$2", i+1)))
+		}
+	}
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A3", "A3", style))
+	getColumn := func(col string) []string {
+		var values []string
+		for row := 2; row <= 6; row++ {
+			val, err := f.GetCellValue("Sheet1", fmt.Sprintf("%s%d", col, row))
+			assert.NoError(t, err)
+			values = append(values, val)
+		}
+		return values
+	}
+	// Test sort by text column, the blank cells will be sorted at the end
+	assert.NoError(t, f.SortRange("Sheet1", "E6:A2", []SortKey{{Column: "A"}}))
+	assert.Equal(t, []string{"Apple", "apple", "banana", "cherry", ""}, getColumn("A"))
+	assert.Equal(t, []string{"10", "30", "30", "TRUE", "20"}, getColumn("C"))
+	styleID, err := f.GetCellStyle("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	for cell, expected := range map[string]string{"E2": "C2*$C$2", "E3": "C3*$C$2", "E6": "C6*$C$2"} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	// Test sort by multiple columns in descending order
+	assert.NoError(t, f.SortRange("Sheet1", "A2:E6", []SortKey{{Column: "C", Descending: true}, {Column: "A", Descending: true}}))
+	assert.Equal(t, []string{"TRUE", "30", "30", "20", "10"}, getColumn("C"))
+	assert.Equal(t, []string{"cherry", "banana", "apple", "", "Apple"}, getColumn("A"))
+	// Test sort by date column
+	assert.NoError(t, f.SortRange("Sheet1", "A2:E6", []SortKey{{Column: "D"}}))
+	assert.Equal(t, []string{"apple", "Apple", "cherry", "banana", ""}, getColumn("A"))
+	// Test sort by custom list
+	assert.NoError(t, f.SortRange("Sheet1", "A2:E6", []SortKey{{Column: "B", CustomList: []string{"high", "medium", "low"}}, {Column: "C"}}))
+	assert.Equal(t, []string{"High", "High", "Medium", "Medium", "Low"}, getColumn("B"))
+	assert.Equal(t, []string{"10", "TRUE", "20", "30", "30"}, getColumn("C"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSortRange.xlsx")))
+
+	// Test sort the data rows of the table
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "A1:E6", Name: "Table1", ShowTotalsRow: true}))
+	assert.NoError(t, f.SortRange("Sheet1", "table1", []SortKey{{Column: "A", Descending: true}}))
+	assert.Equal(t, []string{"cherry", "banana", "Apple", "apple", ""}, getColumn("A"))
+	header, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Name", header)
+	// Test sort range with shared formulas
+	f = NewFile()
+	for row, val := range []int{3, 1, 2} {
+		assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("A%d", row+1), val))
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "A1+1", FormulaOpts{Ref: stringPtr("B1:B4"), Type: stringPtr(STCellFormulaTypeShared)}))
+	assert.NoError(t, f.SortRange("Sheet1", "A1:B3", []SortKey{{Column: "A"}}))
+	for cell, expected := range map[string]string{"B1": "A1+1", "B2": "A2+1", "B3": "A3+1", "B4": "A4+1"} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	// Test sort range with errors and logical values
+	assert.NoError(t, f.SetCellValue("Sheet1", "C1", false))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C2", "text"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C3", 0))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[2].C[2] = xlsxC{R: "C3", T: "e", V: "#N/A"}
+	assert.NoError(t, f.SortRange("Sheet1", "C1:C4", []SortKey{{Column: "C"}}))
+	for cell, expected := range map[string]string{"C1": "text", "C2": "FALSE", "C3": "#N/A", "C4": ""} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+
+	// Test sort range with invalid parameters
+	assert.Equal(t, ErrParameterRequired, f.SortRange("Sheet1", "A1:B3", nil))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SortRange("Sheet1", "A:B3", []SortKey{{Column: "A"}}))
+	assert.Equal(t, newInvalidColumnNameError("-"), f.SortRange("Sheet1", "A1:B3", []SortKey{{Column: "-"}}))
+	assert.Equal(t, newInvalidSortKeyColumnError("C"), f.SortRange("Sheet1", "A1:B3", []SortKey{{Column: "C"}}))
+	assert.Equal(t, newNoExistTableError("Table1"), f.SortRange("Sheet1", "Table1", []SortKey{{Column: "A"}}))
+	assert.EqualError(t, f.SortRange("SheetN", "A1:B3", []SortKey{{Column: "A"}}), "sheet SheetN does not exist")
+	assert.EqualError(t, f.SortRange("SheetN", "Table1", []SortKey{{Column: "A"}}), "sheet SheetN does not exist")
+	assert.NoError(t, f.MergeCell("Sheet1", "B2", "C3"))
+	assert.Equal(t, ErrSortRangeMergeCell, f.SortRange("Sheet1", "A1:B3", []SortKey{{Column: "A"}}))
+	ws.(*xlsxWorksheet).MergeCells.Cells[0].Ref = "B2:C"
+	assert.Equal(t, newCellNameToCoordinatesError("C", newInvalidCellNameError("C")), f.SortRange("Sheet1", "A1:B3", []SortKey{{Column: "A"}}))
+	ws.(*xlsxWorksheet).MergeCells = nil
+	// Test sort the table with only the header row
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "E1:F2", Name: "Table2", ShowHeaderRow: boolPtr(true)}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "E1", "Column1"))
+	f.Pkg.Store("xl/tables/table1.xml", []byte(`<table name="Table2" ref="E1:F1"></table>`))
+	assert.NoError(t, f.SortRange("Sheet1", "Table2", []SortKey{{Column: "E"}}))
+	// Test sort range with invalid table range
+	f.Pkg.Store("xl/tables/table1.xml", []byte(`<table name="Table2" ref="E1:F"></table>`))
+	assert.Equal(t, newCellNameToCoordinatesError("F", newInvalidCellNameError("F")), f.SortRange("Sheet1", "Table2", []SortKey{{Column: "E"}}))
+	// Test sort range with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SortRange("Sheet1", "A1:B3", []SortKey{{Column: "A"}}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestSetFilteredHeader(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetFilteredHeader("Sheet1", "E10:B2"))
+	panes, err := f.GetPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, Panes{
+		Freeze: true, YSplit: 2, TopLeftCell: "A3", ActivePane: "bottomLeft",
+		Selection: []Selection{{SQRef: "A3", ActiveCell: "A3", Pane: "bottomLeft"}},
+	}, panes)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, "$B$2:$E$10", ws.(*xlsxWorksheet).AutoFilter.Ref)
+	assert.Equal(t, "'Sheet1'!$B$2:$E$10", f.WorkBook.DefinedNames.DefinedName[0].Data)
+	// Test set filtered header without data row
+	assert.Equal(t, ErrFilteredHeaderRange, f.SetFilteredHeader("Sheet1", "A1:D1"))
+	// Test set filtered header with invalid range reference
+	assert.Equal(t, ErrParameterInvalid, f.SetFilteredHeader("Sheet1", "A1"))
+	// Test set filtered header on not exists worksheet
+	assert.EqualError(t, f.SetFilteredHeader("SheetN", "A1:D10"), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
 func TestParseFilterTokens(t *testing.T) {
 	f := NewFile()
 	// Test with unknown operator
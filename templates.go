@@ -27,6 +27,7 @@ var (
 	NameSpaceDrawingMLSlicer                = xml.Attr{Name: xml.Name{Local: "sle", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2010/slicer"}
 	NameSpaceDrawingMLSlicerX15             = xml.Attr{Name: xml.Name{Local: "sle15", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2012/slicer"}
 	NameSpaceDrawingMLSpreadSheet           = xml.Attr{Name: xml.Name{Local: "xdr", Space: "xmlns"}, Value: "http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing"}
+	NameSpaceDynamicArray                   = xml.Attr{Name: xml.Name{Local: "xda", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/spreadsheetml/2017/dynamicarray"}
 	NameSpaceMacExcel2008Main               = xml.Attr{Name: xml.Name{Local: "mx", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/mac/excel/2008/main"}
 	NameSpaceSpreadSheet                    = xml.Attr{Name: xml.Name{Local: "xmlns"}, Value: "http://schemas.openxmlformats.org/spreadsheetml/2006/main"}
 	NameSpaceSpreadSheetExcel2006Main       = xml.Attr{Name: xml.Name{Local: "xne", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/excel/2006/main"}
@@ -43,25 +44,33 @@ var (
 // Source relationship and namespace.
 const (
 	ContentTypeAddinMacro                         = "application/vnd.ms-excel.addin.macroEnabled.main+xml"
+	ContentTypeCustomProperties                   = "application/vnd.openxmlformats-officedocument.custom-properties+xml"
 	ContentTypeDrawing                            = "application/vnd.openxmlformats-officedocument.drawing+xml"
 	ContentTypeDrawingML                          = "application/vnd.openxmlformats-officedocument.drawingml.chart+xml"
 	ContentTypeMacro                              = "application/vnd.ms-excel.sheet.macroEnabled.main+xml"
+	ContentTypeOLEObject                          = "application/vnd.openxmlformats-officedocument.oleObject"
+	ContentTypePerson                             = "application/vnd.ms-excel.person+xml"
 	ContentTypeRelationships                      = "application/vnd.openxmlformats-package.relationships+xml"
 	ContentTypeSheetML                            = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"
 	ContentTypeSlicer                             = "application/vnd.ms-excel.slicer+xml"
 	ContentTypeSlicerCache                        = "application/vnd.ms-excel.slicerCache+xml"
 	ContentTypeSpreadSheetMLChartsheet            = "application/vnd.openxmlformats-officedocument.spreadsheetml.chartsheet+xml"
 	ContentTypeSpreadSheetMLComments              = "application/vnd.openxmlformats-officedocument.spreadsheetml.comments+xml"
+	ContentTypeSpreadSheetMLMetadata              = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheetMetadata+xml"
 	ContentTypeSpreadSheetMLPivotCacheDefinition  = "application/vnd.openxmlformats-officedocument.spreadsheetml.pivotCacheDefinition+xml"
+	ContentTypeSpreadSheetMLPivotCacheRecords     = "application/vnd.openxmlformats-officedocument.spreadsheetml.pivotCacheRecords+xml"
 	ContentTypeSpreadSheetMLPivotTable            = "application/vnd.openxmlformats-officedocument.spreadsheetml.pivotTable+xml"
 	ContentTypeSpreadSheetMLSharedStrings         = "application/vnd.openxmlformats-officedocument.spreadsheetml.sharedStrings+xml"
+	ContentTypeSpreadSheetMLStyles                = "application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"
 	ContentTypeSpreadSheetMLTable                 = "application/vnd.openxmlformats-officedocument.spreadsheetml.table+xml"
 	ContentTypeSpreadSheetMLWorksheet             = "application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"
 	ContentTypeTemplate                           = "application/vnd.openxmlformats-officedocument.spreadsheetml.template.main+xml"
 	ContentTypeTemplateMacro                      = "application/vnd.ms-excel.template.macroEnabled.main+xml"
+	ContentTypeThreadedComments                   = "application/vnd.ms-excel.threadedcomments+xml"
 	ContentTypeVBA                                = "application/vnd.ms-office.vbaProject"
 	ContentTypeVML                                = "application/vnd.openxmlformats-officedocument.vmlDrawing"
 	NameSpaceDrawingMLMain                        = "http://schemas.openxmlformats.org/drawingml/2006/main"
+	NameSpaceCustomProperties                     = "http://schemas.openxmlformats.org/officeDocument/2006/custom-properties"
 	NameSpaceDublinCore                           = "http://purl.org/dc/elements/1.1/"
 	NameSpaceDublinCoreMetadataInitiative         = "http://purl.org/dc/dcmitype/"
 	NameSpaceDublinCoreTerms                      = "http://purl.org/dc/terms/"
@@ -71,21 +80,29 @@ const (
 	SourceRelationshipChart                       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/chart"
 	SourceRelationshipChartsheet                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/chartsheet"
 	SourceRelationshipComments                    = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/comments"
+	SourceRelationshipCustomProperties            = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/custom-properties"
 	SourceRelationshipDialogsheet                 = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/dialogsheet"
 	SourceRelationshipDrawingML                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/drawing"
 	SourceRelationshipDrawingVML                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/vmlDrawing"
 	SourceRelationshipExtendProperties            = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/extended-properties"
 	SourceRelationshipHyperLink                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink"
 	SourceRelationshipImage                       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/image"
+	SourceRelationshipOLEObject                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/oleObject"
 	SourceRelationshipOfficeDocument              = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument"
+	SourceRelationshipPerson                      = "http://schemas.microsoft.com/office/2017/10/relationships/person"
 	SourceRelationshipPivotCache                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/pivotCacheDefinition"
+	SourceRelationshipPivotCacheRecords           = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/pivotCacheRecords"
 	SourceRelationshipPivotTable                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/pivotTable"
 	SourceRelationshipSharedStrings               = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings"
+	SourceRelationshipSheetMetadata               = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/sheetMetadata"
 	SourceRelationshipSlicer                      = "http://schemas.microsoft.com/office/2007/relationships/slicer"
 	SourceRelationshipSlicerCache                 = "http://schemas.microsoft.com/office/2007/relationships/slicerCache"
+	SourceRelationshipStyles                      = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles"
 	SourceRelationshipTable                       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/table"
+	SourceRelationshipThreadedComment             = "http://schemas.microsoft.com/office/2017/10/relationships/threadedComment"
 	SourceRelationshipVBAProject                  = "http://schemas.microsoft.com/office/2006/relationships/vbaProject"
 	SourceRelationshipWorkSheet                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet"
+	StrictNameSpaceCustomProperties               = "http://purl.oclc.org/ooxml/officeDocument/customProperties"
 	StrictNameSpaceDocumentPropertiesVariantTypes = "http://purl.oclc.org/ooxml/officeDocument/docPropsVTypes"
 	StrictNameSpaceDrawingMLMain                  = "http://purl.oclc.org/ooxml/drawingml/main"
 	StrictNameSpaceExtendedProperties             = "http://purl.oclc.org/ooxml/officeDocument/extendedProperties"
@@ -105,6 +122,7 @@ const (
 	ExtURIDataModel                      = "{FCE2AD5D-F65C-4FA6-A056-5C36A1767C68}"
 	ExtURIDataValidations                = "{CCE6A557-97BC-4B89-ADB6-D9C93CAAB3DF}"
 	ExtURIDrawingBlip                    = "{28A0092B-C50C-407E-A947-70E740481C1C}"
+	ExtURIDynamicArrayProperties         = "{bdbb8cdc-fa1e-496e-a857-3c3f30c029c3}"
 	ExtURIExternalLinkPr                 = "{FCE6A71B-6B00-49CD-AB44-F6B1AE7CDE65}"
 	ExtURIIgnoredErrors                  = "{01252117-D84E-4E92-8308-4BE1C098FCBB}"
 	ExtURIMacExcelMX                     = "{64002731-A6B0-56B0-2670-7721B7C09600}"
@@ -164,7 +182,9 @@ var worksheetExtURIPriority = []string{
 // Excel specifications and limits
 const (
 	MaxCellStyles        = 65430
+	MaxChartMarkerSize   = 72
 	MaxColumns           = 16384
+	MaxCommentBoxSize    = 4096
 	MaxColumnWidth       = 255
 	MaxFieldLength       = 255
 	MaxFilePathLength    = 207
@@ -173,6 +193,7 @@ const (
 	MaxFontSize          = 409
 	MaxRowHeight         = 409
 	MaxSheetNameLength   = 31
+	MinChartMarkerSize   = 2
 	MinColumns           = 1
 	MinFontSize          = 1
 	StreamChunkSize      = 1 << 24
@@ -218,17 +239,20 @@ const (
 )
 
 const (
-	defaultTempFileSST          = "sharedStrings"
-	defaultXMLPathCalcChain     = "xl/calcChain.xml"
-	defaultXMLPathContentTypes  = "[Content_Types].xml"
-	defaultXMLPathDocPropsApp   = "docProps/app.xml"
-	defaultXMLPathDocPropsCore  = "docProps/core.xml"
-	defaultXMLPathSharedStrings = "xl/sharedStrings.xml"
-	defaultXMLPathStyles        = "xl/styles.xml"
-	defaultXMLPathTheme         = "xl/theme/theme1.xml"
-	defaultXMLPathVolatileDeps  = "xl/volatileDependencies.xml"
-	defaultXMLPathWorkbook      = "xl/workbook.xml"
-	defaultXMLPathWorkbookRels  = "xl/_rels/workbook.xml.rels"
+	defaultTempFileSST           = "sharedStrings"
+	defaultXMLPathCalcChain      = "xl/calcChain.xml"
+	defaultXMLPathContentTypes   = "[Content_Types].xml"
+	defaultXMLPathDocPropsApp    = "docProps/app.xml"
+	defaultXMLPathDocPropsCore   = "docProps/core.xml"
+	defaultXMLPathDocPropsCustom = "docProps/custom.xml"
+	defaultXMLPathMetadata       = "xl/metadata.xml"
+	defaultXMLPathPerson         = "xl/persons/person.xml"
+	defaultXMLPathSharedStrings  = "xl/sharedStrings.xml"
+	defaultXMLPathStyles         = "xl/styles.xml"
+	defaultXMLPathTheme          = "xl/theme/theme1.xml"
+	defaultXMLPathVolatileDeps   = "xl/volatileDependencies.xml"
+	defaultXMLPathWorkbook       = "xl/workbook.xml"
+	defaultXMLPathWorkbookRels   = "xl/_rels/workbook.xml.rels"
 )
 
 // IndexedColorMapping is the table of default mappings from indexed color value
@@ -278,6 +302,11 @@ var supportedDrawingUnderlineTypes = []string{
 	"wavyDbl",
 }
 
+// supportedChartMarkerSymbols defined supported chart marker symbol types.
+var supportedChartMarkerSymbols = []string{
+	"circle", "dash", "diamond", "dot", "none", "picture", "plus", "square", "star", "triangle", "x", "auto",
+}
+
 // supportedPositioning defined supported positioning types.
 var supportedPositioning = []string{"absolute", "oneCell", "twoCell"}
 
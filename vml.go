@@ -16,9 +16,12 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
+	"math"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 // FormControlType is the type of supported form controls.
@@ -34,9 +37,13 @@ const (
 	FormControlGroupBox
 	FormControlLabel
 	FormControlScrollBar
+	FormControlComboBox
 )
 
 // GetComments retrieves all comments in a worksheet by given worksheet name.
+// The legacy notes and the threaded comments will be returned together, the
+// ThreadedComment field of the comment will be populated with the author,
+// replies and resolved state of the conversation thread in the cell.
 func (f *File) GetComments(sheet string) ([]Comment, error) {
 	var comments []Comment
 	sheetXMLPath, ok := f.getSheetXMLPath(sheet)
@@ -52,9 +59,25 @@ func (f *File) GetComments(sheet string) ([]Comment, error) {
 	if err != nil {
 		return comments, err
 	}
+	threads, err := f.getThreadedComments(sheet)
+	if err != nil {
+		return comments, err
+	}
+	threadRefs := make(map[string]*ThreadedComment, len(threads))
+	for _, thread := range threads {
+		threadRefs[thread.Cell] = thread
+	}
 	if cmts != nil {
+		boxes, err := f.getCommentBoxes(sheet)
+		if err != nil {
+			return comments, err
+		}
 		for _, cmt := range cmts.CommentList.Comment {
-			comment := Comment{}
+			comment := boxes[cmt.Ref]
+			if thread, ok := threadRefs[cmt.Ref]; ok {
+				comment.ThreadedComment = thread
+				delete(threadRefs, cmt.Ref)
+			}
 			if cmt.AuthorID < len(cmts.Authors.Author) {
 				comment.Author = cmts.Authors.Author[cmt.AuthorID]
 			}
@@ -75,9 +98,72 @@ func (f *File) GetComments(sheet string) ([]Comment, error) {
 			comments = append(comments, comment)
 		}
 	}
+	for _, thread := range threads {
+		if _, ok := threadRefs[thread.Cell]; ok {
+			comments = append(comments, Comment{Cell: thread.Cell, ThreadedComment: thread})
+		}
+	}
 	return comments, nil
 }
 
+// getCommentBoxes provides a function to get the width, height and fill
+// color of the comment boxes in the VML drawing of the worksheet by given
+// worksheet name, the returned map uses the cell reference as the key.
+func (f *File) getCommentBoxes(sheet string) (map[string]Comment, error) {
+	boxes := map[string]Comment{}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.LegacyDrawing == nil {
+		return boxes, err
+	}
+	target := f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID)
+	drawingVML := strings.ReplaceAll(target, "..", "xl")
+	var shapes []xlsxShape
+	if vml := f.VMLDrawing[drawingVML]; vml != nil {
+		shapes = vml.Shape
+	} else {
+		d, err := f.decodeVMLDrawingReader(drawingVML)
+		if err != nil || d == nil {
+			return boxes, err
+		}
+		for _, sp := range d.Shape {
+			shapes = append(shapes, xlsxShape{Style: sp.Style, FillColor: sp.FillColor, Val: sp.Val})
+		}
+	}
+	for _, sp := range shapes {
+		var shapeVal decodeShapeVal
+		if err = xml.Unmarshal([]byte(fmt.Sprintf("<shape>%s</shape>", sp.Val)), &shapeVal); err != nil {
+			return boxes, err
+		}
+		clientData := shapeVal.ClientData
+		if clientData.ObjectType != "Note" || clientData.Column == nil || clientData.Row == nil {
+			continue
+		}
+		cell, err := CoordinatesToCellName(*clientData.Column+1, *clientData.Row+1)
+		if err != nil {
+			return boxes, err
+		}
+		box := Comment{FillColor: strings.ToUpper(sp.FillColor)}
+		for _, prop := range strings.Split(sp.Style, ";") {
+			kv := strings.SplitN(prop, ":", 2)
+			if len(kv) != 2 || !strings.HasSuffix(kv[1], "pt") {
+				continue
+			}
+			size, err := strconv.ParseFloat(strings.TrimSuffix(kv[1], "pt"), 64)
+			if err != nil {
+				continue
+			}
+			switch kv[0] {
+			case "width":
+				box.Width = uint(math.Round(size / 0.75))
+			case "height":
+				box.Height = uint(math.Round(size / 0.75))
+			}
+		}
+		boxes[cell] = box
+	}
+	return boxes, err
+}
+
 // getSheetComments provides the method to get the target comment reference by
 // given worksheet file path.
 func (f *File) getSheetComments(sheetFile string) string {
@@ -97,8 +183,10 @@ func (f *File) getSheetComments(sheetFile string) string {
 // AddComment provides the method to add comments in a sheet by giving the
 // worksheet name, cell reference, and format set (such as author and text).
 // Note that the maximum author name length is 255 and the max text length is
-// 32512. For example, add a rich-text comment with a specified comments box
-// size in Sheet1!A5:
+// 32512. The width and height of the comments box are in pixels, and the fill
+// color of the comments box should be in hex format. For example, add a
+// rich-text comment with a specified comments box size and red fill color in
+// Sheet1!A5:
 //
 //	err := f.AddComment("Sheet1", excelize.Comment{
 //	    Cell:   "A5",
@@ -107,10 +195,14 @@ func (f *File) getSheetComments(sheetFile string) string {
 //	        {Text: "Excelize: ", Font: &excelize.Font{Bold: true}},
 //	        {Text: "This is a comment."},
 //	    },
-//	    Height: 40,
-//	    Width:  180,
+//	    Height:    40,
+//	    Width:     180,
+//	    FillColor: "#FF0000",
 //	})
 func (f *File) AddComment(sheet string, opts Comment) error {
+	if err := prepareCommentOptions(&opts); err != nil {
+		return err
+	}
 	return f.addVMLObject(vmlOptions{
 		sheet: sheet, Comment: opts,
 		FormControl: FormControl{
@@ -124,9 +216,50 @@ func (f *File) AddComment(sheet string, opts Comment) error {
 	})
 }
 
+// prepareCommentOptions provides a function to validate the size and fill
+// color of the comment box, and format the fill color in upper case.
+func prepareCommentOptions(opts *Comment) error {
+	if opts.Width > MaxCommentBoxSize || opts.Height > MaxCommentBoxSize {
+		return ErrCommentBoxSize
+	}
+	if opts.FillColor != "" {
+		hex := strings.TrimPrefix(opts.FillColor, "#")
+		if _, err := strconv.ParseUint(hex, 16, 32); err != nil || len(hex) != 6 {
+			return newInvalidCommentFillColorError(opts.FillColor)
+		}
+		opts.FillColor = "#" + strings.ToUpper(hex)
+	}
+	return nil
+}
+
+// SetComment provides the method to set the comment in a worksheet by given
+// worksheet name and comment options. The existing comment in the cell will be
+// replaced by the new one, including the threaded comment, or the comment will
+// be added if the cell has no comment. For example, change the author and text
+// of the comment in Sheet1!A5:
+//
+//	err := f.SetComment("Sheet1", excelize.Comment{
+//	    Cell:   "A5",
+//	    Author: "Excelize",
+//	    Text:   "This is the updated comment.",
+//	})
+func (f *File) SetComment(sheet string, opts Comment) error {
+	if err := prepareCommentOptions(&opts); err != nil {
+		return err
+	}
+	if _, _, err := CellNameToCoordinates(opts.Cell); err != nil {
+		return err
+	}
+	if err := f.DeleteComment(sheet, opts.Cell); err != nil {
+		return err
+	}
+	return f.AddComment(sheet, opts)
+}
+
 // DeleteComment provides the method to delete comment in a worksheet by given
-// worksheet name and cell reference. For example, delete the comment in
-// Sheet1!$A$30:
+// worksheet name and cell reference. The comments part, VML drawing and their
+// relationships will be removed when the last comment in the worksheet has
+// been deleted. For example, delete the comment in Sheet1!$A$30:
 //
 //	err := f.DeleteComment("Sheet1", "A30")
 func (f *File) DeleteComment(sheet, cell string) error {
@@ -163,10 +296,397 @@ func (f *File) DeleteComment(sheet, cell string) error {
 			cmts.CommentList.Comment = nil
 		}
 		f.Comments[commentsXML] = cmts
+		if err = f.deleteCommentShape(sheet, cell); err != nil {
+			return err
+		}
+		if len(cmts.CommentList.Comment) == 0 {
+			delete(f.Comments, commentsXML)
+			f.Pkg.Delete(commentsXML)
+			f.deleteSheetRelationshipsByType(sheet, SourceRelationshipComments)
+			if err = f.removeContentTypesPart(ContentTypeSpreadSheetMLComments, "/"+commentsXML); err != nil {
+				return err
+			}
+		}
+	}
+	return f.deleteThreadedComment(sheet, cell)
+}
+
+// deleteCommentShape provides a function to delete the note shape of the
+// comment in the VML drawing by given worksheet name and cell reference. The
+// VML drawing and the legacy drawing of the worksheet will be removed if there
+// are no shapes left in the VML drawing.
+func (f *File) deleteCommentShape(sheet, cell string) error {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.LegacyDrawing == nil {
+		return err
+	}
+	drawingVML, vml, err := f.getSheetVMLDrawing(sheet, ws.LegacyDrawing.RID)
+	if err != nil {
+		return err
+	}
+	shapes := vml.Shape[:0]
+	for _, sp := range vml.Shape {
+		var shapeVal decodeShapeVal
+		if err = xml.Unmarshal([]byte(fmt.Sprintf("<shape>%s</shape>", sp.Val)), &shapeVal); err == nil &&
+			shapeVal.ClientData.ObjectType == "Note" && shapeVal.ClientData.Column != nil && shapeVal.ClientData.Row != nil &&
+			*shapeVal.ClientData.Column == col-1 && *shapeVal.ClientData.Row == row-1 {
+			continue
+		}
+		shapes = append(shapes, sp)
+	}
+	vml.Shape = shapes
+	if len(vml.Shape) > 0 {
+		f.VMLDrawing[drawingVML] = vml
+		return nil
 	}
+	drawingVMLRels := "xl/drawings/_rels/" + filepath.Base(drawingVML) + ".rels"
+	delete(f.VMLDrawing, drawingVML)
+	delete(f.DecodeVMLDrawing, drawingVML)
+	f.Pkg.Delete(drawingVML)
+	f.Pkg.Delete(drawingVMLRels)
+	f.Relationships.Delete(drawingVMLRels)
+	f.deleteSheetRelationships(sheet, ws.LegacyDrawing.RID)
+	ws.LegacyDrawing = nil
+	return nil
+}
+
+// deleteSheetRelationshipsByType provides a function to delete the
+// relationships of the worksheet by given worksheet name and relationship
+// type.
+func (f *File) deleteSheetRelationshipsByType(sheet, relType string) {
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	rels, _ := f.relsReader("xl/worksheets/_rels/" + filepath.Base(sheetXMLPath) + ".rels")
+	if rels == nil {
+		return
+	}
+	var rIDs []string
+	rels.mu.Lock()
+	for _, v := range rels.Relationships {
+		if v.Type == relType {
+			rIDs = append(rIDs, v.ID)
+		}
+	}
+	rels.mu.Unlock()
+	for _, rID := range rIDs {
+		f.deleteSheetRelationships(sheet, rID)
+	}
+}
+
+// AddThreadedComment provides the method to add a threaded comment with the
+// replies in a worksheet by given worksheet name and threaded comment options.
+// The authors of the comment and replies will be added into the persons of the
+// workbook, and a legacy note will be added in the cell for the compatibility
+// with the spreadsheet applications which don't support threaded comments.
+// The current time will be used if the date of the comment or reply is not
+// specified. For example, add a resolved threaded comment with a reply in
+// Sheet1!A1:
+//
+//	err := f.AddThreadedComment("Sheet1", excelize.ThreadedComment{
+//	    Cell:   "A1",
+//	    Author: "Excelize",
+//	    Text:   "Please check the value.",
+//	    Done:   true,
+//	    Replies: []excelize.ThreadedCommentReply{
+//	        {Author: "Reviewer", Text: "The value has been checked."},
+//	    },
+//	})
+func (f *File) AddThreadedComment(sheet string, opts ThreadedComment) error {
+	col, row, err := CellNameToCoordinates(opts.Cell)
+	if err != nil {
+		return err
+	}
+	if opts.Cell, err = CoordinatesToCellName(col, row); err != nil {
+		return err
+	}
+	if _, err = f.workSheetReader(sheet); err != nil {
+		return err
+	}
+	threadedCommentsXML := f.getSheetThreadedComments(sheet)
+	cmts, err := f.threadedCommentsReader(threadedCommentsXML)
+	if err != nil {
+		return err
+	}
+	IDs := map[string]bool{}
+	for _, cmt := range cmts.ThreadedComment {
+		if cmt.Ref == opts.Cell && cmt.ParentID == "" {
+			return ErrThreadedCommentExists
+		}
+		IDs[cmt.ID] = true
+	}
+	authors := []string{opts.Author}
+	for _, reply := range opts.Replies {
+		authors = append(authors, reply.Author)
+	}
+	persons, personIDs, err := f.getThreadedCommentPersons(authors)
+	if err != nil {
+		return err
+	}
+	sheetID := f.getSheetID(sheet)
+	newID := func() string {
+		for idx := len(cmts.ThreadedComment) + 1; ; idx++ {
+			if ID := newThreadedCommentID(sheetID, idx); !IDs[ID] {
+				IDs[ID] = true
+				return ID
+			}
+		}
+	}
+	threadID := newID()
+	cmts.ThreadedComment = append(cmts.ThreadedComment, xlsxThreadedComment{
+		Ref:      opts.Cell,
+		DT:       formatThreadedCommentDate(opts.Date),
+		PersonID: personIDs[0],
+		ID:       threadID,
+		Done:     opts.Done,
+		Text:     opts.Text,
+	})
+	for idx, reply := range opts.Replies {
+		cmts.ThreadedComment = append(cmts.ThreadedComment, xlsxThreadedComment{
+			Ref:      opts.Cell,
+			DT:       formatThreadedCommentDate(reply.Date),
+			PersonID: personIDs[idx+1],
+			ID:       newID(),
+			ParentID: threadID,
+			Text:     reply.Text,
+		})
+	}
+	output, err := xml.Marshal(cmts)
+	if err != nil {
+		return err
+	}
+	text := "[Threaded comment]\n\nYour version of Excel allows you to read this threaded comment; however, any edits to it will get removed if the file is opened in a newer version of Excel. Learn more: https://go.microsoft.com/fwlink/?linkid=870924\n\nComment:\n    " + opts.Text
+	for _, reply := range opts.Replies {
+		text += "\nReply:\n    " + reply.Text
+	}
+	if err = f.AddComment(sheet, Comment{Cell: opts.Cell, Author: "tc=" + threadID, Text: text}); err != nil {
+		return err
+	}
+	if err = f.savePersonList(persons); err != nil {
+		return err
+	}
+	if threadedCommentsXML == "" {
+		threadedCommentID := f.countPartsWithPrefix("xl/threadedComments/threadedComment") + 1
+		threadedCommentsXML = "xl/threadedComments/threadedComment" + strconv.Itoa(threadedCommentID) + ".xml"
+		sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+		sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+		f.addRels(sheetRels, SourceRelationshipThreadedComment, "../threadedComments/threadedComment"+strconv.Itoa(threadedCommentID)+".xml", "")
+		if err = f.addContentTypePart(threadedCommentID, "threadedComment"); err != nil {
+			return err
+		}
+	}
+	f.saveFileList(threadedCommentsXML, output)
 	return err
 }
 
+// newThreadedCommentID provides a function to generate the ID of the threaded
+// comment by given worksheet ID and index of the threaded comment.
+func newThreadedCommentID(sheetID, idx int) string {
+	return fmt.Sprintf("{00000000-0000-0000-%04X-%012X}", sheetID, idx)
+}
+
+// formatThreadedCommentDate provides a function to format the date of the
+// threaded comment, the current time will be used for the zero date.
+func formatThreadedCommentDate(date time.Time) string {
+	if date.IsZero() {
+		date = time.Now()
+	}
+	return date.Format("2006-01-02T15:04:05.00")
+}
+
+// getThreadedCommentPersons provides a function to get the persons of the
+// workbook with the authors of the threaded comments, and returns the person
+// IDs of the authors. The persons which don't exist will be appended into the
+// returned person list, which should be saved by the savePersonList function.
+func (f *File) getThreadedCommentPersons(authors []string) (*xlsxPersonList, []string, error) {
+	persons, err := f.personListReader(f.getPersonListPath())
+	if err != nil {
+		return nil, nil, err
+	}
+	var personIDs []string
+	for _, author := range authors {
+		if author == "" {
+			author = "Author"
+		}
+		if utf8.RuneCountInString(author) > MaxFieldLength {
+			author = string([]rune(author)[:MaxFieldLength])
+		}
+		var personID string
+		for _, person := range persons.Person {
+			if person.DisplayName == author {
+				personID = person.ID
+				break
+			}
+		}
+		if personID == "" {
+			personID = fmt.Sprintf("{00000000-0000-0000-0000-%012X}", len(persons.Person)+1)
+			persons.Person = append(persons.Person, xlsxPerson{
+				DisplayName: author, ID: personID, UserID: author, ProviderID: "None",
+			})
+		}
+		personIDs = append(personIDs, personID)
+	}
+	return persons, personIDs, err
+}
+
+// savePersonList provides a function to save the persons of the workbook, the
+// persons part will be created if it doesn't exist in the workbook.
+func (f *File) savePersonList(persons *xlsxPersonList) error {
+	output, err := xml.Marshal(persons)
+	if err != nil {
+		return err
+	}
+	personXML := f.getPersonListPath()
+	if personXML == "" {
+		personXML = defaultXMLPathPerson
+		f.addRels(f.getWorkbookRelsPath(), SourceRelationshipPerson, strings.TrimPrefix(personXML, "xl/"), "")
+		if err = f.addContentTypePart(0, "person"); err != nil {
+			return err
+		}
+	}
+	f.saveFileList(personXML, output)
+	return err
+}
+
+// getThreadedComments provides a function to get the threaded comments in the
+// worksheet by given worksheet name, the replies will be grouped into the
+// first comment of each conversation thread.
+func (f *File) getThreadedComments(sheet string) ([]*ThreadedComment, error) {
+	var threads []*ThreadedComment
+	threadedCommentsXML := f.getSheetThreadedComments(sheet)
+	if threadedCommentsXML == "" {
+		return threads, nil
+	}
+	cmts, err := f.threadedCommentsReader(threadedCommentsXML)
+	if err != nil {
+		return threads, err
+	}
+	persons, err := f.personListReader(f.getPersonListPath())
+	if err != nil {
+		return threads, err
+	}
+	authors, IDs := map[string]string{}, map[string]*ThreadedComment{}
+	for _, person := range persons.Person {
+		authors[person.ID] = person.DisplayName
+	}
+	for _, cmt := range cmts.ThreadedComment {
+		date, _ := time.Parse("2006-01-02T15:04:05", cmt.DT)
+		if cmt.ParentID == "" {
+			thread := &ThreadedComment{
+				Cell: cmt.Ref, Author: authors[cmt.PersonID], Text: cmt.Text, Date: date, Done: cmt.Done,
+			}
+			IDs[cmt.ID] = thread
+			threads = append(threads, thread)
+			continue
+		}
+		if thread, ok := IDs[cmt.ParentID]; ok {
+			thread.Replies = append(thread.Replies, ThreadedCommentReply{
+				Author: authors[cmt.PersonID], Text: cmt.Text, Date: date,
+			})
+		}
+	}
+	return threads, err
+}
+
+// deleteThreadedComment provides a function to delete the threaded comment
+// and the replies in a worksheet by given worksheet name and cell reference.
+func (f *File) deleteThreadedComment(sheet, cell string) error {
+	threadedCommentsXML := f.getSheetThreadedComments(sheet)
+	if threadedCommentsXML == "" {
+		return nil
+	}
+	cmts, err := f.threadedCommentsReader(threadedCommentsXML)
+	if err != nil {
+		return err
+	}
+	threadedComments := cmts.ThreadedComment[:0]
+	for _, cmt := range cmts.ThreadedComment {
+		if cmt.Ref != cell {
+			threadedComments = append(threadedComments, cmt)
+		}
+	}
+	cmts.ThreadedComment = threadedComments
+	if len(cmts.ThreadedComment) == 0 {
+		f.Pkg.Delete(threadedCommentsXML)
+		f.deleteSheetRelationshipsByType(sheet, SourceRelationshipThreadedComment)
+		return f.removeContentTypesPart(ContentTypeThreadedComments, "/"+threadedCommentsXML)
+	}
+	output, err := xml.Marshal(cmts)
+	f.saveFileList(threadedCommentsXML, output)
+	return err
+}
+
+// getSheetThreadedComments provides a function to get the threaded comments
+// part path by given worksheet name, it returns an empty string if the
+// worksheet has no threaded comments.
+func (f *File) getSheetThreadedComments(sheet string) string {
+	sheetXMLPath, ok := f.getSheetXMLPath(sheet)
+	if !ok {
+		return ""
+	}
+	rels, _ := f.relsReader("xl/worksheets/_rels/" + filepath.Base(sheetXMLPath) + ".rels")
+	if rels != nil {
+		rels.mu.Lock()
+		defer rels.mu.Unlock()
+		for _, v := range rels.Relationships {
+			if v.Type == SourceRelationshipThreadedComment {
+				if strings.HasPrefix(v.Target, "/") {
+					return strings.TrimPrefix(v.Target, "/")
+				}
+				return "xl" + strings.TrimPrefix(v.Target, "..")
+			}
+		}
+	}
+	return ""
+}
+
+// getPersonListPath provides a function to get the person part path of the
+// workbook, it returns an empty string if the workbook has no persons.
+func (f *File) getPersonListPath() string {
+	rels, _ := f.relsReader(f.getWorkbookRelsPath())
+	if rels != nil {
+		rels.mu.Lock()
+		defer rels.mu.Unlock()
+		for _, v := range rels.Relationships {
+			if v.Type == SourceRelationshipPerson {
+				if strings.HasPrefix(v.Target, "/") {
+					return strings.TrimPrefix(v.Target, "/")
+				}
+				return "xl/" + v.Target
+			}
+		}
+	}
+	return ""
+}
+
+// threadedCommentsReader provides a function to get the pointer to the
+// structure after deserialization of xl/threadedComments/threadedComment%d.xml.
+func (f *File) threadedCommentsReader(path string) (*xlsxThreadedComments, error) {
+	cmts := &xlsxThreadedComments{XMLNSX: NameSpaceSpreadSheet.Value}
+	if content, ok := f.Pkg.Load(path); ok && content != nil {
+		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+			Decode(cmts); err != nil && err != io.EOF {
+			return nil, err
+		}
+	}
+	return cmts, nil
+}
+
+// personListReader provides a function to get the pointer to the structure
+// after deserialization of xl/persons/person.xml.
+func (f *File) personListReader(path string) (*xlsxPersonList, error) {
+	persons := &xlsxPersonList{XMLNSX: NameSpaceSpreadSheet.Value}
+	if content, ok := f.Pkg.Load(path); ok && content != nil {
+		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+			Decode(persons); err != nil && err != io.EOF {
+			return nil, err
+		}
+	}
+	return persons, nil
+}
+
 // addComment provides a function to create chart as xl/comments%d.xml by
 // given cell and format sets.
 func (f *File) addComment(commentsXML string, opts vmlOptions) error {
@@ -180,11 +700,11 @@ func (f *File) addComment(commentsXML string, opts vmlOptions) error {
 	if err != nil {
 		return err
 	}
-	var authorID int
 	if cmts == nil {
 		cmts = &xlsxComments{Authors: xlsxAuthor{Author: []string{opts.Author}}}
 	}
-	if inStrSlice(cmts.Authors.Author, opts.Author, true) == -1 {
+	authorID := inStrSlice(cmts.Authors.Author, opts.Author, true)
+	if authorID == -1 {
 		cmts.Authors.Author = append(cmts.Authors.Author, opts.Author)
 		authorID = len(cmts.Authors.Author) - 1
 	}
@@ -227,7 +747,14 @@ func (f *File) addComment(commentsXML string, opts vmlOptions) error {
 			}},
 		}
 		if run.Font != nil {
-			r.RPr = newRpr(run.Font)
+			rPr := newRpr(run.Font)
+			if rPr.Sz == nil {
+				rPr.Sz = r.RPr.Sz
+			}
+			if rPr.RFont == nil {
+				rPr.RFont, rPr.Family = r.RPr.RFont, r.RPr.Family
+			}
+			r.RPr = rPr
 		}
 		cmt.Text.R = append(cmt.Text.R, r)
 	}
@@ -237,7 +764,9 @@ func (f *File) addComment(commentsXML string, opts vmlOptions) error {
 }
 
 // countComments provides a function to get comments files count storage in
-// the folder xl.
+// the folder xl. The largest comments file ID will be returned if the comments
+// files are not numbered continuously, such as after the comments have been
+// deleted.
 func (f *File) countComments() int {
 	comments := map[string]struct{}{}
 	f.Pkg.Range(func(k, v interface{}) bool {
@@ -251,7 +780,7 @@ func (f *File) countComments() int {
 			comments[rel] = struct{}{}
 		}
 	}
-	return len(comments)
+	return countNumberedParts(comments, "xl/comments", ".xml")
 }
 
 // commentsReader provides a function to get the pointer to the structure
@@ -283,9 +812,12 @@ func (f *File) commentsWriter() {
 
 // AddFormControl provides the method to add form control button in a worksheet
 // by given worksheet name and form control options. Supported form control
-// type: button, check box, group box, label, option button, scroll bar and
-// spinner. If set macro for the form control, the workbook extension should be
-// XLSM or XLTM. Scroll value must be between 0 and 30000.
+// type: button, check box, combo box, group box, label, option button, scroll
+// bar and spinner. If set macro for the form control, the workbook extension
+// should be XLSM or XLTM. Scroll value must be between 0 and 30000. The check
+// box, option button, combo box, scroll bar and spinner could be linked to a
+// cell by the CellLink, the linked cell will receive the checked state, the
+// selected item index or the current value of the form control.
 //
 // Example 1, add button form control with macro, rich-text, custom button size,
 // print property on Sheet1!A2, and let the button do not move or size with
@@ -359,6 +891,21 @@ func (f *File) commentsWriter() {
 //	    CellLink:     "A1",
 //	    Horizontally: true,
 //	})
+//
+// Example 5, add combo box form control on Sheet1!C1 with the list items in
+// the range Sheet1!$A$1:$A$5, show 5 items in the drop down list, select the
+// second item and write the selected item index to the Sheet1!B1:
+//
+//	err := f.AddFormControl("Sheet1", excelize.FormControl{
+//	    Cell:       "C1",
+//	    Type:       excelize.FormControlComboBox,
+//	    Width:      100,
+//	    Height:     20,
+//	    InputRange: "$A$1:$A$5",
+//	    DropLines:  5,
+//	    CurrentVal: 2,
+//	    CellLink:   "B1",
+//	})
 func (f *File) AddFormControl(sheet string, opts FormControl) error {
 	return f.addVMLObject(vmlOptions{
 		formCtrl: true, sheet: sheet, FormControl: opts,
@@ -382,55 +929,14 @@ func (f *File) DeleteFormControl(sheet, cell string) error {
 	if ws.LegacyDrawing == nil {
 		return err
 	}
-	sheetRelationshipsDrawingVML := f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID)
-	vmlID, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(sheetRelationshipsDrawingVML, "../drawings/vmlDrawing"), ".vml"))
-	drawingVML := strings.ReplaceAll(sheetRelationshipsDrawingVML, "..", "xl")
-	vml := f.VMLDrawing[drawingVML]
-	if vml == nil {
-		vml = &vmlDrawing{
-			XMLNSv:  "urn:schemas-microsoft-com:vml",
-			XMLNSo:  "urn:schemas-microsoft-com:office:office",
-			XMLNSx:  "urn:schemas-microsoft-com:office:excel",
-			XMLNSmv: "http://macVmlSchemaUri",
-			ShapeLayout: &xlsxShapeLayout{
-				Ext: "edit", IDmap: &xlsxIDmap{Ext: "edit", Data: vmlID},
-			},
-			ShapeType: &xlsxShapeType{
-				Stroke: &xlsxStroke{JoinStyle: "miter"},
-				VPath:  &vPath{GradientShapeOK: "t", ConnectType: "rect"},
-			},
-		}
-		// Load exist VML shapes from xl/drawings/vmlDrawing%d.vml
-		d, err := f.decodeVMLDrawingReader(drawingVML)
-		if err != nil {
-			return err
-		}
-		if d != nil {
-			vml.ShapeType.ID = d.ShapeType.ID
-			vml.ShapeType.CoordSize = d.ShapeType.CoordSize
-			vml.ShapeType.Spt = d.ShapeType.Spt
-			vml.ShapeType.Path = d.ShapeType.Path
-			for _, v := range d.Shape {
-				s := xlsxShape{
-					ID:          v.ID,
-					Type:        v.Type,
-					Style:       v.Style,
-					Button:      v.Button,
-					Filled:      v.Filled,
-					FillColor:   v.FillColor,
-					InsetMode:   v.InsetMode,
-					Stroked:     v.Stroked,
-					StrokeColor: v.StrokeColor,
-					Val:         v.Val,
-				}
-				vml.Shape = append(vml.Shape, s)
-			}
-		}
+	drawingVML, vml, err := f.getSheetVMLDrawing(sheet, ws.LegacyDrawing.RID)
+	if err != nil {
+		return err
 	}
 	for i, sp := range vml.Shape {
 		var shapeVal decodeShapeVal
 		if err = xml.Unmarshal([]byte(fmt.Sprintf("<shape>%s</shape>", sp.Val)), &shapeVal); err == nil &&
-			shapeVal.ClientData.ObjectType != "Note" && shapeVal.ClientData.Anchor != "" {
+			shapeVal.ClientData.ObjectType != "Note" && shapeVal.ClientData.ObjectType != "Pict" && shapeVal.ClientData.Anchor != "" {
 			leftCol, topRow, err := extractAnchorCell(shapeVal.ClientData.Anchor)
 			if err != nil {
 				return err
@@ -445,8 +951,97 @@ func (f *File) DeleteFormControl(sheet, cell string) error {
 	return err
 }
 
+// getSheetVMLDrawing provides a function to get the VML drawing part path and
+// the VML drawing with the exist shapes by given worksheet name and the
+// relationship ID of the legacy drawing.
+func (f *File) getSheetVMLDrawing(sheet, rID string) (string, *vmlDrawing, error) {
+	sheetRelationshipsDrawingVML := f.getSheetRelationshipsTargetByID(sheet, rID)
+	vmlID, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(sheetRelationshipsDrawingVML, "../drawings/vmlDrawing"), ".vml"))
+	drawingVML := strings.ReplaceAll(sheetRelationshipsDrawingVML, "..", "xl")
+	vml, err := f.getVMLDrawing(drawingVML, vmlID)
+	return drawingVML, vml, err
+}
+
+// getVMLDrawing provides a function to get the VML drawing by given VML
+// drawing part path and data ID, the exist shape types and shapes will be
+// loaded from the VML drawing part if the VML drawing hasn't been read.
+func (f *File) getVMLDrawing(drawingVML string, dataID int) (*vmlDrawing, error) {
+	if vml := f.VMLDrawing[drawingVML]; vml != nil {
+		return vml, nil
+	}
+	vml := &vmlDrawing{
+		XMLNSv:  "urn:schemas-microsoft-com:vml",
+		XMLNSo:  "urn:schemas-microsoft-com:office:office",
+		XMLNSx:  "urn:schemas-microsoft-com:office:excel",
+		XMLNSmv: "http://macVmlSchemaUri",
+		ShapeLayout: &xlsxShapeLayout{
+			Ext: "edit", IDmap: &xlsxIDmap{Ext: "edit", Data: dataID},
+		},
+	}
+	// Load exist VML shapes from xl/drawings/vmlDrawing%d.vml
+	d, err := f.decodeVMLDrawingReader(drawingVML)
+	if err != nil {
+		return nil, err
+	}
+	if d != nil {
+		for _, v := range d.ShapeType {
+			vml.ShapeType = append(vml.ShapeType, &xlsxShapeType{
+				ID:             v.ID,
+				CoordSize:      v.CoordSize,
+				Spt:            v.Spt,
+				PreferRelative: v.PreferRelative,
+				Path:           v.Path,
+				Filled:         v.Filled,
+				Stroked:        v.Stroked,
+				Val:            v.Val,
+			})
+		}
+		for _, v := range d.Shape {
+			s := xlsxShape{
+				ID:          v.ID,
+				Type:        v.Type,
+				Style:       v.Style,
+				Button:      v.Button,
+				Filled:      v.Filled,
+				FillColor:   v.FillColor,
+				InsetMode:   v.InsetMode,
+				Stroked:     v.Stroked,
+				StrokeColor: v.StrokeColor,
+				Val:         v.Val,
+			}
+			vml.Shape = append(vml.Shape, s)
+		}
+	}
+	return vml, err
+}
+
+// addShapeType provides a function to add the shape type to the VML drawing
+// if the shape type with the same ID doesn't exist.
+func (vml *vmlDrawing) addShapeType(shapeType *xlsxShapeType) {
+	for _, st := range vml.ShapeType {
+		if st.ID == shapeType.ID {
+			return
+		}
+	}
+	vml.ShapeType = append(vml.ShapeType, shapeType)
+}
+
+// nextShapeID provides a function to allocate a new shape ID in the VML
+// drawing. The shape IDs of each VML drawing are started from 1024 times the
+// data ID of the drawing plus one.
+func (vml *vmlDrawing) nextShapeID() int {
+	shapeID := vml.ShapeLayout.IDmap.Data * 1024
+	for _, sp := range vml.Shape {
+		if ID, err := strconv.Atoi(strings.TrimPrefix(sp.ID, "_x0000_s")); err == nil && ID > shapeID {
+			shapeID = ID
+		}
+	}
+	return shapeID + 1
+}
+
 // countVMLDrawing provides a function to get VML drawing files count storage
-// in the folder xl/drawings.
+// in the folder xl/drawings. The largest VML drawing file ID will be returned
+// if the VML drawing files are not numbered continuously.
 func (f *File) countVMLDrawing() int {
 	drawings := map[string]struct{}{}
 	f.Pkg.Range(func(k, v interface{}) bool {
@@ -460,7 +1055,7 @@ func (f *File) countVMLDrawing() int {
 			drawings[rel] = struct{}{}
 		}
 	}
-	return len(drawings)
+	return countNumberedParts(drawings, "xl/drawings/vmlDrawing", ".vml")
 }
 
 // decodeVMLDrawingReader provides a function to get the pointer to the
@@ -500,26 +1095,14 @@ func (f *File) addVMLObject(opts vmlOptions) error {
 	}
 	vmlID := f.countComments() + 1
 	if opts.formCtrl {
-		if opts.Type > FormControlScrollBar {
+		if opts.Type > FormControlComboBox {
 			return ErrParameterInvalid
 		}
 		vmlID = f.countVMLDrawing() + 1
 	}
-	drawingVML := "xl/drawings/vmlDrawing" + strconv.Itoa(vmlID) + ".vml"
-	sheetRelationshipsDrawingVML := "../drawings/vmlDrawing" + strconv.Itoa(vmlID) + ".vml"
+	vmlID, drawingVML := f.addSheetVMLDrawing(ws, opts.sheet, vmlID)
 	sheetXMLPath, _ := f.getSheetXMLPath(opts.sheet)
 	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
-	if ws.LegacyDrawing != nil {
-		// The worksheet already has a VML relationships, use the relationships drawing ../drawings/vmlDrawing%d.vml.
-		sheetRelationshipsDrawingVML = f.getSheetRelationshipsTargetByID(opts.sheet, ws.LegacyDrawing.RID)
-		vmlID, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(sheetRelationshipsDrawingVML, "../drawings/vmlDrawing"), ".vml"))
-		drawingVML = strings.ReplaceAll(sheetRelationshipsDrawingVML, "..", "xl")
-	} else {
-		// Add first VML drawing for given sheet.
-		rID := f.addRels(sheetRels, SourceRelationshipDrawingVML, sheetRelationshipsDrawingVML, "")
-		f.addSheetNameSpace(opts.sheet, SourceRelationship)
-		f.addSheetLegacyDrawing(opts.sheet, rID)
-	}
 	if err = f.addDrawingVML(vmlID, drawingVML, prepareFormCtrlOptions(&opts)); err != nil {
 		return err
 	}
@@ -536,6 +1119,26 @@ func (f *File) addVMLObject(opts vmlOptions) error {
 	return f.addContentTypePart(vmlID, "comments")
 }
 
+// addSheetVMLDrawing provides a function to get the VML drawing ID and the VML
+// drawing part path of the worksheet by given worksheet name. The VML drawing
+// relationships and the legacy drawing of the worksheet will be created with
+// the given VML drawing ID if the worksheet doesn't have a VML drawing.
+func (f *File) addSheetVMLDrawing(ws *xlsxWorksheet, sheet string, vmlID int) (int, string) {
+	if ws.LegacyDrawing != nil {
+		// The worksheet already has a VML relationships, use the relationships drawing ../drawings/vmlDrawing%d.vml.
+		sheetRelationshipsDrawingVML := f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID)
+		vmlID, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(sheetRelationshipsDrawingVML, "../drawings/vmlDrawing"), ".vml"))
+		return vmlID, strings.ReplaceAll(sheetRelationshipsDrawingVML, "..", "xl")
+	}
+	// Add first VML drawing for given sheet.
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+	rID := f.addRels(sheetRels, SourceRelationshipDrawingVML, "../drawings/vmlDrawing"+strconv.Itoa(vmlID)+".vml", "")
+	f.addSheetNameSpace(sheet, SourceRelationship)
+	f.addSheetLegacyDrawing(sheet, rID)
+	return vmlID, "xl/drawings/vmlDrawing" + strconv.Itoa(vmlID) + ".vml"
+}
+
 // prepareFormCtrlOptions provides a function to parse the format settings of
 // the form control with default value.
 func prepareFormCtrlOptions(opts *vmlOptions) *vmlOptions {
@@ -554,20 +1157,32 @@ func prepareFormCtrlOptions(opts *vmlOptions) *vmlOptions {
 	return opts
 }
 
-// formCtrlText returns font element in the VML for control form text.
+// formCtrlText returns font element in the VML for control form and note text,
+// the text runs of the note will not be separated by line breaks.
 func formCtrlText(opts *vmlOptions) []vmlFont {
-	var font []vmlFont
+	var (
+		font      []vmlFont
+		lineBreak string
+	)
+	escapeText := func(text string) string {
+		var buf bytes.Buffer
+		_ = xml.EscapeText(&buf, []byte(text))
+		return buf.String()
+	}
+	if opts.formCtrl {
+		lineBreak = "<br></br>\r\n"
+	}
 	if opts.FormControl.Text != "" {
-		font = append(font, vmlFont{Content: opts.FormControl.Text})
+		font = append(font, vmlFont{Content: escapeText(opts.FormControl.Text)})
 	}
 	for _, run := range opts.FormControl.Paragraph {
 		fnt := vmlFont{
-			Content: run.Text + "<br></br>\r\n",
+			Content: escapeText(run.Text) + lineBreak,
 		}
 		if run.Font != nil {
 			fnt.Face = run.Font.Family
 			fnt.Color = run.Font.Color
-			if !strings.HasPrefix(run.Font.Color, "#") {
+			if fnt.Color != "" && !strings.HasPrefix(fnt.Color, "#") {
 				fnt.Color = "#" + fnt.Color
 			}
 			if run.Font.Size != 0 {
@@ -707,6 +1322,21 @@ var formCtrlPresets = map[FormControlType]formCtrlPreset{
 		firstButton:  nil,
 		shadow:       nil,
 	},
+	FormControlComboBox: {
+		objectType:   "Drop",
+		autoFill:     "False",
+		filled:       "",
+		fillColor:    "",
+		stroked:      "f",
+		strokeColor:  "windowText [64]",
+		strokeButton: "",
+		fill:         nil,
+		textHAlign:   "",
+		textVAlign:   "",
+		noThreeD:     nil,
+		firstButton:  nil,
+		shadow:       nil,
+	},
 	FormControlSpinButton: {
 		objectType:   "Spin",
 		autoFill:     "False",
@@ -724,9 +1354,20 @@ var formCtrlPresets = map[FormControlType]formCtrlPreset{
 	},
 }
 
-// addFormCtrl check and add scroll bar or spinner form control by given options.
+// addFormCtrl check and add the cell link of the check box, option button,
+// combo box, scroll bar or spinner form control by given options.
 func (sp *encodeShape) addFormCtrl(opts *vmlOptions) error {
-	if opts.Type != FormControlScrollBar && opts.Type != FormControlSpinButton {
+	switch opts.Type {
+	case FormControlCheckBox, FormControlOptionButton:
+		if err := checkFormCtrlCellLink(opts.CellLink); err != nil {
+			return err
+		}
+		sp.ClientData.FmlaLink = opts.CellLink
+		return nil
+	case FormControlComboBox:
+		return sp.addFormCtrlComboBox(opts)
+	case FormControlScrollBar, FormControlSpinButton:
+	default:
 		return nil
 	}
 	if opts.CurrentVal > MaxFormControlValue ||
@@ -736,10 +1377,8 @@ func (sp *encodeShape) addFormCtrl(opts *vmlOptions) error {
 		opts.PageChange > MaxFormControlValue {
 		return ErrFormControlValue
 	}
-	if opts.CellLink != "" {
-		if _, _, err := CellNameToCoordinates(opts.CellLink); err != nil {
-			return err
-		}
+	if err := checkFormCtrlCellLink(opts.CellLink); err != nil {
+		return err
 	}
 	sp.ClientData.FmlaLink = opts.CellLink
 	sp.ClientData.Val = opts.CurrentVal
@@ -756,6 +1395,45 @@ func (sp *encodeShape) addFormCtrl(opts *vmlOptions) error {
 	return nil
 }
 
+// addFormCtrlComboBox check and add the input range, drop down lines, cell
+// link and selected item of the combo box form control by given options.
+func (sp *encodeShape) addFormCtrlComboBox(opts *vmlOptions) error {
+	if opts.CurrentVal > MaxFormControlValue || opts.DropLines > MaxFormControlValue {
+		return ErrFormControlValue
+	}
+	if err := checkFormCtrlCellLink(opts.CellLink); err != nil {
+		return err
+	}
+	if opts.InputRange != "" {
+		ref := opts.InputRange
+		if i := strings.LastIndex(ref, "!"); i != -1 {
+			ref = ref[i+1:]
+		}
+		if _, err := rangeRefToCoordinates(strings.ReplaceAll(ref, "$", "")); err != nil {
+			return err
+		}
+	}
+	sp.ClientData.FmlaLink = opts.CellLink
+	sp.ClientData.FmlaRange = opts.InputRange
+	sp.ClientData.Sel = opts.CurrentVal
+	sp.ClientData.DropStyle = "Combo"
+	sp.ClientData.DropLines = opts.DropLines
+	if sp.ClientData.DropLines == 0 {
+		sp.ClientData.DropLines = 8
+	}
+	return nil
+}
+
+// checkFormCtrlCellLink check the cell reference of the form control cell
+// link, the empty cell link is allowed.
+func checkFormCtrlCellLink(cellLink string) error {
+	if cellLink == "" {
+		return nil
+	}
+	_, _, err := CellNameToCoordinates(cellLink)
+	return err
+}
+
 // addFormCtrlShape returns a VML shape by given preset and options.
 func (f *File) addFormCtrlShape(preset formCtrlPreset, col, row int, anchor string, opts *vmlOptions) (*encodeShape, error) {
 	sp := encodeShape{
@@ -792,11 +1470,16 @@ func (f *File) addFormCtrlShape(preset formCtrlPreset, col, row int, anchor stri
 	if opts.FormControl.Type == FormControlNote {
 		sp.ClientData.MoveWithCells = stringPtr("")
 		sp.ClientData.SizeWithCells = stringPtr("")
+		if opts.Comment.FillColor != "" {
+			fill := *preset.fill
+			fill.Color2 = opts.Comment.FillColor
+			sp.Fill = &fill
+		}
 	}
+	sp.TextBox.Div.Font = formCtrlText(opts)
 	if !opts.formCtrl {
 		return &sp, nil
 	}
-	sp.TextBox.Div.Font = formCtrlText(opts)
 	sp.ClientData.FmlaMacro = opts.Macro
 	if (opts.Type == FormControlCheckBox || opts.Type == FormControlOptionButton) && opts.Checked {
 		sp.ClientData.Checked = 1
@@ -814,66 +1497,34 @@ func (f *File) addDrawingVML(dataID int, drawingVML string, opts *vmlOptions) er
 	if err != nil {
 		return err
 	}
-	leftOffset, vmlID, vml, preset := 23, 202, f.VMLDrawing[drawingVML], formCtrlPresets[opts.Type]
-	style := "position:absolute;73.5pt;width:108pt;height:59.25pt;z-index:1;visibility:hidden"
+	leftOffset, vmlID, preset := 23, 202, formCtrlPresets[opts.Type]
+	size := fmt.Sprintf("width:%gpt;height:%gpt", float64(opts.FormControl.Width)*0.75, float64(opts.FormControl.Height)*0.75)
+	style := "position:absolute;73.5pt;" + size + ";z-index:1;visibility:hidden"
 	if opts.formCtrl {
 		leftOffset, vmlID = 0, 201
-		style = "position:absolute;73.5pt;width:108pt;height:59.25pt;z-index:1;mso-wrap-style:tight"
+		style = "position:absolute;73.5pt;" + size + ";z-index:1;mso-wrap-style:tight"
 	}
 	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(opts.sheet, col, row, opts.Format.OffsetX, opts.Format.OffsetY, int(opts.FormControl.Width), int(opts.FormControl.Height))
 	anchor := fmt.Sprintf("%d, %d, %d, 0, %d, %d, %d, %d", colStart, leftOffset, rowStart, colEnd, x2, rowEnd, y2)
-	if vml == nil {
-		vml = &vmlDrawing{
-			XMLNSv:  "urn:schemas-microsoft-com:vml",
-			XMLNSo:  "urn:schemas-microsoft-com:office:office",
-			XMLNSx:  "urn:schemas-microsoft-com:office:excel",
-			XMLNSmv: "http://macVmlSchemaUri",
-			ShapeLayout: &xlsxShapeLayout{
-				Ext: "edit", IDmap: &xlsxIDmap{Ext: "edit", Data: dataID},
-			},
-			ShapeType: &xlsxShapeType{
-				ID:        fmt.Sprintf("_x0000_t%d", vmlID),
-				CoordSize: "21600,21600",
-				Spt:       202,
-				Path:      "m0,0l0,21600,21600,21600,21600,0xe",
-				Stroke:    &xlsxStroke{JoinStyle: "miter"},
-				VPath:     &vPath{GradientShapeOK: "t", ConnectType: "rect"},
-			},
-		}
-		// Load exist VML shapes from xl/drawings/vmlDrawing%d.vml
-		d, err := f.decodeVMLDrawingReader(drawingVML)
-		if err != nil {
-			return err
-		}
-		if d != nil {
-			vml.ShapeType.ID = d.ShapeType.ID
-			vml.ShapeType.CoordSize = d.ShapeType.CoordSize
-			vml.ShapeType.Spt = d.ShapeType.Spt
-			vml.ShapeType.Path = d.ShapeType.Path
-			for _, v := range d.Shape {
-				s := xlsxShape{
-					ID:          v.ID,
-					Type:        v.Type,
-					Style:       v.Style,
-					Button:      v.Button,
-					Filled:      v.Filled,
-					FillColor:   v.FillColor,
-					InsetMode:   v.InsetMode,
-					Stroked:     v.Stroked,
-					StrokeColor: v.StrokeColor,
-					Val:         v.Val,
-				}
-				vml.Shape = append(vml.Shape, s)
-			}
-		}
+	vml, err := f.getVMLDrawing(drawingVML, dataID)
+	if err != nil {
+		return err
 	}
+	vml.addShapeType(&xlsxShapeType{
+		ID:        fmt.Sprintf("_x0000_t%d", vmlID),
+		CoordSize: "21600,21600",
+		Spt:       202,
+		Path:      "m0,0l0,21600,21600,21600,21600,0xe",
+		Stroke:    &xlsxStroke{JoinStyle: "miter"},
+		VPath:     &vPath{GradientShapeOK: "t", ConnectType: "rect"},
+	})
 	sp, err := f.addFormCtrlShape(preset, col, row, anchor, opts)
 	if err != nil {
 		return err
 	}
 	s, _ := xml.Marshal(sp)
 	shape := xlsxShape{
-		ID:          "_x0000_s1025",
+		ID:          fmt.Sprintf("_x0000_s%d", vml.nextShapeID()),
 		Type:        fmt.Sprintf("#_x0000_t%d", vmlID),
 		Style:       style,
 		Button:      preset.strokeButton,
@@ -883,6 +1534,9 @@ func (f *File) addDrawingVML(dataID int, drawingVML string, opts *vmlOptions) er
 		StrokeColor: preset.strokeColor,
 		Val:         string(s[13 : len(s)-14]),
 	}
+	if !opts.formCtrl && opts.Comment.FillColor != "" {
+		shape.FillColor = opts.Comment.FillColor
+	}
 	vml.Shape = append(vml.Shape, shape)
 	f.VMLDrawing[drawingVML] = vml
 	return err
@@ -971,6 +1625,11 @@ func extractFormControl(clientData string) (FormControl, error) {
 			formControl.Checked = shapeVal.ClientData.Checked != 0
 			formControl.CellLink = shapeVal.ClientData.FmlaLink
 			formControl.CurrentVal = shapeVal.ClientData.Val
+			if formCtrlType == FormControlComboBox {
+				formControl.CurrentVal = shapeVal.ClientData.Sel
+			}
+			formControl.InputRange = shapeVal.ClientData.FmlaRange
+			formControl.DropLines = shapeVal.ClientData.DropLines
 			formControl.MinVal = shapeVal.ClientData.Min
 			formControl.MaxVal = shapeVal.ClientData.Max
 			formControl.IncChange = shapeVal.ClientData.Inc
@@ -1032,6 +1691,7 @@ func extractVMLFont(font []decodeVMLFont) []RichTextRun {
 			return
 		}
 		extractI(b.I, run)
+		extractU(b.U, run)
 		run.Text += b.Val
 		if run.Font == nil {
 			run.Font = &Font{}
@@ -1049,7 +1709,7 @@ func extractVMLFont(font []decodeVMLFont) []RichTextRun {
 				run.Font = &Font{}
 			}
 			run.Font.Family = fnt.Face
-			run.Font.Size = float64(fnt.Size / 20)
+			run.Font.Size = float64(fnt.Size) / 20
 			run.Font.Color = fnt.Color
 		}
 		runs = append(runs, run)
@@ -22,7 +22,7 @@ type vmlDrawing struct {
 	XMLNSx      string           `xml:"xmlns:x,attr"`
 	XMLNSmv     string           `xml:"xmlns:mv,attr"`
 	ShapeLayout *xlsxShapeLayout `xml:"o:shapelayout"`
-	ShapeType   *xlsxShapeType   `xml:"v:shapetype"`
+	ShapeType   []*xlsxShapeType `xml:"v:shapetype"`
 	Shape       []xlsxShape      `xml:"v:shape"`
 }
 
@@ -57,12 +57,18 @@ type xlsxShape struct {
 
 // xlsxShapeType directly maps the shapetype element.
 type xlsxShapeType struct {
-	ID        string      `xml:"id,attr"`
-	CoordSize string      `xml:"coordsize,attr"`
-	Spt       int         `xml:"o:spt,attr"`
-	Path      string      `xml:"path,attr"`
-	Stroke    *xlsxStroke `xml:"v:stroke"`
-	VPath     *vPath      `xml:"v:path"`
+	ID             string      `xml:"id,attr"`
+	CoordSize      string      `xml:"coordsize,attr"`
+	Spt            int         `xml:"o:spt,attr"`
+	PreferRelative string      `xml:"o:preferrelative,attr,omitempty"`
+	Path           string      `xml:"path,attr"`
+	Filled         string      `xml:"filled,attr,omitempty"`
+	Stroked        string      `xml:"stroked,attr,omitempty"`
+	Stroke         *xlsxStroke `xml:"v:stroke"`
+	Formulas       *vFormulas  `xml:"v:formulas"`
+	VPath          *vPath      `xml:"v:path"`
+	Lock           *oLock      `xml:"o:lock"`
+	Val            string      `xml:",innerxml"`
 }
 
 // xlsxStroke directly maps the stroke element.
@@ -70,12 +76,29 @@ type xlsxStroke struct {
 	JoinStyle string `xml:"joinstyle,attr"`
 }
 
+// vFormulas directly maps the v:formulas element.
+type vFormulas struct {
+	F []vFormula `xml:"v:f"`
+}
+
+// vFormula directly maps the v:f element.
+type vFormula struct {
+	Eqn string `xml:"eqn,attr"`
+}
+
 // vPath directly maps the v:path element.
 type vPath struct {
+	ExtrusionOK     string `xml:"o:extrusionok,attr,omitempty"`
 	GradientShapeOK string `xml:"gradientshapeok,attr,omitempty"`
 	ConnectType     string `xml:"o:connecttype,attr"`
 }
 
+// oLock directly maps the o:lock element.
+type oLock struct {
+	Ext         string `xml:"v:ext,attr"`
+	AspectRatio string `xml:"aspectratio,attr,omitempty"`
+}
+
 // vFill directly maps the v:fill element. This element must be defined within a
 // Shape element.
 type vFill struct {
@@ -139,6 +162,10 @@ type xClientData struct {
 	TextVAlign    string  `xml:"x:TextVAlign,omitempty"`
 	Row           *int    `xml:"x:Row"`
 	Column        *int    `xml:"x:Column"`
+	FmlaRange     string  `xml:"x:FmlaRange,omitempty"`
+	Sel           uint    `xml:"x:Sel,omitempty"`
+	DropStyle     string  `xml:"x:DropStyle,omitempty"`
+	DropLines     uint    `xml:"x:DropLines,omitempty"`
 	Checked       int     `xml:"x:Checked,omitempty"`
 	FmlaLink      string  `xml:"x:FmlaLink,omitempty"`
 	NoThreeD      *string `xml:"x:NoThreeD"`
@@ -155,17 +182,21 @@ type xClientData struct {
 // decodeVmlDrawing defines the structure used to parse the file
 // xl/drawings/vmlDrawing%d.vml.
 type decodeVmlDrawing struct {
-	ShapeType decodeShapeType `xml:"urn:schemas-microsoft-com:vml shapetype"`
-	Shape     []decodeShape   `xml:"urn:schemas-microsoft-com:vml shape"`
+	ShapeType []decodeShapeType `xml:"urn:schemas-microsoft-com:vml shapetype"`
+	Shape     []decodeShape     `xml:"urn:schemas-microsoft-com:vml shape"`
 }
 
 // decodeShapeType defines the structure used to parse the shapetype element in
 // the file xl/drawings/vmlDrawing%d.vml.
 type decodeShapeType struct {
-	ID        string `xml:"id,attr"`
-	CoordSize string `xml:"coordsize,attr"`
-	Spt       int    `xml:"spt,attr"`
-	Path      string `xml:"path,attr"`
+	ID             string `xml:"id,attr"`
+	CoordSize      string `xml:"coordsize,attr"`
+	Spt            int    `xml:"spt,attr"`
+	PreferRelative string `xml:"preferrelative,attr"`
+	Path           string `xml:"path,attr"`
+	Filled         string `xml:"filled,attr"`
+	Stroked        string `xml:"stroked,attr"`
+	Val            string `xml:",innerxml"`
 }
 
 // decodeShape defines the structure used to parse the particular shape element.
@@ -238,6 +269,9 @@ type decodeVMLClientData struct {
 	FmlaMacro  string
 	Column     *int
 	Row        *int
+	FmlaRange  string
+	Sel        uint
+	DropLines  uint
 	Checked    int
 	FmlaLink   string
 	Val        uint
@@ -296,6 +330,8 @@ type FormControl struct {
 	PageChange   uint
 	Horizontally bool
 	CellLink     string
+	InputRange   string
+	DropLines    uint
 	Text         string
 	Paragraph    []RichTextRun
 	Type         FormControlType
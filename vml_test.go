@@ -18,6 +18,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -81,6 +82,83 @@ func TestAddComment(t *testing.T) {
 	assert.EqualError(t, err, "sheet SheetN does not exist")
 }
 
+func TestCommentFont(t *testing.T) {
+	f := NewFile()
+	paragraph := []RichTextRun{
+		{Text: "Bold ", Font: &Font{Bold: true, Size: 10.5, Color: "FF0000", Family: "Arial", Underline: "double"}},
+		{Text: "italic ", Font: &Font{Italic: true, Strike: true, Underline: "single", VertAlign: "superscript", ColorTheme: intPtr(1), ColorTint: -0.5}},
+		{Text: "plain"},
+	}
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Paragraph: paragraph}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCommentFont.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err := OpenFile(filepath.Join("test", "TestCommentFont.xlsx"))
+	assert.NoError(t, err)
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, []RichTextRun{
+		{Text: "Bold ", Font: &Font{Bold: true, Size: 10.5, Color: "FF0000", Family: "Arial", Underline: "double"}},
+		{Text: "italic ", Font: &Font{Italic: true, Strike: true, Underline: "single", VertAlign: "superscript", ColorTheme: intPtr(1), ColorTint: -0.5, Size: 9, Family: "Calibri"}},
+		{Text: "plain", Font: &Font{Underline: "none", Size: 9, Family: "Calibri", ColorIndexed: 81}},
+	}, comments[0].Paragraph)
+	// Test the font of the legacy note shape in the VML drawing
+	vml, err := f.decodeVMLDrawingReader("xl/drawings/vmlDrawing1.vml")
+	assert.NoError(t, err)
+	assert.Len(t, vml.Shape, 1)
+	var shape decodeShapeVal
+	assert.NoError(t, xml.Unmarshal([]byte("<shape>"+vml.Shape[0].Val+"</shape>"), &shape))
+	runs := extractVMLFont(shape.TextBox.Div.Font)
+	assert.Equal(t, &Font{Bold: true, Size: 10.5, Color: "#FF0000", Family: "Arial", Underline: "double"}, runs[0].Font)
+	assert.Equal(t, &Font{Italic: true, Underline: "single"}, runs[1].Font)
+	assert.Nil(t, runs[2].Font)
+	assert.NoError(t, f.Close())
+}
+
+func TestCommentBox(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Warning", Width: 180, Height: 40, FillColor: "ff0000"}))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "B2", Author: "Excelize", Text: "Note"}))
+	expected := []Comment{
+		{Author: "Excelize", Cell: "A1", Text: "Warning", Width: 180, Height: 40, FillColor: "#FF0000"},
+		{Author: "Excelize", Cell: "B2", Text: "Note", Width: 140, Height: 60, FillColor: "#FBF6D6"},
+	}
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, comments)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCommentBox.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestCommentBox.xlsx"))
+	assert.NoError(t, err)
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, comments)
+	// Test get comments with unsupported charset VML drawing
+	f.DecodeVMLDrawing["xl/drawings/vmlDrawing1.vml"] = nil
+	f.Pkg.Store("xl/drawings/vmlDrawing1.vml", MacintoshCyrillicCharset)
+	_, err = f.GetComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get comments with invalid client data of the note shape
+	f.VMLDrawing["xl/drawings/vmlDrawing1.vml"] = &vmlDrawing{Shape: []xlsxShape{{Val: "<x:ClientData ObjectType=\"Note\"><x:Row>0</x:Row><x:Column>-1</x:Column></x:ClientData>"}}}
+	_, err = f.GetComments("Sheet1")
+	assert.Equal(t, newCoordinatesToCellNameError(0, 1), err)
+	f.VMLDrawing["xl/drawings/vmlDrawing1.vml"] = &vmlDrawing{Shape: []xlsxShape{{Val: "<x:ClientData>"}}}
+	_, err = f.GetComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: element <ClientData> closed by </shape>")
+	assert.NoError(t, f.Close())
+
+	// Test add comment with invalid box size and fill color
+	f = NewFile()
+	assert.Equal(t, ErrCommentBoxSize, f.AddComment("Sheet1", Comment{Cell: "A1", Text: "Comment", Width: MaxCommentBoxSize + 1}))
+	assert.Equal(t, ErrCommentBoxSize, f.AddComment("Sheet1", Comment{Cell: "A1", Text: "Comment", Height: MaxCommentBoxSize + 1}))
+	for _, color := range []string{"red", "#FF00", "#GG0000"} {
+		assert.Equal(t, newInvalidCommentFillColorError(color), f.AddComment("Sheet1", Comment{Cell: "A1", Text: "Comment", FillColor: color}))
+	}
+	assert.NoError(t, f.Close())
+}
+
 func TestDeleteComment(t *testing.T) {
 	f, err := prepareTestBook1()
 	if !assert.NoError(t, err) {
@@ -107,23 +185,233 @@ func TestDeleteComment(t *testing.T) {
 	// Test delete comment with invalid sheet name
 	assert.Equal(t, ErrSheetNameInvalid, f.DeleteComment("Sheet:1", "A1"))
 	// Test delete all comments in a worksheet
+	sheetXMLPath, _ := f.getSheetXMLPath("Sheet2")
+	commentsXML := "xl" + strings.TrimPrefix(f.getSheetComments(filepath.Base(sheetXMLPath)), "..")
+	ws, err := f.workSheetReader("Sheet2")
+	assert.NoError(t, err)
+	drawingVML := strings.ReplaceAll(f.getSheetRelationshipsTargetByID("Sheet2", ws.LegacyDrawing.RID), "..", "xl")
 	assert.NoError(t, f.DeleteComment("Sheet2", "A41"))
 	assert.NoError(t, f.DeleteComment("Sheet2", "C41"))
+	assert.Len(t, f.VMLDrawing[drawingVML].Shape, 1)
 	assert.NoError(t, f.DeleteComment("Sheet2", "C42"))
 	comments, err = f.GetComments("Sheet2")
 	assert.NoError(t, err)
 	assert.EqualValues(t, 0, len(comments))
+	// Test the comments part, VML drawing and relationships have been removed
+	assert.Nil(t, ws.LegacyDrawing)
+	assert.Empty(t, f.getSheetComments(filepath.Base(sheetXMLPath)))
+	for _, part := range []string{commentsXML, drawingVML} {
+		_, ok := f.Pkg.Load(part)
+		assert.False(t, ok, part)
+	}
+	assert.Nil(t, f.Comments[commentsXML])
+	assert.Nil(t, f.VMLDrawing[drawingVML])
+	contentTypes, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	for _, override := range contentTypes.Overrides {
+		assert.NotEqual(t, "/"+commentsXML, override.PartName)
+	}
+	assert.NoError(t, f.AddComment("Sheet2", Comment{Cell: "A41", Text: "Excelize: This is a comment1."}))
+	commentsXML = "xl" + strings.TrimPrefix(f.getSheetComments(filepath.Base(sheetXMLPath)), "..")
 	// Test delete comment on not exists worksheet
 	assert.EqualError(t, f.DeleteComment("SheetN", "A1"), "sheet SheetN does not exist")
 	// Test delete comment with worksheet part
 	f.Pkg.Delete("xl/worksheets/sheet1.xml")
 	assert.NoError(t, f.DeleteComment("Sheet1", "A22"))
 
-	f.Comments["xl/comments2.xml"] = nil
-	f.Pkg.Store("xl/comments2.xml", MacintoshCyrillicCharset)
+	f.Comments[commentsXML] = nil
+	f.Pkg.Store(commentsXML, MacintoshCyrillicCharset)
 	assert.EqualError(t, f.DeleteComment("Sheet2", "A41"), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestSetComment(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Comment 1"}))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "B2", Author: "Excelize", Text: "Comment 2"}))
+	assert.NoError(t, f.AddFormControl("Sheet1", FormControl{Cell: "D4", Type: FormControlButton, Text: "Button"}))
+	// Test set comment to replace the exist comment
+	assert.NoError(t, f.SetComment("Sheet1", Comment{Cell: "A1", Author: "Reviewer", Text: "Updated", Width: 100, Height: 50}))
+	// Test set comment to add the comment in the cell without comment
+	assert.NoError(t, f.SetComment("Sheet1", Comment{Cell: "C3", Author: "Excelize", Text: "Comment 3"}))
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 3)
+	assert.Equal(t, Comment{Author: "Excelize", AuthorID: 0, Cell: "B2", Text: "Comment 2"}, Comment{
+		Author: comments[0].Author, AuthorID: comments[0].AuthorID, Cell: comments[0].Cell, Text: comments[0].Text,
+	})
+	assert.Equal(t, Comment{Author: "Reviewer", AuthorID: 1, Cell: "A1", Text: "Updated", Width: 100, Height: 50}, Comment{
+		Author: comments[1].Author, AuthorID: comments[1].AuthorID, Cell: comments[1].Cell, Text: comments[1].Text,
+		Width: comments[1].Width, Height: comments[1].Height,
+	})
+	assert.Equal(t, "Excelize", comments[2].Author)
+	assert.Equal(t, 0, comments[2].AuthorID)
+	// Test set comment to replace the threaded comment
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "E5", Author: "Excelize", Text: "Thread"}))
+	assert.NoError(t, f.SetComment("Sheet1", Comment{Cell: "E5", Author: "Excelize", Text: "Note"}))
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 4)
+	assert.Equal(t, "Note", comments[3].Text)
+	assert.Nil(t, comments[3].ThreadedComment)
+	assert.Empty(t, f.getSheetThreadedComments("Sheet1"))
+	// Test delete all comments keeps the VML drawing with form controls
+	for _, cell := range []string{"A1", "B2", "C3", "E5"} {
+		assert.NoError(t, f.DeleteComment("Sheet1", cell))
+	}
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, comments)
+	formControls, err := f.GetFormControls("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, formControls, 1)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetComment.xlsx")))
+	// Test set comment with invalid options
+	assert.Equal(t, ErrCommentBoxSize, f.SetComment("Sheet1", Comment{Cell: "A1", Width: MaxCommentBoxSize + 1}))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetComment("Sheet1", Comment{Cell: "A"}))
+	assert.EqualError(t, f.SetComment("SheetN", Comment{Cell: "A1"}), "sheet SheetN does not exist")
+	// Test delete comment with invalid cell reference
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Text: "Comment"}))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.DeleteComment("Sheet1", "A"))
+	// Test delete comment with unsupported charset VML drawing
+	f.VMLDrawing = map[string]*vmlDrawing{}
+	f.Pkg.Store("xl/drawings/vmlDrawing1.vml", MacintoshCyrillicCharset)
+	f.DecodeVMLDrawing = map[string]*decodeVmlDrawing{}
+	assert.EqualError(t, f.DeleteComment("Sheet1", "A1"), "XML syntax error on line 1: invalid UTF-8")
+	// Test delete comment with unsupported charset content types
+	f = NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Text: "Comment"}))
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.DeleteComment("Sheet1", "A1"), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestCountNumberedParts(t *testing.T) {
+	f := NewFile()
+	f.Comments["xl/comments3.xml"] = nil
+	f.VMLDrawing["xl/drawings/vmlDrawing4.vml"] = nil
+	assert.Equal(t, 3, f.countComments())
+	assert.Equal(t, 4, f.countVMLDrawing())
+}
+
+func TestThreadedComment(t *testing.T) {
+	f := NewFile()
+	date := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Legacy note"}))
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{
+		Cell: "B2", Author: "Excelize", Text: "Please check the value.", Date: date, Done: true,
+		Replies: []ThreadedCommentReply{
+			{Author: "Reviewer", Text: "Checked.", Date: date.Add(time.Hour)},
+			{Author: "Excelize", Text: "Thanks.", Date: date.Add(2 * time.Hour)},
+		},
+	}))
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "C3", Text: "Without author"}))
+	// Test add threaded comment in the cell which already has a threaded comment
+	assert.Equal(t, ErrThreadedCommentExists, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "B2"}))
+	assert.Equal(t, ErrThreadedCommentExists, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "b2"}))
+	// Test add threaded comment with invalid cell reference and worksheet name
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A"}))
+	assert.EqualError(t, f.AddThreadedComment("SheetN", ThreadedComment{Cell: "A1"}), "sheet SheetN does not exist")
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddThreadedComment("Sheet2", ThreadedComment{Cell: "A1", Author: strings.Repeat("c", MaxFieldLength+1), Text: "Sheet2"}))
+
+	path := filepath.Join("test", "TestThreadedComment.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 3)
+	assert.Equal(t, "Excelize", comments[0].Author)
+	assert.Nil(t, comments[0].ThreadedComment)
+	assert.Equal(t, "B2", comments[1].Cell)
+	assert.True(t, strings.HasPrefix(comments[1].Author, "tc={"))
+	assert.True(t, strings.HasSuffix(comments[1].Text, "Comment:\n    Please check the value.\nReply:\n    Checked.\nReply:\n    Thanks."))
+	assert.Equal(t, &ThreadedComment{
+		Cell: "B2", Author: "Excelize", Text: "Please check the value.", Date: date, Done: true,
+		Replies: []ThreadedCommentReply{
+			{Author: "Reviewer", Text: "Checked.", Date: date.Add(time.Hour)},
+			{Author: "Excelize", Text: "Thanks.", Date: date.Add(2 * time.Hour)},
+		},
+	}, comments[1].ThreadedComment)
+	assert.Equal(t, "Author", comments[2].ThreadedComment.Author)
+	assert.False(t, comments[2].ThreadedComment.Done)
+	assert.False(t, comments[2].ThreadedComment.Date.IsZero())
+	comments, err = f.GetComments("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, strings.Repeat("c", MaxFieldLength), comments[0].ThreadedComment.Author)
+	persons, err := f.personListReader(f.getPersonListPath())
+	assert.NoError(t, err)
+	assert.Len(t, persons.Person, 4)
+	// Test add threaded comment in the worksheet which has threaded comments
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "D4", Author: "Reviewer", Text: "New thread"}))
+	// Test get threaded comment without legacy note
+	assert.NoError(t, f.DeleteComment("Sheet1", "B2"))
+	f.Comments["xl/comments1.xml"].CommentList.Comment = f.Comments["xl/comments1.xml"].CommentList.Comment[:1]
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 3)
+	assert.Equal(t, Comment{Cell: "C3", ThreadedComment: comments[1].ThreadedComment}, comments[1])
+	assert.Equal(t, "D4", comments[2].ThreadedComment.Cell)
+	// Test get threaded comment with the reply which has no parent comment
+	threadedCommentsXML := f.getSheetThreadedComments("Sheet1")
+	f.Pkg.Store(threadedCommentsXML, []byte(`<ThreadedComments xmlns="http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments"><threadedComment ref="E5" personId="{0}" id="{1}" parentId="{2}"><text>reply</text></threadedComment></ThreadedComments>`))
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	// Test get, add and delete threaded comments with unsupported charset
+	f.Pkg.Store(threadedCommentsXML, MacintoshCyrillicCharset)
+	_, err = f.GetComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "F6"}), "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.DeleteComment("Sheet1", "A1"), "XML syntax error on line 1: invalid UTF-8")
+	f.Pkg.Store(threadedCommentsXML, []byte(`<ThreadedComments xmlns="http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments"/>`))
+	f.Pkg.Store(defaultXMLPathPerson, MacintoshCyrillicCharset)
+	_, err = f.GetComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "F6"}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	// Test get person list path with absolute relationship target
+	f = NewFile()
+	f.Relationships.Store(defaultXMLPathWorkbookRels, &xlsxRelationships{Relationships: []xlsxRelationship{{Type: SourceRelationshipPerson, Target: "/xl/persons/person.xml"}}})
+	assert.Equal(t, defaultXMLPathPerson, f.getPersonListPath())
+	f.Relationships.Store("xl/worksheets/_rels/sheet1.xml.rels", &xlsxRelationships{Relationships: []xlsxRelationship{{Type: SourceRelationshipThreadedComment, Target: "/xl/threadedComments/threadedComment1.xml"}}})
+	assert.Equal(t, "xl/threadedComments/threadedComment1.xml", f.getSheetThreadedComments("Sheet1"))
+	assert.Empty(t, f.getSheetThreadedComments("SheetN"))
+	// Test add threaded comment with unsupported charset content types
+	f = NewFile()
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1"}), "XML syntax error on line 1: invalid UTF-8")
+	_, ok := f.Pkg.Load(defaultXMLPathPerson)
+	assert.False(t, ok)
+	assert.Empty(t, f.getSheetThreadedComments("Sheet1"))
+	// Test add threaded comment with unsupported charset comments, the persons
+	// and threaded comments should not be changed
+	f = NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Legacy note"}))
+	f.Comments["xl/comments1.xml"] = nil
+	f.Pkg.Store("xl/comments1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "B2", Author: "Excelize"}), "XML syntax error on line 1: invalid UTF-8")
+	_, ok = f.Pkg.Load(defaultXMLPathPerson)
+	assert.False(t, ok)
+	assert.Empty(t, f.getSheetThreadedComments("Sheet1"))
+	// Test add threaded comment with lower case cell reference and the author
+	// which exceeds the maximum length in characters
+	f = NewFile()
+	author := strings.Repeat("中", MaxFieldLength+1)
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "c3", Author: author, Text: "Thread"}))
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "C3", comments[0].Cell)
+	assert.Equal(t, "C3", comments[0].ThreadedComment.Cell)
+	assert.Equal(t, string([]rune(author)[:MaxFieldLength]), comments[0].ThreadedComment.Author)
+	assert.NoError(t, f.Close())
+}
+
 func TestDecodeVMLDrawingReader(t *testing.T) {
 	f := NewFile()
 	path := "xl/drawings/vmlDrawing1.xml"
@@ -279,6 +567,20 @@ func TestFormControl(t *testing.T) {
 	assert.Equal(t, f.AddFormControl("Sheet1", FormControl{
 		Cell: "C5", Type: FormControlSpinButton, CurrentVal: MaxFormControlValue + 1,
 	}), ErrFormControlValue)
+	// Test add check box form control with illegal cell link reference
+	assert.Equal(t, f.AddFormControl("Sheet1", FormControl{
+		Cell: "A5", Type: FormControlCheckBox, CellLink: "*",
+	}), newCellNameToCoordinatesError("*", newInvalidCellNameError("*")))
+	// Test add combo box form control with invalid options
+	assert.Equal(t, f.AddFormControl("Sheet1", FormControl{
+		Cell: "E9", Type: FormControlComboBox, DropLines: MaxFormControlValue + 1,
+	}), ErrFormControlValue)
+	assert.Equal(t, f.AddFormControl("Sheet1", FormControl{
+		Cell: "E9", Type: FormControlComboBox, CellLink: "*",
+	}), newCellNameToCoordinatesError("*", newInvalidCellNameError("*")))
+	assert.Equal(t, f.AddFormControl("Sheet1", FormControl{
+		Cell: "E9", Type: FormControlComboBox, InputRange: "Sheet1!$A$1",
+	}), ErrParameterInvalid)
 	assert.NoError(t, f.Close())
 	// Test delete form control
 	f, err = OpenFile(filepath.Join("test", "TestAddFormControl.xlsm"))
@@ -401,6 +703,49 @@ func TestFormControl(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, formControls, 0)
 	assert.NoError(t, f.Close())
+	// Test add check box, option button and combo box form controls with cell link
+	f = NewFile()
+	for _, formCtrl := range []FormControl{
+		{Cell: "A1", Type: FormControlCheckBox, Text: "Check Box 1", CellLink: "B1"},
+		{Cell: "A2", Type: FormControlOptionButton, Text: "Option Button 1", Checked: true, CellLink: "B2"},
+		{
+			Cell: "A3", Type: FormControlComboBox, Width: 100, Height: 20,
+			InputRange: "$D$1:$D$5", DropLines: 5, CurrentVal: 2, CellLink: "B3",
+		},
+		{
+			Cell: "A5", Type: FormControlComboBox, Width: 100, Height: 20,
+			InputRange: "'Sheet 1'!$A$1:$A$5", DropLines: 8,
+		},
+	} {
+		assert.NoError(t, f.AddFormControl("Sheet1", formCtrl))
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddFormControlCellLink.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestAddFormControlCellLink.xlsx"))
+	assert.NoError(t, err)
+	result, err = f.GetFormControls("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, result, 4)
+	assert.Equal(t, FormControlCheckBox, result[0].Type)
+	assert.Equal(t, "A1", result[0].Cell)
+	assert.Equal(t, "B1", result[0].CellLink)
+	assert.False(t, result[0].Checked)
+	assert.Equal(t, FormControlOptionButton, result[1].Type)
+	assert.Equal(t, "A2", result[1].Cell)
+	assert.Equal(t, "B2", result[1].CellLink)
+	assert.True(t, result[1].Checked)
+	assert.Equal(t, FormControlComboBox, result[2].Type)
+	assert.Equal(t, "A3", result[2].Cell)
+	assert.Equal(t, "B3", result[2].CellLink)
+	assert.Equal(t, "$D$1:$D$5", result[2].InputRange)
+	assert.Equal(t, uint(5), result[2].DropLines)
+	assert.Equal(t, uint(2), result[2].CurrentVal)
+	assert.Equal(t, FormControlComboBox, result[3].Type)
+	assert.Equal(t, "A5", result[3].Cell)
+	assert.Empty(t, result[3].CellLink)
+	assert.Equal(t, "'Sheet 1'!$A$1:$A$5", result[3].InputRange)
+	assert.Equal(t, uint(8), result[3].DropLines)
+	assert.NoError(t, f.Close())
 }
 
 func TestExtractFormControl(t *testing.T) {
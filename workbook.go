@@ -59,6 +59,81 @@ func (f *File) GetWorkbookProps() (WorkbookPropsOptions, error) {
 	return opts, err
 }
 
+// SetWorkbookView provides a function to sets the sheet tabs and scroll bars
+// visibility of the active workbook view. For example, hide the sheet tabs and
+// scroll bars of the workbook:
+//
+//	disable := false
+//	err := f.SetWorkbookView(&excelize.WorkbookViewOptions{
+//	    ShowSheetTabs:        &disable,
+//	    ShowHorizontalScroll: &disable,
+//	    ShowVerticalScroll:   &disable,
+//	})
+func (f *File) SetWorkbookView(opts *WorkbookViewOptions) error {
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if opts == nil {
+		return nil
+	}
+	if wb.BookViews == nil {
+		wb.BookViews = &xlsxBookViews{}
+	}
+	if len(wb.BookViews.WorkBookView) == 0 {
+		wb.BookViews.WorkBookView = append(wb.BookViews.WorkBookView, xlsxWorkBookView{})
+	}
+	view := &wb.BookViews.WorkBookView[0]
+	if opts.ShowSheetTabs != nil {
+		view.ShowSheetTabs = boolPtr(*opts.ShowSheetTabs)
+	}
+	if opts.ShowHorizontalScroll != nil {
+		view.ShowHorizontalScroll = boolPtr(*opts.ShowHorizontalScroll)
+	}
+	if opts.ShowVerticalScroll != nil {
+		view.ShowVerticalScroll = boolPtr(*opts.ShowVerticalScroll)
+	}
+	return nil
+}
+
+// GetWorkbookView provides a function to gets the sheet tabs and scroll bars
+// visibility of the active workbook view.
+func (f *File) GetWorkbookView() (WorkbookViewOptions, error) {
+	opts := WorkbookViewOptions{
+		ShowSheetTabs:        boolPtr(true),
+		ShowHorizontalScroll: boolPtr(true),
+		ShowVerticalScroll:   boolPtr(true),
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return opts, err
+	}
+	if wb.BookViews == nil || len(wb.BookViews.WorkBookView) == 0 {
+		return opts, err
+	}
+	view := wb.BookViews.WorkBookView[0]
+	if view.ShowSheetTabs != nil {
+		opts.ShowSheetTabs = boolPtr(*view.ShowSheetTabs)
+	}
+	if view.ShowHorizontalScroll != nil {
+		opts.ShowHorizontalScroll = boolPtr(*view.ShowHorizontalScroll)
+	}
+	if view.ShowVerticalScroll != nil {
+		opts.ShowVerticalScroll = boolPtr(*view.ShowVerticalScroll)
+	}
+	return opts, err
+}
+
+// getDate1904 provides a function to get the date system of the workbook,
+// returns true if the workbook uses the 1904 date system.
+func (f *File) getDate1904() (bool, error) {
+	wb, err := f.workbookReader()
+	if err != nil {
+		return false, err
+	}
+	return wb != nil && wb.WorkbookPr != nil && wb.WorkbookPr.Date1904, err
+}
+
 // ProtectWorkbook provides a function to prevent other users from viewing
 // hidden worksheets, adding, moving, deleting, or hiding worksheets, and
 // renaming worksheets in a workbook. The optional field AlgorithmName
@@ -310,28 +385,38 @@ func (f *File) addContentTypePart(index int, contentType string) error {
 		"drawings": f.setContentTypePartImageExtensions,
 	}
 	partNames := map[string]string{
-		"chart":         "/xl/charts/chart" + strconv.Itoa(index) + ".xml",
-		"chartsheet":    "/xl/chartsheets/sheet" + strconv.Itoa(index) + ".xml",
-		"comments":      "/xl/comments" + strconv.Itoa(index) + ".xml",
-		"drawings":      "/xl/drawings/drawing" + strconv.Itoa(index) + ".xml",
-		"table":         "/xl/tables/table" + strconv.Itoa(index) + ".xml",
-		"pivotTable":    "/xl/pivotTables/pivotTable" + strconv.Itoa(index) + ".xml",
-		"pivotCache":    "/xl/pivotCache/pivotCacheDefinition" + strconv.Itoa(index) + ".xml",
-		"sharedStrings": "/xl/sharedStrings.xml",
-		"slicer":        "/xl/slicers/slicer" + strconv.Itoa(index) + ".xml",
-		"slicerCache":   "/xl/slicerCaches/slicerCache" + strconv.Itoa(index) + ".xml",
+		"chart":            "/xl/charts/chart" + strconv.Itoa(index) + ".xml",
+		"chartsheet":       "/xl/chartsheets/sheet" + strconv.Itoa(index) + ".xml",
+		"comments":         "/xl/comments" + strconv.Itoa(index) + ".xml",
+		"customProperties": "/" + defaultXMLPathDocPropsCustom,
+		"drawings":         "/xl/drawings/drawing" + strconv.Itoa(index) + ".xml",
+		"metadata":         "/xl/metadata.xml",
+		"oleObject":        "/xl/embeddings/oleObject" + strconv.Itoa(index) + ".bin",
+		"person":           "/" + defaultXMLPathPerson,
+		"table":            "/xl/tables/table" + strconv.Itoa(index) + ".xml",
+		"pivotTable":       "/xl/pivotTables/pivotTable" + strconv.Itoa(index) + ".xml",
+		"pivotCache":       "/xl/pivotCache/pivotCacheDefinition" + strconv.Itoa(index) + ".xml",
+		"sharedStrings":    "/xl/sharedStrings.xml",
+		"slicer":           "/xl/slicers/slicer" + strconv.Itoa(index) + ".xml",
+		"slicerCache":      "/xl/slicerCaches/slicerCache" + strconv.Itoa(index) + ".xml",
+		"threadedComment":  "/xl/threadedComments/threadedComment" + strconv.Itoa(index) + ".xml",
 	}
 	contentTypes := map[string]string{
-		"chart":         ContentTypeDrawingML,
-		"chartsheet":    ContentTypeSpreadSheetMLChartsheet,
-		"comments":      ContentTypeSpreadSheetMLComments,
-		"drawings":      ContentTypeDrawing,
-		"table":         ContentTypeSpreadSheetMLTable,
-		"pivotTable":    ContentTypeSpreadSheetMLPivotTable,
-		"pivotCache":    ContentTypeSpreadSheetMLPivotCacheDefinition,
-		"sharedStrings": ContentTypeSpreadSheetMLSharedStrings,
-		"slicer":        ContentTypeSlicer,
-		"slicerCache":   ContentTypeSlicerCache,
+		"chart":            ContentTypeDrawingML,
+		"chartsheet":       ContentTypeSpreadSheetMLChartsheet,
+		"comments":         ContentTypeSpreadSheetMLComments,
+		"customProperties": ContentTypeCustomProperties,
+		"drawings":         ContentTypeDrawing,
+		"metadata":         ContentTypeSpreadSheetMLMetadata,
+		"oleObject":        ContentTypeOLEObject,
+		"person":           ContentTypePerson,
+		"table":            ContentTypeSpreadSheetMLTable,
+		"pivotTable":       ContentTypeSpreadSheetMLPivotTable,
+		"pivotCache":       ContentTypeSpreadSheetMLPivotCacheDefinition,
+		"sharedStrings":    ContentTypeSpreadSheetMLSharedStrings,
+		"slicer":           ContentTypeSlicer,
+		"slicerCache":      ContentTypeSlicerCache,
+		"threadedComment":  ContentTypeThreadedComments,
 	}
 	s, ok := setContentType[contentType]
 	if ok {
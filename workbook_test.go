@@ -1,7 +1,10 @@
 package excelize
 
 import (
+	"container/list"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -32,6 +35,107 @@ func TestWorkbookProps(t *testing.T) {
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestWorkbookView(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetWorkbookView(nil))
+	opts, err := f.GetWorkbookView()
+	assert.NoError(t, err)
+	assert.Equal(t, WorkbookViewOptions{
+		ShowSheetTabs:        boolPtr(true),
+		ShowHorizontalScroll: boolPtr(true),
+		ShowVerticalScroll:   boolPtr(true),
+	}, opts)
+	f.WorkBook.BookViews = nil
+	expected := WorkbookViewOptions{
+		ShowSheetTabs:        boolPtr(false),
+		ShowHorizontalScroll: boolPtr(false),
+		ShowVerticalScroll:   boolPtr(true),
+	}
+	assert.NoError(t, f.SetWorkbookView(&expected))
+	opts, err = f.GetWorkbookView()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, opts)
+	assert.NoError(t, f.SetWorkbookView(&WorkbookViewOptions{ShowVerticalScroll: boolPtr(false)}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestWorkbookView.xlsx")))
+	assert.NoError(t, f.Close())
+	// Test get workbook view after round-trip
+	f, err = OpenFile(filepath.Join("test", "TestWorkbookView.xlsx"))
+	assert.NoError(t, err)
+	opts, err = f.GetWorkbookView()
+	assert.NoError(t, err)
+	assert.Equal(t, WorkbookViewOptions{
+		ShowSheetTabs:        boolPtr(false),
+		ShowHorizontalScroll: boolPtr(false),
+		ShowVerticalScroll:   boolPtr(false),
+	}, opts)
+	assert.NoError(t, f.Close())
+	// Test set workbook view with unsupported charset workbook
+	f = NewFile()
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetWorkbookView(&expected), "XML syntax error on line 1: invalid UTF-8")
+	// Test get workbook view with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.GetWorkbookView()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestDate1904(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetWorkbookProps(&WorkbookPropsOptions{Date1904: boolPtr(true)}))
+	style, err := f.NewStyle(&Style{CustomNumFmt: stringPtr("yyyy-mm-dd hh:mm")})
+	assert.NoError(t, err)
+	for i, value := range []interface{}{
+		time.Date(1904, 1, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(1904, 3, 1, 12, 30, 0, 0, time.UTC),
+		time.Date(2023, 10, 1, 8, 0, 0, 0, time.UTC),
+		43000.5,
+	} {
+		cell, err := CoordinatesToCellName(1, i+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+		assert.NoError(t, f.SetCellStyle("Sheet1", cell, cell, style))
+		assert.NoError(t, f.SetCellFormula("Sheet1", "B"+cell[1:], "TEXT("+cell+",\"yyyy-mm-dd\")"))
+	}
+	file := filepath.Join("test", "TestDate1904.xlsx")
+	assert.NoError(t, f.SaveAs(file))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(file)
+	assert.NoError(t, err)
+	expected := [][]string{
+		{"1904-01-02 00:00", "1904-01-02"},
+		{"1904-03-01 12:30", "1904-03-01"},
+		{"2023-10-01 08:00", "2023-10-01"},
+		{"2021-09-23 12:00", "2021-09-23"},
+	}
+	for i, row := range expected {
+		cell, err := CoordinatesToCellName(1, i+1)
+		assert.NoError(t, err)
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, row[0], value, cell)
+		result, err := f.CalcCellValue("Sheet1", "B"+cell[1:])
+		assert.NoError(t, err)
+		assert.Equal(t, row[1], result, cell)
+	}
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	for i, row := range rows {
+		assert.Equal(t, expected[i][0], row[0])
+	}
+	// Test calculate TEXT function with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	fn := formulaFuncs{f: f}
+	args := list.New()
+	args.PushBack(newNumberFormulaArg(1))
+	args.PushBack(newStringFormulaArg("yyyy-mm-dd"))
+	assert.Equal(t, newErrorFormulaArg(formulaErrorVALUE, "XML syntax error on line 1: invalid UTF-8"), fn.TEXT(args))
+	assert.NoError(t, f.Close())
+}
+
 func TestDeleteWorkbookRels(t *testing.T) {
 	f := NewFile()
 	// Test delete pivot table without worksheet relationships
@@ -0,0 +1,524 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// Record types of the BIFF12 records used in the binary workbook parts.
+const (
+	brtRowHdr            = 0
+	brtCellBlank         = 1
+	brtCellRk            = 2
+	brtCellError         = 3
+	brtCellBool          = 4
+	brtCellReal          = 5
+	brtCellSt            = 6
+	brtCellIsst          = 7
+	brtFmlaString        = 8
+	brtFmlaNum           = 9
+	brtFmlaBool          = 10
+	brtFmlaError         = 11
+	brtSSTItem           = 19
+	brtFmt               = 44
+	brtXF                = 47
+	brtColInfo           = 60
+	brtWbProp            = 153
+	brtBundleSh          = 156
+	brtMergeCell         = 176
+	brtBeginCellXFs      = 617
+	brtEndCellXFs        = 618
+	brtBeginCellStyleXFs = 626
+	brtEndCellStyleXFs   = 627
+)
+
+// biff12ErrorValues defined the error values mapping by the error code in
+// the BIFF12 records.
+var biff12ErrorValues = map[byte]string{
+	0x00: formulaErrorNULL,
+	0x07: formulaErrorDIV,
+	0x0F: formulaErrorVALUE,
+	0x17: formulaErrorREF,
+	0x1D: formulaErrorNAME,
+	0x24: formulaErrorNUM,
+	0x2A: formulaErrorNA,
+	0x2B: formulaErrorGETTINGDATA,
+}
+
+// biff12Record directly maps a record in the binary workbook parts.
+type biff12Record struct {
+	typ  int
+	data []byte
+}
+
+// biff12Reader provides a reader to iterate records in the binary workbook
+// parts.
+type biff12Reader struct {
+	data   []byte
+	offset int
+}
+
+// readVarUint reads a variable-length unsigned integer which is used to
+// store the record type and record size with at most given bytes.
+func (r *biff12Reader) readVarUint(maxBytes int) (int, bool) {
+	var value int
+	for i := 0; i < maxBytes; i++ {
+		if r.offset >= len(r.data) {
+			return 0, false
+		}
+		b := r.data[r.offset]
+		r.offset++
+		value |= int(b&0x7F) << (7 * i)
+		if b&0x80 == 0 {
+			break
+		}
+	}
+	return value, true
+}
+
+// next returns the next record in the binary part, and returns false if
+// there are no more records.
+func (r *biff12Reader) next() (biff12Record, bool) {
+	typ, ok := r.readVarUint(2)
+	if !ok {
+		return biff12Record{}, false
+	}
+	size, ok := r.readVarUint(4)
+	if !ok || r.offset+size > len(r.data) {
+		return biff12Record{}, false
+	}
+	record := biff12Record{typ: typ, data: r.data[r.offset : r.offset+size]}
+	r.offset += size
+	return record, true
+}
+
+// uint16At returns the 2 bytes unsigned integer at given offset of the
+// record data.
+func (rec *biff12Record) uint16At(offset int) (uint16, bool) {
+	if offset+2 > len(rec.data) {
+		return 0, false
+	}
+	return binary.LittleEndian.Uint16(rec.data[offset:]), true
+}
+
+// uint32At returns the 4 bytes unsigned integer at given offset of the
+// record data.
+func (rec *biff12Record) uint32At(offset int) (uint32, bool) {
+	if offset+4 > len(rec.data) {
+		return 0, false
+	}
+	return binary.LittleEndian.Uint32(rec.data[offset:]), true
+}
+
+// float64At returns the 8 bytes floating-point number at given offset of the
+// record data.
+func (rec *biff12Record) float64At(offset int) (float64, bool) {
+	if offset+8 > len(rec.data) {
+		return 0, false
+	}
+	return math.Float64frombits(binary.LittleEndian.Uint64(rec.data[offset:])), true
+}
+
+// wideStringAt returns the XLWideString at given offset of the record data
+// and the offset after the string. The null XLNullableWideString will be
+// returned as an empty string.
+func (rec *biff12Record) wideStringAt(offset int) (string, int, bool) {
+	cch, ok := rec.uint32At(offset)
+	if !ok {
+		return "", offset, false
+	}
+	offset += 4
+	if cch == math.MaxUint32 {
+		return "", offset, true
+	}
+	if uint64(offset)+uint64(cch)*2 > uint64(len(rec.data)) {
+		return "", offset, false
+	}
+	u := make([]uint16, cch)
+	for i := range u {
+		u[i] = binary.LittleEndian.Uint16(rec.data[offset+i*2:])
+	}
+	return string(utf16.Decode(u)), offset + int(cch)*2, true
+}
+
+// rkNumber returns the number stored in the RkNumber structure.
+func rkNumber(rk uint32) float64 {
+	var num float64
+	if rk&0x02 != 0 {
+		num = float64(int32(rk) >> 2)
+	} else {
+		num = math.Float64frombits(uint64(rk&0xFFFFFFFC) << 32)
+	}
+	if rk&0x01 != 0 {
+		num /= 100
+	}
+	return num
+}
+
+// convertXLSB provides a function to convert the binary workbook parts of
+// the XLSB spreadsheet into the XML parts, so that the workbook could be
+// read and modified by the same API as the XLSX spreadsheet. Formulas in the
+// binary worksheets will be kept as their cached results, the relationships
+// parts of the binary worksheets and the binary parts which couldn't be
+// converted will be removed from the workbook.
+func (f *File) convertXLSB() error {
+	rels := new(xlsxRelationships)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML("_rels/.rels")))).
+		Decode(rels); err != nil && err != io.EOF {
+		return err
+	}
+	idx := -1
+	for i, rel := range rels.Relationships {
+		if rel.Type == SourceRelationshipOfficeDocument && strings.HasSuffix(strings.ToLower(rel.Target), ".bin") {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		return nil
+	}
+	wbBinPath := strings.TrimPrefix(rels.Relationships[idx].Target, "/")
+	wbBinRelsPath := strings.TrimPrefix(filepath.ToSlash(filepath.Dir(wbBinPath))+"/_rels/"+filepath.Base(wbBinPath)+".rels", "./")
+	wbPath := strings.TrimSuffix(wbBinPath, filepath.Ext(wbBinPath)) + ".xml"
+	rels.Relationships[idx].Target = wbPath
+	output, _ := xml.Marshal(rels)
+	f.saveFileList("_rels/.rels", output)
+
+	contentTypes, err := f.contentTypesReader()
+	if err != nil {
+		return err
+	}
+	wbRels := new(xlsxRelationships)
+	if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(wbBinRelsPath)))).
+		Decode(wbRels); err != nil && err != io.EOF {
+		return err
+	}
+	parts := map[string]string{"/" + wbBinPath: "/" + wbPath}
+	overrides := map[string]string{"/" + wbPath: ContentTypeSheetML}
+	convertedRels := make(map[string]bool)
+	var relationships []xlsxRelationship
+	for _, rel := range wbRels.Relationships {
+		if !strings.HasSuffix(strings.ToLower(rel.Target), ".bin") || rel.Type == SourceRelationshipVBAProject {
+			relationships = append(relationships, rel)
+			continue
+		}
+		binPath := f.getWorksheetPath(rel.Target)
+		xmlPath := strings.TrimSuffix(binPath, filepath.Ext(binPath)) + ".xml"
+		var content []byte
+		switch rel.Type {
+		case SourceRelationshipWorkSheet:
+			content = convertXLSBWorksheet(f.readBytes(binPath))
+			overrides["/"+xmlPath] = ContentTypeSpreadSheetMLWorksheet
+			dir, name := filepath.ToSlash(filepath.Dir(binPath)), filepath.Base(binPath)
+			f.Pkg.Delete(dir + "/_rels/" + name + ".rels")
+		case SourceRelationshipSharedStrings:
+			content = convertXLSBSharedStrings(f.readBytes(binPath))
+			overrides["/"+xmlPath] = ContentTypeSpreadSheetMLSharedStrings
+		case SourceRelationshipStyles:
+			content = convertXLSBStyles(f.readBytes(binPath))
+			overrides["/"+xmlPath] = ContentTypeSpreadSheetMLStyles
+		}
+		f.Pkg.Delete(binPath)
+		f.zipFiles.Delete(binPath)
+		if path, ok := f.tempFiles.Load(binPath); ok {
+			f.tempFiles.Delete(binPath)
+			if err = os.Remove(path.(string)); err != nil {
+				return err
+			}
+		}
+		parts["/"+binPath] = ""
+		if content == nil {
+			continue
+		}
+		f.saveFileList(xmlPath, content)
+		parts["/"+binPath] = "/" + xmlPath
+		rel.Target = strings.TrimSuffix(rel.Target, filepath.Ext(rel.Target)) + ".xml"
+		relationships = append(relationships, rel)
+		convertedRels[rel.ID] = true
+	}
+	wbRels.Relationships = relationships
+	output, _ = xml.Marshal(wbRels)
+	f.Pkg.Delete(wbBinRelsPath)
+	f.saveFileList(f.getWorkbookRelsPath(), output)
+
+	wb := convertXLSBWorkbook(f.readBytes(wbBinPath), convertedRels)
+	output, _ = xml.Marshal(wb)
+	f.Pkg.Delete(wbBinPath)
+	f.saveFileList(wbPath, replaceRelationshipsBytes(f.replaceNameSpaceBytes(wbPath, output)))
+
+	contentTypes.mu.Lock()
+	defer contentTypes.mu.Unlock()
+	var typeOverrides []xlsxOverride
+	for _, override := range contentTypes.Overrides {
+		if _, ok := parts[override.PartName]; !ok {
+			typeOverrides = append(typeOverrides, override)
+		}
+	}
+	for _, partName := range parts {
+		if contentType, ok := overrides[partName]; ok {
+			typeOverrides = append(typeOverrides, xlsxOverride{PartName: partName, ContentType: contentType})
+		}
+	}
+	contentTypes.Overrides = typeOverrides
+	return err
+}
+
+// convertXLSBWorkbook converts the binary workbook part into the workbook
+// XML structure. Only the date system and the sheets with given converted
+// relationship IDs will be kept, the defined names will be dropped.
+func convertXLSBWorkbook(data []byte, convertedRels map[string]bool) *xlsxWorkbook {
+	wb := &xlsxWorkbook{WorkbookPr: &xlsxWorkbookPr{}}
+	reader := biff12Reader{data: data}
+	for rec, ok := reader.next(); ok; rec, ok = reader.next() {
+		switch rec.typ {
+		case brtWbProp:
+			if flags, ok := rec.uint32At(0); ok {
+				wb.WorkbookPr.Date1904 = flags&0x01 != 0
+			}
+		case brtBundleSh:
+			state, _ := rec.uint32At(0)
+			tabID, _ := rec.uint32At(4)
+			rID, offset, ok := rec.wideStringAt(8)
+			if !ok {
+				continue
+			}
+			name, _, ok := rec.wideStringAt(offset)
+			if !ok || !convertedRels[rID] {
+				continue
+			}
+			sheet := xlsxSheet{Name: name, SheetID: int(tabID), ID: rID}
+			switch state {
+			case 1:
+				sheet.State = "hidden"
+			case 2:
+				sheet.State = "veryHidden"
+			}
+			wb.Sheets.Sheet = append(wb.Sheets.Sheet, sheet)
+		}
+	}
+	return wb
+}
+
+// convertXLSBSharedStrings converts the binary shared strings part into the
+// shared strings table XML part.
+func convertXLSBSharedStrings(data []byte) []byte {
+	sst := &xlsxSST{}
+	reader := biff12Reader{data: data}
+	for rec, ok := reader.next(); ok; rec, ok = reader.next() {
+		if rec.typ != brtSSTItem {
+			continue
+		}
+		val, _, _ := rec.wideStringAt(1)
+		sst.SI = append(sst.SI, xlsxSI{T: &xlsxT{Val: val}})
+	}
+	sst.Count, sst.UniqueCount = len(sst.SI), len(sst.SI)
+	output, _ := xml.Marshal(sst)
+	return output
+}
+
+// convertXLSBStyles converts the binary styles part into the styles XML
+// part. Only the number formats of the cell formats will be kept, so that
+// the date, time and number cell values could be formatted as expected, and
+// the fonts, fills and borders of the cell formats will be reset to the
+// default one at index 0.
+func convertXLSBStyles(data []byte) []byte {
+	styleSheet := &xlsxStyleSheet{}
+	_ = xml.Unmarshal([]byte(templateStyles), styleSheet)
+	styleSheet.CellXfs.Xf = nil
+	var inCellXfs bool
+	reader := biff12Reader{data: data}
+	for rec, ok := reader.next(); ok; rec, ok = reader.next() {
+		switch rec.typ {
+		case brtFmt:
+			numFmtID, ok := rec.uint16At(0)
+			if !ok {
+				continue
+			}
+			formatCode, _, ok := rec.wideStringAt(2)
+			if !ok {
+				continue
+			}
+			if styleSheet.NumFmts == nil {
+				styleSheet.NumFmts = &xlsxNumFmts{}
+			}
+			styleSheet.NumFmts.NumFmt = append(styleSheet.NumFmts.NumFmt, &xlsxNumFmt{NumFmtID: int(numFmtID), FormatCode: formatCode})
+			styleSheet.NumFmts.Count = len(styleSheet.NumFmts.NumFmt)
+		case brtBeginCellXFs:
+			inCellXfs = true
+		case brtEndCellXFs, brtBeginCellStyleXFs, brtEndCellStyleXFs:
+			inCellXfs = false
+		case brtXF:
+			if !inCellXfs {
+				continue
+			}
+			numFmtID, _ := rec.uint16At(2)
+			xf := xlsxXf{NumFmtID: intPtr(int(numFmtID)), FontID: intPtr(0), FillID: intPtr(0), BorderID: intPtr(0), XfID: intPtr(0)}
+			if numFmtID != 0 {
+				xf.ApplyNumberFormat = boolPtr(true)
+			}
+			styleSheet.CellXfs.Xf = append(styleSheet.CellXfs.Xf, xf)
+		}
+	}
+	if len(styleSheet.CellXfs.Xf) == 0 {
+		styleSheet.CellXfs.Xf = append(styleSheet.CellXfs.Xf, xlsxXf{NumFmtID: intPtr(0), FontID: intPtr(0), FillID: intPtr(0), BorderID: intPtr(0), XfID: intPtr(0)})
+	}
+	styleSheet.CellXfs.Count = len(styleSheet.CellXfs.Xf)
+	output, _ := xml.Marshal(styleSheet)
+	return output
+}
+
+// convertXLSBWorksheet converts the binary worksheet part into the worksheet
+// XML part. Only the cells, rows, columns and merged cells will be kept.
+func convertXLSBWorksheet(data []byte) []byte {
+	ws := &xlsxWorksheet{}
+	var row *xlsxRow
+	reader := biff12Reader{data: data}
+	for rec, ok := reader.next(); ok; rec, ok = reader.next() {
+		switch {
+		case rec.typ == brtRowHdr:
+			rowIdx, ok := rec.uint32At(0)
+			if !ok {
+				continue
+			}
+			ws.SheetData.Row = append(ws.SheetData.Row, xlsxRow{R: intPtr(int(rowIdx) + 1)})
+			row = &ws.SheetData.Row[len(ws.SheetData.Row)-1]
+			styleID, _ := rec.uint32At(4)
+			height, _ := rec.uint16At(8)
+			flags, _ := rec.uint16At(11)
+			if flags&0x20 != 0 {
+				row.Ht, row.CustomHeight = float64Ptr(float64(height)/20), true
+			}
+			row.Hidden = flags&0x10 != 0
+			if flags&0x40 != 0 {
+				row.S, row.CustomFormat = int(styleID), true
+			}
+		case rec.typ >= brtCellBlank && rec.typ <= brtFmlaError:
+			if row == nil {
+				continue
+			}
+			if c, ok := convertXLSBCell(&rec, *row.R); ok {
+				row.C = append(row.C, c)
+			}
+		case rec.typ == brtColInfo:
+			colFirst, ok1 := rec.uint32At(0)
+			colLast, ok2 := rec.uint32At(4)
+			width, ok3 := rec.uint32At(8)
+			if !ok1 || !ok2 || !ok3 {
+				continue
+			}
+			styleID, _ := rec.uint32At(12)
+			flags, _ := rec.uint16At(16)
+			if ws.Cols == nil {
+				ws.Cols = &xlsxCols{}
+			}
+			ws.Cols.Col = append(ws.Cols.Col, xlsxCol{
+				Min: int(colFirst) + 1, Max: int(colLast) + 1, Width: float64Ptr(float64(width) / 256),
+				Style: int(styleID), Hidden: flags&0x01 != 0, CustomWidth: flags&0x02 != 0,
+			})
+		case rec.typ == brtMergeCell:
+			rowFirst, ok1 := rec.uint32At(0)
+			rowLast, ok2 := rec.uint32At(4)
+			colFirst, ok3 := rec.uint32At(8)
+			colLast, ok4 := rec.uint32At(12)
+			if !ok1 || !ok2 || !ok3 || !ok4 {
+				continue
+			}
+			topLeftCell, err := CoordinatesToCellName(int(colFirst)+1, int(rowFirst)+1)
+			if err != nil {
+				continue
+			}
+			bottomRightCell, err := CoordinatesToCellName(int(colLast)+1, int(rowLast)+1)
+			if err != nil {
+				continue
+			}
+			if ws.MergeCells == nil {
+				ws.MergeCells = &xlsxMergeCells{}
+			}
+			ws.MergeCells.Cells = append(ws.MergeCells.Cells, &xlsxMergeCell{Ref: topLeftCell + ":" + bottomRightCell})
+			ws.MergeCells.Count = len(ws.MergeCells.Cells)
+		}
+	}
+	output, _ := xml.Marshal(ws)
+	return output
+}
+
+// convertXLSBCell converts the binary cell record into the cell XML
+// structure by given row number.
+func convertXLSBCell(rec *biff12Record, row int) (xlsxC, bool) {
+	col, ok := rec.uint32At(0)
+	if !ok {
+		return xlsxC{}, false
+	}
+	styleID, ok := rec.uint32At(4)
+	if !ok {
+		return xlsxC{}, false
+	}
+	cell, err := CoordinatesToCellName(int(col)+1, row)
+	if err != nil {
+		return xlsxC{}, false
+	}
+	c := xlsxC{R: cell, S: int(styleID & 0xFFFFFF)}
+	switch rec.typ {
+	case brtCellRk:
+		rk, ok := rec.uint32At(8)
+		if !ok {
+			return c, false
+		}
+		c.V = strconv.FormatFloat(rkNumber(rk), 'f', -1, 64)
+	case brtCellReal, brtFmlaNum:
+		num, ok := rec.float64At(8)
+		if !ok {
+			return c, false
+		}
+		c.V = strconv.FormatFloat(num, 'f', -1, 64)
+	case brtCellBool, brtFmlaBool:
+		if len(rec.data) < 9 {
+			return c, false
+		}
+		c.T, c.V = "b", "0"
+		if rec.data[8] != 0 {
+			c.V = "1"
+		}
+	case brtCellError, brtFmlaError:
+		if len(rec.data) < 9 {
+			return c, false
+		}
+		c.T, c.V = "e", biff12ErrorValues[rec.data[8]]
+	case brtCellSt, brtFmlaString:
+		val, _, ok := rec.wideStringAt(8)
+		if !ok {
+			return c, false
+		}
+		c.T, c.V = "str", val
+		if rec.typ == brtCellSt {
+			c.T, c.IS = "inlineStr", &xlsxSI{T: &xlsxT{Val: val}}
+			c.V = ""
+		}
+	case brtCellIsst:
+		idx, ok := rec.uint32At(8)
+		if !ok {
+			return c, false
+		}
+		c.T, c.V = "s", strconv.FormatUint(uint64(idx), 10)
+	}
+	return c, true
+}
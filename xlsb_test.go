@@ -0,0 +1,258 @@
+package excelize
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"math"
+	"path/filepath"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// biff12Buffer provides a buffer for building the binary workbook parts in
+// the test cases.
+type biff12Buffer struct {
+	bytes.Buffer
+}
+
+func (b *biff12Buffer) writeVarUint(v int) {
+	for {
+		c := byte(v & 0x7F)
+		if v >>= 7; v > 0 {
+			c |= 0x80
+		}
+		b.WriteByte(c)
+		if v == 0 {
+			return
+		}
+	}
+}
+
+func (b *biff12Buffer) record(typ int, data ...[]byte) {
+	payload := bytes.Join(data, nil)
+	b.writeVarUint(typ)
+	b.writeVarUint(len(payload))
+	b.Write(payload)
+}
+
+func biff12Uint16(v uint16) []byte {
+	b := make([]byte, 2)
+	binary.LittleEndian.PutUint16(b, v)
+	return b
+}
+
+func biff12Uint32(v uint32) []byte {
+	b := make([]byte, 4)
+	binary.LittleEndian.PutUint32(b, v)
+	return b
+}
+
+func biff12Float64(v float64) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, math.Float64bits(v))
+	return b
+}
+
+func biff12WideString(s string) []byte {
+	u := utf16.Encode([]rune(s))
+	b := biff12Uint32(uint32(len(u)))
+	for _, c := range u {
+		b = append(b, biff12Uint16(c)...)
+	}
+	return b
+}
+
+func biff12Cell(col, styleID uint32) []byte {
+	return append(biff12Uint32(col), biff12Uint32(styleID)...)
+}
+
+func prepareXLSB(t *testing.T, parts map[string][]byte) []byte {
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	for name, content := range parts {
+		fi, err := zw.Create(name)
+		assert.NoError(t, err)
+		_, err = fi.Write(content)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func prepareXLSBParts() map[string][]byte {
+	wb := new(biff12Buffer)
+	wb.record(brtWbProp, biff12Uint32(0), biff12Uint32(0), biff12WideString(""))
+	wb.record(brtBundleSh, biff12Uint32(0), biff12Uint32(1), biff12WideString("rId1"), biff12WideString("Sheet1"))
+	wb.record(brtBundleSh, biff12Uint32(1), biff12Uint32(2), biff12WideString("rId2"), biff12WideString("Sheet2"))
+	wb.record(brtBundleSh, biff12Uint32(0), biff12Uint32(3), biff12WideString("rId6"), biff12WideString("Chart1"))
+	wb.record(brtBundleSh, biff12Uint32(0))
+
+	ws := new(biff12Buffer)
+	ws.record(brtColInfo, biff12Uint32(0), biff12Uint32(1), biff12Uint32(20*256), biff12Uint32(0), biff12Uint16(0x02))
+	ws.record(brtRowHdr, biff12Uint32(0), biff12Uint32(0), biff12Uint16(300), []byte{0}, biff12Uint16(0), make([]byte, 4))
+	ws.record(brtCellIsst, biff12Cell(0, 0), biff12Uint32(0))
+	ws.record(brtCellRk, biff12Cell(1, 0), biff12Uint32(100<<2|0x02))
+	ws.record(brtCellReal, biff12Cell(2, 0), biff12Float64(1.5))
+	ws.record(brtCellBool, biff12Cell(3, 0), []byte{1})
+	ws.record(brtCellRk, biff12Cell(4, 0), biff12Uint32(125<<2|0x03))
+	ws.record(brtCellRk, biff12Cell(5, 0), biff12Uint32(0x3FF80000))
+	ws.record(brtRowHdr, biff12Uint32(1), biff12Uint32(0), biff12Uint16(600), []byte{0}, biff12Uint16(0x20), make([]byte, 4))
+	ws.record(brtCellError, biff12Cell(0, 0), []byte{0x07})
+	ws.record(brtFmlaNum, biff12Cell(1, 0), biff12Float64(3), biff12Uint16(0))
+	ws.record(brtFmlaString, biff12Cell(2, 0), biff12WideString("abc"), biff12Uint16(0))
+	ws.record(brtCellSt, biff12Cell(3, 0), biff12WideString("inline"))
+	ws.record(brtCellReal, biff12Cell(4, 1), biff12Float64(45000))
+	ws.record(brtFmlaBool, biff12Cell(5, 0), []byte{0})
+	ws.record(brtRowHdr, biff12Uint32(2), biff12Uint32(0), biff12Uint16(300), []byte{0}, biff12Uint16(0x10), make([]byte, 4))
+	ws.record(brtCellBlank, biff12Cell(0, 0))
+	ws.record(brtFmlaError, biff12Cell(1, 0), []byte{0x2A})
+	ws.record(brtMergeCell, biff12Uint32(3), biff12Uint32(3), biff12Uint32(0), biff12Uint32(1))
+
+	hidden := new(biff12Buffer)
+	hidden.record(brtRowHdr, biff12Uint32(0), biff12Uint32(0), biff12Uint16(300), []byte{0}, biff12Uint16(0), make([]byte, 4))
+	hidden.record(brtCellIsst, biff12Cell(0, 0), biff12Uint32(1))
+
+	sst := new(biff12Buffer)
+	sst.record(brtSSTItem, []byte{0}, biff12WideString("Hello"))
+	sst.record(brtSSTItem, []byte{0}, biff12WideString("World"))
+
+	styles := new(biff12Buffer)
+	styles.record(brtFmt, biff12Uint16(164), biff12WideString("yyyy-mm-dd"))
+	styles.record(brtBeginCellStyleXFs, biff12Uint32(1))
+	styles.record(brtXF, biff12Uint16(0xFFFF), biff12Uint16(0), make([]byte, 12))
+	styles.record(brtEndCellStyleXFs)
+	styles.record(brtBeginCellXFs, biff12Uint32(2))
+	styles.record(brtXF, biff12Uint16(0), biff12Uint16(0), make([]byte, 12))
+	styles.record(brtXF, biff12Uint16(0), biff12Uint16(164), make([]byte, 12))
+	styles.record(brtEndCellXFs)
+
+	return map[string][]byte{
+		"[Content_Types].xml":                 []byte(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Default Extension="bin" ContentType="application/vnd.ms-excel.sheet.binary.macroEnabled.main"/><Override PartName="/xl/worksheets/sheet1.bin" ContentType="application/vnd.ms-excel.worksheet"/><Override PartName="/xl/worksheets/sheet2.bin" ContentType="application/vnd.ms-excel.worksheet"/><Override PartName="/xl/chartsheets/sheet1.bin" ContentType="application/vnd.ms-excel.chartsheet"/><Override PartName="/xl/sharedStrings.bin" ContentType="application/vnd.ms-excel.sharedStrings"/><Override PartName="/xl/styles.bin" ContentType="application/vnd.ms-excel.styles"/><Override PartName="/xl/calcChain.bin" ContentType="application/vnd.ms-excel.calcChain"/><Override PartName="/xl/theme/theme1.xml" ContentType="application/vnd.openxmlformats-officedocument.theme+xml"/></Types>`),
+		"_rels/.rels":                         []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.bin"/></Relationships>`),
+		"xl/_rels/workbook.bin.rels":          []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.bin"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet2.bin"/><Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings" Target="sharedStrings.bin"/><Relationship Id="rId4" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.bin"/><Relationship Id="rId5" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/calcChain" Target="calcChain.bin"/><Relationship Id="rId6" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/chartsheet" Target="chartsheets/sheet1.bin"/><Relationship Id="rId7" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/theme" Target="theme/theme1.xml"/></Relationships>`),
+		"xl/workbook.bin":                     wb.Bytes(),
+		"xl/worksheets/sheet1.bin":            ws.Bytes(),
+		"xl/worksheets/_rels/sheet1.bin.rels": []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"></Relationships>`),
+		"xl/worksheets/sheet2.bin":            hidden.Bytes(),
+		"xl/chartsheets/sheet1.bin":           {},
+		"xl/sharedStrings.bin":                sst.Bytes(),
+		"xl/styles.bin":                       styles.Bytes(),
+		"xl/calcChain.bin":                    {},
+		"xl/theme/theme1.xml":                 []byte(templateTheme),
+	}
+}
+
+func TestOpenXLSB(t *testing.T) {
+	f, err := OpenReader(bytes.NewReader(prepareXLSB(t, prepareXLSBParts())))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sheet1", "Sheet2"}, f.GetSheetList())
+	visible, err := f.GetSheetVisible("Sheet2")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	for cell, expected := range map[string]string{
+		"A1": "Hello", "B1": "100", "C1": "1.5", "D1": "TRUE", "E1": "1.25", "F1": "1.5",
+		"A2": "#DIV/0!", "B2": "3", "C2": "abc", "D2": "inline", "E2": "2023-03-15", "F2": "FALSE",
+		"A3": "", "B3": "#N/A",
+	} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	value, err := f.GetCellValue("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "World", value)
+	width, err := f.GetColWidth("Sheet1", "B")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+	height, err := f.GetRowHeight("Sheet1", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, height)
+	visible, err = f.GetRowVisible("Sheet1", 3)
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "A4", mergeCells[0].GetStartAxis())
+	assert.Equal(t, "B4", mergeCells[0].GetEndAxis())
+	for _, part := range []string{"xl/workbook.bin", "xl/worksheets/sheet1.bin", "xl/worksheets/_rels/sheet1.bin.rels", "xl/chartsheets/sheet1.bin", "xl/calcChain.bin"} {
+		_, ok := f.Pkg.Load(part)
+		assert.False(t, ok, part)
+	}
+	// Test modify and save the converted workbook
+	assert.NoError(t, f.SetCellValue("Sheet1", "A5", "Excelize"))
+	assert.Equal(t, ErrWorkbookFileFormat, f.SaveAs(filepath.Join("test", "TestOpenXLSB.xlsb")))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestOpenXLSB.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestOpenXLSB.xlsx"))
+	assert.NoError(t, err)
+	for cell, expected := range map[string]string{"A1": "Hello", "E2": "2023-03-15", "A5": "Excelize"} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	assert.NoError(t, f.Close())
+
+	// Test open XLSB workbook with large worksheet extracted to temporary file
+	f, err = OpenReader(bytes.NewReader(prepareXLSB(t, prepareXLSBParts())), Options{UnzipXMLSizeLimit: 64})
+	assert.NoError(t, err)
+	value, err = f.GetCellValue("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", value)
+	assert.NoError(t, f.Close())
+
+	// Test open XLSB workbook with unsupported charset
+	for _, part := range []string{"_rels/.rels", "[Content_Types].xml", "xl/_rels/workbook.bin.rels"} {
+		parts := prepareXLSBParts()
+		parts[part] = MacintoshCyrillicCharset
+		_, err = OpenReader(bytes.NewReader(prepareXLSB(t, parts)))
+		assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8", part)
+	}
+}
+
+func TestConvertXLSBParts(t *testing.T) {
+	// Test convert binary parts with truncated records
+	buf := new(biff12Buffer)
+	buf.record(brtRowHdr)
+	buf.record(brtCellRk, biff12Cell(0, 0))
+	buf.record(brtRowHdr, biff12Uint32(0))
+	for _, typ := range []int{brtCellRk, brtCellReal, brtCellBool, brtCellError, brtCellSt, brtCellIsst} {
+		buf.record(typ, biff12Cell(0, 0))
+	}
+	buf.record(brtCellBlank, biff12Uint32(0))
+	buf.record(brtCellBlank)
+	buf.record(brtCellBlank, biff12Cell(MaxColumns, 0))
+	buf.record(brtColInfo)
+	buf.record(brtMergeCell)
+	buf.record(brtMergeCell, biff12Uint32(0), biff12Uint32(0), biff12Uint32(MaxColumns), biff12Uint32(MaxColumns))
+	buf.record(brtMergeCell, biff12Uint32(0), biff12Uint32(0), biff12Uint32(0), biff12Uint32(MaxColumns))
+	buf.record(brtFmt)
+	buf.record(brtFmt, biff12Uint16(164))
+	buf.record(brtXF, make([]byte, 4))
+	buf.record(brtBundleSh, biff12Uint32(0), biff12Uint32(1))
+	buf.record(brtBundleSh, biff12Uint32(0), biff12Uint32(1), biff12WideString("rId1"))
+	buf.record(brtSSTItem, []byte{0}, biff12Uint32(2))
+	buf.Write([]byte{0x80})
+	assert.Equal(t, `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row r="1"></row></sheetData></worksheet>`,
+		string(convertXLSBWorksheet(buf.Bytes())))
+	assert.Empty(t, convertXLSBWorkbook(buf.Bytes(), map[string]bool{"rId1": true}).Sheets.Sheet)
+	assert.Contains(t, string(convertXLSBSharedStrings(buf.Bytes())), `<si><t></t></si>`)
+	assert.Contains(t, string(convertXLSBStyles(buf.Bytes())), `<cellXfs count="1">`)
+	// Test read record with truncated size and data
+	reader := biff12Reader{data: []byte{0x01}}
+	_, ok := reader.next()
+	assert.False(t, ok)
+	reader = biff12Reader{data: []byte{0x01, 0x02, 0x00}}
+	_, ok = reader.next()
+	assert.False(t, ok)
+	// Test read null wide string
+	rec := biff12Record{data: biff12Uint32(math.MaxUint32)}
+	str, _, ok := rec.wideStringAt(0)
+	assert.True(t, ok)
+	assert.Empty(t, str)
+	assert.Equal(t, -1.5, rkNumber(uint32(0xBFF80000)))
+}
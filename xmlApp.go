@@ -73,3 +73,29 @@ type xlsxVectorLpstr struct {
 type xlsxDigSig struct {
 	Content string `xml:",innerxml"`
 }
+
+// xlsxCustomProperties specifies to an OOXML document custom properties,
+// which are the user defined properties of the document.
+type xlsxCustomProperties struct {
+	XMLName  xml.Name             `xml:"http://schemas.openxmlformats.org/officeDocument/2006/custom-properties Properties"`
+	Vt       string               `xml:"xmlns:vt,attr"`
+	Property []xlsxCustomProperty `xml:"property"`
+}
+
+// xlsxCustomProperty directly maps the property element, which specifies a
+// single custom property. The value of the property is stored as the inner
+// XML with the element of the variant type.
+type xlsxCustomProperty struct {
+	FmtID      string `xml:"fmtid,attr"`
+	PID        int    `xml:"pid,attr"`
+	Name       string `xml:"name,attr,omitempty"`
+	LinkTarget string `xml:"linkTarget,attr,omitempty"`
+	Value      string `xml:",innerxml"`
+}
+
+// decodeCustomPropertyValue directly maps the variant type element of the
+// custom property value.
+type decodeCustomPropertyValue struct {
+	XMLName xml.Name
+	Val     string `xml:",chardata"`
+}
@@ -521,6 +521,122 @@ type cPageMargins struct {
 	T      float64 `xml:"t,attr"`
 }
 
+// decodeChartSpace defines the structure used to parse the chart part for
+// getting the definition of the chart.
+type decodeChartSpace struct {
+	XMLName xml.Name    `xml:"chartSpace"`
+	Chart   decodeChart `xml:"chart"`
+}
+
+// decodeChart defines the structure used to parse the chart element of the
+// chart part.
+type decodeChart struct {
+	Title        *decodeChartTitle   `xml:"title"`
+	PlotArea     decodeChartPlotArea `xml:"plotArea"`
+	Legend       *cLegend            `xml:"legend"`
+	DispBlanksAs *attrValString      `xml:"dispBlanksAs"`
+}
+
+// decodeChartTitle defines the structure used to parse the text runs of the
+// chart title and axis title.
+type decodeChartTitle struct {
+	P []decodeChartParagraph `xml:"tx>rich>p"`
+}
+
+// decodeChartParagraph defines the structure used to parse the paragraph of
+// the rich text in the chart.
+type decodeChartParagraph struct {
+	R []decodeChartRun `xml:"r"`
+}
+
+// decodeChartRun defines the structure used to parse the text run of the rich
+// text in the chart.
+type decodeChartRun struct {
+	RPr *decodeChartRunPr `xml:"rPr"`
+	T   string            `xml:"t"`
+}
+
+// decodeChartRunPr defines the structure used to parse the run properties of
+// the rich text in the chart.
+type decodeChartRunPr struct {
+	B         bool           `xml:"b,attr"`
+	I         bool           `xml:"i,attr"`
+	Sz        float64        `xml:"sz,attr"`
+	SolidFill *decodeSrgbClr `xml:"solidFill"`
+}
+
+// decodeSrgbClr defines the structure used to parse the RGB color of the
+// solid fill in the chart.
+type decodeSrgbClr struct {
+	SrgbClr *attrValString `xml:"srgbClr"`
+}
+
+// decodeChartPlotArea defines the structure used to parse the plot area of
+// the chart, the plot groups will be kept in the order of the chart part.
+type decodeChartPlotArea struct {
+	Groups []decodeChartGroup `xml:",any"`
+	CatAx  []*decodeChartAxis `xml:"catAx"`
+	ValAx  []*decodeChartAxis `xml:"valAx"`
+	SerAx  []*decodeChartAxis `xml:"serAx"`
+}
+
+// decodeChartGroup defines the structure used to parse the plot group of the
+// chart, such as the c:barChart or the c:lineChart element.
+type decodeChartGroup struct {
+	XMLName    xml.Name
+	BarDir     *attrValString   `xml:"barDir"`
+	Grouping   *attrValString   `xml:"grouping"`
+	OfPieType  *attrValString   `xml:"ofPieType"`
+	VaryColors *attrValBool     `xml:"varyColors"`
+	Wireframe  *attrValBool     `xml:"wireframe"`
+	Ser        []decodeChartSer `xml:"ser"`
+	SplitPos   *attrValInt      `xml:"splitPos"`
+	DLbls      *cDLbls          `xml:"dLbls"`
+	Shape      *attrValString   `xml:"shape"`
+	HoleSize   *attrValInt      `xml:"holeSize"`
+	AxID       []*attrValInt    `xml:"axId"`
+}
+
+// decodeChartSer defines the structure used to parse the series of the plot
+// group in the chart.
+type decodeChartSer struct {
+	Tx         *decodeChartSerTx   `xml:"tx"`
+	DLbls      *cDLbls             `xml:"dLbls"`
+	Cat        *decodeChartSerData `xml:"cat"`
+	Val        *decodeChartSerData `xml:"val"`
+	XVal       *decodeChartSerData `xml:"xVal"`
+	YVal       *decodeChartSerData `xml:"yVal"`
+	BubbleSize *decodeChartSerData `xml:"bubbleSize"`
+	Bubble3D   *attrValBool        `xml:"bubble3D"`
+}
+
+// decodeChartSerTx defines the structure used to parse the series name of the
+// chart.
+type decodeChartSerTx struct {
+	StrRef *cStrRef `xml:"strRef"`
+	V      string   `xml:"v"`
+}
+
+// decodeChartSerData defines the structure used to parse the data references
+// of the series in the chart.
+type decodeChartSerData struct {
+	StrRef *cStrRef `xml:"strRef"`
+	NumRef *cNumRef `xml:"numRef"`
+}
+
+// decodeChartAxis defines the structure used to parse the axis of the chart.
+type decodeChartAxis struct {
+	AxID           *attrValInt       `xml:"axId"`
+	Scaling        *cScaling         `xml:"scaling"`
+	Delete         *attrValBool      `xml:"delete"`
+	MajorGridlines *cChartLines      `xml:"majorGridlines"`
+	MinorGridlines *cChartLines      `xml:"minorGridlines"`
+	Title          *decodeChartTitle `xml:"title"`
+	NumFmt         *cNumFmt          `xml:"numFmt"`
+	MajorUnit      *attrValFloat     `xml:"majorUnit"`
+	TickLblSkip    *attrValInt       `xml:"tickLblSkip"`
+}
+
 // ChartNumFmt directly maps the number format settings of the chart.
 type ChartNumFmt struct {
 	CustomNumFmt string
@@ -561,6 +677,8 @@ type ChartPlotArea struct {
 	ShowSerName      bool
 	ShowVal          bool
 	NumFmt           ChartNumFmt
+	Fill             Fill
+	Border           ChartLine
 }
 
 // Chart directly maps the format settings of the chart.
@@ -575,6 +693,7 @@ type Chart struct {
 	XAxis        ChartAxis
 	YAxis        ChartAxis
 	PlotArea     ChartPlotArea
+	Fill         Fill
 	Border       ChartLine
 	ShowBlanksAs string
 	HoleSize     int
@@ -596,6 +715,7 @@ type ChartMarker struct {
 // ChartLine directly maps the format settings of the chart line.
 type ChartLine struct {
 	Type   ChartLineType
+	Fill   Fill
 	Smooth bool
 	Width  float64
 }
@@ -11,7 +11,10 @@
 
 package excelize
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"time"
+)
 
 // xlsxComments directly maps the comments element from the namespace
 // http://schemas.openxmlformats.org/spreadsheetml/2006/main. A comment is a
@@ -72,13 +75,78 @@ type xlsxPhoneticRun struct {
 	T  string `xml:"t"`
 }
 
-// Comment directly maps the comment information.
+// xlsxThreadedComments directly maps the ThreadedComments element from the
+// namespace http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments.
+// This element is the root of the threaded comments part of the worksheet,
+// the comments with the same cell reference are a conversation thread.
+type xlsxThreadedComments struct {
+	XMLName         xml.Name              `xml:"http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments ThreadedComments"`
+	XMLNSX          string                `xml:"xmlns:x,attr"`
+	ThreadedComment []xlsxThreadedComment `xml:"threadedComment"`
+}
+
+// xlsxThreadedComment directly maps the threadedComment element. The comment
+// without the parent ID is the first comment of the thread, and the other
+// comments of the thread are the replies to it.
+type xlsxThreadedComment struct {
+	Ref      string        `xml:"ref,attr,omitempty"`
+	DT       string        `xml:"dT,attr,omitempty"`
+	PersonID string        `xml:"personId,attr"`
+	ID       string        `xml:"id,attr"`
+	ParentID string        `xml:"parentId,attr,omitempty"`
+	Done     bool          `xml:"done,attr,omitempty"`
+	Text     string        `xml:"text"`
+	Mentions *xlsxInnerXML `xml:"mentions"`
+	ExtLst   *xlsxExtLst   `xml:"extLst"`
+}
+
+// xlsxPersonList directly maps the personList element. This element is the
+// root of the person part of the workbook, which specifies the authors of the
+// threaded comments.
+type xlsxPersonList struct {
+	XMLName xml.Name     `xml:"http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments personList"`
+	XMLNSX  string       `xml:"xmlns:x,attr"`
+	Person  []xlsxPerson `xml:"person"`
+}
+
+// xlsxPerson directly maps the person element, which specifies an author of
+// the threaded comments.
+type xlsxPerson struct {
+	DisplayName string      `xml:"displayName,attr"`
+	ID          string      `xml:"id,attr"`
+	UserID      string      `xml:"userId,attr,omitempty"`
+	ProviderID  string      `xml:"providerId,attr,omitempty"`
+	ExtLst      *xlsxExtLst `xml:"extLst"`
+}
+
+// Comment directly maps the comment information. The ThreadedComment will be
+// populated if the comment is the legacy note of a threaded comment.
 type Comment struct {
-	Author    string
-	AuthorID  int
-	Cell      string
-	Text      string
-	Width     uint
-	Height    uint
-	Paragraph []RichTextRun
+	Author          string
+	AuthorID        int
+	Cell            string
+	Text            string
+	Width           uint
+	Height          uint
+	FillColor       string
+	Paragraph       []RichTextRun
+	ThreadedComment *ThreadedComment
+}
+
+// ThreadedComment directly maps the threaded comment information, the Done
+// specifies whether the conversation thread has been resolved.
+type ThreadedComment struct {
+	Cell    string
+	Author  string
+	Text    string
+	Date    time.Time
+	Done    bool
+	Replies []ThreadedCommentReply
+}
+
+// ThreadedCommentReply directly maps the reply of the threaded comment.
+type ThreadedCommentReply struct {
+	Author string
+	Text   string
+	Date   time.Time
 }
@@ -11,7 +11,10 @@
 
 package excelize
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"time"
+)
 
 // DocProperties directly maps the document core properties.
 type DocProperties struct {
@@ -29,6 +32,8 @@ type DocProperties struct {
 	Title          string
 	Language       string
 	Version        string
+	CreatedTime    time.Time
+	ModifiedTime   time.Time
 }
 
 // decodeDcTerms directly maps the DCMI metadata terms for the coreProperties.
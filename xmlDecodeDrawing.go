@@ -88,10 +88,24 @@ type decodeWsDr struct {
 	Xdr              string              `xml:"xmlns xdr,attr"`
 	R                string              `xml:"xmlns r,attr"`
 	AlternateContent []*xlsxInnerXML     `xml:"http://schemas.openxmlformats.org/markup-compatibility/2006 AlternateContent"`
+	AbsoluteAnchor   []*decodeCellAnchor `xml:"absoluteAnchor,omitempty"`
 	OneCellAnchor    []*decodeCellAnchor `xml:"oneCellAnchor,omitempty"`
 	TwoCellAnchor    []*decodeCellAnchor `xml:"twoCellAnchor,omitempty"`
 }
 
+// decodeChartAnchor defines the structure used to parse the start anchor cell
+// and the relationship ID of the chart in the graphic frame of the cell anchor.
+type decodeChartAnchor struct {
+	From  *decodeFrom     `xml:"from"`
+	Chart *decodeChartRef `xml:"graphicFrame>graphic>graphicData>chart"`
+}
+
+// decodeChartRef defines the structure used to parse the c:chart element in
+// the graphic data of the graphic frame.
+type decodeChartRef struct {
+	RID string `xml:"id,attr"`
+}
+
 // decodeCNvPr directly maps the cNvPr (Non-Visual Drawing Properties). This
 // element specifies non-visual canvas properties. This allows for additional
 // information that does not affect the appearance of the picture to be
@@ -409,6 +409,7 @@ type xdrTxBody struct {
 
 // Picture maps the format settings of the picture.
 type Picture struct {
+	Cell      string
 	Extension string
 	File      []byte
 	Format    *GraphicOptions
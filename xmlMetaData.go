@@ -0,0 +1,120 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import "encoding/xml"
+
+// xlsxMetadata directly maps the metadata element. A cell in a spreadsheet
+// application can have metadata associated with it. Metadata is just a set of
+// additional properties about the particular cell, and this metadata is
+// stored in the metadata part. There are two types of metadata: cell metadata
+// and value metadata. Cell metadata contains information about the cell
+// itself, and this metadata can be carried along with the cell as it moves
+// (insert, shift, copy/paste, merge, unmerge, etc). Value metadata is
+// information about the value of a particular cell. Value metadata properties
+// can be propagated along with the value as it is referenced in formulas.
+type xlsxMetadata struct {
+	XMLName         xml.Name              `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main metadata"`
+	MetadataTypes   *xlsxMetadataTypes    `xml:"metadataTypes"`
+	MetadataStrings *xlsxMetadataInnerXML `xml:"metadataStrings"`
+	MdxMetadata     *xlsxMetadataInnerXML `xml:"mdxMetadata"`
+	FutureMetadata  []xlsxFutureMetadata  `xml:"futureMetadata"`
+	CellMetadata    *xlsxMetadataBlocks   `xml:"cellMetadata"`
+	ValueMetadata   *xlsxMetadataBlocks   `xml:"valueMetadata"`
+	ExtLst          *xlsxInnerXML         `xml:"extLst"`
+}
+
+// xlsxMetadataInnerXML directly maps the elements with a count attribute in
+// the metadata part, which contents will be kept as is.
+type xlsxMetadataInnerXML struct {
+	Count   int    `xml:"count,attr,omitempty"`
+	Content string `xml:",innerxml"`
+}
+
+// xlsxMetadataTypes directly maps the metadataTypes element. This element
+// represents the set of metadata types used in this workbook.
+type xlsxMetadataTypes struct {
+	Count        int                `xml:"count,attr,omitempty"`
+	MetadataType []xlsxMetadataType `xml:"metadataType"`
+}
+
+// xlsxMetadataType directly maps the metadataType element. This element
+// represents a single set of metadata, and the attributes defines how the
+// metadata should be handled by the spreadsheet application when the cell
+// associated with the metadata are edited.
+type xlsxMetadataType struct {
+	Name                string `xml:"name,attr"`
+	MinSupportedVersion int    `xml:"minSupportedVersion,attr"`
+	GhostRow            bool   `xml:"ghostRow,attr,omitempty"`
+	GhostCol            bool   `xml:"ghostCol,attr,omitempty"`
+	Edit                bool   `xml:"edit,attr,omitempty"`
+	Delete              bool   `xml:"delete,attr,omitempty"`
+	Copy                bool   `xml:"copy,attr,omitempty"`
+	PasteAll            bool   `xml:"pasteAll,attr,omitempty"`
+	PasteFormulas       bool   `xml:"pasteFormulas,attr,omitempty"`
+	PasteValues         bool   `xml:"pasteValues,attr,omitempty"`
+	PasteFormats        bool   `xml:"pasteFormats,attr,omitempty"`
+	PasteComments       bool   `xml:"pasteComments,attr,omitempty"`
+	PasteDataValidation bool   `xml:"pasteDataValidation,attr,omitempty"`
+	PasteBorders        bool   `xml:"pasteBorders,attr,omitempty"`
+	PasteColWidths      bool   `xml:"pasteColWidths,attr,omitempty"`
+	PasteNumberFormats  bool   `xml:"pasteNumberFormats,attr,omitempty"`
+	Merge               bool   `xml:"merge,attr,omitempty"`
+	SplitFirst          bool   `xml:"splitFirst,attr,omitempty"`
+	SplitAll            bool   `xml:"splitAll,attr,omitempty"`
+	RowColShift         bool   `xml:"rowColShift,attr,omitempty"`
+	ClearAll            bool   `xml:"clearAll,attr,omitempty"`
+	ClearFormats        bool   `xml:"clearFormats,attr,omitempty"`
+	ClearContents       bool   `xml:"clearContents,attr,omitempty"`
+	ClearComments       bool   `xml:"clearComments,attr,omitempty"`
+	Assign              bool   `xml:"assign,attr,omitempty"`
+	Coerce              bool   `xml:"coerce,attr,omitempty"`
+	Adjust              bool   `xml:"adjust,attr,omitempty"`
+	CellMeta            bool   `xml:"cellMeta,attr,omitempty"`
+}
+
+// xlsxFutureMetadata directly maps the futureMetadata element. This element
+// represents future metadata information.
+type xlsxFutureMetadata struct {
+	Name   string                    `xml:"name,attr"`
+	Count  int                       `xml:"count,attr,omitempty"`
+	Bk     []xlsxFutureMetadataBlock `xml:"bk"`
+	ExtLst *xlsxInnerXML             `xml:"extLst"`
+}
+
+// xlsxFutureMetadataBlock directly maps the bk element in the futureMetadata
+// element. This element represents a block of future metadata information.
+type xlsxFutureMetadataBlock struct {
+	ExtLst *xlsxInnerXML `xml:"extLst"`
+}
+
+// xlsxMetadataBlocks directly maps the cellMetadata and valueMetadata
+// elements. These elements represent the cell and value metadata blocks.
+type xlsxMetadataBlocks struct {
+	Count int                 `xml:"count,attr,omitempty"`
+	Bk    []xlsxMetadataBlock `xml:"bk"`
+}
+
+// xlsxMetadataBlock directly maps the bk element. This element represents a
+// block of metadata records.
+type xlsxMetadataBlock struct {
+	Rc []xlsxMetadataRecord `xml:"rc"`
+}
+
+// xlsxMetadataRecord directly maps the rc element. This element represents a
+// reference to a specific metadata record. The t attribute is an index of the
+// metadata type by 1-based, and the v attribute is an index of the metadata
+// value by 0-based.
+type xlsxMetadataRecord struct {
+	T int `xml:"t,attr"`
+	V int `xml:"v,attr"`
+}
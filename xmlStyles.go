@@ -328,11 +328,15 @@ type Alignment struct {
 	WrapText        bool
 }
 
-// Border directly maps the border settings of the cells.
+// Border directly maps the border settings of the cells. The ColorTheme
+// specifies the zero-based index of the theme color, and the ColorTint
+// specifies the tint value applied to the color.
 type Border struct {
-	Type  string
-	Color string
-	Style int
+	Type       string
+	Color      string
+	ColorTheme *int
+	ColorTint  float64
+	Style      int
 }
 
 // Font directly maps the font settings of the fonts.
@@ -350,12 +354,17 @@ type Font struct {
 	VertAlign    string
 }
 
-// Fill directly maps the fill settings of the cells.
+// Fill directly maps the fill settings of the cells. The ColorTheme and
+// ColorTint specifies the zero-based index of the theme color and the tint
+// value applied to the color, each element of them corresponds to the color
+// at the same index.
 type Fill struct {
-	Type    string
-	Pattern int
-	Color   []string
-	Shading int
+	Type       string
+	Pattern    int
+	Color      []string
+	ColorTheme []*int
+	ColorTint  []float64
+	Shading    int
 }
 
 // Protection directly maps the protection settings of the cells.
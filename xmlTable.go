@@ -244,10 +244,61 @@ type Table struct {
 	ShowHeaderRow     *bool
 	ShowLastColumn    bool
 	ShowRowStripes    *bool
+	ShowTotalsRow     bool
+	Columns           []TableColumn
+}
+
+// TableColumn directly maps the totals row settings of the table column.
+type TableColumn struct {
+	Name              string
+	TotalsRowFunction string
+	TotalsRowLabel    string
 }
 
 // AutoFilterOptions directly maps the auto filter settings.
 type AutoFilterOptions struct {
 	Column     string
 	Expression string
+	Values     []string
+	Blanks     bool
+	DateGroups []AutoFilterDateGroup
+	Top10      *AutoFilterTop10
+	Color      *AutoFilterColor
+}
+
+// AutoFilterDateGroup directly maps the date group filter criteria settings
+// of the auto filter column.
+type AutoFilterDateGroup struct {
+	Grouping string
+	Year     int
+	Month    int
+	Day      int
+	Hour     int
+	Minute   int
+	Second   int
+}
+
+// AutoFilterTop10 directly maps the top or bottom N items (percent or number
+// of items) filter criteria settings of the auto filter column.
+type AutoFilterTop10 struct {
+	Bottom  bool
+	Percent bool
+	Value   float64
+}
+
+// AutoFilterColor directly maps the cell fill color or font color filter
+// criteria settings of the auto filter column, the Style specifies the
+// conditional format style ID which created by NewConditionalStyle function.
+type AutoFilterColor struct {
+	FontColor bool
+	Style     int
+}
+
+// SortKey directly maps the sort key settings of the SortRange function. The
+// Column specifies the column name to sort by, the Descending specifies the
+// sort order, and the CustomList specifies the custom sort order of values.
+type SortKey struct {
+	Column     string
+	Descending bool
+	CustomList []string
 }
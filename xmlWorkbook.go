@@ -391,6 +391,13 @@ type WorkbookPropsOptions struct {
 	CodeName      *string
 }
 
+// WorkbookViewOptions directly maps the settings of the active workbook view.
+type WorkbookViewOptions struct {
+	ShowSheetTabs        *bool
+	ShowHorizontalScroll *bool
+	ShowVerticalScroll   *bool
+}
+
 // WorkbookProtectionOptions directly maps the settings of workbook protection.
 type WorkbookProtectionOptions struct {
 	AlgorithmName string
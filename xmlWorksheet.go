@@ -29,7 +29,7 @@ type xlsxWorksheet struct {
 	SheetData              xlsxSheetData                `xml:"sheetData"`
 	SheetCalcPr            *xlsxInnerXML                `xml:"sheetCalcPr"`
 	SheetProtection        *xlsxSheetProtection         `xml:"sheetProtection"`
-	ProtectedRanges        *xlsxInnerXML                `xml:"protectedRanges"`
+	ProtectedRanges        *xlsxProtectedRanges         `xml:"protectedRanges"`
 	Scenarios              *xlsxInnerXML                `xml:"scenarios"`
 	AutoFilter             *xlsxAutoFilter              `xml:"autoFilter"`
 	SortState              *xlsxSortState               `xml:"sortState"`
@@ -48,7 +48,7 @@ type xlsxWorksheet struct {
 	ColBreaks              *xlsxColBreaks               `xml:"colBreaks"`
 	CustomProperties       *xlsxInnerXML                `xml:"customProperties"`
 	CellWatches            *xlsxInnerXML                `xml:"cellWatches"`
-	IgnoredErrors          *xlsxInnerXML                `xml:"ignoredErrors"`
+	IgnoredErrors          *xlsxIgnoredErrors           `xml:"ignoredErrors"`
 	SmartTags              *xlsxInnerXML                `xml:"smartTags"`
 	Drawing                *xlsxDrawing                 `xml:"drawing"`
 	LegacyDrawing          *xlsxLegacyDrawing           `xml:"legacyDrawing"`
@@ -62,6 +62,7 @@ type xlsxWorksheet struct {
 	TableParts             *xlsxTableParts              `xml:"tableParts"`
 	ExtLst                 *xlsxExtLst                  `xml:"extLst"`
 	DecodeAlternateContent *xlsxInnerXML                `xml:"http://schemas.openxmlformats.org/markup-compatibility/2006 AlternateContent"`
+	numberStoredAsText     [][]int
 }
 
 // xlsxDrawing change r:id to rid in the namespace.
@@ -198,7 +199,7 @@ type xlsxSheetView struct {
 	DefaultGridColor         *bool            `xml:"defaultGridColor,attr"`
 	View                     string           `xml:"view,attr,omitempty"`
 	TopLeftCell              string           `xml:"topLeftCell,attr,omitempty"`
-	ColorID                  int              `xml:"colorId,attr,omitempty"`
+	ColorID                  *int             `xml:"colorId,attr"`
 	ZoomScale                float64          `xml:"zoomScale,attr,omitempty"`
 	ZoomScaleNormal          float64          `xml:"zoomScaleNormal,attr,omitempty"`
 	ZoomScalePageLayoutView  float64          `xml:"zoomScalePageLayoutView,attr,omitempty"`
@@ -414,6 +415,7 @@ type xlsxMergeCells struct {
 	XMLName xml.Name         `xml:"mergeCells"`
 	Count   int              `xml:"count,attr,omitempty"`
 	Cells   []*xlsxMergeCell `xml:"mergeCell,omitempty"`
+	merged  bool
 }
 
 // xlsxDataValidations expresses all data validation information for cells in a
@@ -524,6 +526,28 @@ type xlsxSheetProtection struct {
 	SelectUnlockedCells bool     `xml:"selectUnlockedCells,attr"`
 }
 
+// xlsxProtectedRanges directly maps the protectedRanges element. This
+// collection represents the ranges to be protected.
+type xlsxProtectedRanges struct {
+	ProtectedRange []*xlsxProtectedRange `xml:"protectedRange"`
+}
+
+// xlsxProtectedRange directly maps the protectedRange element. This element
+// specifies a protected range within a sheet, the range could be edited by
+// the users which know the password or have been granted by the security
+// descriptor, when the sheet is protected.
+type xlsxProtectedRange struct {
+	Password                  string   `xml:"password,attr,omitempty"`
+	Sqref                     string   `xml:"sqref,attr"`
+	Name                      string   `xml:"name,attr"`
+	SecurityDescriptorAttr    string   `xml:"securityDescriptor,attr,omitempty"`
+	AlgorithmName             string   `xml:"algorithmName,attr,omitempty"`
+	HashValue                 string   `xml:"hashValue,attr,omitempty"`
+	SaltValue                 string   `xml:"saltValue,attr,omitempty"`
+	SpinCount                 int      `xml:"spinCount,attr,omitempty"`
+	SecurityDescriptorElement []string `xml:"securityDescriptor"`
+}
+
 // xlsxPhoneticPr (Phonetic Properties) represents a collection of phonetic
 // properties that affect the display of phonetic text for this String Item
 // (si). Phonetic text is used to give hints as to the pronunciation of an East
@@ -600,7 +624,7 @@ type xlsxIconSet struct {
 // cfvo (Conditional Format Value Object) describes the values of the
 // interpolation points in a gradient scale.
 type xlsxCfvo struct {
-	Gte    bool        `xml:"gte,attr,omitempty"`
+	Gte    *bool       `xml:"gte,attr"`
 	Type   string      `xml:"type,attr,omitempty"`
 	Val    string      `xml:"val,attr,omitempty"`
 	ExtLst *xlsxExtLst `xml:"extLst"`
@@ -625,6 +649,30 @@ type xlsxHyperlink struct {
 	RID      string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr,omitempty"`
 }
 
+// xlsxIgnoredErrors directly maps the ignoredErrors element. This collection
+// of elements specifies the ranges of cells of which the spreadsheet
+// application shall ignore the errors.
+type xlsxIgnoredErrors struct {
+	IgnoredError []*xlsxIgnoredError `xml:"ignoredError"`
+	ExtLst       *xlsxInnerXML       `xml:"extLst"`
+}
+
+// xlsxIgnoredError directly maps the ignoredError element. This element
+// specifies a single range of cells of which the spreadsheet application
+// shall ignore the error types specified by the attributes.
+type xlsxIgnoredError struct {
+	Sqref              string `xml:"sqref,attr"`
+	EvalError          bool   `xml:"evalError,attr,omitempty"`
+	TwoDigitTextYear   bool   `xml:"twoDigitTextYear,attr,omitempty"`
+	NumberStoredAsText bool   `xml:"numberStoredAsText,attr,omitempty"`
+	Formula            bool   `xml:"formula,attr,omitempty"`
+	FormulaRange       bool   `xml:"formulaRange,attr,omitempty"`
+	UnlockedFormula    bool   `xml:"unlockedFormula,attr,omitempty"`
+	EmptyCellReference bool   `xml:"emptyCellReference,attr,omitempty"`
+	ListDataValidation bool   `xml:"listDataValidation,attr,omitempty"`
+	CalculatedColumn   bool   `xml:"calculatedColumn,attr,omitempty"`
+}
+
 // xlsxTableParts directly maps the tableParts element in the namespace
 // http://schemas.openxmlformats.org/spreadsheetml/2006/main - The table element
 // has several attributes applied to identify the table and the data range it
@@ -701,9 +749,119 @@ type xlsxLegacyDrawingHF struct {
 
 // decodeX14SparklineGroups directly maps the sparklineGroups element.
 type decodeX14SparklineGroups struct {
-	XMLName xml.Name `xml:"sparklineGroups"`
-	XMLNSXM string   `xml:"xmlns:xm,attr"`
-	Content string   `xml:",innerxml"`
+	XMLName         xml.Name                   `xml:"sparklineGroups"`
+	XMLNSXM         string                     `xml:"xmlns:xm,attr"`
+	SparklineGroups []*decodeX14SparklineGroup `xml:"sparklineGroup"`
+	Content         string                     `xml:",innerxml"`
+}
+
+// decodeX14SparklineGroup directly maps the sparklineGroup element.
+type decodeX14SparklineGroup struct {
+	XMLName             xml.Name            `xml:"sparklineGroup"`
+	ManualMax           float64             `xml:"manualMax,attr"`
+	ManualMin           float64             `xml:"manualMin,attr"`
+	LineWeight          float64             `xml:"lineWeight,attr"`
+	Type                string              `xml:"type,attr"`
+	DateAxis            bool                `xml:"dateAxis,attr"`
+	DisplayEmptyCellsAs string              `xml:"displayEmptyCellsAs,attr"`
+	Markers             bool                `xml:"markers,attr"`
+	High                bool                `xml:"high,attr"`
+	Low                 bool                `xml:"low,attr"`
+	First               bool                `xml:"first,attr"`
+	Last                bool                `xml:"last,attr"`
+	Negative            bool                `xml:"negative,attr"`
+	DisplayXAxis        bool                `xml:"displayXAxis,attr"`
+	DisplayHidden       bool                `xml:"displayHidden,attr"`
+	MinAxisType         string              `xml:"minAxisType,attr"`
+	MaxAxisType         string              `xml:"maxAxisType,attr"`
+	RightToLeft         bool                `xml:"rightToLeft,attr"`
+	ColorSeries         *xlsxColor          `xml:"colorSeries"`
+	ColorNegative       *xlsxColor          `xml:"colorNegative"`
+	ColorAxis           *xlsxColor          `xml:"colorAxis"`
+	ColorMarkers        *xlsxColor          `xml:"colorMarkers"`
+	ColorFirst          *xlsxColor          `xml:"colorFirst"`
+	ColorLast           *xlsxColor          `xml:"colorLast"`
+	ColorHigh           *xlsxColor          `xml:"colorHigh"`
+	ColorLow            *xlsxColor          `xml:"colorLow"`
+	Sparklines          decodeX14Sparklines `xml:"sparklines"`
+}
+
+// decodeX14Sparklines directly maps the sparklines element.
+type decodeX14Sparklines struct {
+	Sparkline []*decodeX14Sparkline `xml:"sparkline"`
+}
+
+// decodeX14Sparkline directly maps the sparkline element.
+type decodeX14Sparkline struct {
+	F     string `xml:"f"`
+	Sqref string `xml:"sqref"`
+}
+
+// decodeX14DataValidations directly maps the dataValidations element in the
+// worksheet extension list.
+type decodeX14DataValidations struct {
+	XMLName        xml.Name                   `xml:"dataValidations"`
+	DataValidation []*decodeX14DataValidation `xml:"dataValidation"`
+}
+
+// decodeX14DataValidation directly maps the dataValidation element in the
+// worksheet extension list, the formulas and reference sequence of it are
+// stored in the child elements.
+type decodeX14DataValidation struct {
+	AllowBlank       bool                `xml:"allowBlank,attr"`
+	Error            *string             `xml:"error,attr"`
+	ErrorStyle       *string             `xml:"errorStyle,attr"`
+	ErrorTitle       *string             `xml:"errorTitle,attr"`
+	Operator         string              `xml:"operator,attr"`
+	Prompt           *string             `xml:"prompt,attr"`
+	PromptTitle      *string             `xml:"promptTitle,attr"`
+	ShowDropDown     bool                `xml:"showDropDown,attr"`
+	ShowErrorMessage bool                `xml:"showErrorMessage,attr"`
+	ShowInputMessage bool                `xml:"showInputMessage,attr"`
+	Type             string              `xml:"type,attr"`
+	Formula1         *decodeX14DVFormula `xml:"formula1"`
+	Formula2         *decodeX14DVFormula `xml:"formula2"`
+	Sqref            string              `xml:"sqref"`
+}
+
+// decodeX14DVFormula directly maps the formula1 and formula2 element of the
+// data validation in the worksheet extension list.
+type decodeX14DVFormula struct {
+	F string `xml:"f"`
+}
+
+// xlsxX14DataValidations directly maps the dataValidations element in the
+// worksheet extension list.
+type xlsxX14DataValidations struct {
+	XMLName        xml.Name                 `xml:"x14:dataValidations"`
+	XMLNSXM        string                   `xml:"xmlns:xm,attr"`
+	Count          int                      `xml:"count,attr"`
+	DataValidation []*xlsxX14DataValidation `xml:"x14:dataValidation"`
+}
+
+// xlsxX14DataValidation directly maps the dataValidation element in the
+// worksheet extension list.
+type xlsxX14DataValidation struct {
+	AllowBlank       bool              `xml:"allowBlank,attr,omitempty"`
+	Error            *string           `xml:"error,attr"`
+	ErrorStyle       *string           `xml:"errorStyle,attr"`
+	ErrorTitle       *string           `xml:"errorTitle,attr"`
+	Operator         string            `xml:"operator,attr,omitempty"`
+	Prompt           *string           `xml:"prompt,attr"`
+	PromptTitle      *string           `xml:"promptTitle,attr"`
+	ShowDropDown     bool              `xml:"showDropDown,attr,omitempty"`
+	ShowErrorMessage bool              `xml:"showErrorMessage,attr,omitempty"`
+	ShowInputMessage bool              `xml:"showInputMessage,attr,omitempty"`
+	Type             string            `xml:"type,attr,omitempty"`
+	Formula1         *xlsxX14DVFormula `xml:"x14:formula1"`
+	Formula2         *xlsxX14DVFormula `xml:"x14:formula2"`
+	Sqref            string            `xml:"xm:sqref"`
+}
+
+// xlsxX14DVFormula directly maps the formula1 and formula2 element of the
+// data validation in the worksheet extension list.
+type xlsxX14DVFormula struct {
+	F string `xml:"xm:f"`
 }
 
 // decodeX14ConditionalFormattingExt directly maps the ext element.
@@ -796,8 +954,8 @@ type xlsxX14SparklineGroups struct {
 // xlsxX14SparklineGroup directly maps the sparklineGroup element.
 type xlsxX14SparklineGroup struct {
 	XMLName             xml.Name          `xml:"x14:sparklineGroup"`
-	ManualMax           int               `xml:"manualMax,attr,omitempty"`
-	ManualMin           int               `xml:"manualMin,attr,omitempty"`
+	ManualMax           float64           `xml:"manualMax,attr,omitempty"`
+	ManualMin           float64           `xml:"manualMin,attr,omitempty"`
 	LineWeight          float64           `xml:"lineWeight,attr,omitempty"`
 	Type                string            `xml:"type,attr,omitempty"`
 	DateAxis            bool              `xml:"dateAxis,attr,omitempty"`
@@ -853,6 +1011,35 @@ type DataValidation struct {
 	Formula2         string
 }
 
+// RowCell directly maps the cell data of the current row read by the rows
+// iterator. Ref specifies the cell reference, Type specifies the data type of
+// the cell, StyleID specifies the style index of the cell, Formula specifies
+// the formula of the cell, and Value specifies the formatted value of the
+// cell, or the raw value if the RawCellValue option is set.
+type RowCell struct {
+	Ref     string
+	Type    CellType
+	StyleID int
+	Formula string
+	Value   string
+}
+
+// CopyDataValidationOptions directly maps the settings of copying data
+// validations between worksheets. The ColOffset and RowOffset specify the
+// number of columns and rows to move the reference sequence of each copied
+// data validation.
+type CopyDataValidationOptions struct {
+	ColOffset int
+	RowOffset int
+}
+
+// AutoFitColumnOptions directly maps the settings of auto-fit column width.
+// The MaxWidth specifies the maximum width of each column in characters, the
+// default value is 255.
+type AutoFitColumnOptions struct {
+	MaxWidth float64
+}
+
 // SparklineOptions directly maps the settings of the sparkline.
 type SparklineOptions struct {
 	Location      []string
@@ -881,6 +1068,7 @@ type SparklineOptions struct {
 	LastColor     string
 	HightColor    string
 	LowColor      string
+	AxisColor     string
 	EmptyCells    string
 }
 
@@ -925,9 +1113,35 @@ type ConditionalFormatOptions struct {
 	BarOnly        bool
 	BarSolid       bool
 	IconStyle      string
+	IconThresholds []ConditionalFormatIconThreshold
 	ReverseIcons   bool
 	IconsOnly      bool
 	StopIfTrue     bool
+	Priority       int
+}
+
+// ConditionalFormatIconThreshold directly maps the threshold settings of each
+// icon in the icon set conditional format.
+type ConditionalFormatIconThreshold struct {
+	Type        string
+	Value       string
+	GreaterThan bool
+}
+
+// ProtectedRangeOptions directly maps the settings of the protected range,
+// which could be edited when the worksheet is protected. The Range specifies
+// the cell reference sequence, like "A1:B2 D4". The optional Password
+// specifies the password to edit the range, and the optional AlgorithmName
+// specifies the hash algorithm of the password, support XOR, MD4, MD5, SHA-1,
+// SHA-256, SHA-384, and SHA-512. The optional SecurityDescriptor specifies
+// the users who could edit the range without password in the security
+// descriptor definition language (SDDL).
+type ProtectedRangeOptions struct {
+	Name               string
+	Range              string
+	Password           string
+	AlgorithmName      string
+	SecurityDescriptor string
 }
 
 // SheetProtectionOptions directly maps the settings of worksheet protection.
@@ -951,10 +1165,17 @@ type SheetProtectionOptions struct {
 	Sort                bool
 }
 
-// HeaderFooterOptions directly maps the settings of header and footer.
+// HeaderFooterOptions directly maps the settings of header and footer. The
+// odd page header and footer are used for all pages unless DifferentOddEven
+// or DifferentFirst is enabled.
 type HeaderFooterOptions struct {
 	AlignWithMargins *bool
-	DifferentFirst   bool
+	// DifferentFirst specifies whether the first page has a different header
+	// and footer, which set by FirstHeader and FirstFooter.
+	DifferentFirst bool
+	// DifferentOddEven specifies whether the odd and even pages have
+	// different headers and footers, the even page header and footer set by
+	// EvenHeader and EvenFooter.
 	DifferentOddEven bool
 	ScaleWithDoc     *bool
 	OddHeader        string
@@ -990,9 +1211,13 @@ type PageLayoutOptions struct {
 	// value ranging from 10 (10%) to 400 (400%). This setting is overridden
 	// when fitToWidth and/or fitToHeight are in use.
 	AdjustTo *uint
-	// FitToHeight specified the number of vertical pages to fit on.
+	// FitToHeight specified the number of vertical pages to fit on, set it
+	// as 0 to use as many pages as needed. Setting it enables the Fit to Page
+	// print option of the worksheet.
 	FitToHeight *int
-	// FitToWidth specified the number of horizontal pages to fit on.
+	// FitToWidth specified the number of horizontal pages to fit on, set it
+	// as 0 to use as many pages as needed. Setting it enables the Fit to Page
+	// print option of the worksheet.
 	FitToWidth *int
 	// BlackAndWhite specified print black and white.
 	BlackAndWhite *bool
@@ -1004,6 +1229,11 @@ type ViewOptions struct {
 	// the default grid lines color(system dependent). Overrides any color
 	// specified in colorId.
 	DefaultGridColor *bool
+	// GridLineColor specifies the grid lines color in hex RGB format (such as
+	// "FF0000"), the color must be one of the indexed color palette of the
+	// workbook. The default grid lines color will be disabled when this color
+	// is set, unless DefaultGridColor was specified.
+	GridLineColor *string
 	// RightToLeft indicating whether the sheet is in 'right to left' display
 	// mode. When in this mode, Column A is on the far right, Column B; is one
 	// column left of Column A, and so on. Also, information in cells is
@@ -1093,3 +1323,66 @@ type SheetPropsOptions struct {
 	// ThickBottom specifies if rows have a thick bottom border by default.
 	ThickBottom *bool
 }
+
+// decodeOleObjects directly maps the oleObjects element of the worksheet,
+// the OLE objects may be wrapped in the alternate content, and the objects in
+// the fallback content are the same objects of the choice content.
+type decodeOleObjects struct {
+	AlternateContent []struct {
+		Choice   *decodeOleObjectList `xml:"Choice"`
+		Fallback *decodeOleObjectList `xml:"Fallback"`
+	} `xml:"AlternateContent"`
+	OleObject []decodeOleObject `xml:"oleObject"`
+}
+
+// decodeOleObjectList directly maps the list of the oleObject elements.
+type decodeOleObjectList struct {
+	OleObject []decodeOleObject `xml:"oleObject"`
+}
+
+// decodeOleObject directly maps the oleObject element, which specifies an
+// embedded OLE object.
+type decodeOleObject struct {
+	ProgID   string `xml:"progId,attr"`
+	DvAspect string `xml:"dvAspect,attr"`
+	ShapeID  int    `xml:"shapeId,attr"`
+	RID      string `xml:"id,attr"`
+	ObjectPr *struct {
+		RID    string `xml:"id,attr"`
+		Anchor struct {
+			From struct {
+				Col int `xml:"col"`
+				Row int `xml:"row"`
+			} `xml:"from"`
+		} `xml:"anchor"`
+	} `xml:"objectPr"`
+}
+
+// OLEObjectOptions directly maps the settings of the OLE object. The ProgID
+// specifies the programmatic identifier of the application which handles the
+// object, the default value is "Package". The Icon and IconExtension specifies
+// the preview image of the object. The Width and Height specifies the size of
+// the preview image in pixels.
+type OLEObjectOptions struct {
+	ProgID        string
+	DisplayAsIcon bool
+	Icon          []byte
+	IconExtension string
+	Width         uint
+	Height        uint
+	OffsetX       int
+	OffsetY       int
+}
+
+// OLEObject directly maps the OLE object embedded in the worksheet. The Data
+// specifies the content of the embedded file for the package objects, or the
+// raw content of the embedded part for the other objects.
+type OLEObject struct {
+	Cell          string
+	ProgID        string
+	DisplayAsIcon bool
+	FileName      string
+	Data          []byte
+	Icon          []byte
+	IconExtension string
+}